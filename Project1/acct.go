@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"github.com/olekukonko/tablewriter"
+)
+
+func acctCommand(args []string) {
+	fs := flag.NewFlagSet("acct", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "acct [flags] (workload.csv | -example name)")
+	selected := fs.String("algorithms", "",
+		"comma-separated algorithms to run, in order (default all: "+algorithmNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 1, "round-robin time quantum")
+	fs.Int64Var(&options.switchCost, "switch-cost", 0,
+		"time units charged for every context switch between two different processes")
+	syscall := fs.String("syscall", "",
+		"percent of its running time each process spends again in the kernel, as PERCENT for all and PID=PERCENT "+
+			"for one, such as 10,3=40 (default none)")
+	output := fs.String("output", "text", "record format: text, csv, or json (a record per algorithm per process)")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for the lottery draws (default: based on the current time)")
+	exampleName := fs.String("example", "", "account for a bundled example workload instead of a file ("+
+		exampleNames()+")")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	run, err := parseAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	if options.syscall, err = parseSyscallOverhead(*syscall); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs))
+	}
+	if *output != "text" && *output != "csv" && *output != "json" {
+		fatal(exitInvalid, fmt.Errorf("%w: -output must be text, csv, or json", ErrInvalidArgs))
+	}
+	options.seed = resolveSeed(options.seed)
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+	reports := runAlgorithms(sched.WithLogger(context.Background(), logger), io.Discard, run, processes)
+
+	var records []report.AccountingRecord
+	for _, r := range reports {
+		records = append(records, r.Accounting()...)
+	}
+	switch *output {
+	case "csv":
+		err = report.WriteCSV(os.Stdout, records)
+	case "json":
+		err = encodeAccounting(os.Stdout, records)
+	default:
+		for _, r := range reports {
+			outputAccounting(os.Stdout, r)
+		}
+	}
+	if err != nil {
+		fatal(exitFailure, err)
+	}
+}
+
+// encodeAccounting writes records as an indented JSON array.
+func encodeAccounting(w io.Writer, records []report.AccountingRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(records); err != nil {
+		return fmt.Errorf("%w: encoding JSON accounting records", err)
+	}
+
+	return nil
+}
+
+// outputAccounting writes the accounting record of every process of r the way ps lists processes, and the times of
+// them all together the way time(1) reports a command's.
+func outputAccounting(w io.Writer, r report.Report) {
+	outputTitle(w, r.Title)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"PID", "Start", "End", "Real", "User", "Sys", "Wait", "Blocked", "%CPU"})
+	var total report.AccountingRecord
+	for _, a := range r.Accounting() {
+		table.Append([]string{strconv.FormatInt(a.PID, 10), strconv.FormatInt(a.Start, 10),
+			strconv.FormatInt(a.End, 10), strconv.FormatInt(a.Real, 10), strconv.FormatInt(a.User, 10),
+			strconv.FormatInt(a.Sys, 10), strconv.FormatInt(a.Wait, 10), strconv.FormatInt(a.Blocked, 10),
+			fmt.Sprintf("%.1f", a.CPUPercent)})
+		total.Real, total.User, total.Sys = total.Real+a.Real, total.User+a.User, total.Sys+a.Sys
+		total.Wait, total.Blocked = total.Wait+a.Wait, total.Blocked+a.Blocked
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Total: real %d, user %d, sys %d, wait %d, blocked %d\n\n", total.Real, total.User,
+		total.Sys, total.Wait, total.Blocked)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputAccounting(t *testing.T) {
+	t.Parallel()
+	r := report.New("RR", sched.NewResult([]sched.TimeSlice{
+		{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4},
+	}, []workload.Process{
+		{ProcessID: 2, ArrivalTime: 1, Burst: 1, Wait: 1, Turnaround: 2, Completion: 3},
+		{ProcessID: 1, Burst: 3, Wait: 1, Turnaround: 4, Completion: 4},
+	}))
+	var w bytes.Buffer
+	outputAccounting(&w, r)
+	for _, want := range []string{
+		"|   2 |     2 |   3 |    2 |    1 |   0 |    1 |       0 | 50.0 |",
+		"|   1 |     0 |   4 |    4 |    3 |   0 |    1 |       0 | 75.0 |",
+		"Total: real 6, user 4, sys 0, wait 2, blocked 0",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputAccounting() missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_encodeAccounting(t *testing.T) {
+	t.Parallel()
+	records := []report.AccountingRecord{{SchemaVersion: 1, Algorithm: "FCFS", PID: 1, End: 3, Real: 3, User: 3,
+		CPUPercent: 100}}
+	var w bytes.Buffer
+	if err := encodeAccounting(&w, records); err != nil {
+		t.Fatal(err)
+	}
+	var got []report.AccountingRecord
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil || !reflect.DeepEqual(got, records) {
+		t.Errorf("encodeAccounting() = %s, %v, want %+v", w.String(), err, records)
+	}
+}
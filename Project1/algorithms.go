@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// algorithms is the registry of every scheduler in the order they run by default. Registering a scheduler here is
+// all it takes for -algorithms, -list-algorithms, help, and completion to pick it up.
+var algorithms = sched.NewRegistry(
+	sched.Algorithm{
+		Scheduler:   sched.New("fcfs", sched.FCFS),
+		Title:       "First-come, first-serve",
+		Description: "non-preemptive, runs processes in arrival order",
+	},
+	sched.Algorithm{
+		Scheduler:   sched.New("sjf", sched.SJF),
+		Title:       "Shortest-job-first",
+		Description: "preemptive, always runs the process with the least remaining burst",
+	},
+	sched.Algorithm{
+		Scheduler:   sched.New("priority", sched.Priority),
+		Title:       "Priority",
+		Description: "preemptive, always runs the highest-priority (lowest value) process",
+	},
+	sched.Algorithm{
+		Scheduler:   roundRobin(0),
+		Title:       "Round-robin",
+		Description: "preemptive, cycles through ready processes one time quantum at a time",
+	},
+	sched.Algorithm{
+		// the draws come from -seed, so a run is reproducible
+		Scheduler: sched.New("lottery", func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+			return sched.Lottery(ctx, processes, newRand(options.seed, "lottery"))
+		}),
+		Title:       "Lottery",
+		Description: "preemptive, draws the process for every time unit at random, weighted towards higher priority",
+	},
+)
+
+// roundRobin returns the rr scheduler switching processes every timeQuantum time units, or every -quantum time units
+// when timeQuantum is 0.
+func roundRobin(timeQuantum int64) sched.Scheduler {
+	return sched.New("rr", func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+		if timeQuantum == 0 {
+			return sched.RR(ctx, processes, options.quantum)
+		}
+		return sched.RR(ctx, processes, timeQuantum)
+	})
+}
+
+// parseAlgorithms resolves a comma-separated list of algorithm names, in the order given. An empty list selects
+// every algorithm.
+func parseAlgorithms(s string) ([]sched.Algorithm, error) {
+	selected, err := algorithms.Select(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgs, err)
+	}
+
+	return selected, nil
+}
+
+func algorithmNames() string {
+	return strings.Join(algorithms.Names(), ",")
+}
+
+// schedule runs s on processes and outputs its report under title. Its decisions are logged under the title, and
+// -step and the progress bar follow along.
+func schedule(ctx context.Context, w io.Writer, s sched.Scheduler, title string, processes []workload.Process) report.Report {
+	if options.streamDir != "" {
+		return streamSchedule(ctx, w, s, title, processes)
+	}
+	result, err := s.Schedule(schedContext(ctx, title), processes)
+	return outputReport(w, title, result, err, runSeed(s))
+}
+
+// schedContext prepares ctx for running the scheduler titled title.
+func schedContext(ctx context.Context, title string) context.Context {
+	ctx = sched.WithLogger(ctx, sched.LoggerFrom(ctx).With("algorithm", title))
+	var hooks sched.Hooks
+	if options.step != nil {
+		hooks.Decide = func(time int64, running workload.Process, ready []workload.Process, gantt []sched.TimeSlice) {
+			options.step.decide(title, time, running, ready, gantt)
+		}
+	}
+	if options.progress != nil {
+		hooks.Advance = options.progress.advance
+	}
+
+	return sched.WithHooks(ctx, hooks)
+}
+
+// outputAlgorithms lists every algorithm with its one-line description, each line starting with indent.
+func outputAlgorithms(w io.Writer, indent string) {
+	for _, a := range algorithms.Algorithms() {
+		_, _ = fmt.Fprintf(w, "%s%-10s %s: %s\n", indent, a.Name(), a.Title, a.Description)
+	}
+}
+
+// runAlgorithms runs the algorithms concurrently on processes, which schedulers only read, and writes their text
+// output to w in the order given so the result doesn't depend on which finishes first. Stepping through decisions is
+// interactive, so with -step the algorithms run one after another instead.
+func runAlgorithms(ctx context.Context, w io.Writer, run []sched.Algorithm, processes []workload.Process) []report.Report {
+	reports := make([]report.Report, len(run))
+	if options.step != nil {
+		for i, a := range run {
+			reports[i] = schedule(ctx, w, a, a.Title, processes)
+		}
+		return reports
+	}
+
+	outputs := make([]bytes.Buffer, len(run))
+	var wg sync.WaitGroup
+	for i := range run {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var out io.Writer = &outputs[i]
+			if w == io.Discard {
+				out = io.Discard
+			}
+			reports[i] = schedule(ctx, out, run[i], run[i].Title, processes)
+		}(i)
+	}
+	wg.Wait()
+	for i := range outputs {
+		_, _ = outputs[i].WriteTo(w)
+	}
+
+	return reports
+}
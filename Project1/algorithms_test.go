@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/api"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_parseAlgorithms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "default",
+			want: []string{"fcfs", "sjf", "priority", "rr", "lottery"},
+		},
+		{
+			name: "subset in given order",
+			s:    "rr, FCFS",
+			want: []string{"rr", "fcfs"},
+		},
+		{
+			name:    "unknown",
+			s:       "fcfs,mlfq",
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseAlgorithms(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, a := range got {
+				names = append(names, a.Name())
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("parseAlgorithms() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runAlgorithms(t *testing.T) {
+	t.Parallel()
+	processes := workload.Generate(rand.New(rand.NewSource(3)),
+		workload.GenerateOptions{Count: 40, MaxBurst: 9, MaxArrival: 30, MaxPriority: 4})
+	original := append([]workload.Process(nil), processes...)
+
+	var concurrent bytes.Buffer
+	reports := runAlgorithms(context.Background(), &concurrent, algorithms.Algorithms(), processes)
+
+	// the same reports and text, in the same order, as running the algorithms one at a time
+	var sequential bytes.Buffer
+	for i, a := range algorithms.Algorithms() {
+		want := schedule(context.Background(), &sequential, a, a.Title, processes)
+		if !reflect.DeepEqual(reports[i], want) {
+			t.Errorf("%s: concurrent report differs from a sequential run", a.Name())
+		}
+	}
+	if concurrent.String() != sequential.String() {
+		t.Errorf("concurrent output differs from sequential output")
+	}
+	if !reflect.DeepEqual(processes, original) {
+		t.Errorf("running the algorithms modified the workload")
+	}
+}
+
+// The api package serves the same algorithms to non-CLI callers, so its listing must match the CLI's.
+func Test_algorithmsMatchAPI(t *testing.T) {
+	t.Parallel()
+	want := algorithms.Algorithms()
+	got := api.Algorithms(1, 0).Algorithms()
+	if len(got) != len(want) {
+		t.Fatalf("api has %d algorithms, the CLI %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Name() != want[i].Name() || got[i].Title != want[i].Title || got[i].Description != want[i].Description {
+			t.Errorf("api algorithm %d is %s %q, the CLI's is %s %q", i, got[i].Name(), got[i].Title,
+				want[i].Name(), want[i].Title)
+		}
+	}
+}
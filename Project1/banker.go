@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/banker"
+	"github.com/olekukonko/tablewriter"
+)
+
+func bankerCommand(args []string) {
+	fs := flag.NewFlagSet("banker", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "banker [flags] (state.txt | -state \"available 3 3 2; process P0 0 1 0 / 7 5 3; ...\")")
+	inline := fs.String("state", "", "state of semicolon-separated available, process, and request entries")
+	requests := fs.String("requests", "", "semicolon-separated requests to decide after any in the state, as \"P1 1 0 2\"")
+	trace := fs.Bool("trace", true, "show the work and finish vectors of every safety check")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if (*inline == "") == (fs.NArg() == 0) {
+		fatal(exitInvalid, fmt.Errorf("%w: give one state file or -state", ErrInvalidArgs))
+	}
+
+	var in io.Reader = strings.NewReader(*inline)
+	if *inline == "" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: opening banker's state", err))
+		}
+		defer f.Close()
+		in = f
+	}
+	var more strings.Builder
+	for _, r := range strings.Split(*requests, ";") {
+		if strings.TrimSpace(r) != "" {
+			more.WriteString("\nrequest " + r)
+		}
+	}
+	s, queue, err := banker.Parse(io.MultiReader(in, strings.NewReader(more.String())))
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+
+	outputBankerState(os.Stdout, "Banker's state", s)
+	if len(queue) == 0 {
+		outputSafety(os.Stdout, s, s.Check(), *trace)
+		return
+	}
+	decisions, after, err := banker.Decide(s, queue)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	outputDecisions(os.Stdout, s, decisions, *trace)
+	outputBankerState(os.Stdout, "After the requests", after)
+}
+
+// outputBankerState writes what every process of s holds, may hold at most, and still needs, and what is available.
+func outputBankerState(w io.Writer, title string, s banker.State) {
+	_, _ = fmt.Fprintf(w, "%s: %d processes sharing resources %s\n", title, len(s.Processes),
+		strings.Join(s.Resources, " "))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Process", "Allocation", "Max", "Need"})
+	for i, name := range s.Processes {
+		table.Append([]string{name, s.Allocation[i].String(), s.Max[i].String(), s.Need(i).String()})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Available: %s\n\n", s.Available)
+}
+
+// outputSafety writes the outcome of a safety check of s, and if trace is set, every step it took.
+func outputSafety(w io.Writer, s banker.State, safety banker.Safety, trace bool) {
+	if trace && len(safety.Steps) > 0 {
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"Step", "Process", "Work", "Need", "Work after", "Finish"})
+		for i, step := range safety.Steps {
+			finish := make([]string, len(step.Finish))
+			for j, done := range step.Finish {
+				finish[j] = "F"
+				if done {
+					finish[j] = "T"
+				}
+			}
+			table.Append([]string{strconv.Itoa(i + 1), s.Processes[step.Process], step.Work.String(),
+				step.Need.String(), step.After.String(), strings.Join(finish, " ")})
+		}
+		table.Render()
+	}
+	if safety.Safe {
+		_, _ = fmt.Fprintf(w, "Safe: the processes can finish in the order %s\n\n",
+			processNames(s, safety.Sequence))
+		return
+	}
+	if len(safety.Sequence) == 0 {
+		_, _ = fmt.Fprintf(w, "Unsafe: none of %s can be sure to finish\n\n", processNames(s, safety.Stuck))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Unsafe: after %s, none of %s can be sure to finish\n\n", processNames(s, safety.Sequence),
+		processNames(s, safety.Stuck))
+}
+
+// processNames returns the names of the processes at indexes, separated by commas.
+func processNames(s banker.State, indexes []int) string {
+	names := make([]string, len(indexes))
+	for i, p := range indexes {
+		names[i] = s.Processes[p]
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// outputDecisions writes the banker's decision on every request, each checked against the state the ones before it
+// left, the safety check behind it, and which request was the first denied.
+func outputDecisions(w io.Writer, s banker.State, decisions []banker.Decision, trace bool) {
+	granted, denied := 0, -1
+	for i, d := range decisions {
+		_, _ = fmt.Fprintf(w, "Request %d: %s asks for %s: %s\n", i+1, d.Request.Process, d.Request.Amount,
+			d.Outcome)
+		if d.Safety != nil {
+			outputSafety(w, s, *d.Safety, trace)
+		}
+		if d.Outcome == banker.Granted {
+			granted++
+		} else if denied < 0 {
+			denied = i
+		}
+	}
+	if len(decisions) > 0 && decisions[len(decisions)-1].Safety == nil {
+		_, _ = fmt.Fprintln(w)
+	}
+	_, _ = fmt.Fprintf(w, "Granted %d of %d requests", granted, len(decisions))
+	if denied >= 0 {
+		d := decisions[denied]
+		_, _ = fmt.Fprintf(w, "; the first denied is request %d, %s asking for %s", denied+1, d.Request.Process,
+			d.Request.Amount)
+	}
+	_, _ = fmt.Fprint(w, "\n\n")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/banker"
+)
+
+const bankerTextbook = `available 3 3 2
+process P0 0 1 0 / 7 5 3
+process P1 2 0 0 / 3 2 2
+process P2 3 0 2 / 9 0 2
+process P3 2 1 1 / 2 2 2
+process P4 0 0 2 / 4 3 3
+`
+
+func Test_outputSafety(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		in    string
+		trace bool
+		want  []string
+	}{
+		{
+			name:  "safe",
+			in:    bankerTextbook,
+			trace: true,
+			want: []string{
+				"|    1 | P1      | 3 3 2 | 1 2 2 | 5 3 2      | F T F F F |",
+				"Safe: the processes can finish in the order P1, P3, P4, P0, P2\n",
+			},
+		},
+		{
+			name: "unsafe",
+			in:   "available 1; process P0 1 / 4; process P1 1 / 2; process P2 1 / 5",
+			want: []string{"Unsafe: after P1, none of P0, P2 can be sure to finish\n"},
+		},
+		{
+			name: "none can finish",
+			in:   "available 0; process P0 1 / 2; process P1 1 / 2",
+			want: []string{"Unsafe: none of P0, P1 can be sure to finish\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, _, err := banker.Parse(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var w bytes.Buffer
+			outputSafety(&w, s, s.Check(), tt.trace)
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("outputSafety() missing %q:\n%s", want, w.String())
+				}
+			}
+			if !tt.trace && strings.Contains(w.String(), "WORK") {
+				t.Errorf("outputSafety() traced without trace:\n%s", w.String())
+			}
+		})
+	}
+}
+
+func Test_outputDecisions(t *testing.T) {
+	t.Parallel()
+	s, requests, err := banker.Parse(strings.NewReader(bankerTextbook + "request P1 1 0 2; request P4 3 3 0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decisions, _, err := banker.Decide(s, requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	outputDecisions(&w, s, decisions, false)
+	want := "Request 1: P1 asks for 1 0 2: granted\n" +
+		"Safe: the processes can finish in the order P1, P3, P4, P0, P2\n\n" +
+		"Request 2: P4 asks for 3 3 0: denied: must wait, not enough available\n\n" +
+		"Granted 1 of 2 requests; the first denied is request 2, P4 asking for 3 3 0\n\n"
+	if w.String() != want {
+		t.Errorf("outputDecisions() = %q, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// benchResult is how long each algorithm took to simulate one generated workload.
+type benchResult struct {
+	Size    int
+	Elapsed []time.Duration
+	// Stopped marks the algorithms that hit the time limit, whose Elapsed is only a lower bound.
+	Stopped []bool
+}
+
+// benchCommand times every selected algorithm on random workloads of increasing size.
+func benchCommand(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "bench [flags]")
+	sizes := fs.String("sizes", "100,1000,10000,100000", "comma-separated workload sizes to time, in processes")
+	selected := fs.String("algorithms", "",
+		"comma-separated algorithms to time, in order (default all: "+algorithmNames()+")")
+	limit := fs.Duration("timeout", 10*time.Second, "give up on a single simulation after this long")
+	seed := fs.Int64("seed", 1, "random seed for the generated workloads")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+
+	run, err := parseAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	counts, err := parseSizes(*sizes)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *limit <= 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -timeout must be positive", ErrInvalidArgs))
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	results := make([]benchResult, len(counts))
+	for i, n := range counts {
+		results[i] = benchWorkload(run, benchWorkloadOptions(n), rng, *limit)
+	}
+	outputBench(os.Stdout, run, results, *limit)
+}
+
+// benchWorkloadOptions spreads n arrivals over about as long as their bursts take, keeping the CPU busy without
+// letting the ready queue grow without bound.
+func benchWorkloadOptions(n int) workload.GenerateOptions {
+	const maxBurst = 10
+	return workload.GenerateOptions{Count: n, MaxBurst: maxBurst, MaxArrival: int64(n) * (maxBurst + 1) / 2, MaxPriority: 5}
+}
+
+// benchWorkload times each algorithm, one after another, on the same generated workload.
+func benchWorkload(run []sched.Algorithm, opts workload.GenerateOptions, rng *rand.Rand, limit time.Duration) benchResult {
+	processes := workload.Generate(rng, opts)
+	result := benchResult{
+		Size:    opts.Count,
+		Elapsed: make([]time.Duration, len(run)),
+		Stopped: make([]bool, len(run)),
+	}
+	for i, a := range run {
+		ctx, cancel := context.WithTimeout(sched.WithLogger(context.Background(), logger), limit)
+		start := time.Now()
+		r := schedule(ctx, io.Discard, a, a.Title, processes)
+		result.Elapsed[i] = time.Since(start)
+		result.Stopped[i] = r.Stopped != ""
+		cancel()
+	}
+
+	return result
+}
+
+// parseSizes parses a comma-separated list of positive workload sizes.
+func parseSizes(s string) ([]int, error) {
+	var sizes []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%w: bad workload size %q", ErrInvalidArgs, field)
+		}
+		sizes = append(sizes, n)
+	}
+
+	return sizes, nil
+}
+
+// outputBench writes one row per workload size with each algorithm's simulation time.
+func outputBench(w io.Writer, run []sched.Algorithm, results []benchResult, limit time.Duration) {
+	header := []string{"Processes"}
+	for _, a := range run {
+		header = append(header, a.Name())
+	}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		rows[i] = []string{fmt.Sprint(r.Size)}
+		for j := range run {
+			cell := r.Elapsed[j].Round(time.Microsecond).String()
+			if r.Stopped[j] {
+				cell = "> " + limit.String()
+			}
+			rows[i] = append(rows[i], cell)
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Simulation time by workload size")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.Render()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseSizes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s       string
+		want    []int
+		wantErr error
+	}{
+		{s: "100,1000", want: []int{100, 1000}},
+		{s: " 5 ", want: []int{5}},
+		{s: "10,0", wantErr: ErrInvalidArgs},
+		{s: "10,", wantErr: ErrInvalidArgs},
+		{s: "1k", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSizes(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSizes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_benchWorkload(t *testing.T) {
+	t.Parallel()
+	run := algorithms.Algorithms()
+	r := benchWorkload(run, benchWorkloadOptions(50), rand.New(rand.NewSource(1)), time.Minute)
+	if r.Size != 50 || len(r.Elapsed) != len(run) || len(r.Stopped) != len(run) {
+		t.Fatalf("benchWorkload() = %+v", r)
+	}
+	for i, stopped := range r.Stopped {
+		if stopped || r.Elapsed[i] <= 0 {
+			t.Errorf("%s: elapsed %v, stopped %v", run[i].Name(), r.Elapsed[i], stopped)
+		}
+	}
+
+	// a simulation that hits the limit is shown as a lower bound
+	r = benchWorkload(run[1:2], benchWorkloadOptions(50), rand.New(rand.NewSource(1)), time.Nanosecond)
+	var w bytes.Buffer
+	outputBench(&w, run[1:2], []benchResult{r}, time.Nanosecond)
+	if !strings.Contains(w.String(), "| > 1ns |") {
+		t.Errorf("stopped simulation not marked:\n%s", w.String())
+	}
+}
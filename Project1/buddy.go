@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/buddy"
+	"github.com/olekukonko/tablewriter"
+)
+
+// buddyMapWidth is roughly how many characters wide the text memory map is drawn.
+const buddyMapWidth = 64
+
+func buddyCommand(args []string) {
+	fs := flag.NewFlagSet("buddy", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "buddy [flags] (trace.txt | -trace \"alloc A 34; free A\")")
+	memory := fs.Int64("memory", 1024, "units of memory to allocate from, a power of two")
+	minBlock := fs.Int64("min-block", 16, "smallest block the allocator hands out, a power of two")
+	inline := fs.String("trace", "", "trace of semicolon-separated \"alloc NAME SIZE\" and \"free NAME\" requests")
+	svgPath := fs.String("svg", "", "also draw the memory map over time as an SVG file")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if (*inline == "") == (fs.NArg() == 0) {
+		fatal(exitInvalid, fmt.Errorf("%w: give one trace file or -trace", ErrInvalidArgs))
+	}
+
+	var in io.Reader = strings.NewReader(*inline)
+	if *inline == "" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: opening trace", err))
+		}
+		defer f.Close()
+		in = f
+	}
+	requests, err := buddy.ParseTrace(in)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	r, err := buddy.Simulate(requests, *memory, *minBlock)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	outputBuddy(os.Stdout, r)
+
+	if *svgPath != "" {
+		f, err := os.Create(*svgPath)
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: creating memory map SVG", err))
+		}
+		if err := buddy.WriteMapSVG(f, r); err != nil {
+			_ = f.Close()
+			fatal(exitFailure, fmt.Errorf("%w: writing memory map SVG", err))
+		}
+		if err := f.Close(); err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: writing memory map SVG", err))
+		}
+	}
+}
+
+// outputBuddy writes every step of r with its splits, merges, and internal fragmentation, then the memory map after
+// each, and the peak internal fragmentation.
+func outputBuddy(w io.Writer, r buddy.Result) {
+	_, _ = fmt.Fprintf(w, "Buddy allocator: %d units, smallest block %d\n", r.Memory, r.MinBlock)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Step", "Request", "Block", "Splits", "Merges", "Internal fragmentation"})
+	for i, s := range r.Steps {
+		block := s.Failed
+		if block == "" {
+			block = fmt.Sprintf("%d-%d (%d)", s.Block.Offset, s.Block.Offset+s.Block.Size-1, s.Block.Size)
+		}
+		table.Append([]string{strconv.Itoa(i + 1), s.Request.String(), block, strconv.Itoa(s.Splits),
+			strconv.Itoa(s.Merges), strconv.FormatInt(s.Internal, 10)})
+	}
+	table.Render()
+
+	_, _ = fmt.Fprintln(w, "Memory map")
+	labelWidth := len(strconv.Itoa(len(r.Steps)))
+	for i, s := range r.Steps {
+		_, _ = fmt.Fprintf(w, "%*d %s\n", labelWidth, i+1, buddy.MapLine(s.Map, r.Memory, buddyMapWidth))
+	}
+	_, _ = fmt.Fprintln(w)
+
+	peak := -1
+	for i, s := range r.Steps {
+		if peak < 0 || s.Internal > r.Steps[peak].Internal {
+			peak = i
+		}
+	}
+	if peak >= 0 && r.Steps[peak].Internal > 0 {
+		s := r.Steps[peak]
+		_, _ = fmt.Fprintf(w, "Peak internal fragmentation: %d units after step %d, %.1f%% of the %d allocated\n",
+			s.Internal, peak+1, 100*float64(s.Internal)/float64(s.Allocated()), s.Allocated())
+	}
+	if failed := r.Failures(); failed > 0 {
+		_, _ = fmt.Fprintf(w, "Requests that failed: %d\n", failed)
+	}
+}
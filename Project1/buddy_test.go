@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/buddy"
+)
+
+func Test_outputBuddy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		trace string
+		want  []string
+		not   []string
+	}{
+		{
+			name:  "fragmentation",
+			trace: "alloc A 34; alloc B 66; free A",
+			want: []string{
+				"Buddy allocator: 1024 units, smallest block 64",
+				"|    1 | alloc A 34 | 0-63 (64)     |      4 |      0 |                     30 |",
+				"|    3 | free A     | 0-63 (64)     |      0 |      1 |                     62 |",
+				"2 |A###|....|B#######|",
+				"Peak internal fragmentation: 92 units after step 2, 47.9% of the 192 allocated",
+			},
+			not: []string{"failed"},
+		},
+		{
+			name:  "failure",
+			trace: "alloc A 2000",
+			want:  []string{"no free block of 2048 units", "Requests that failed: 1"},
+			not:   []string{"Peak"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			requests, err := buddy.ParseTrace(strings.NewReader(tt.trace))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := buddy.Simulate(requests, 1024, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var w bytes.Buffer
+			outputBuddy(&w, r)
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("outputBuddy() missing %q:\n%s", want, w.String())
+				}
+			}
+			for _, not := range tt.not {
+				if strings.Contains(w.String(), not) {
+					t.Errorf("outputBuddy() has %q:\n%s", not, w.String())
+				}
+			}
+		})
+	}
+}
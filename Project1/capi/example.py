@@ -0,0 +1,37 @@
+"""Call the simulator through the shared library built from this directory.
+
+    go build -buildmode=c-shared -o libscheduler.so ./capi
+    python3 capi/example.py ./libscheduler.so
+"""
+import ctypes
+import json
+import sys
+
+lib = ctypes.CDLL(sys.argv[1] if len(sys.argv) > 1 else "./libscheduler.so")
+lib.RunSchedule.argtypes = [ctypes.c_char_p]
+lib.RunSchedule.restype = ctypes.c_void_p
+lib.FreeString.argtypes = [ctypes.c_void_p]
+
+
+def run_schedule(request):
+    """Run a request dict and return the response dict."""
+    out = lib.RunSchedule(json.dumps(request).encode())
+    try:
+        return json.loads(ctypes.string_at(out))
+    finally:
+        lib.FreeString(out)
+
+
+response = run_schedule({
+    "processes": [
+        {"pid": 1, "arrival": 0, "burst": 5, "priority": 2},
+        {"pid": 2, "arrival": 1, "burst": 3, "priority": 1},
+        {"pid": 3, "arrival": 2, "burst": 1, "priority": 3},
+    ],
+    "algorithms": ["fcfs", "rr"],
+    "quantum": 2,
+})
+if response.get("error"):
+    sys.exit(response["error"])
+for result in response["results"]:
+    print(result["algorithm"], "average wait", result["summary"]["avg_wait"])
@@ -0,0 +1,34 @@
+// Command capi builds the simulator as a C shared library, so Python notebooks and other languages can call it
+// directly instead of parsing the CLI's text:
+//
+//	go build -buildmode=c-shared -o libscheduler.so ./capi
+//
+// The library exports two functions. RunSchedule takes a request as a JSON C string and returns the JSON response,
+// both in the documents of package api; it never fails, so errors come back in the response's error field. The
+// caller owns the returned string and must release it with FreeString. example.py shows the calls from Python.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"context"
+	"unsafe"
+
+	"GolandProjects/Project1/pkg/api"
+)
+
+//export RunSchedule
+func RunSchedule(request *C.char) *C.char {
+	return C.CString(string(api.RunJSON(context.Background(), []byte(C.GoString(request)))))
+}
+
+//export FreeString
+func FreeString(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+// main is required by -buildmode=c-shared and never runs.
+func main() {}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// command is one subcommand of the CLI, each parsing its own flags from args.
+type command struct {
+	Name        string
+	Description string
+	Run         func(args []string)
+}
+
+// commands lists the subcommands. Anything that isn't a subcommand name is handed to run, so the original
+// "scheduler workload.csv" form keeps working.
+var commands []command
+
+func init() {
+	commands = []command{
+		{Name: "run", Description: "simulate the scheduling algorithms on a workload file (default)", Run: runCommand},
+		{Name: "generate", Description: "write a random workload CSV", Run: generateCommand},
+		{Name: "sweep", Description: "compare round-robin across a range of time quanta", Run: sweepCommand},
+		{Name: "run-experiment", Description: "run a manifest's matrix of workloads, algorithms, and parameters and compare them all", Run: runExperimentCommand},
+		{Name: "ensemble", Description: "compare the algorithms over many random workloads with 95% confidence intervals", Run: ensembleCommand},
+		{Name: "bench", Description: "time the algorithms on random workloads of increasing size", Run: benchCommand},
+		{Name: "compare", Description: "diff two result sets saved with -output json", Run: compareCommand},
+		{Name: "grade", Description: "score a submitted result set against a reference", Run: gradeCommand},
+		{Name: "selftest", Description: "check every algorithm against textbook answers", Run: selftestCommand},
+		{Name: "history", Description: "list, filter, and show again the runs recorded in the results database", Run: historyCommand},
+		{Name: "play", Description: "replay the algorithms event by event at a chosen speed", Run: playCommand},
+		{Name: "serve", Description: "serve the web dashboard and the REST and WebSocket API for front-ends and autograders", Run: serveCommand},
+		{Name: "snapshot", Description: "simulate a workload up to a time and save the paused simulation", Run: snapshotCommand},
+		{Name: "resume", Description: "continue a saved simulation under one or more algorithms", Run: resumeCommand},
+		{Name: "whatif", Description: "rerun a workload with a burst, priority, or quantum changed from a time on and show what changes", Run: whatIfCommand},
+		{Name: "paging", Description: "simulate page replacement over a reference string and compare the faults", Run: pagingCommand},
+		{Name: "buddy", Description: "run a trace of allocations and frees through a buddy-system allocator", Run: buddyCommand},
+		{Name: "segment", Description: "translate logical addresses through segment tables and measure fragmentation", Run: segmentCommand},
+		{Name: "vm", Description: "schedule processes whose page faults block them, to see thrashing", Run: vmCommand},
+		{Name: "disk", Description: "compare disk-scheduling algorithms on a queue of cylinder requests", Run: diskCommand},
+		{Name: "io", Description: "couple the CPU schedule to a disk scheduler through processes' I/O bursts",
+			Run: ioCommand},
+		{Name: "banker", Description: "check the safety of a state or decide requests with the banker's algorithm",
+			Run: bankerCommand},
+		{Name: "deadlock", Description: "replay resource events and detect deadlocks in the resource-allocation graph",
+			Run: deadlockCommand},
+		{Name: "dining", Description: "simulate the dining philosophers under strategies that avoid deadlock, or not",
+			Run: diningCommand},
+		{Name: "rw", Description: "compare readers–writers lock policies on a generated access trace", Run: rwCommand},
+		{Name: "sync", Description: "run a trace of semaphore and mutex operations by threads, flagging misuse and deadlock",
+			Run: syncCommand},
+		{Name: "threads", Description: "compare many-to-one and one-to-one threading on processes with threads",
+			Run: threadsCommand},
+		{Name: "files", Description: "lay a trace of file creates, grows, and deletes out on a block device",
+			Run: filesCommand},
+		{Name: "acct", Description: "account for every process's user, system, wait, and blocked time, like time and ps",
+			Run: acctCommand},
+		{Name: "estimate", Description: "schedule SJF and SRTF by ever worse burst estimates to see their advantage erode",
+			Run: estimateCommand},
+		{Name: "closed", Description: "run a fixed population of users who think, submit a job, and wait for it",
+			Run: closedCommand},
+		{Name: "queue", Description: "compare simulated waits with queueing theory on M/M/1-like workloads",
+			Run: queueCommand},
+		{Name: "validate", Description: "check workload files without running any scheduler", Run: validateCommand},
+		{Name: "completion", Description: "print a bash, zsh, or fish completion script", Run: completionCommand},
+		{Name: "help", Description: "list the subcommands and algorithms, or show the flags of one command", Run: helpCommand},
+	}
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return command{}, false
+}
+
+// helpCommand shows the flags of the command named by args, or lists every command without one.
+func helpCommand(args []string) {
+	if len(args) > 0 {
+		if c, ok := lookupCommand(args[0]); ok && c.Name != "help" {
+			c.Run([]string{"-h"})
+			return
+		}
+	}
+	outputCommands(os.Stdout)
+}
+
+func outputCommands(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage: scheduler <command> [flags] [args]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		_, _ = fmt.Fprintf(w, "  %-11s %s\n", c.Name, c.Description)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Algorithms (select with -algorithms):")
+	outputAlgorithms(w, "  ")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, `Run "scheduler help <command>" or "scheduler <command> -h" for the flags of a command.`)
+}
+
+// commandUsage returns a flag.FlagSet usage function printing synopsis followed by the flag defaults, leaving out the
+// aliases aliasFlag registered.
+func commandUsage(fs *flag.FlagSet, synopsis string) func() {
+	return func() {
+		_, _ = fmt.Fprintf(fs.Output(), "usage: scheduler %s\n", synopsis)
+		documented := flag.NewFlagSet(fs.Name(), flag.ContinueOnError)
+		documented.SetOutput(fs.Output())
+		fs.VisitAll(func(f *flag.Flag) {
+			if f.Usage != "" {
+				documented.Var(f.Value, f.Name, f.Usage)
+				documented.Lookup(f.Name).DefValue = f.DefValue
+			}
+		})
+		documented.PrintDefaults()
+		_, _ = fmt.Fprintf(fs.Output(), "Any flag can also be set with a %sFLAG_NAME environment variable.\n", envPrefix)
+	}
+}
+
+// aliasFlag registers alias as an old name of fs's flag name, so scripts using it keep working. Aliases share the
+// flag's value and are left out of the usage, which documents only name.
+func aliasFlag(fs *flag.FlagSet, alias, name string) {
+	fs.Var(fs.Lookup(name).Value, alias, "")
+}
+
+//region generate
+
+func generateCommand(args []string) {
+	var opts workload.GenerateOptions
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "generate [flags] > workload.csv")
+	fs.IntVar(&opts.Count, "n", 10, "number of processes")
+	fs.Int64Var(&opts.MaxBurst, "max-burst", 10, "longest burst duration")
+	fs.Int64Var(&opts.MaxArrival, "max-arrival", 20, "latest arrival time")
+	fs.Int64Var(&opts.MaxPriority, "max-priority", 5, "largest (lowest) priority value")
+	profile := fs.String("profile", "uniform", "arrival and burst pattern to draw ("+profileNames()+")")
+	seed := fs.Int64("seed", 0, "random seed (default: based on the current time)")
+	fs.String("config", "",
+		"file of default flag values (default scheduler.toml, scheduler.yaml, or scheduler.yml if present)")
+	_ = fs.Parse(args)
+	if err := applyDefaults(fs); err != nil {
+		fatal(exitInvalid, err)
+	}
+
+	if opts.Count < 1 || opts.MaxBurst < 1 || opts.MaxArrival < 0 || opts.MaxPriority < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -n, -max-burst, and -max-priority must be positive", ErrInvalidArgs))
+	}
+	var err error
+	if opts.Profile, err = parseProfile(*profile); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *seed == 0 {
+		*seed = resolveSeed(*seed)
+		logger.Info("generated workload", "seed", *seed)
+	}
+	if err := workload.Write(os.Stdout, workload.Generate(rand.New(rand.NewSource(*seed)), opts)); err != nil {
+		fatal(exitFailure, err)
+	}
+}
+
+// profileNames lists the names of every generator profile for flag help and errors.
+func profileNames() string {
+	var names []string
+	for _, p := range workload.Profiles {
+		names = append(names, p.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parseProfile resolves a generator profile name.
+func parseProfile(name string) (workload.Profile, error) {
+	for _, p := range workload.Profiles {
+		if p.Name == strings.TrimSpace(name) {
+			return p, nil
+		}
+	}
+
+	return workload.Profile{}, fmt.Errorf("%w: unknown profile %q (want one of %s)", ErrInvalidArgs, name,
+		profileNames())
+}
+
+//endregion
+
+//region sweep
+
+func sweepCommand(args []string) {
+	fs := flag.NewFlagSet("sweep", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "sweep [flags] (workload.csv | -example name)")
+	quanta := fs.String("quantum", "1:8", "inclusive range of round-robin quanta to try, as from:to")
+	exampleName := fs.String("example", "", "sweep a bundled example workload instead of a file ("+exampleNames()+")")
+	recommend := fs.String("recommend", "",
+		"recommend the quantum giving the lowest value of this metric ("+strings.Join(sweepObjectiveNames, ", ")+")")
+	fs.Int64Var(&options.switchCost, "switch-cost", 0,
+		"time units charged for every context switch between two different processes")
+	fs.String("config", "",
+		"file of default flag values (default scheduler.toml, scheduler.yaml, or scheduler.yml if present)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyDefaults(fs); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+
+	from, to, err := parseRange(*quanta)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	objective, ok := sweepObjectives[*recommend]
+	if *recommend != "" && !ok {
+		fatal(exitInvalid, fmt.Errorf("%w: -recommend must be one of %s", ErrInvalidArgs, strings.Join(sweepObjectiveNames, ", ")))
+	}
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+
+	reports := make([]report.Report, 0, to-from+1)
+	for q := from; q <= to; q++ {
+		ctx := sched.WithLogger(context.Background(), logger)
+		reports = append(reports, schedule(ctx, io.Discard, roundRobin(q), fmt.Sprintf("q=%d", q), processes))
+	}
+	outputSweep(os.Stdout, from, reports)
+	outputSensitivity(os.Stdout, from, reports)
+	if objective != nil {
+		q := recommendQuantum(from, reports, objective)
+		_, _ = fmt.Fprintf(os.Stdout, "Recommended quantum for the lowest avg %s: %d (%.2f)\n",
+			*recommend, q, objective(reports[q-from]))
+	}
+}
+
+// parseRange parses an inclusive from:to range of positive integers; a single number is a range of one.
+func parseRange(s string) (int64, int64, error) {
+	lo, hi, found := strings.Cut(s, ":")
+	if !found {
+		hi = lo
+	}
+	from, err := strconv.ParseInt(strings.TrimSpace(lo), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: bad range %q", ErrInvalidArgs, s)
+	}
+	to, err := strconv.ParseInt(strings.TrimSpace(hi), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: bad range %q", ErrInvalidArgs, s)
+	}
+	if from < 1 || to < from {
+		return 0, 0, fmt.Errorf("%w: range %q must satisfy 1 <= from <= to", ErrInvalidArgs, s)
+	}
+
+	return from, to, nil
+}
+
+// outputSweep writes one row per quantum, starting at from.
+func outputSweep(w io.Writer, from int64, reports []report.Report) {
+	rows := make([][]string, len(reports))
+	for i, r := range reports {
+		rows[i] = []string{
+			fmt.Sprint(from + int64(i)),
+			fmt.Sprintf("%.2f", r.Summary.Wait),
+			fmt.Sprintf("%.2f", r.Summary.Turnaround),
+			fmt.Sprintf("%.2f", r.Summary.Normalized),
+			fmt.Sprint(r.Switches),
+			fmt.Sprintf("%.2f%%", r.Utilization()*100),
+		}
+	}
+	_, _ = fmt.Fprintln(w, "Round-robin quantum sweep")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Quantum", "Avg wait", "Avg turnaround", "Avg norm TAT", "Switches", "Utilization"})
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// sweepObjectives are the metrics sweep can recommend a quantum for, by -recommend name.
+var sweepObjectives = map[string]func(report.Report) float64{
+	"wait":       func(r report.Report) float64 { return r.Summary.Wait },
+	"turnaround": func(r report.Report) float64 { return r.Summary.Turnaround },
+	"normalized": func(r report.Report) float64 { return r.Summary.Normalized },
+}
+
+var sweepObjectiveNames = []string{"wait", "turnaround", "normalized"}
+
+// sensitivity is how much one metric moves across a quantum sweep.
+type sensitivity struct {
+	Metric resultMetric
+	// Slope is the least-squares change in the metric per unit of quantum, and Relative the same as a percentage of
+	// the metric's mean, which makes metrics of different units comparable.
+	Slope    float64
+	Relative float64
+	Low      float64
+	High     float64
+}
+
+// sensitivities returns the sensitivity of every metric in resultMetrics to the quanta from, from+1, ... of reports,
+// most sensitive first by relative slope.
+func sensitivities(from int64, reports []report.Report) []sensitivity {
+	xs := make([]float64, len(reports))
+	for i := range xs {
+		xs[i] = float64(from + int64(i))
+	}
+	result := make([]sensitivity, len(resultMetrics))
+	for i, m := range resultMetrics {
+		ys := make([]float64, len(reports))
+		var mean float64
+		for j, r := range reports {
+			ys[j] = m.Value(r)
+			mean += ys[j]
+		}
+		mean /= float64(len(ys))
+		s := sensitivity{Metric: m, Slope: report.Slope(xs, ys), Low: slices.Min(ys), High: slices.Max(ys)}
+		if mean != 0 {
+			s.Relative = s.Slope / math.Abs(mean) * 100
+		}
+		result[i] = s
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return math.Abs(result[i].Relative) > math.Abs(result[j].Relative)
+	})
+
+	return result
+}
+
+// outputSensitivity writes how much each metric changes per unit of quantum across the sweep, most sensitive first.
+// A sweep of a single quantum has nothing to compare and writes nothing.
+func outputSensitivity(w io.Writer, from int64, reports []report.Report) {
+	if len(reports) < 2 {
+		return
+	}
+	all := sensitivities(from, reports)
+	rows := make([][]string, len(all))
+	for i, s := range all {
+		rows[i] = []string{
+			s.Metric.Name,
+			fmt.Sprintf("%+.4f", s.Slope),
+			fmt.Sprintf("%+.2f%%", s.Relative),
+			fmt.Sprintf(s.Metric.Format+" - "+s.Metric.Format, s.Low, s.High),
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Sensitivity to the quantum (least-squares change per unit)")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Metric", "Per unit", "% of mean per unit", "Range"})
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Most sensitive: %s\n", all[0].Metric.Name)
+}
+
+// recommendQuantum returns the quantum of reports, which start at from, with the lowest objective. Ties go to the
+// longest quantum, which switches least.
+func recommendQuantum(from int64, reports []report.Report, objective func(report.Report) float64) int64 {
+	best := 0
+	for i, r := range reports {
+		if objective(r) <= objective(reports[best]) {
+			best = i
+		}
+	}
+
+	return from + int64(best)
+}
+
+//endregion
+
+// mustLoadWorkload opens and parses the workload file named by args, exiting on failure.
+func mustLoadWorkload(args []string) []workload.Process {
+	f, closeFile, err := openProcessingFile(append([]string{os.Args[0]}, args...)...)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	defer closeFile()
+
+	processes, err := workload.Load(f)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+
+	return processes
+}
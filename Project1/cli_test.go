@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_generateWorkload(t *testing.T) {
+	t.Parallel()
+	opts := workload.GenerateOptions{Count: 50, MaxBurst: 4, MaxArrival: 10, MaxPriority: 3}
+	processes := workload.Generate(rand.New(rand.NewSource(1)), opts)
+	if len(processes) != opts.Count {
+		t.Fatalf("generated %d processes, want %d", len(processes), opts.Count)
+	}
+	for i, p := range processes {
+		if p.ProcessID != int64(i+1) {
+			t.Errorf("process %d has PID %d", i, p.ProcessID)
+		}
+		if i > 0 && p.ArrivalTime < processes[i-1].ArrivalTime {
+			t.Errorf("process %d arrives before process %d", p.ProcessID, processes[i-1].ProcessID)
+		}
+		if p.BurstDuration < 1 || p.BurstDuration > opts.MaxBurst ||
+			p.ArrivalTime < 0 || p.ArrivalTime > opts.MaxArrival ||
+			p.Priority < 1 || p.Priority > opts.MaxPriority {
+			t.Errorf("process %+v out of bounds %+v", p, opts)
+		}
+	}
+
+	// the written workload loads back unchanged
+	var w bytes.Buffer
+	if err := workload.Write(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := workload.Load(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range loaded {
+		if loaded[i] != processes[i] {
+			t.Errorf("loaded %+v, want %+v", loaded[i], processes[i])
+		}
+	}
+}
+
+func Test_aliasFlag(t *testing.T) {
+	t.Parallel()
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	svg := fs.String("svg", "", "directory for SVG charts")
+	aliasFlag(fs, "histogram-svg", "svg")
+	if err := fs.Parse([]string{"-histogram-svg", "charts"}); err != nil || *svg != "charts" {
+		t.Errorf("-histogram-svg charts set -svg to %q, %v, want charts", *svg, err)
+	}
+
+	var usage bytes.Buffer
+	fs.SetOutput(&usage)
+	commandUsage(fs, "run")()
+	if !strings.Contains(usage.String(), "-svg") || strings.Contains(usage.String(), "histogram-svg") {
+		t.Errorf("usage should document -svg but not its alias:\n%s", usage.String())
+	}
+}
+
+func Test_parseProfile(t *testing.T) {
+	t.Parallel()
+	if got, err := parseProfile(" heavy-tail"); err != nil || got != workload.Profiles[3] {
+		t.Errorf("parseProfile(\"heavy-tail\") = %+v, %v", got, err)
+	}
+	if _, err := parseProfile("poisson"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseProfile(\"poisson\") error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_parseRange(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s        string
+		from, to int64
+		wantErr  error
+	}{
+		{s: "1:8", from: 1, to: 8},
+		{s: "3", from: 3, to: 3},
+		{s: "4:2", wantErr: ErrInvalidArgs},
+		{s: "0:2", wantErr: ErrInvalidArgs},
+		{s: "a:b", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			from, to, err := parseRange(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if from != tt.from || to != tt.to {
+				t.Errorf("parseRange() = %d, %d, want %d, %d", from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func Test_sensitivities(t *testing.T) {
+	t.Parallel()
+	reports := []report.Report{
+		{Summary: sched.Metrics{Wait: 10, Turnaround: 20}, Switches: 30},
+		{Summary: sched.Metrics{Wait: 8, Turnaround: 18}, Switches: 20},
+		{Summary: sched.Metrics{Wait: 8, Turnaround: 18}, Switches: 10},
+	}
+	all := sensitivities(2, reports)
+	if len(all) != len(resultMetrics) || all[0].Metric.Name != "context switches" || all[0].Slope != -10 ||
+		all[0].Relative != -50 || all[0].Low != 10 || all[0].High != 30 {
+		t.Errorf("most sensitive = %+v, want context switches falling 10 (50%%) per unit", all[0])
+	}
+
+	var out bytes.Buffer
+	outputSensitivity(&out, 2, reports)
+	if !strings.Contains(out.String(), "| context switches     | -10.0000 | -50.00%") ||
+		!strings.Contains(out.String(), "Most sensitive: context switches") {
+		t.Errorf("outputSensitivity() =\n%s", out.String())
+	}
+	out.Reset()
+	outputSensitivity(&out, 2, reports[:1])
+	if out.Len() != 0 {
+		t.Errorf("outputSensitivity() of one quantum =\n%s, want nothing", out.String())
+	}
+
+	// waits of 8 tie at quanta 3 and 4; the longer one switches less
+	if got := recommendQuantum(2, reports, sweepObjectives["wait"]); got != 4 {
+		t.Errorf("recommendQuantum() = %d, want 4", got)
+	}
+}
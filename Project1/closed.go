@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"github.com/olekukonko/tablewriter"
+)
+
+// closedModel describes the closed workloads the closed command runs: populations of Users from UsersFrom to
+// UsersTo, each user thinking for an exponentially distributed time averaging Think between jobs of bursts and
+// priorities drawn uniformly up to MaxBurst and MaxPriority, measured from Warmup up to Horizon.
+type closedModel struct {
+	UsersFrom   int64
+	UsersTo     int64
+	Think       float64
+	MaxBurst    int64
+	MaxPriority int64
+	Warmup      int64
+	Horizon     int64
+	Seed        int64
+}
+
+// loop returns the closed loop of users users. Each user draws its think times and jobs from a stream of its own, so
+// every algorithm sees the same jobs from the same user.
+func (m closedModel) loop(users int) sched.ClosedLoop {
+	rngs := make([]*rand.Rand, users)
+	for u := range rngs {
+		rngs[u] = newRand(m.Seed, fmt.Sprintf("closed/%d", u))
+	}
+
+	return sched.ClosedLoop{
+		Users:   users,
+		Horizon: m.Horizon,
+		Think: func(u int) int64 {
+			return int64(math.Round(rngs[u].ExpFloat64() * m.Think))
+		},
+		Job: func(u int) (int64, int64) {
+			return rngs[u].Int63n(m.MaxBurst) + 1, rngs[u].Int63n(m.MaxPriority) + 1
+		},
+	}
+}
+
+func closedCommand(args []string) {
+	var m closedModel
+	fs := flag.NewFlagSet("closed", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "closed [flags]")
+	users := fs.String("users", "10", "number of users, or an inclusive from:to range of them to compare")
+	fs.Float64Var(&m.Think, "think", 20, "mean think time between a job's completion and its user's next submission")
+	fs.Int64Var(&m.MaxBurst, "max-burst", 10, "longest burst of a job")
+	fs.Int64Var(&m.MaxPriority, "max-priority", 5, "largest (lowest) priority value of a job")
+	fs.Int64Var(&m.Horizon, "horizon", 2000, "time at which users stop submitting jobs and the run ends")
+	fs.Int64Var(&m.Warmup, "warmup", 200, "time before which finished jobs are left out of the steady state")
+	selected := fs.String("algorithms", "fcfs,sjf,rr",
+		"comma-separated algorithms to compare, in order ("+resumableNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 1, "round-robin time quantum")
+	fs.Int64Var(&m.Seed, "seed", 0, "random seed for the think times and jobs (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	var err error
+	if m.UsersFrom, m.UsersTo, err = parseRange(*users); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if m.Think < 0 || m.MaxBurst < 1 || m.MaxPriority < 1 || m.Warmup < 0 || m.Horizon <= m.Warmup ||
+		options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -think must not be negative, -max-burst, -max-priority, and -quantum must "+
+			"be positive, and -horizon must come after -warmup", ErrInvalidArgs))
+	}
+	run, err := parseAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	for _, a := range run {
+		if _, ok := resumable[a.Name()]; !ok {
+			fatal(exitInvalid, fmt.Errorf("%w: closed cannot simulate %s (want one of %s)", ErrInvalidArgs,
+				a.Name(), resumableNames()))
+		}
+	}
+	m.Seed = resolveSeed(m.Seed)
+
+	ctx := sched.WithLogger(context.Background(), logger)
+	var (
+		names  []string
+		states []report.SteadyState
+	)
+	for _, a := range run {
+		for n := m.UsersFrom; n <= m.UsersTo; n++ {
+			policy := whatIfPolicy(a.Name(), func(int64) int64 { return options.quantum })
+			r, err := sched.RunClosed(ctx, m.loop(int(n)), policy)
+			if err != nil {
+				fatal(exitCode(err), err)
+			}
+			names = append(names, a.Title)
+			states = append(states, report.NewSteadyState(r, int(n), m.Think, m.Warmup, m.Horizon))
+		}
+	}
+	outputClosed(os.Stdout, m, names, states)
+}
+
+// outputClosed writes the steady state of every closed run, titled by names, with the response time the interactive
+// response time law predicts from its throughput.
+func outputClosed(w io.Writer, m closedModel, names []string, states []report.SteadyState) {
+	outputTitle(w, "Closed workload")
+	_, _ = fmt.Fprintf(w, "Jobs of 1 to %d units after think times averaging %g; steady state from %d to %d, seed %d\n\n",
+		m.MaxBurst, m.Think, m.Warmup, m.Horizon, m.Seed)
+	table := tablewriter.NewWriter(w)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{"Algorithm", "Users", "Jobs", "Throughput", "Avg response", "Avg wait",
+		"CPU utilization", "N/X − Z"})
+	for i, s := range states {
+		table.Append([]string{names[i], fmt.Sprint(s.Users), fmt.Sprint(s.Jobs), fmt.Sprintf("%.4f", s.Throughput),
+			fmt.Sprintf("%.2f", s.Response), fmt.Sprintf("%.2f", s.Wait), fmt.Sprintf("%.1f%%", 100*s.Utilization),
+			fmt.Sprintf("%.2f", s.LawResponse())})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w, "Throughput is jobs finished per time unit. N/X − Z is the average response the "+
+		"interactive response time law predicts from it; the further it is from Avg response, the less settled or "+
+		"the shorter the window.")
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+)
+
+func Test_closedModel_loop(t *testing.T) {
+	t.Parallel()
+	m := closedModel{Think: 5, MaxBurst: 4, MaxPriority: 2, Horizon: 100, Seed: 1}
+	a, b := m.loop(3), m.loop(3)
+	for u := 0; u < 3; u++ {
+		for i := 0; i < 20; i++ {
+			think := a.Think(u)
+			burst, priority := a.Job(u)
+			// every run of the same model sees the same jobs from the same user
+			if think != b.Think(u) {
+				t.Fatalf("user %d thinks differently in two loops", u)
+			}
+			if again, _ := b.Job(u); again != burst {
+				t.Fatalf("user %d submits different jobs in two loops", u)
+			}
+			if think < 0 || burst < 1 || burst > m.MaxBurst || priority < 1 || priority > m.MaxPriority {
+				t.Errorf("user %d thinks %d and submits a burst of %d at priority %d", u, think, burst, priority)
+			}
+		}
+	}
+}
+
+func Test_outputClosed(t *testing.T) {
+	t.Parallel()
+	m := closedModel{Think: 20, MaxBurst: 10, Warmup: 200, Horizon: 2000, Seed: 1}
+	states := []report.SteadyState{{Users: 5, Think: 20, Jobs: 270, Throughput: 0.15, Response: 10.78, Wait: 5.29,
+		Utilization: 0.821}}
+	var w bytes.Buffer
+	outputClosed(&w, m, []string{"First-come, first-serve"}, states)
+	for _, want := range []string{
+		"Jobs of 1 to 10 units after think times averaging 20; steady state from 200 to 2000, seed 1",
+		"| First-come, first-serve |     5 |  270 |     0.1500 |        10.78 |     5.29 | 82.1%           |   13.33 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputClosed() missing %q:\n%s", want, w.String())
+		}
+	}
+}
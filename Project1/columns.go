@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// scheduleColumn is one selectable column of the schedule table.
+type scheduleColumn struct {
+	Name   string
+	Header string
+	Value  func(p workload.Process) string
+	Footer func(s sched.Metrics) string
+}
+
+var scheduleColumns = []scheduleColumn{
+	{
+		Name:   "id",
+		Header: "ID",
+		Value:  func(p workload.Process) string { return fmt.Sprint(p.ProcessID) },
+	},
+	{
+		Name:   "priority",
+		Header: "Priority",
+		Value:  func(p workload.Process) string { return fmt.Sprint(p.Priority) },
+	},
+	{
+		Name:   "burst",
+		Header: "Burst",
+		Value:  func(p workload.Process) string { return fmt.Sprint(p.Burst) },
+	},
+	{
+		Name:   "arrival",
+		Header: "Arrival",
+		Value:  func(p workload.Process) string { return fmt.Sprint(p.ArrivalTime) },
+	},
+	{
+		Name:   "wait",
+		Header: "Wait",
+		Value:  func(p workload.Process) string { return fmt.Sprint(p.Wait) },
+		Footer: func(s sched.Metrics) string { return fmt.Sprintf("Average\n%.2f", s.Wait) },
+	},
+	{
+		Name:   "turnaround",
+		Header: "Turnaround",
+		Value:  func(p workload.Process) string { return fmt.Sprint(p.Turnaround) },
+		Footer: func(s sched.Metrics) string { return fmt.Sprintf("Average\n%.2f", s.Turnaround) },
+	},
+	{
+		Name:   "ntat",
+		Header: "Norm TAT",
+		Value:  func(p workload.Process) string { return fmt.Sprintf("%.2f", sched.NormalizedTurnaround(p)) },
+		Footer: func(s sched.Metrics) string { return fmt.Sprintf("Average\n%.2f", s.Normalized) },
+	},
+	{
+		Name:   "exit",
+		Header: "Exit",
+		Value:  func(p workload.Process) string { return fmt.Sprint(p.Completion) },
+		Footer: func(s sched.Metrics) string { return fmt.Sprintf("Throughput\n%.2f/t", s.Throughput) },
+	},
+}
+
+// parseColumns resolves a comma-separated list of column names, in the order given. An empty list selects every
+// column.
+func parseColumns(s string) ([]scheduleColumn, error) {
+	if strings.TrimSpace(s) == "" {
+		return scheduleColumns, nil
+	}
+	columns := make([]scheduleColumn, 0)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, c := range scheduleColumns {
+			if c.Name == name {
+				columns = append(columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown column %q (want one of %s)", ErrInvalidArgs, name, columnNames())
+		}
+	}
+
+	return columns, nil
+}
+
+func columnNames() string {
+	names := make([]string, len(scheduleColumns))
+	for i := range scheduleColumns {
+		names[i] = scheduleColumns[i].Name
+	}
+
+	return strings.Join(names, ",")
+}
+
+// scheduleOrders maps a -sort value to the ordering it applies to the schedule table rows. Ties keep the order the
+// scheduler reported them in.
+var scheduleOrders = map[string]func(a, b workload.Process) bool{
+	"pid":        func(a, b workload.Process) bool { return a.ProcessID < b.ProcessID },
+	"arrival":    func(a, b workload.Process) bool { return a.ArrivalTime < b.ArrivalTime },
+	"burst":      func(a, b workload.Process) bool { return a.Burst < b.Burst },
+	"priority":   func(a, b workload.Process) bool { return a.Priority < b.Priority },
+	"wait":       func(a, b workload.Process) bool { return a.Wait < b.Wait },
+	"turnaround": func(a, b workload.Process) bool { return a.Turnaround < b.Turnaround },
+	"completion": func(a, b workload.Process) bool { return a.Completion < b.Completion },
+}
+
+// validateSortOrder reports whether s names one of scheduleOrders; the empty string keeps the scheduler's order.
+func validateSortOrder(s string) error {
+	if _, ok := scheduleOrders[s]; ok || s == "" {
+		return nil
+	}
+	names := make([]string, 0, len(scheduleOrders))
+	for name := range scheduleOrders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return fmt.Errorf("%w: unknown sort order %q (want one of %s)", ErrInvalidArgs, s, strings.Join(names, ","))
+}
+
+// sortedRows returns a copy of done ordered by the named sort order.
+func sortedRows(done []workload.Process, order string) []workload.Process {
+	rows := make([]workload.Process, len(done))
+	copy(rows, done)
+	if less, ok := scheduleOrders[order]; ok {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return less(rows[i], rows[j])
+		})
+	}
+
+	return rows
+}
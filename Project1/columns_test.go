@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_parseColumns(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "default",
+			want: []string{"id", "priority", "burst", "arrival", "wait", "turnaround", "ntat", "exit"},
+		},
+		{
+			name: "subset reordered",
+			s:    "wait, ID,exit",
+			want: []string{"wait", "id", "exit"},
+		},
+		{
+			name:    "unknown",
+			s:       "id,bogus",
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseColumns(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, c := range got {
+				names = append(names, c.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("parseColumns() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sortedRows(t *testing.T) {
+	t.Parallel()
+	done := []workload.Process{
+		{ProcessID: 1, Wait: 4, Completion: 9},
+		{ProcessID: 2, Wait: 0, Completion: 3},
+		{ProcessID: 3, Wait: 4, Completion: 6},
+	}
+	pids := func(ps []workload.Process) []int64 {
+		ids := make([]int64, len(ps))
+		for i := range ps {
+			ids[i] = ps[i].ProcessID
+		}
+		return ids
+	}
+	if got, want := pids(sortedRows(done, "wait")), []int64{2, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sort by wait = %v, want %v", got, want)
+	}
+	if got, want := pids(sortedRows(done, "completion")), []int64{2, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sort by completion = %v, want %v", got, want)
+	}
+	if got, want := pids(sortedRows(done, "")), []int64{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scheduler order = %v, want %v", got, want)
+	}
+	if err := validateSortOrder("bogus"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("validateSortOrder() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"github.com/olekukonko/tablewriter"
+)
+
+// maxGanttDivergences caps how many differing Gantt slices compare lists per algorithm.
+const maxGanttDivergences = 10
+
+// compareCommand diffs two result sets saved with -output json, exiting with exitDiffer if they disagree.
+func compareCommand(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "compare a.json b.json")
+	_ = fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		fatal(exitInvalid, fmt.Errorf("%w: must give two result files to compare", ErrInvalidArgs))
+	}
+
+	a, err := loadResults(fs.Arg(0))
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	b, err := loadResults(fs.Arg(1))
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	if outputComparison(os.Stdout, a, b) {
+		os.Exit(exitDiffer)
+	}
+}
+
+// loadResults reads a result set written by -output json.
+func loadResults(path string) ([]report.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: opening result file", err)
+	}
+	defer f.Close()
+
+	var reports []report.Report
+	if err := json.NewDecoder(f).Decode(&reports); err != nil {
+		return nil, fmt.Errorf("%w: %s is not a JSON result set", err, path)
+	}
+
+	return reports, nil
+}
+
+// resultMetric is one number compare shows side by side.
+type resultMetric struct {
+	Name  string
+	Value func(report.Report) float64
+	// Format prints values and deltas of the metric.
+	Format string
+}
+
+var resultMetrics = []resultMetric{
+	{Name: "avg wait", Value: func(r report.Report) float64 { return r.Summary.Wait }, Format: "%.2f"},
+	{Name: "avg turnaround", Value: func(r report.Report) float64 { return r.Summary.Turnaround }, Format: "%.2f"},
+	{Name: "avg norm TAT", Value: func(r report.Report) float64 { return r.Summary.Normalized }, Format: "%.2f"},
+	{Name: "throughput", Value: func(r report.Report) float64 { return r.Summary.Throughput }, Format: "%.4f"},
+	{Name: "utilization", Value: report.Report.Utilization, Format: "%.4f"},
+	{Name: "context switches", Value: func(r report.Report) float64 { return float64(r.Switches) }, Format: "%.0f"},
+	{Name: "fairness (CPU share)", Value: func(r report.Report) float64 { return r.Fairness.Share }, Format: "%.4f"},
+	{Name: "fairness (wait)", Value: func(r report.Report) float64 { return r.Fairness.Wait }, Format: "%.4f"},
+}
+
+// metricDeltas returns a row for every resultMetric with its value in a and b and the delta b minus a, or "=" where
+// they agree to the metric's precision, and reports whether any disagreed.
+func metricDeltas(a, b report.Report) ([][]string, bool) {
+	differ := false
+	rows := make([][]string, 0, len(resultMetrics))
+	for _, m := range resultMetrics {
+		va, vb := m.Value(a), m.Value(b)
+		delta := fmt.Sprintf("%+"+m.Format[1:], vb-va)
+		if fmt.Sprintf(m.Format, va) == fmt.Sprintf(m.Format, vb) {
+			delta = "="
+		} else {
+			differ = true
+		}
+		rows = append(rows, []string{m.Name, fmt.Sprintf(m.Format, va), fmt.Sprintf(m.Format, vb), delta})
+	}
+
+	return rows, differ
+}
+
+// ganttDivergence is a position at which two Gantt charts hold different slices; a nil side ran out of slices.
+type ganttDivergence struct {
+	Index int
+	A, B  *sched.TimeSlice
+}
+
+// ganttDivergences compares a and b slice by slice.
+func ganttDivergences(a, b []sched.TimeSlice) []ganttDivergence {
+	var diffs []ganttDivergence
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var sa, sb *sched.TimeSlice
+		if i < len(a) {
+			sa = &a[i]
+		}
+		if i < len(b) {
+			sb = &b[i]
+		}
+		if sa == nil || sb == nil || *sa != *sb {
+			diffs = append(diffs, ganttDivergence{Index: i, A: sa, B: sb})
+		}
+	}
+
+	return diffs
+}
+
+func formatSlice(s *sched.TimeSlice) string {
+	switch {
+	case s == nil:
+		return "(none)"
+	case s.Switch:
+		return fmt.Sprintf("switch to P%d %d-%d", s.PID, s.Start, s.Stop)
+	}
+
+	return fmt.Sprintf("P%d %d-%d", s.PID, s.Start, s.Stop)
+}
+
+// outputComparison writes the metric deltas (b minus a) and divergent Gantt slices of every algorithm, matched by
+// title, and reports whether the result sets differ at all.
+func outputComparison(w io.Writer, a, b []report.Report) bool {
+	differ := false
+	matched := make(map[string]bool)
+	for _, ra := range a {
+		var rb *report.Report
+		for i := range b {
+			if b[i].Title == ra.Title {
+				rb = &b[i]
+				break
+			}
+		}
+		if rb == nil {
+			_, _ = fmt.Fprintf(w, "%s: only in the first result set\n\n", ra.Title)
+			differ = true
+			continue
+		}
+		matched[ra.Title] = true
+
+		rows, changed := metricDeltas(ra, *rb)
+		if changed {
+			differ = true
+		}
+		_, _ = fmt.Fprintln(w, ra.Title)
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"Metric", "A", "B", "Delta"})
+		table.AppendBulk(rows)
+		table.Render()
+
+		diffs := ganttDivergences(ra.Gantt, rb.Gantt)
+		if len(diffs) == 0 {
+			_, _ = fmt.Fprint(w, "Gantt charts match\n\n")
+			continue
+		}
+		differ = true
+		_, _ = fmt.Fprintf(w, "Gantt charts differ at %d slices:\n", len(diffs))
+		for i, d := range diffs {
+			if i == maxGanttDivergences {
+				_, _ = fmt.Fprintf(w, "  ... and %d more\n", len(diffs)-i)
+				break
+			}
+			_, _ = fmt.Fprintf(w, "  slice %d: %s vs %s\n", d.Index, formatSlice(d.A), formatSlice(d.B))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	for _, rb := range b {
+		if !matched[rb.Title] {
+			_, _ = fmt.Fprintf(w, "%s: only in the second result set\n\n", rb.Title)
+			differ = true
+		}
+	}
+
+	return differ
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_ganttDivergences(t *testing.T) {
+	t.Parallel()
+	a := []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 5}}
+	tests := []struct {
+		name string
+		b    []sched.TimeSlice
+		want []int
+	}{
+		{name: "same", b: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 5}}},
+		{name: "different slice", b: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 2, Stop: 5}}, want: []int{0}},
+		{name: "switch differs", b: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 5, Switch: true}}, want: []int{1}},
+		{name: "shorter", b: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}}, want: []int{1}},
+		{name: "longer", b: append(append([]sched.TimeSlice(nil), a...), sched.TimeSlice{PID: 3, Start: 5, Stop: 6}), want: []int{2}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []int
+			for _, d := range ganttDivergences(a, tt.b) {
+				got = append(got, d.Index)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ganttDivergences() at %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputComparison(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+	}
+	fcfs := scheduleWith("fcfs")(context.Background(), io.Discard, "FCFS", processes)
+	sjf := scheduleWith("sjf")(context.Background(), io.Discard, "SJF", processes)
+
+	// results survive the trip through -output json
+	path := filepath.Join(t.TempDir(), "a.json")
+	var saved bytes.Buffer
+	if err := encodeJSON(&saved, []report.Report{fcfs, sjf}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, saved.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a, err := loadResults(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var w bytes.Buffer
+	if outputComparison(&w, a, []report.Report{fcfs, sjf}) {
+		t.Errorf("a result set differs from itself:\n%s", w.String())
+	}
+
+	// SJF saved under FCFS's title: every difference is reported
+	renamed := sjf
+	renamed.Title = "FCFS"
+	w.Reset()
+	if !outputComparison(&w, a, []report.Report{renamed}) {
+		t.Error("different results compared equal")
+	}
+	for _, want := range []string{"| avg wait", "Gantt charts differ", "SJF: only in the first result set"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("comparison missing %q:\n%s", want, w.String())
+		}
+	}
+}
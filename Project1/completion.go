@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// completionValue lists the values a flag takes, for completing its argument. List flags take comma-separated
+// values, and each element after a comma is completed on its own.
+type completionValue struct {
+	Flag   string
+	Values []string
+	List   bool
+}
+
+// completionValues returns the flags whose values the completion scripts know, drawn from the same tables the flags
+// are validated against.
+func completionValues() []completionValue {
+	orders := make([]string, 0, len(scheduleOrders))
+	for name := range scheduleOrders {
+		orders = append(orders, name)
+	}
+	sort.Strings(orders)
+	formats := []string{"text"}
+	for name := range resultEncoders {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats[1:])
+
+	return []completionValue{
+		{Flag: "algorithms", Values: algorithms.Names(), List: true},
+		{Flag: "algorithm", Values: strings.Split(resumableNames(), ",")},
+		{Flag: "columns", Values: strings.Split(columnNames(), ","), List: true},
+		{Flag: "sort", Values: orders},
+		{Flag: "output", Values: formats},
+		{Flag: "summary", Values: []string{"kv", "json"}},
+		{Flag: "example", Values: strings.Split(exampleNames(), ",")},
+		{Flag: "profile", Values: strings.Split(profileNames(), ",")},
+		{Flag: "log-level", Values: []string{"debug", "info", "warn", "error"}},
+		{Flag: "log-format", Values: []string{"text", "json"}},
+	}
+}
+
+// completionScripts holds a completion script template per shell. The scripts ask the binary itself for a
+// command's flags by parsing its -h output, so they never fall out of date with the flag sets.
+var completionScripts = map[string]*template.Template{
+	"bash": completionTemplate("bash", bashCompletion),
+	"zsh":  completionTemplate("zsh", zshCompletion),
+	"fish": completionTemplate("fish", fishCompletion),
+}
+
+func completionTemplate(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(template.FuncMap{
+		"join":   strings.Join,
+		"squote": func(s string) string { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'" },
+		"fquote": func(s string) string { return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'" },
+		"zdesc":  func(s string) string { return strings.ReplaceAll(s, ":", `\:`) },
+	}).Parse(text))
+}
+
+func completionCommand(args []string) {
+	if len(args) != 1 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		_, _ = fmt.Fprintln(os.Stderr, "usage: scheduler completion (bash | zsh | fish)")
+		_, _ = fmt.Fprintln(os.Stderr)
+		_, _ = fmt.Fprintln(os.Stderr, "Print a shell completion script. For example:")
+		_, _ = fmt.Fprintln(os.Stderr, "  bash: source <(scheduler completion bash)")
+		_, _ = fmt.Fprintln(os.Stderr, "  zsh:  scheduler completion zsh > \"${fpath[1]}/_scheduler\"")
+		_, _ = fmt.Fprintln(os.Stderr, "  fish: scheduler completion fish > ~/.config/fish/completions/scheduler.fish")
+		os.Exit(exitInvalid)
+	}
+	if err := outputCompletion(os.Stdout, args[0]); err != nil {
+		fatal(exitInvalid, err)
+	}
+}
+
+// outputCompletion writes the completion script for shell.
+func outputCompletion(w io.Writer, shell string) error {
+	t, ok := completionScripts[shell]
+	if !ok {
+		return fmt.Errorf("%w: unknown shell %q (want bash, zsh, or fish)", ErrInvalidArgs, shell)
+	}
+	names := make([]string, len(commands))
+	for i := range commands {
+		names[i] = commands[i].Name
+	}
+	data := struct {
+		Commands []command
+		Names    []string
+		Values   []completionValue
+	}{commands, names, completionValues()}
+	if err := t.Execute(w, data); err != nil {
+		return fmt.Errorf("%w: writing %s completion", err, shell)
+	}
+
+	return nil
+}
+
+const bashCompletion = `# bash completion for scheduler
+_scheduler() {
+	local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]} cmd=run prefix=
+	if [[ COMP_CWORD -gt 1 && " {{join .Names " "}} " == *" ${COMP_WORDS[1]} "* ]]; then
+		cmd=${COMP_WORDS[1]}
+	fi
+	prev=${prev#-}
+	case ${prev#-} in
+{{- range .Values}}
+	{{.Flag}})
+{{- if .List}}
+		if [[ $cur == *,* ]]; then
+			prefix=${cur%,*},
+			cur=${cur##*,}
+		fi
+{{- end}}
+		COMPREPLY=($(compgen -P "$prefix" -W {{squote (join .Values " ")}} -- "$cur"))
+		return
+		;;
+{{- end}}
+	esac
+	if [[ $cur == -* ]]; then
+		COMPREPLY=($(compgen -W "$("${COMP_WORDS[0]}" "$cmd" -h 2>&1 | sed -n 's/^  \(-[a-z0-9-]*\).*/\1/p')" -- "$cur"))
+	elif [[ COMP_CWORD -eq 1 ]]; then
+		COMPREPLY=($(compgen -W {{squote (join .Names " ")}} -- "$cur") $(compgen -f -- "$cur"))
+	else
+		COMPREPLY=($(compgen -f -- "$cur"))
+	fi
+}
+complete -o filenames -F _scheduler scheduler
+`
+
+const zshCompletion = `#compdef scheduler
+# zsh completion for scheduler
+_scheduler() {
+	local -a subcommands flags
+	subcommands=(
+{{- range .Commands}}
+		{{squote (printf "%s:%s" .Name (zdesc .Description))}}
+{{- end}}
+	)
+	local cmd=run
+	if (( CURRENT > 2 && ${subcommands[(I)$words[2]:*]} )); then
+		cmd=$words[2]
+	fi
+	case ${${words[CURRENT-1]#-}#-} in
+{{- range .Values}}
+	({{.Flag}})
+{{- if .List}}
+		compset -P '*,'
+{{- end}}
+		compadd -- {{join .Values " "}}
+		return
+		;;
+{{- end}}
+	esac
+	if [[ $PREFIX == -* ]]; then
+		flags=(${(f)"$("$words[1]" $cmd -h 2>&1 | sed -n 's/^  \(-[a-z0-9-]*\).*/\1/p')"})
+		compadd -- $flags
+	elif (( CURRENT == 2 )); then
+		_describe 'command' subcommands
+		_files
+	else
+		_files
+	fi
+}
+if [[ $funcstack[1] == _scheduler ]]; then
+	_scheduler "$@"
+else
+	compdef _scheduler scheduler
+fi
+`
+
+const fishCompletion = `# fish completion for scheduler
+function __scheduler_command
+	set -l words (commandline -opc)
+	if contains -- "$words[2]" {{join .Names " "}}
+		echo $words[2]
+	else
+		echo run
+	end
+end
+
+function __scheduler_flags
+	scheduler (__scheduler_command) -h 2>&1 | string replace -rf '^  -([a-z0-9-]+).*' '-$1'
+end
+
+{{- range .Commands}}
+complete -c scheduler -n __fish_use_subcommand -a {{.Name}} -d {{fquote .Description}}
+{{- end}}
+complete -c scheduler -n 'string match -q -- "-*" (commandline -ct)' -a '(__scheduler_flags)'
+{{- range .Values}}
+complete -c scheduler -o {{.Flag}} -x -a {{fquote (join .Values " ")}}
+{{- end}}
+`
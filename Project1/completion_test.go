@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func Test_outputCompletion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		shell  string
+		syntax []string // command checking the script's syntax, read from stdin
+	}{
+		{shell: "bash", syntax: []string{"bash", "-n"}},
+		{shell: "zsh", syntax: []string{"zsh", "-n"}},
+		{shell: "fish", syntax: []string{"fish", "--no-execute"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.shell, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := outputCompletion(&w, tt.shell); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"selftest", "completion", "fcfs sjf priority rr", "msgpack", "convoy"} {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("%s completion missing %q", tt.shell, want)
+				}
+			}
+
+			if _, err := exec.LookPath(tt.syntax[0]); err != nil {
+				t.Skipf("%s not installed, so the syntax is unchecked", tt.syntax[0])
+			}
+			cmd := exec.Command(tt.syntax[0], tt.syntax[1:]...)
+			cmd.Stdin = &w
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("%s rejects the script: %v\n%s", tt.shell, err, out)
+			}
+		})
+	}
+
+	if err := outputCompletion(&bytes.Buffer{}, "tcsh"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("outputCompletion(tcsh) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_outputCommands(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputCommands(&w)
+	for _, c := range commands {
+		if !strings.Contains(w.String(), "  "+c.Name+" ") {
+			t.Errorf("help missing command %q:\n%s", c.Name, w.String())
+		}
+	}
+	for _, a := range algorithms.Algorithms() {
+		if !strings.Contains(w.String(), a.Description) {
+			t.Errorf("help missing the description of %q:\n%s", a.Name(), w.String())
+		}
+	}
+}
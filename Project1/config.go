@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultConfigFiles are looked for in the working directory when no -config is given.
+var defaultConfigFiles = []string{"scheduler.toml", "scheduler.yaml", "scheduler.yml"}
+
+// config maps a section name to its key/value settings. Top-level keys live in the "" section and apply to every
+// command that has a flag of that name; keys in a section named after a command apply to that command only.
+type config map[string]map[string]string
+
+// envPrefix starts the environment variable that sets each flag, e.g. SCHED_QUANTUM for -quantum.
+const envPrefix = "SCHED_"
+
+// applyDefaults fills in every flag of fs not given on the command line, first from the environment and then from
+// the config file, so flags beat the environment and the environment beats the file.
+func applyDefaults(fs *flag.FlagSet) error {
+	if err := applyEnv(fs, os.LookupEnv); err != nil {
+		return err
+	}
+	path := ""
+	if f := fs.Lookup("config"); f != nil {
+		path = f.Value.String()
+	}
+
+	return applyConfig(fs, path)
+}
+
+// envName returns the environment variable for a flag: envPrefix, then the name upper-cased with - as _.
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// applyEnv sets every flag of fs that was not given on the command line and has its envName variable set.
+func applyEnv(fs *flag.FlagSet, lookup func(string) (string, bool)) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		value, ok := lookup(envName(f.Name))
+		if !ok || explicit[f.Name] || err != nil {
+			return
+		}
+		if setErr := fs.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("%w: %s: %v", ErrInvalidArgs, envName(f.Name), setErr)
+		}
+	})
+
+	return err
+}
+
+// applyConfig sets every flag of fs that was not given on the command line from the config file at path, or from
+// the first of defaultConfigFiles that exists when path is empty. Keys are flag names, so anything a flag can set
+// can be configured.
+func applyConfig(fs *flag.FlagSet, path string) error {
+	if path == "" {
+		for _, name := range defaultConfigFiles {
+			if _, err := os.Stat(name); err == nil {
+				path = name
+				break
+			}
+		}
+		if path == "" {
+			return nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%w: opening config file", err)
+	}
+	defer f.Close()
+
+	var cfg config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		cfg, err = parseTOMLConfig(f)
+	case ".yaml", ".yml":
+		cfg, err = parseYAMLConfig(f)
+	default:
+		return fmt.Errorf("%w: config file %s must be .toml, .yaml, or .yml", ErrInvalidArgs, path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, path)
+	}
+
+	return cfg.apply(fs)
+}
+
+// apply sets the flags of fs from the top-level keys and then the fs.Name() section, skipping flags that were set
+// explicitly.
+func (c config) apply(fs *flag.FlagSet) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for key, value := range c[""] {
+		if fs.Lookup(key) == nil || explicit[key] {
+			continue
+		}
+		if err := fs.Set(key, value); err != nil {
+			return fmt.Errorf("%w: config %s: %v", ErrInvalidArgs, key, err)
+		}
+	}
+	for key, value := range c[fs.Name()] {
+		if fs.Lookup(key) == nil {
+			return fmt.Errorf("%w: config [%s] has unknown setting %q", ErrInvalidArgs, fs.Name(), key)
+		}
+		if explicit[key] {
+			continue
+		}
+		if err := fs.Set(key, value); err != nil {
+			return fmt.Errorf("%w: config [%s] %s: %v", ErrInvalidArgs, fs.Name(), key, err)
+		}
+	}
+
+	return nil
+}
+
+func (c config) set(section, key, value string) {
+	if c[section] == nil {
+		c[section] = make(map[string]string)
+	}
+	c[section][key] = value
+}
+
+// parseTOMLConfig reads the flat subset of TOML the settings need: [section] headers and key = value pairs whose
+// values are strings, numbers, booleans, or arrays of those.
+func parseTOMLConfig(r io.Reader) (config, error) {
+	cfg := make(config)
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("%w: line %d: want key = value", ErrInvalidArgs, n)
+		}
+		v, err := configValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d", err, n)
+		}
+		cfg.set(section, strings.TrimSpace(key), v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading config", err)
+	}
+
+	return cfg, nil
+}
+
+// parseYAMLConfig reads the flat subset of YAML the settings need: top-level key: value pairs, one level of
+// command sections holding indented pairs, and lists either inline ([a, b]) or as "- item" lines.
+func parseYAMLConfig(r io.Reader) (config, error) {
+	cfg := make(config)
+	var (
+		section string
+		open    string   // key whose value is still empty: a section or a block list
+		list    []string // "- item" lines collected for open
+	)
+	flush := func() {
+		if list != nil {
+			cfg.set(section, open, strings.Join(list, ","))
+		}
+		open, list = "", nil
+	}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		raw := stripComment(scanner.Text())
+		line := strings.TrimSpace(raw)
+		if line == "" || line == "---" {
+			continue
+		}
+		indented := raw[0] == ' ' || raw[0] == '\t'
+
+		if strings.HasPrefix(line, "- ") {
+			if open == "" {
+				return nil, fmt.Errorf("%w: line %d: list item without a key", ErrInvalidArgs, n)
+			}
+			v, err := configValue(strings.TrimPrefix(line, "- "))
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, n)
+			}
+			list = append(list, v)
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("%w: line %d: want key: value", ErrInvalidArgs, n)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case !indented:
+			flush()
+			section = ""
+		case open != "" && list == nil && section == "":
+			// the first indented pair under an empty top-level key makes it a section
+			section, open = open, ""
+		default:
+			flush()
+		}
+		if value == "" {
+			open = key
+			continue
+		}
+		v, err := configValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d", err, n)
+		}
+		cfg.set(section, key, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading config", err)
+	}
+	flush()
+
+	return cfg, nil
+}
+
+// configValue unquotes a scalar or flattens an inline [a, "b"] array into the comma-separated form the flags take.
+func configValue(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		items := strings.Split(s[1:len(s)-1], ",")
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			if strings.TrimSpace(item) == "" {
+				continue
+			}
+			v, err := configValue(item)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, v)
+		}
+		return strings.Join(values, ","), nil
+	}
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') {
+		if s[0] == '\'' && s[len(s)-1] == '\'' {
+			return s[1 : len(s)-1], nil
+		}
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("%w: bad string %s", ErrInvalidArgs, s)
+		}
+		return v, nil
+	}
+
+	return s, nil
+}
+
+// stripComment drops a # comment that is not inside quotes.
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
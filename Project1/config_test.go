@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseConfig(t *testing.T) {
+	t.Parallel()
+	want := config{
+		"":    {"quantum": "4", "algorithms": "fcfs,rr", "output": "json"},
+		"run": {"sort": "wait", "no-color": "true"},
+	}
+	tests := []struct {
+		name  string
+		parse func(string) (config, error)
+		in    string
+	}{
+		{
+			name:  "toml",
+			parse: func(s string) (config, error) { return parseTOMLConfig(strings.NewReader(s)) },
+			in: `# defaults
+quantum = 4
+algorithms = ["fcfs", "rr"]
+output = "json" # trailing comment
+
+[run]
+sort = 'wait'
+no-color = true
+`,
+		},
+		{
+			name:  "yaml",
+			parse: func(s string) (config, error) { return parseYAMLConfig(strings.NewReader(s)) },
+			in: `---
+quantum: 4
+algorithms:
+  - fcfs
+  - rr
+output: "json"
+run:
+  sort: wait # row order
+  no-color: true
+`,
+		},
+		{
+			name:  "yaml inline list",
+			parse: func(s string) (config, error) { return parseYAMLConfig(strings.NewReader(s)) },
+			in:    "quantum: 4\nalgorithms: [fcfs, rr]\noutput: json\nrun:\n  sort: wait\n  no-color: true\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.parse(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("parsed %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_configApply(t *testing.T) {
+	t.Parallel()
+	newFlags := func() (*flag.FlagSet, *int64, *string) {
+		fs := flag.NewFlagSet("run", flag.ContinueOnError)
+		return fs, fs.Int64("quantum", 1, ""), fs.String("output", "text", "")
+	}
+
+	fs, quantum, output := newFlags()
+	if err := fs.Parse([]string{"-output", "pb"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config{"": {"quantum": "4", "output": "json", "bins": "5"}}
+	if err := cfg.apply(fs); err != nil {
+		t.Fatal(err)
+	}
+	if *quantum != 4 || *output != "pb" {
+		t.Errorf("quantum %d, output %q; want the config quantum and the flag's output", *quantum, *output)
+	}
+
+	// keys in the command's own section must be its flags, and values must parse
+	for _, cfg := range []config{{"run": {"bins": "5"}}, {"": {"quantum": "four"}}} {
+		fs, _, _ := newFlags()
+		if err := cfg.apply(fs); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("applying %v returned %v, want ErrInvalidArgs", cfg, err)
+		}
+	}
+}
+
+func Test_applyEnv(t *testing.T) {
+	t.Parallel()
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	quantum := fs.Int64("quantum", 1, "")
+	output := fs.String("output", "text", "")
+	switchCost := fs.Int64("switch-cost", 0, "")
+	if err := fs.Parse([]string{"-output", "pb"}); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{"SCHED_QUANTUM": "3", "SCHED_OUTPUT": "json", "SCHED_SWITCH_COST": "2"}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	if err := applyEnv(fs, lookup); err != nil {
+		t.Fatal(err)
+	}
+	if *quantum != 3 || *switchCost != 2 || *output != "pb" {
+		t.Errorf("quantum %d, switch cost %d, output %q; want the environment's except -output",
+			*quantum, *switchCost, *output)
+	}
+
+	// the environment is applied first, so the config file does not override it
+	cfg := config{"": {"quantum": "8"}}
+	if err := cfg.apply(fs); err != nil {
+		t.Fatal(err)
+	}
+	if *quantum != 3 {
+		t.Errorf("config overrode SCHED_QUANTUM: quantum %d", *quantum)
+	}
+
+	env["SCHED_QUANTUM"] = "three"
+	fs = flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.Int64("quantum", 1, "")
+	if err := applyEnv(fs, lookup); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("bad SCHED_QUANTUM returned %v, want ErrInvalidArgs", err)
+	}
+}
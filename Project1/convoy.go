@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"github.com/olekukonko/tablewriter"
+)
+
+// outputConvoys writes the convoys in the fcfs report, if fcfs ran and had any: each long process, the short ones
+// stuck behind it, and the wait that cost them. For contrast it shows how long the same followers waited under sjf
+// and rr, or how to run those too.
+func outputConvoys(w io.Writer, run []sched.Algorithm, reports []report.Report) {
+	fcfs := -1
+	for i, a := range run {
+		if a.Name() == "fcfs" {
+			fcfs = i
+		}
+	}
+	if fcfs < 0 {
+		return
+	}
+	convoys := report.FindConvoys(reports[fcfs].Gantt, reports[fcfs].Processes)
+	if len(convoys) == 0 {
+		return
+	}
+
+	var (
+		rows      = make([][]string, len(convoys))
+		followers = make(map[int64]bool)
+		extra     int64
+	)
+	for i, c := range convoys {
+		pids := make([]string, len(c.Followers))
+		for j, p := range c.Followers {
+			pids[j] = fmt.Sprint(p.ProcessID)
+			followers[p.ProcessID] = true
+		}
+		extra += c.ExtraWait
+		rows[i] = []string{
+			fmt.Sprint(c.Leader.ProcessID),
+			fmt.Sprint(c.Leader.Burst),
+			fmt.Sprintf("%d-%d", c.Start, c.Stop),
+			strings.Join(pids, ", "),
+			fmt.Sprint(c.ExtraWait),
+		}
+	}
+	_, _ = fmt.Fprintf(w, "Convoy effect under %s\n", reports[fcfs].Title)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Leader", "Burst", "Ran", "Followers", "Extra wait"})
+	table.AppendBulk(rows)
+	table.Render()
+	total := followersWait(reports[fcfs], nil)
+	_, _ = fmt.Fprintf(w, "%d of the run's total wait of %d was spent queued behind convoys.\n", extra, total)
+
+	var contrast []string
+	for i, a := range run {
+		if a.Name() == "sjf" || a.Name() == "rr" {
+			contrast = append(contrast, fmt.Sprintf("%d under %s", followersWait(reports[i], followers), reports[i].Title))
+		}
+	}
+	if len(contrast) == 0 {
+		_, _ = fmt.Fprintln(w, "Run with -algorithms fcfs,sjf,rr to see how shortest-job-first and round-robin avoid them.")
+	} else {
+		_, _ = fmt.Fprintf(w, "The followers waited %d in all, against %s.\n",
+			followersWait(reports[fcfs], followers), strings.Join(contrast, " and "))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// followersWait returns the total wait in r of the processes in pids, or of every process when pids is nil.
+func followersWait(r report.Report, pids map[int64]bool) int64 {
+	var total int64
+	for _, p := range r.Processes {
+		if pids == nil || pids[p.ProcessID] {
+			total += p.Wait
+		}
+	}
+	return total
+}
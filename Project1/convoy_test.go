@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputConvoys(t *testing.T) {
+	t.Parallel()
+	convoy, err := loadExample("convoy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spread := []workload.Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2, ArrivalTime: 5}}
+	tests := []struct {
+		name       string
+		algorithms string
+		processes  []workload.Process
+		want       []string
+	}{
+		{
+			name:       "with contrast",
+			algorithms: "fcfs,sjf,rr",
+			processes:  convoy,
+			want: []string{"|      1 |    24 | 0-24 | 2, 3      |         48 |",
+				"48 of the run's total wait of 51", "waited 51 in all, against 3 under Shortest-job-first and 11 under Round-robin"},
+		},
+		{name: "fcfs alone", algorithms: "fcfs", processes: convoy, want: []string{"Run with -algorithms fcfs,sjf,rr"}},
+		{name: "no fcfs", algorithms: "sjf,rr", processes: convoy},
+		{name: "no convoy", algorithms: "fcfs,sjf", processes: spread},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			run, err := parseAlgorithms(tt.algorithms)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reports := make([]report.Report, len(run))
+			for i, a := range run {
+				result, err := a.Schedule(context.Background(), tt.processes)
+				if err != nil {
+					t.Fatal(err)
+				}
+				reports[i] = report.New(a.Title, result)
+			}
+			var out bytes.Buffer
+			outputConvoys(&out, run, reports)
+			if len(tt.want) == 0 && out.Len() > 0 {
+				t.Errorf("outputConvoys() =\n%s, want nothing", out.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("outputConvoys() =\n%s\nwant %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
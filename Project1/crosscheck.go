@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/verify"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// crosscheckPolicy returns the policy engine policy that reimplements the built-in algorithm named name, for
+// -crosscheck to run alongside it. Lottery only exists on the policy engine, so it has nothing to be checked against.
+func crosscheckPolicy(name string) (func() sched.Policy, bool) {
+	if name == "lottery" {
+		return nil, false
+	}
+	policy, ok := resumable[name]
+
+	return policy, ok
+}
+
+// outputCrosscheck runs every algorithm of run that has a policy engine counterpart on both engines and writes where
+// they disagree, and reports whether any did.
+func outputCrosscheck(ctx context.Context, w io.Writer, run []sched.Algorithm, processes []workload.Process) bool {
+	differ := false
+	for _, a := range run {
+		policy, ok := crosscheckPolicy(a.Name())
+		if !ok {
+			_, _ = fmt.Fprintf(w, "%s: no second engine to check against\n\n", a.Title)
+			continue
+		}
+		engine := sched.New(a.Name(), func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+			return sched.RunPolicy(ctx, processes, policy())
+		})
+		if crosscheckEngines(ctx, w, a.Title, a, engine, processes) {
+			differ = true
+		}
+	}
+
+	return differ
+}
+
+// crosscheckEngines runs builtin and engine, two implementations of the algorithm titled title, on processes and
+// writes any difference in their per-process metrics or Gantt charts, and any invariant either breaks. It reports
+// whether they disagreed or broke an invariant.
+func crosscheckEngines(ctx context.Context, w io.Writer, title string, builtin, engine sched.Scheduler, processes []workload.Process) bool {
+	results, errs := sched.RunAll(ctx, []sched.Scheduler{builtin, engine}, processes)
+	for i, err := range errs {
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "%s: %s engine stopped: %v\n\n", title, crosscheckEngineNames[i], err)
+			return true
+		}
+	}
+
+	var rows [][]string
+	metrics := func(r sched.Result) map[int64]sched.ProcessMetrics {
+		byPID := make(map[int64]sched.ProcessMetrics, len(r.PerProcess))
+		for _, p := range r.PerProcess {
+			byPID[p.ProcessID] = p
+		}
+		return byPID
+	}
+	a, b := metrics(results[0]), metrics(results[1])
+	for _, p := range processes {
+		pa, pb := a[p.ProcessID], b[p.ProcessID]
+		for _, m := range []struct {
+			name   string
+			va, vb int64
+		}{
+			{"wait", pa.Wait, pb.Wait},
+			{"turnaround", pa.Turnaround, pb.Turnaround},
+			{"completion", pa.Completion, pb.Completion},
+		} {
+			if m.va != m.vb {
+				rows = append(rows, []string{fmt.Sprintf("P%d", p.ProcessID), m.name,
+					strconv.FormatInt(m.va, 10), strconv.FormatInt(m.vb, 10)})
+			}
+		}
+	}
+	diffs := sched.DiffGantt(results[0].Slices, results[1].Slices)
+
+	var violations []string
+	for i, r := range results {
+		if err := verify.Check(processes, r); err != nil {
+			violations = append(violations, fmt.Sprintf("%s engine: %v", crosscheckEngineNames[i], err))
+		}
+	}
+
+	if len(rows) == 0 && len(diffs) == 0 && len(violations) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: engines agree\n\n", title)
+		return false
+	}
+	_, _ = fmt.Fprintf(w, "%s: engines disagree\n", title)
+	if len(rows) > 0 {
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"Process", "Metric", crosscheckEngineNames[0], crosscheckEngineNames[1]})
+		table.AppendBulk(rows)
+		table.Render()
+	}
+	for i, d := range diffs {
+		if i == maxGanttDivergences {
+			_, _ = fmt.Fprintf(w, "  ... and %d more\n", len(diffs)-i)
+			break
+		}
+		_, _ = fmt.Fprintf(w, "  %d-%d: %s runs %s, %s runs %s\n", d.Start, d.Stop,
+			crosscheckEngineNames[0], runningName(d.A), crosscheckEngineNames[1], runningName(d.B))
+	}
+	for _, v := range violations {
+		_, _ = fmt.Fprintf(w, "  %s\n", v)
+	}
+	_, _ = fmt.Fprintln(w)
+
+	return true
+}
+
+// crosscheckEngineNames name the two sides of a cross-check in its output.
+var crosscheckEngineNames = [2]string{"built-in", "policy"}
+
+// runningName describes the PID a Gantt diff has running, where 0 is nothing.
+func runningName(pid int64) string {
+	if pid == 0 {
+		return "nothing"
+	}
+
+	return fmt.Sprintf("P%d", pid)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+func Test_outputCrosscheck(t *testing.T) {
+	t.Parallel()
+	run, err := parseAlgorithms("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range examples {
+		processes, err := loadExample(e.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var w bytes.Buffer
+		if outputCrosscheck(context.Background(), &w, run, processes) {
+			t.Errorf("engines disagree on %s:\n%s", e.Name, w.String())
+		}
+		if got := strings.Count(w.String(), "engines agree"); got != 4 {
+			t.Errorf("%d algorithms cross-checked on %s, want fcfs, sjf, priority, and rr:\n%s", got, e.Name, w.String())
+		}
+	}
+
+	// two different algorithms stand in for engines that disagree
+	processes, err := loadExample("convoy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	if !crosscheckEngines(context.Background(), &w, "FCFS", sched.New("fcfs", sched.FCFS), sched.New("sjf", sched.SJF), processes) {
+		t.Error("FCFS and SJF reported as agreeing on the convoy")
+	}
+	for _, want := range []string{"FCFS: engines disagree", "| P1      | wait", "0-3: built-in runs P1, policy runs P2"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"GolandProjects/Project1/pkg/report"
+	"github.com/olekukonko/tablewriter"
+)
+
+// outputDeadlines writes the lateness and tardiness of every process of r with a deadline, in PID order, and how
+// many deadlines were missed. It writes nothing when no process has a deadline.
+func outputDeadlines(w io.Writer, r report.Report) {
+	if r.Deadlines == nil {
+		return
+	}
+	processes := append(r.Processes[:0:0], r.Processes...)
+	sort.Slice(processes, func(i, j int) bool { return processes[i].ProcessID < processes[j].ProcessID })
+	var rows [][]string
+	for _, p := range processes {
+		if p.Deadline == 0 {
+			continue
+		}
+		met := "yes"
+		if report.Tardiness(p) > 0 {
+			met = "no"
+		}
+		rows = append(rows, []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Deadline),
+			fmt.Sprint(p.Completion),
+			fmt.Sprint(report.Lateness(p)),
+			fmt.Sprint(report.Tardiness(p)),
+			met,
+		})
+	}
+
+	_, _ = fmt.Fprintln(w, "Deadlines")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Deadline", "Exit", "Lateness", "Tardiness", "Met"})
+	table.AppendBulk(rows)
+	table.Render()
+	d := r.Deadlines
+	_, _ = fmt.Fprintf(w, "Missed %d of %d deadlines; max tardiness %d, total %d, avg lateness %.2f\n\n",
+		d.Misses, d.Processes, d.MaxTardiness, d.TotalTardiness, d.AvgLateness)
+}
+
+// outputDeadlineMisses compares how every algorithm met the deadlines. It writes nothing when no process has a
+// deadline.
+func outputDeadlineMisses(w io.Writer, reports []report.Report) {
+	var rows [][]string
+	for _, r := range reports {
+		if d := r.Deadlines; d != nil {
+			rows = append(rows, []string{
+				r.Title,
+				fmt.Sprintf("%d of %d", d.Misses, d.Processes),
+				fmt.Sprint(d.MaxTardiness),
+				fmt.Sprint(d.TotalTardiness),
+				fmt.Sprintf("%.2f", d.AvgLateness),
+			})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "Deadline misses")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Missed", "Max tardiness", "Total tardiness", "Avg lateness"})
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
+
+// deadlineMisses returns an error naming the first of reports in which a process missed its deadline, or nil.
+func deadlineMisses(reports []report.Report) error {
+	for _, r := range reports {
+		if d := r.Deadlines; d != nil && d.Misses > 0 {
+			return fmt.Errorf("%s missed %d of %d deadlines", r.Title, d.Misses, d.Processes)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputDeadlines(t *testing.T) {
+	t.Parallel()
+	fcfs := report.New("FCFS", sched.NewResult(nil, []workload.Process{
+		{ProcessID: 2, ArrivalTime: 1, Burst: 3, Wait: 7, Turnaround: 10, Completion: 11, Deadline: 6},
+		{ProcessID: 1, Burst: 8, Turnaround: 8, Completion: 8, Deadline: 12},
+		{ProcessID: 3, ArrivalTime: 2, Burst: 2, Wait: 9, Turnaround: 11, Completion: 13},
+	}))
+	sjf := report.New("SJF", sched.NewResult(nil, []workload.Process{
+		{ProcessID: 2, ArrivalTime: 1, Burst: 3, Turnaround: 3, Completion: 4, Deadline: 6},
+		{ProcessID: 3, ArrivalTime: 2, Burst: 2, Wait: 2, Turnaround: 4, Completion: 6},
+		{ProcessID: 1, Burst: 8, Wait: 5, Turnaround: 13, Completion: 13, Deadline: 12},
+	}))
+	none := report.New("RR", sched.NewResult(nil, []workload.Process{{ProcessID: 1, Burst: 1, Turnaround: 1, Completion: 1}}))
+
+	var w bytes.Buffer
+	outputDeadlines(&w, fcfs)
+	for _, want := range []string{
+		"|  1 |       12 |    8 |       -4 |         0 | yes |\n|  2 |        6 |   11 |        5 |         5 | no  |",
+		"Missed 1 of 2 deadlines; max tardiness 5, total 5, avg lateness 0.50",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputDeadlines() missing %q:\n%s", want, w.String())
+		}
+	}
+
+	w.Reset()
+	outputDeadlineMisses(&w, []report.Report{fcfs, sjf, none})
+	for _, want := range []string{"| FCFS      | 1 of 2 |             5 |               5 |         0.50 |",
+		"| SJF       | 1 of 2 |             1 |               1 |        -0.50 |"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputDeadlineMisses() missing %q:\n%s", want, w.String())
+		}
+	}
+	if strings.Contains(w.String(), "RR") {
+		t.Errorf("outputDeadlineMisses() lists a run without deadlines:\n%s", w.String())
+	}
+	if err := deadlineMisses([]report.Report{none, fcfs}); err == nil || !strings.HasPrefix(err.Error(), "FCFS missed 1 of 2") {
+		t.Errorf("deadlineMisses() = %v, want FCFS's misses", err)
+	}
+
+	w.Reset()
+	outputDeadlines(&w, none)
+	outputDeadlineMisses(&w, []report.Report{none})
+	if w.Len() != 0 || deadlineMisses([]report.Report{none}) != nil {
+		t.Errorf("a run without deadlines wrote\n%s", w.String())
+	}
+}
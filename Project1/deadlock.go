@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/deadlock"
+	"github.com/olekukonko/tablewriter"
+)
+
+func deadlockCommand(args []string) {
+	fs := flag.NewFlagSet("deadlock", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "deadlock [flags] (events.txt | -events \"1 assign P1 R1; 2 request P2 R1; ...\")")
+	inline := fs.String("events", "", "semicolon-separated \"TIME request|assign|release PROCESS RESOURCE\" events")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if (*inline == "") == (fs.NArg() == 0) {
+		fatal(exitInvalid, fmt.Errorf("%w: give one event file or -events", ErrInvalidArgs))
+	}
+
+	var in io.Reader = strings.NewReader(*inline)
+	if *inline == "" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: opening events", err))
+		}
+		defer f.Close()
+		in = f
+	}
+	g, events, err := deadlock.Parse(in)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	snapshots, err := deadlock.Replay(g, events)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	outputDeadlocks(os.Stdout, snapshots)
+	outputGraph(os.Stdout, g)
+}
+
+// outputDeadlocks writes every event with the processes deadlocked after it, then each deadlock as it formed, with
+// the resources and a cycle it involves and the victim to abort, and when it cleared.
+func outputDeadlocks(w io.Writer, snapshots []deadlock.Snapshot) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Time", "Event", "Deadlocked"})
+	for _, s := range snapshots {
+		table.Append([]string{strconv.FormatInt(s.Event.Time, 10), s.Event.String(),
+			strings.Join(s.Deadlock.Processes, ", ")})
+	}
+	table.Render()
+
+	previous, formed := "", 0
+	for _, s := range snapshots {
+		d := s.Deadlock
+		now := strings.Join(d.Processes, ", ")
+		switch {
+		case now == previous:
+		case now == "":
+			_, _ = fmt.Fprintf(w, "Time %d, %s: the deadlock is over\n", s.Event.Time, s.Event)
+		default:
+			formed++
+			_, _ = fmt.Fprintf(w, "Time %d, %s: %s deadlocked over %s\n", s.Event.Time, s.Event, now,
+				strings.Join(d.Resources, ", "))
+			if d.Cycle != nil {
+				_, _ = fmt.Fprintf(w, "  Cycle: %s\n", strings.Join(d.Cycle, " -> "))
+			}
+			ends := "ends the deadlock"
+			if s.Left > 0 {
+				ends = fmt.Sprintf("leaves %d deadlocked", s.Left)
+			}
+			_, _ = fmt.Fprintf(w, "  Suggested victim: %s, aborting it %s\n", s.Victim, ends)
+		}
+		previous = now
+	}
+	if formed == 0 {
+		_, _ = fmt.Fprintln(w, "No deadlock")
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputGraph writes the resource-allocation graph g: the instances of every resource each process holds and waits
+// for.
+func outputGraph(w io.Writer, g *deadlock.Graph) {
+	_, _ = fmt.Fprintln(w, "Resource-allocation graph after the last event")
+	edges := func(to map[string]int) string {
+		var names []string
+		for _, r := range g.Resources {
+			switch n := to[r]; {
+			case n == 1:
+				names = append(names, r)
+			case n > 1:
+				names = append(names, fmt.Sprintf("%s x%d", r, n))
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Process", "Holds", "Waits for"})
+	for _, p := range g.Processes {
+		table.Append([]string{p, edges(g.Held[p]), edges(g.Waiting[p])})
+	}
+	table.Render()
+	var free []string
+	for _, r := range g.Resources {
+		free = append(free, fmt.Sprintf("%s %d of %d", r, g.Free(r), g.Instances[r]))
+	}
+	_, _ = fmt.Fprintf(w, "Free: %s\n\n", strings.Join(free, ", "))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/deadlock"
+)
+
+func Test_outputDeadlocks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		events string
+		want   []string
+	}{
+		{
+			name:   "deadlock and recovery",
+			events: "1 assign P1 R1; 2 assign P2 R2; 3 request P1 R2; 4 request P2 R1; 5 release P2 R2",
+			want: []string{
+				"|    4 | request P2 R1 | P1, P2     |",
+				"Time 4, request P2 R1: P1, P2 deadlocked over R1, R2\n" +
+					"  Cycle: P1 -> R2 -> P2 -> R1 -> P1\n" +
+					"  Suggested victim: P2, aborting it ends the deadlock\n" +
+					"Time 5, release P2 R2: the deadlock is over\n",
+				"| P1      | R1    | R2        |",
+				"Free: R1 0 of 1, R2 1 of 1\n",
+			},
+		},
+		{
+			name:   "no deadlock",
+			events: "resource R1 2; 1 assign P1 R1; 2 assign P2 R1; 3 request P1 R1",
+			want:   []string{"No deadlock\n", "| P1      | R1    | R1        |", "Free: R1 0 of 2\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g, events, err := deadlock.Parse(strings.NewReader(tt.events))
+			if err != nil {
+				t.Fatal(err)
+			}
+			snapshots, err := deadlock.Replay(g, events)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var w bytes.Buffer
+			outputDeadlocks(&w, snapshots)
+			outputGraph(&w, g)
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
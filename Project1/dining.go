@@ -0,0 +1,154 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/dining"
+	"github.com/olekukonko/tablewriter"
+)
+
+// diningTimelineWidth is how many time units each block of the philosophers' timelines spans.
+const diningTimelineWidth = 80
+
+func diningStrategyNames() string {
+	var names []string
+	for _, s := range dining.Strategies {
+		names = append(names, s.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parseDiningStrategies resolves a comma-separated list of dining-philosophers strategy names, in the order given. An
+// empty list selects every strategy.
+func parseDiningStrategies(list string) ([]dining.Strategy, error) {
+	if list == "" {
+		return dining.Strategies, nil
+	}
+	var selected []dining.Strategy
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, s := range dining.Strategies {
+			if s.Name == strings.TrimSpace(name) {
+				selected, found = append(selected, s), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown dining-philosophers strategy %q (want one of %s)", ErrInvalidArgs, name,
+				diningStrategyNames())
+		}
+	}
+
+	return selected, nil
+}
+
+func diningCommand(args []string) {
+	fs := flag.NewFlagSet("dining", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "dining [flags]")
+	var c dining.Config
+	fs.IntVar(&c.Philosophers, "philosophers", 5, "philosophers around the table, with a fork between every two")
+	fs.Int64Var(&c.Duration, "duration", 160, "time units to simulate")
+	think := fs.String("think", "1:4", "from:to range of time units a philosopher thinks for")
+	eat := fs.String("eat", "1:3", "from:to range of time units a philosopher eats for")
+	selected := fs.String("strategies", "",
+		"comma-separated strategies to run, in order (default all: "+diningStrategyNames()+")")
+	starve := fs.Int64("starve", 20, "count a philosopher as starving when hungry longer than this at once")
+	timeline := fs.Bool("timeline", true, "show every philosopher's timeline")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for thinking and eating times (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if c.Philosophers < 2 || c.Duration < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -philosophers must be at least 2 and -duration at least 1", ErrInvalidArgs))
+	}
+	var err error
+	if c.ThinkMin, c.ThinkMax, err = parseRange(*think); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if c.EatMin, c.EatMax, err = parseRange(*eat); err != nil {
+		fatal(exitInvalid, err)
+	}
+	run, err := parseDiningStrategies(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	options.seed = resolveSeed(options.seed)
+
+	results := make([]dining.Result, len(run))
+	for i, s := range run {
+		// every strategy draws the same thinking and eating times
+		results[i] = dining.Simulate(newRand(options.seed, "dining"), s, c)
+		outputDining(os.Stdout, results[i], *starve, *timeline)
+	}
+	if len(run) > 1 {
+		outputDiningSummary(os.Stdout, results, *starve)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "Random seed: %d (rerun with -seed %d to reproduce)\n", options.seed, options.seed)
+}
+
+// outputDining writes the timeline of every philosopher in r if timeline is set, then the meals each ate and how long
+// it went hungry, the throughput, who starved longer than starve, and whether the philosophers deadlocked.
+func outputDining(w io.Writer, r dining.Result, starve int64, timeline bool) {
+	outputTitle(w, fmt.Sprintf("%s: %d philosophers", r.Strategy.Title, len(r.Meals)))
+	if timeline {
+		_ = dining.WriteTimeline(w, r, diningTimelineWidth)
+		_, _ = fmt.Fprintf(w, "%c thinking, %c hungry, %c hungry holding one fork, %c eating\n\n", dining.MarkThinking,
+			dining.MarkHungry, dining.MarkOneFork, dining.MarkEating)
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Philosopher", "Meals", "Hungry", "Longest hungry"})
+	for p := range r.Meals {
+		table.Append([]string{fmt.Sprintf("P%d", p+1), strconv.Itoa(r.Meals[p]), strconv.FormatInt(r.Hungry[p], 10),
+			strconv.FormatInt(r.Longest[p], 10)})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Throughput: %d meals, %.2f per 100 time units\n", r.TotalMeals(), r.Throughput())
+	_, _ = fmt.Fprintf(w, "Starving, hungry longer than %d at once: %s\n", starve, philosopherNames(r.Starved(starve)))
+	if r.Deadlock >= 0 {
+		_, _ = fmt.Fprintf(w, "Deadlock at time %d: every philosopher is hungry and none can get a fork\n\n",
+			r.Deadlock)
+		return
+	}
+	_, _ = fmt.Fprint(w, "No deadlock\n\n")
+}
+
+// philosopherNames returns the names of the philosophers, numbered from 0, separated by commas, or "none".
+func philosopherNames(philosophers []int) string {
+	if len(philosophers) == 0 {
+		return "none"
+	}
+	names := make([]string, len(philosophers))
+	for i, p := range philosophers {
+		names[i] = fmt.Sprintf("P%d", p+1)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// outputDiningSummary writes the throughput, starvation, and deadlock of every strategy side by side.
+func outputDiningSummary(w io.Writer, results []dining.Result, starve int64) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Strategy", "Meals", "Per 100", "Fewest meals", "Longest hungry", "Starving",
+		"Deadlock"})
+	for _, r := range results {
+		fewest, longest := r.Meals[0], int64(0)
+		for p := range r.Meals {
+			fewest, longest = min(fewest, r.Meals[p]), max(longest, r.Longest[p])
+		}
+		deadlock := "no"
+		if r.Deadlock >= 0 {
+			deadlock = fmt.Sprintf("at %d", r.Deadlock)
+		}
+		table.Append([]string{r.Strategy.Title, strconv.Itoa(r.TotalMeals()), fmt.Sprintf("%.2f", r.Throughput()),
+			strconv.Itoa(fewest), strconv.FormatInt(longest, 10), strconv.Itoa(len(r.Starved(starve))), deadlock})
+	}
+	table.Render()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/dining"
+)
+
+func Test_parseDiningStrategies(t *testing.T) {
+	t.Parallel()
+	got, err := parseDiningStrategies("waiter, naive")
+	if err != nil || len(got) != 2 || got[0].Name != "waiter" || got[1].Name != "naive" {
+		t.Errorf("parseDiningStrategies() = %v, %v", got, err)
+	}
+	if all, _ := parseDiningStrategies(""); len(all) != len(dining.Strategies) {
+		t.Errorf("parseDiningStrategies(\"\") = %d strategies, want all %d", len(all), len(dining.Strategies))
+	}
+	if _, err := parseDiningStrategies("polite"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseDiningStrategies(\"polite\") error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_outputDining(t *testing.T) {
+	t.Parallel()
+	c := dining.Config{Philosophers: 3, Duration: 40, ThinkMin: 1, ThinkMax: 1, EatMin: 1, EatMax: 1}
+	results := make([]dining.Result, 2)
+	for i, s := range []dining.Strategy{dining.Strategies[0], dining.Strategies[2]} {
+		results[i] = dining.Simulate(rand.New(rand.NewSource(1)), s, c)
+	}
+	var w bytes.Buffer
+	outputDining(&w, results[0], 10, true)
+	outputDining(&w, results[1], 10, false)
+	outputDiningSummary(&w, results, 10)
+	for _, want := range []string{
+		"P1 .=X\n",
+		". thinking, - hungry, = hungry holding one fork, # eating\n",
+		"Starving, hungry longer than 10 at once: P1, P2, P3\n",
+		"Deadlock at time 2: every philosopher is hungry and none can get a fork\n",
+		"No deadlock\n",
+		"| Naive    |     0 |    0.00 |            0 |              1 |        3 | at 2     |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/disk"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// diskChartWidth is how many characters wide the head-movement chart is drawn.
+const diskChartWidth = 60
+
+func diskAlgorithmNames() string {
+	var names []string
+	for _, a := range disk.Algorithms {
+		names = append(names, a.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parseDiskAlgorithms resolves a comma-separated list of disk-scheduling algorithm names, in the order given. An empty
+// list selects every algorithm.
+func parseDiskAlgorithms(list string) ([]disk.Algorithm, error) {
+	if list == "" {
+		return disk.Algorithms, nil
+	}
+	var selected []disk.Algorithm
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, a := range disk.Algorithms {
+			if a.Name == strings.TrimSpace(name) {
+				selected, found = append(selected, a), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown disk-scheduling algorithm %q (want one of %s)", ErrInvalidArgs, name,
+				diskAlgorithmNames())
+		}
+	}
+
+	return selected, nil
+}
+
+func diskCommand(args []string) {
+	fs := flag.NewFlagSet("disk", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "disk [flags] (requests.txt | -requests \"98 183 37 ...\" | -random n)")
+	var d disk.Disk
+	fs.Int64Var(&d.Cylinders, "cylinders", 200, "number of cylinders")
+	fs.Int64Var(&d.Head, "head", 53, "cylinder the head starts over")
+	direction := fs.String("direction", "up", "direction the head is moving in, up toward higher cylinders or down")
+	selected := fs.String("algorithms", "",
+		"comma-separated disk-scheduling algorithms to run, in order (default all: "+diskAlgorithmNames()+")")
+	list := fs.String("requests", "", "request queue of cylinder numbers separated by spaces or commas")
+	random := fs.Int("random", 0, "simulate this many random requests instead")
+	chart := fs.Bool("chart", true, "show the head movement and waiting-time histogram of every algorithm")
+	svgDir := fs.String("svg", "", "also draw every algorithm's head movement and waiting times as SVG files here")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for -random (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *direction != "up" && *direction != "down" {
+		fatal(exitInvalid, fmt.Errorf("%w: -direction must be up or down, not %q", ErrInvalidArgs, *direction))
+	}
+	d.Up = *direction == "up"
+	run, err := parseDiskAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	sources := fs.NArg()
+	if *list != "" {
+		sources++
+	}
+	if *random > 0 {
+		sources++
+	}
+	if sources != 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: give one request queue file, -requests, or -random", ErrInvalidArgs))
+	}
+
+	var requests []int64
+	switch {
+	case *random > 0:
+		options.seed = resolveSeed(options.seed)
+		requests = disk.Generate(newRand(options.seed, "disk"), *random, d.Cylinders)
+		logger.Info("generated requests", "seed", options.seed)
+	case *list != "":
+		requests, err = disk.ParseRequests(*list)
+	default:
+		var b []byte
+		if b, err = os.ReadFile(fs.Arg(0)); err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: reading request queue", err))
+		}
+		requests, err = disk.ParseRequests(string(b))
+	}
+	if err == nil {
+		err = d.Validate(requests)
+	}
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+
+	results := make([]disk.Result, len(run))
+	for i, a := range run {
+		results[i] = a.Run(requests, d)
+		outputDisk(os.Stdout, a.Title, results[i], *chart)
+		if *svgDir != "" {
+			if err := saveDiskSVGs(*svgDir, a.Title, results[i]); err != nil {
+				logger.Warn("saving disk charts", "algorithm", a.Title, "err", err)
+			}
+		}
+	}
+	if len(run) > 1 {
+		outputDiskSummary(os.Stdout, run, results)
+	}
+}
+
+// diskWaits returns the requests r served as processes whose Wait is the time each waited, for the waiting-time
+// histograms of package report.
+func diskWaits(r disk.Result) []workload.Process {
+	served := r.Served()
+	waits := make([]workload.Process, len(served))
+	for i, s := range served {
+		waits[i] = workload.Process{ProcessID: int64(s.Request + 1), Wait: s.Time}
+	}
+
+	return waits
+}
+
+// outputDisk writes the head movement of r under title and every stop of the head, with how far it moved and how long
+// each request waited, and if chart is set, the head movement drawn out and a histogram of the waits.
+func outputDisk(w io.Writer, title string, r disk.Result, chart bool) {
+	_, _ = fmt.Fprintf(w, "%s: head moved %d cylinders serving %d requests from cylinder %d (average wait %.2f, "+
+		"longest %d)\n", title, r.Movement, len(r.Served()), r.Disk.Head, r.AvgWait(), r.MaxWait())
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Stop", "Request", "Cylinder", "Seek", "Wait"})
+	for i, s := range r.Stops[1:] {
+		request, wait := "edge", ""
+		if s.Request >= 0 {
+			request, wait = strconv.Itoa(s.Request+1), strconv.FormatInt(s.Time, 10)
+		}
+		if s.Jump {
+			request += " (return)"
+		}
+		table.Append([]string{strconv.Itoa(i + 1), request, strconv.FormatInt(s.Cylinder, 10),
+			strconv.FormatInt(s.Seek, 10), wait})
+	}
+	table.Render()
+	if !chart || len(r.Served()) == 0 {
+		_, _ = fmt.Fprintln(w)
+		return
+	}
+	_ = disk.WriteChart(w, r, diskChartWidth)
+	_, _ = fmt.Fprintln(w)
+	report.WriteHistogram(w, report.WaitHistogram(diskWaits(r), report.MaxHistogramBins))
+}
+
+// saveDiskSVGs writes the head movement and waiting-time histogram of r into dir, named after title.
+func saveDiskSVGs(dir, title string, r disk.Result) error {
+	f, err := os.Create(filepath.Join(dir, report.Slug(title)+"-head-movement.svg"))
+	if err != nil {
+		return fmt.Errorf("%w: creating head movement SVG", err)
+	}
+	if err := disk.WriteChartSVG(f, title, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing head movement SVG", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: writing head movement SVG", err)
+	}
+
+	return report.SaveHistogramSVG(dir, title, report.WaitHistogram(diskWaits(r), report.MaxHistogramBins))
+}
+
+// outputDiskSummary writes the head movement and waits of every algorithm of run side by side.
+func outputDiskSummary(w io.Writer, run []disk.Algorithm, results []disk.Result) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Head movement", "Average wait", "Longest wait"})
+	for i, a := range run {
+		table.Append([]string{a.Title, strconv.FormatInt(results[i].Movement, 10),
+			fmt.Sprintf("%.2f", results[i].AvgWait()), strconv.FormatInt(results[i].MaxWait(), 10)})
+	}
+	table.Render()
+}
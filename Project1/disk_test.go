@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/disk"
+)
+
+func Test_parseDiskAlgorithms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		list    string
+		want    []string
+		wantErr bool
+	}{
+		{list: "", want: []string{"fcfs", "sstf", "scan", "cscan", "look", "clook"}},
+		{list: "look, sstf", want: []string{"look", "sstf"}},
+		{list: "scan,elevator", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.list, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDiskAlgorithms(tt.list)
+			if tt.wantErr != errors.Is(err, ErrInvalidArgs) {
+				t.Fatalf("parseDiskAlgorithms(%q) error = %v", tt.list, err)
+			}
+			var names []string
+			for _, a := range got {
+				names = append(names, a.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseDiskAlgorithms(%q) = %v, want %v", tt.list, names, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputDisk(t *testing.T) {
+	t.Parallel()
+	r := disk.CSCAN([]int64{60, 20}, disk.Disk{Cylinders: 100, Head: 50, Up: true})
+	var w bytes.Buffer
+	outputDisk(&w, "C-SCAN", r, true)
+	for _, want := range []string{
+		"C-SCAN: head moved 168 cylinders serving 2 requests from cylinder 50 (average wait 89.00, longest 168)",
+		"|    1 |             1 |       60 |   10 |   10 |",
+		"|    3 | edge (return) |        0 |   99 |      |",
+		"|    4 |             2 |       20 |   20 |  168 |",
+		"Waiting-time histogram",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputDisk() missing %q:\n%s", want, w.String())
+		}
+	}
+}
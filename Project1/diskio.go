@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/disk"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// ioRequest is an I/O burst of a process: after running After time units of its burst, it reads or writes Cylinder
+// of the disk and blocks until the disk has served it.
+type ioRequest struct {
+	PID      int64
+	After    int64
+	Cylinder int64
+}
+
+// ioPlan holds the I/O bursts of every process by PID, in the order it makes them.
+type ioPlan map[int64][]ioRequest
+
+// parseIOPlan parses I/O bursts written PID:AFTER:CYLINDER and separated by commas or spaces, and checks them against
+// processes and d.
+func parseIOPlan(s string, processes []workload.Process, d disk.Disk) (ioPlan, error) {
+	bursts := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		bursts[p.ProcessID] = p.BurstDuration
+	}
+	plan := make(ioPlan)
+	for _, f := range strings.Fields(strings.ReplaceAll(s, ",", " ")) {
+		parts := strings.Split(f, ":")
+		var n [3]int64
+		var err error
+		for i := 0; err == nil && i < len(n) && len(parts) == len(n); i++ {
+			n[i], err = strconv.ParseInt(parts[i], 10, 64)
+		}
+		if len(parts) != len(n) || err != nil {
+			return nil, fmt.Errorf("%w: I/O burst %q is not PID:AFTER:CYLINDER", ErrInvalidArgs, f)
+		}
+		r := ioRequest{PID: n[0], After: n[1], Cylinder: n[2]}
+		burst, ok := bursts[r.PID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: I/O burst %q: there is no P%d", ErrInvalidArgs, f, r.PID)
+		case r.After < 0 || r.After >= burst:
+			return nil, fmt.Errorf("%w: I/O burst %q: P%d can only make I/O after 0 to %d time units", ErrInvalidArgs,
+				f, r.PID, burst-1)
+		}
+		if err := d.Validate([]int64{r.Cylinder}); err != nil {
+			return nil, fmt.Errorf("%w: I/O burst %q: %w", ErrInvalidArgs, f, err)
+		}
+		plan[r.PID] = append(plan[r.PID], r)
+	}
+	for _, requests := range plan {
+		sort.SliceStable(requests, func(i, j int) bool { return requests[i].After < requests[j].After })
+	}
+
+	return plan, nil
+}
+
+// generateIOPlan gives every process of processes an I/O burst after every every time units of its burst, short of
+// its end, for a cylinder drawn uniformly from rng.
+func generateIOPlan(rng *rand.Rand, processes []workload.Process, every, cylinders int64) ioPlan {
+	plan := make(ioPlan, len(processes))
+	for _, p := range processes {
+		for after := every; after < p.BurstDuration; after += every {
+			plan[p.ProcessID] = append(plan[p.ProcessID], ioRequest{PID: p.ProcessID, After: after,
+				Cylinder: rng.Int63n(max(cylinders, 1))})
+		}
+	}
+
+	return plan
+}
+
+// ioConfig is the disk the io command's processes make their I/O bursts of, how fast it serves them, and how the CPU
+// learns it has.
+type ioConfig struct {
+	Disk     disk.Disk
+	Speed    int64
+	Transfer int64
+	Delivery ioDelivery
+}
+
+// ioDelivery is how the CPU learns the disk has served an I/O burst, waking the process that made it: by an
+// interrupt, preempting whatever runs at once to spend Cost time units of the CPU servicing it, or by polling the disk
+// every Quantum time units while it has I/O outstanding, each poll costing Cost whether or not anything finished.
+type ioDelivery struct {
+	Polling bool
+	Quantum int64
+	Cost    int64
+}
+
+// String names d, such as "interrupts".
+func (d ioDelivery) String() string {
+	if d.Polling {
+		return "polling"
+	}
+
+	return "interrupts"
+}
+
+// ioRun is a run of processes with I/O bursts: the CPU's report and every I/O burst in the order the disk served it,
+// with the ID of each job the PID that made it, along with the time from each burst being served to its process
+// waking, the CPU time spent delivering them, and how many times the CPU polled the disk.
+type ioRun struct {
+	Report   report.Report
+	Jobs     []disk.Job
+	Movement int64
+	Delivery ioDelivery
+	Latency  int64
+	Overhead int64
+	Polls    int
+}
+
+// runIO runs processes under policy with every I/O burst of plan blocking its process and queuing at a disk that
+// serves its queue by a, so the disk's choices reach the processes' turnaround, and waking the process once c.Delivery
+// tells the CPU the disk has served it.
+func runIO(ctx context.Context, processes []workload.Process, policy sched.Policy, plan ioPlan, a disk.Algorithm,
+	c ioConfig) (ioRun, error) {
+	device := disk.NewDevice(c.Disk, a.Pick, c.Speed, c.Transfer)
+	made := make(map[int64]int, len(plan)) // how many I/O bursts each process has made
+	block := func(time int64, p workload.Process) int64 {
+		requests := plan[p.ProcessID]
+		if next := made[p.ProcessID]; next < len(requests) && requests[next].After == p.Burst-p.BurstDuration {
+			made[p.ProcessID]++
+			device.Submit(p.ProcessID, requests[next].Cylinder, time)
+			return -1
+		}
+		return 0
+	}
+	// the disk moves in step with the CPU, one time unit at a time, and the bursts it serves wait in served until
+	// delivered
+	sim := sched.NewSimulation(processes)
+	r := ioRun{Delivery: c.Delivery}
+	var served []disk.Job
+	deliver := func(j disk.Job) error {
+		r.Latency += sim.Time - j.Done
+		return sim.Wake(j.ID)
+	}
+	for !sim.Finished() {
+		if err := sim.RunBlocking(ctx, policy, block, sim.Time+1); err != nil {
+			return ioRun{}, err
+		}
+		served = append(served, device.Advance(sim.Time)...)
+		if !c.Delivery.Polling {
+			for len(served) > 0 {
+				j := served[0]
+				served = served[1:]
+				sim.Stall(ctx, j.ID, c.Delivery.Cost)
+				r.Overhead += c.Delivery.Cost
+				if err := deliver(j); err != nil {
+					return ioRun{}, err
+				}
+				served = append(served, device.Advance(sim.Time)...)
+			}
+			continue
+		}
+		if sim.Time%max(c.Delivery.Quantum, 1) != 0 || (len(served) == 0 && !device.Busy()) {
+			continue
+		}
+		// a poll sees what the disk finished by the time it starts, and wakes those processes once it is done
+		r.Polls++
+		sim.Stall(ctx, 0, c.Delivery.Cost)
+		r.Overhead += c.Delivery.Cost
+		for _, j := range served {
+			if err := deliver(j); err != nil {
+				return ioRun{}, err
+			}
+		}
+		served = nil
+	}
+	r.Report, r.Jobs, r.Movement = report.New(a.Title+" disk", sim.Result()), device.Served, device.Movement
+
+	return r, nil
+}
+
+// avgLatency returns the mean time from the disk serving an I/O burst to its process waking, or 0 with none.
+func (r ioRun) avgLatency() float64 {
+	if len(r.Jobs) == 0 {
+		return 0
+	}
+
+	return float64(r.Latency) / float64(len(r.Jobs))
+}
+
+// avgIOTime returns the mean time from making an I/O burst to its being served, or 0 with none.
+func (r ioRun) avgIOTime() float64 {
+	if len(r.Jobs) == 0 {
+		return 0
+	}
+	var total int64
+	for _, j := range r.Jobs {
+		total += j.Done - j.Issued
+	}
+
+	return float64(total) / float64(len(r.Jobs))
+}
+
+func ioCommand(args []string) {
+	fs := flag.NewFlagSet("io", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "io [flags] (workload.csv | -example name)")
+	name := fs.String("algorithm", "rr", "algorithm to schedule the CPU with ("+resumableNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 2, "round-robin time quantum")
+	selected := fs.String("disk", "",
+		"comma-separated disk-scheduling algorithms to compare, in order (default all: "+diskAlgorithmNames()+")")
+	var c ioConfig
+	fs.Int64Var(&c.Disk.Cylinders, "cylinders", 200, "number of cylinders")
+	fs.Int64Var(&c.Disk.Head, "head", 53, "cylinder the head starts over")
+	direction := fs.String("direction", "up", "direction the head starts moving in, up toward higher cylinders or down")
+	fs.Int64Var(&c.Speed, "speed", 20, "cylinders the head travels per time unit")
+	fs.Int64Var(&c.Transfer, "transfer", 1, "time units to read or write a cylinder once the head is over it")
+	delivery := fs.String("delivery", "interrupt",
+		"how the CPU learns I/O is done: interrupt, polling at every -quantum boundary, or both to compare them")
+	interruptCost := fs.Int64("interrupt-cost", 1, "CPU time units to service each I/O completion interrupt")
+	pollCost := fs.Int64("poll-cost", 1, "CPU time units each poll of the disk takes")
+	bursts := fs.String("io", "", "I/O bursts written PID:AFTER:CYLINDER, such as \"1:2:98, 2:1:183\"")
+	every := fs.Int64("every", 3, "without -io, give every process an I/O burst after every this many time units")
+	exampleName := fs.String("example", "", "simulate a bundled example workload instead of a file ("+exampleNames()+")")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for the I/O cylinders (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if _, ok := resumable[*name]; !ok {
+		fatal(exitInvalid, fmt.Errorf("%w: io cannot schedule with %q (want one of %s)", ErrInvalidArgs, *name,
+			resumableNames()))
+	}
+	if *direction != "up" && *direction != "down" {
+		fatal(exitInvalid, fmt.Errorf("%w: -direction must be up or down, not %q", ErrInvalidArgs, *direction))
+	}
+	c.Disk.Up = *direction == "up"
+	if options.quantum < 1 || *every < 1 || c.Speed < 1 || c.Transfer < 0 || *interruptCost < 0 || *pollCost < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -quantum, -every, and -speed must be at least 1, -transfer and the costs at "+
+			"least 0", ErrInvalidArgs))
+	}
+	deliveries, err := parseIODelivery(*delivery, options.quantum, *interruptCost, *pollCost)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	if err := c.Disk.Validate(nil); err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	run, err := parseDiskAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+	var plan ioPlan
+	if *bursts != "" {
+		if plan, err = parseIOPlan(*bursts, processes, c.Disk); err != nil {
+			fatal(exitInvalid, err)
+		}
+	} else {
+		options.seed = resolveSeed(options.seed)
+		plan = generateIOPlan(newRand(options.seed, "io"), processes, *every, c.Disk.Cylinders)
+		logger.Info("generated I/O bursts", "seed", options.seed)
+	}
+	a, _ := parseAlgorithms(*name)
+	ctx := sched.WithLogger(context.Background(), logger)
+
+	// runs holds a run of every delivery for each disk algorithm in turn
+	runs := make([]ioRun, 0, len(run)*len(deliveries))
+	for _, d := range run {
+		for _, c.Delivery = range deliveries {
+			policy := whatIfPolicy(*name, func(int64) int64 { return options.quantum })
+			r, err := runIO(ctx, processes, policy, plan, d, c)
+			if err != nil {
+				fatal(exitCode(err), err)
+			}
+			r.Report.Seed = options.seed
+			outputIO(os.Stdout, fmt.Sprintf("%s CPU, %s disk, %s", a[0].Title, d.Title, c.Delivery), r, plan)
+			runs = append(runs, r)
+		}
+	}
+	switch {
+	case len(deliveries) > 1:
+		outputDeliverySummary(os.Stdout, run, runs)
+	case len(run) > 1:
+		outputIOSummary(os.Stdout, run, runs)
+	}
+}
+
+// parseIODelivery returns the deliveries named by name, interrupt, polling, or both, with interrupts costing
+// interruptCost and polls, every quantum time units, pollCost.
+func parseIODelivery(name string, quantum, interruptCost, pollCost int64) ([]ioDelivery, error) {
+	interrupt := ioDelivery{Cost: interruptCost}
+	polling := ioDelivery{Polling: true, Quantum: quantum, Cost: pollCost}
+	switch name {
+	case "interrupt":
+		return []ioDelivery{interrupt}, nil
+	case "polling":
+		return []ioDelivery{polling}, nil
+	case "both":
+		return []ioDelivery{interrupt, polling}, nil
+	}
+
+	return nil, fmt.Errorf("%w: -delivery must be interrupt, polling, or both, not %q", ErrInvalidArgs, name)
+}
+
+// outputIO writes the Gantt chart of a run with I/O bursts, each process's time blocked on the disk, and every I/O
+// burst as the disk served it.
+func outputIO(w io.Writer, title string, r ioRun, plan ioPlan) {
+	outputTitle(w, title)
+	if r.Report.Seed != 0 {
+		_, _ = fmt.Fprintf(w, "Random seed: %d (rerun with -seed %d to reproduce)\n\n", r.Report.Seed, r.Report.Seed)
+	}
+	report.WriteGantt(w, r.Report.Gantt, options.color)
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"PID", "Burst", "I/O bursts", "Blocked", "Wait", "Turnaround"})
+	for _, p := range r.Report.Processes {
+		table.Append([]string{strconv.FormatInt(p.ProcessID, 10), strconv.FormatInt(p.Burst, 10),
+			strconv.Itoa(len(plan[p.ProcessID])), strconv.FormatInt(p.Turnaround-p.Wait-p.Burst, 10),
+			strconv.FormatInt(p.Wait, 10), strconv.FormatInt(p.Turnaround, 10)})
+	}
+	table.Render()
+	outputUtilization(w, r.Report.Busy, r.Report.Idle, r.Report.Overhead)
+
+	table = tablewriter.NewWriter(w)
+	table.SetHeader([]string{"PID", "Cylinder", "Issued", "Started", "Seek", "Served"})
+	for _, j := range r.Jobs {
+		table.Append([]string{strconv.FormatInt(j.ID, 10), strconv.FormatInt(j.Cylinder, 10),
+			strconv.FormatInt(j.Issued, 10), strconv.FormatInt(j.Started, 10), strconv.FormatInt(j.Seek, 10),
+			strconv.FormatInt(j.Done, 10)})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Disk head moved %d cylinders serving %d I/O bursts, each blocking its process %.2f on "+
+		"average; average turnaround %.2f\n", r.Movement, len(r.Jobs), r.avgIOTime(), r.Report.Summary.Turnaround)
+	if r.Delivery.Polling {
+		_, _ = fmt.Fprintf(w, "Polling every %d: %d polls costing %d CPU time units; ", r.Delivery.Quantum, r.Polls,
+			r.Overhead)
+	} else {
+		_, _ = fmt.Fprintf(w, "Interrupts: %d costing %d CPU time units; ", len(r.Jobs), r.Overhead)
+	}
+	_, _ = fmt.Fprintf(w, "processes woke %.2f after their I/O was served on average\n\n", r.avgLatency())
+}
+
+// outputIOSummary writes the head movement, I/O time, and its effect on the CPU of every disk algorithm of run side
+// by side.
+func outputIOSummary(w io.Writer, run []disk.Algorithm, runs []ioRun) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Disk algorithm", "Head movement", "Avg I/O time", "CPU utilization", "Avg turnaround",
+		"Finished"})
+	best := 0
+	for i, r := range runs {
+		finished := int64(0)
+		for _, p := range r.Report.Processes {
+			finished = max(finished, p.Completion)
+		}
+		table.Append([]string{run[i].Title, strconv.FormatInt(r.Movement, 10), fmt.Sprintf("%.2f", r.avgIOTime()),
+			fmt.Sprintf("%.2f%%", 100*r.Report.Utilization()), fmt.Sprintf("%.2f", r.Report.Summary.Turnaround),
+			strconv.FormatInt(finished, 10)})
+		if r.Report.Summary.Turnaround < runs[best].Report.Summary.Turnaround {
+			best = i
+		}
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Shortest average turnaround: %s at %.2f", run[best].Title, runs[best].Report.Summary.Turnaround)
+	if best > 0 {
+		_, _ = fmt.Fprintf(w, ", against %.2f with %s", runs[0].Report.Summary.Turnaround, run[0].Title)
+	}
+	_, _ = fmt.Fprint(w, "\n\n")
+}
+
+// outputDeliverySummary writes the latency and overhead of every delivery, and their effect on the CPU, side by side
+// for each disk algorithm of run, with runs holding a run of every delivery for each in turn.
+func outputDeliverySummary(w io.Writer, run []disk.Algorithm, runs []ioRun) {
+	per := len(runs) / len(run)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Disk algorithm", "Delivery", "Avg latency", "Overhead", "CPU utilization",
+		"Avg turnaround"})
+	for i, r := range runs {
+		table.Append([]string{run[i/per].Title, r.Delivery.String(), fmt.Sprintf("%.2f", r.avgLatency()),
+			strconv.FormatInt(r.Overhead, 10), fmt.Sprintf("%.2f%%", 100*r.Report.Utilization()),
+			fmt.Sprintf("%.2f", r.Report.Summary.Turnaround)})
+	}
+	table.Render()
+	for i := 0; i+1 < len(runs); i += per {
+		interrupts, polling := runs[i], runs[i+1]
+		_, _ = fmt.Fprintf(w, "%s disk, polling against interrupts: average latency %+.2f, overhead %+d, average "+
+			"turnaround %+.2f\n", run[i/per].Title,
+			polling.avgLatency()-interrupts.avgLatency(), polling.Overhead-interrupts.Overhead,
+			polling.Report.Summary.Turnaround-interrupts.Report.Summary.Turnaround)
+	}
+	_, _ = fmt.Fprintln(w)
+}
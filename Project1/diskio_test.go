@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/disk"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_parseIOPlan(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, BurstDuration: 2}}
+	d := disk.Disk{Cylinders: 200}
+	tests := []struct {
+		in      string
+		want    ioPlan
+		wantErr bool
+	}{
+		{in: "1:3:98, 2:1:183 1:0:37", want: ioPlan{
+			1: {{PID: 1, After: 0, Cylinder: 37}, {PID: 1, After: 3, Cylinder: 98}},
+			2: {{PID: 2, After: 1, Cylinder: 183}},
+		}},
+		{in: "1:3", wantErr: true},
+		{in: "1:x:98", wantErr: true},
+		{in: "3:0:98", wantErr: true},
+		{in: "2:2:98", wantErr: true},
+		{in: "1:0:200", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseIOPlan(tt.in, processes, d)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidArgs) {
+					t.Errorf("parseIOPlan(%q) error = %v, want %v", tt.in, err, ErrInvalidArgs)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseIOPlan(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generateIOPlan(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 7}, {ProcessID: 2, BurstDuration: 3}}
+	plan := generateIOPlan(rand.New(rand.NewSource(1)), processes, 3, 200)
+	// I/O after 3 and 6 units of P1's 7, and none short of the end of P2's 3
+	if len(plan[1]) != 2 || plan[1][0].After != 3 || plan[1][1].After != 6 || len(plan[2]) != 0 {
+		t.Errorf("generateIOPlan() = %v", plan)
+	}
+}
+
+func Test_runIO(t *testing.T) {
+	t.Parallel()
+	// P1 and P2 both make I/O before they run, so P3 runs while the disk decides which to serve first
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 2, BurstDuration: 1},
+		{ProcessID: 3, BurstDuration: 1}}
+	plan := ioPlan{1: {{PID: 1, Cylinder: 190}}, 2: {{PID: 2, Cylinder: 180}}}
+	c := ioConfig{Disk: disk.Disk{Cylinders: 200, Head: 53, Up: true}, Speed: 10, Transfer: 1}
+	tests := []struct {
+		name     string
+		pick     disk.Pick
+		gantt    []sched.TimeSlice
+		movement int64
+	}{
+		{
+			name: "fcfs",
+			pick: disk.PickFCFS,
+			gantt: []sched.TimeSlice{{PID: 3, Start: 0, Stop: 1}, {PID: 1, Start: 15, Stop: 16},
+				{PID: 2, Start: 17, Stop: 18}},
+			movement: 147,
+		},
+		{
+			name: "sstf",
+			pick: disk.PickSSTF,
+			gantt: []sched.TimeSlice{{PID: 3, Start: 0, Stop: 1}, {PID: 2, Start: 14, Stop: 15},
+				{PID: 1, Start: 16, Stop: 17}},
+			movement: 137,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := runIO(context.Background(), processes, sched.FCFSPolicy, plan,
+				disk.Algorithm{Title: tt.name, Pick: tt.pick}, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r.Report.Gantt, tt.gantt) || r.Movement != tt.movement || len(r.Jobs) != 2 {
+				t.Errorf("runIO() = %v moving %d with %d jobs, want %v moving %d with 2", r.Report.Gantt, r.Movement,
+					len(r.Jobs), tt.gantt, tt.movement)
+			}
+		})
+	}
+}
+
+func Test_outputIO(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 2}}
+	plan := ioPlan{1: {{PID: 1, After: 1, Cylinder: 73}}}
+	c := ioConfig{Disk: disk.Disk{Cylinders: 200, Head: 53, Up: true}, Speed: 10, Transfer: 1}
+	run := []disk.Algorithm{disk.Algorithms[0], disk.Algorithms[1]}
+	runs := make([]ioRun, len(run))
+	for i, a := range run {
+		var err error
+		if runs[i], err = runIO(context.Background(), processes, sched.FCFSPolicy, plan, a, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var w bytes.Buffer
+	outputIO(&w, "FCFS CPU, FCFS disk", runs[0], plan)
+	outputIOSummary(&w, run, runs)
+	for _, want := range []string{
+		"|   1 |     2 |          1 |       3 |    0 |          5 |",
+		"|   1 |       73 |      1 |       1 |   20 |      4 |",
+		"Disk head moved 20 cylinders serving 1 I/O bursts, each blocking its process 3.00 on average",
+		"| SSTF           |            20 |         3.00 | 40.00%          |           5.00 |        5 |",
+		"Shortest average turnaround: FCFS at 5.00\n",
+		"Interrupts: 1 costing 0 CPU time units; processes woke 0.00 after their I/O was served on average\n",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_parseIODelivery(t *testing.T) {
+	t.Parallel()
+	got, err := parseIODelivery("both", 4, 1, 2)
+	want := []ioDelivery{{Cost: 1}, {Polling: true, Quantum: 4, Cost: 2}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseIODelivery(\"both\") = %v, %v, want %v", got, err, want)
+	}
+	if _, err := parseIODelivery("dma", 4, 1, 2); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseIODelivery(\"dma\") error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_runIO_delivery(t *testing.T) {
+	t.Parallel()
+	// P1's I/O after its first unit is served at time 4, while P2 runs
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 6}}
+	plan := ioPlan{1: {{PID: 1, After: 1, Cylinder: 73}}}
+	c := ioConfig{Disk: disk.Disk{Cylinders: 200, Head: 53, Up: true}, Speed: 10, Transfer: 1}
+	tests := []struct {
+		name     string
+		delivery ioDelivery
+		gantt    []sched.TimeSlice
+		latency  int64
+		overhead int64
+		polls    int
+	}{
+		{
+			name:     "interrupt",
+			delivery: ioDelivery{Cost: 1},
+			gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4},
+				{PID: 1, Start: 4, Stop: 5, Switch: true}, {PID: 2, Start: 5, Stop: 8}, {PID: 1, Start: 8, Stop: 9}},
+			latency:  1,
+			overhead: 1,
+		},
+		{
+			name:     "polling",
+			delivery: ioDelivery{Polling: true, Quantum: 3, Cost: 1},
+			gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3},
+				{Start: 3, Stop: 4, Switch: true}, {PID: 2, Start: 4, Stop: 6}, {Start: 6, Stop: 7, Switch: true},
+				{PID: 2, Start: 7, Stop: 9}, {PID: 1, Start: 9, Stop: 10}},
+			latency:  3,
+			overhead: 2,
+			polls:    2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := c
+			c.Delivery = tt.delivery
+			r, err := runIO(context.Background(), processes, sched.FCFSPolicy, plan, disk.Algorithms[0], c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r.Report.Gantt, tt.gantt) || r.Latency != tt.latency || r.Overhead != tt.overhead ||
+				r.Polls != tt.polls {
+				t.Errorf("runIO() = %v with latency %d, overhead %d, and %d polls, want %v with %d, %d, and %d",
+					r.Report.Gantt, r.Latency, r.Overhead, r.Polls, tt.gantt, tt.latency, tt.overhead, tt.polls)
+			}
+		})
+	}
+}
+
+func Test_outputDeliverySummary(t *testing.T) {
+	t.Parallel()
+	run := []disk.Algorithm{disk.Algorithms[0]}
+	runs := []ioRun{
+		{Jobs: make([]disk.Job, 2), Latency: 2, Overhead: 2},
+		{Jobs: make([]disk.Job, 2), Delivery: ioDelivery{Polling: true, Quantum: 4}, Latency: 5, Overhead: 3},
+	}
+	var w bytes.Buffer
+	outputDeliverySummary(&w, run, runs)
+	for _, want := range []string{
+		"| FCFS           | polling    |        2.50 |        3 |",
+		"FCFS disk, polling against interrupts: average latency +1.50, overhead +1, average turnaround +0.00\n",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"GolandProjects/Project1/pkg/report"
+)
+
+// resultEncoders write the full result set of a run for -output values other than text.
+var resultEncoders = map[string]func(w io.Writer, reports []report.Report) error{
+	"csv":     encodeCSV,
+	"json":    encodeJSON,
+	"msgpack": encodeMsgpack,
+	"pb":      encodeProtobuf,
+	"pdf":     encodePDF,
+}
+
+// validateOutputFormat reports whether s is text or one of resultEncoders.
+func validateOutputFormat(s string) error {
+	if _, ok := resultEncoders[s]; ok || s == "text" {
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown output format %q (want text, csv, json, msgpack, pb, or pdf)", ErrInvalidArgs, s)
+}
+
+func encodeJSON(w io.Writer, reports []report.Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(reports); err != nil {
+		return fmt.Errorf("%w: encoding JSON results", err)
+	}
+
+	return nil
+}
+
+// encodeCSV writes the metrics of every finished process of every report as report.ProcessRecord rows.
+func encodeCSV(w io.Writer, reports []report.Report) error {
+	var records []report.ProcessRecord
+	for _, r := range reports {
+		records = append(records, r.ProcessRecords()...)
+	}
+
+	return report.WriteCSV(w, records)
+}
+
+//region MessagePack
+
+// encodeMsgpack writes reports as a MessagePack array of result maps. To keep large runs compact, slices are encoded
+// as [pid, start, stop, switch] arrays, where switch is 1 for context-switch overhead and 0 otherwise, and processes
+// as [pid, arrival, burst, priority, wait, turnaround, completion] arrays.
+func encodeMsgpack(w io.Writer, reports []report.Report) error {
+	mp := msgpackWriter{w: bufio.NewWriter(w)}
+	mp.arrayHeader(len(reports))
+	for _, r := range reports {
+		mp.mapHeader(9)
+		mp.str("title")
+		mp.str(r.Title)
+		mp.str("slices")
+		mp.arrayHeader(len(r.Gantt))
+		for _, s := range r.Gantt {
+			mp.arrayHeader(4)
+			mp.int(s.PID)
+			mp.int(s.Start)
+			mp.int(s.Stop)
+			if s.Switch {
+				mp.int(1)
+			} else {
+				mp.int(0)
+			}
+		}
+		mp.str("processes")
+		mp.arrayHeader(len(r.Processes))
+		for _, p := range r.Processes {
+			mp.arrayHeader(7)
+			mp.int(p.ProcessID)
+			mp.int(p.ArrivalTime)
+			mp.int(p.Burst)
+			mp.int(p.Priority)
+			mp.int(p.Wait)
+			mp.int(p.Turnaround)
+			mp.int(p.Completion)
+		}
+		mp.str("avg_wait")
+		mp.float(r.Summary.Wait)
+		mp.str("avg_turnaround")
+		mp.float(r.Summary.Turnaround)
+		mp.str("avg_normalized_turnaround")
+		mp.float(r.Summary.Normalized)
+		mp.str("throughput")
+		mp.float(r.Summary.Throughput)
+		mp.str("busy")
+		mp.int(r.Busy)
+		mp.str("idle")
+		mp.int(r.Idle)
+	}
+	if mp.err != nil {
+		return fmt.Errorf("%w: encoding MessagePack results", mp.err)
+	}
+	if err := mp.w.Flush(); err != nil {
+		return fmt.Errorf("%w: encoding MessagePack results", err)
+	}
+
+	return nil
+}
+
+// msgpackWriter encodes the subset of MessagePack the results need. The first write error sticks and every later
+// write becomes a no-op.
+type msgpackWriter struct {
+	w   *bufio.Writer
+	err error
+	buf [9]byte
+}
+
+func (m *msgpackWriter) write(b []byte) {
+	if m.err == nil {
+		_, m.err = m.w.Write(b)
+	}
+}
+
+// header writes a one-byte marker followed by n as a big-endian integer of size bytes.
+func (m *msgpackWriter) header(marker byte, n uint64, size int) {
+	m.buf[0] = marker
+	switch size {
+	case 1:
+		m.buf[1] = byte(n)
+	case 2:
+		binary.BigEndian.PutUint16(m.buf[1:], uint16(n))
+	case 4:
+		binary.BigEndian.PutUint32(m.buf[1:], uint32(n))
+	case 8:
+		binary.BigEndian.PutUint64(m.buf[1:], n)
+	}
+	m.write(m.buf[:1+size])
+}
+
+func (m *msgpackWriter) int(v int64) {
+	switch {
+	case v >= 0 && v < 128:
+		m.write([]byte{byte(v)})
+	case v < 0 && v >= -32:
+		m.write([]byte{byte(0xe0 | (v + 32))})
+	case v >= 0 && v <= math.MaxUint8:
+		m.header(0xcc, uint64(v), 1)
+	case v >= 0 && v <= math.MaxUint16:
+		m.header(0xcd, uint64(v), 2)
+	case v >= 0 && v <= math.MaxUint32:
+		m.header(0xce, uint64(v), 4)
+	case v >= 0:
+		m.header(0xcf, uint64(v), 8)
+	case v >= math.MinInt8:
+		m.header(0xd0, uint64(v), 1)
+	case v >= math.MinInt16:
+		m.header(0xd1, uint64(v), 2)
+	case v >= math.MinInt32:
+		m.header(0xd2, uint64(v), 4)
+	default:
+		m.header(0xd3, uint64(v), 8)
+	}
+}
+
+func (m *msgpackWriter) float(f float64) {
+	m.header(0xcb, math.Float64bits(f), 8)
+}
+
+func (m *msgpackWriter) str(s string) {
+	n := uint64(len(s))
+	switch {
+	case n < 32:
+		m.write([]byte{0xa0 | byte(n)})
+	case n <= math.MaxUint8:
+		m.header(0xd9, n, 1)
+	case n <= math.MaxUint16:
+		m.header(0xda, n, 2)
+	default:
+		m.header(0xdb, n, 4)
+	}
+	m.write([]byte(s))
+}
+
+func (m *msgpackWriter) arrayHeader(n int) {
+	switch {
+	case n < 16:
+		m.write([]byte{0x90 | byte(n)})
+	case n <= math.MaxUint16:
+		m.header(0xdc, uint64(n), 2)
+	default:
+		m.header(0xdd, uint64(n), 4)
+	}
+}
+
+func (m *msgpackWriter) mapHeader(n int) {
+	switch {
+	case n < 16:
+		m.write([]byte{0x80 | byte(n)})
+	case n <= math.MaxUint16:
+		m.header(0xde, uint64(n), 2)
+	default:
+		m.header(0xdf, uint64(n), 4)
+	}
+}
+
+//endregion
+
+//region Protocol Buffers
+
+// Field numbers and wire types of the messages in results.proto.
+const (
+	pbWireVarint  = 0
+	pbWireFixed64 = 1
+	pbWireBytes   = 2
+)
+
+// encodeProtobuf writes reports as a scheduler.ResultSet message (see results.proto). Each Result is framed as a
+// field of the set as soon as it is built, so only one result is ever buffered.
+func encodeProtobuf(w io.Writer, reports []report.Report) error {
+	bw := bufio.NewWriter(w)
+	var set, result, msg pbBuffer
+	for _, r := range reports {
+		result.reset()
+		result.string(1, r.Title)
+		for _, s := range r.Gantt {
+			msg.reset()
+			msg.int64(1, s.PID)
+			msg.int64(2, s.Start)
+			msg.int64(3, s.Stop)
+			if s.Switch {
+				msg.int64(4, 1)
+			}
+			result.message(2, msg.b)
+		}
+		for _, p := range r.Processes {
+			msg.reset()
+			msg.int64(1, p.ProcessID)
+			msg.int64(2, p.ArrivalTime)
+			msg.int64(3, p.Burst)
+			msg.int64(4, p.Priority)
+			msg.int64(5, p.Wait)
+			msg.int64(6, p.Turnaround)
+			msg.int64(7, p.Completion)
+			msg.int64(8, p.Deadline)
+			result.message(3, msg.b)
+		}
+		result.double(4, r.Summary.Wait)
+		result.double(5, r.Summary.Turnaround)
+		result.double(6, r.Summary.Normalized)
+		result.double(7, r.Summary.Throughput)
+		result.int64(8, r.Busy)
+		result.int64(9, r.Idle)
+
+		set.reset()
+		set.message(1, result.b)
+		if _, err := bw.Write(set.b); err != nil {
+			return fmt.Errorf("%w: encoding protobuf results", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("%w: encoding protobuf results", err)
+	}
+
+	return nil
+}
+
+// pbBuffer appends proto3 fields to a byte slice, omitting fields that hold their zero value as proto3 does.
+type pbBuffer struct {
+	b []byte
+}
+
+func (p *pbBuffer) reset() {
+	p.b = p.b[:0]
+}
+
+func (p *pbBuffer) tag(field, wire int) {
+	p.b = binary.AppendUvarint(p.b, uint64(field)<<3|uint64(wire))
+}
+
+func (p *pbBuffer) int64(field int, v int64) {
+	if v == 0 {
+		return
+	}
+	p.tag(field, pbWireVarint)
+	p.b = binary.AppendUvarint(p.b, uint64(v))
+}
+
+func (p *pbBuffer) double(field int, v float64) {
+	if v == 0 {
+		return
+	}
+	p.tag(field, pbWireFixed64)
+	p.b = binary.LittleEndian.AppendUint64(p.b, math.Float64bits(v))
+}
+
+func (p *pbBuffer) string(field int, s string) {
+	if s == "" {
+		return
+	}
+	p.tag(field, pbWireBytes)
+	p.b = binary.AppendUvarint(p.b, uint64(len(s)))
+	p.b = append(p.b, s...)
+}
+
+func (p *pbBuffer) message(field int, b []byte) {
+	p.tag(field, pbWireBytes)
+	p.b = binary.AppendUvarint(p.b, uint64(len(b)))
+	p.b = append(p.b, b...)
+}
+
+//endregion
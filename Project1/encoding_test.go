@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+)
+
+func Test_msgpackWriter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		write func(m *msgpackWriter)
+		want  []byte
+	}{
+		{name: "fixint", write: func(m *msgpackWriter) { m.int(5) }, want: []byte{0x05}},
+		{name: "negative fixint", write: func(m *msgpackWriter) { m.int(-3) }, want: []byte{0xfd}},
+		{name: "uint16", write: func(m *msgpackWriter) { m.int(300) }, want: []byte{0xcd, 0x01, 0x2c}},
+		{name: "int8", write: func(m *msgpackWriter) { m.int(-100) }, want: []byte{0xd0, 0x9c}},
+		{name: "float", write: func(m *msgpackWriter) { m.float(1) }, want: []byte{0xcb, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0}},
+		{name: "fixstr", write: func(m *msgpackWriter) { m.str("RR") }, want: []byte{0xa2, 'R', 'R'}},
+		{name: "fixarray", write: func(m *msgpackWriter) { m.arrayHeader(3) }, want: []byte{0x93}},
+		{name: "array16", write: func(m *msgpackWriter) { m.arrayHeader(16) }, want: []byte{0xdc, 0x00, 0x10}},
+		{name: "fixmap", write: func(m *msgpackWriter) { m.mapHeader(2) }, want: []byte{0x82}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			m := msgpackWriter{w: bufio.NewWriter(&b)}
+			tt.write(&m)
+			_ = m.w.Flush()
+			if got := b.Bytes(); !bytes.Equal(got, tt.want) {
+				t.Errorf("encoded % x, want % x", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_encodeProtobuf(t *testing.T) {
+	t.Parallel()
+	reports := []report.Report{{
+		Title: "RR",
+		Gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}},
+		Busy:  2,
+	}}
+	var b bytes.Buffer
+	if err := encodeProtobuf(&b, reports); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0x0a, 0x0c, // ResultSet.results, 12 bytes
+		0x0a, 0x02, 'R', 'R', // Result.title
+		0x12, 0x04, 0x08, 0x01, 0x18, 0x02, // Result.slices {pid: 1, stop: 2}
+		0x40, 0x02, // Result.busy
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("encodeProtobuf() = % x, want % x", b.Bytes(), want)
+	}
+}
+
+func Test_validateOutputFormat(t *testing.T) {
+	t.Parallel()
+	for _, ok := range []string{"text", "json", "msgpack", "pb"} {
+		if err := validateOutputFormat(ok); err != nil {
+			t.Errorf("validateOutputFormat(%q) error = %v", ok, err)
+		}
+	}
+	if err := validateOutputFormat("xml"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("validateOutputFormat() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"time"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// ensemble describes a Monte Carlo ensemble: Runs workloads, each either drawn at random with Generate or, when Base
+// is set, Base with its bursts perturbed by Perturb. Run i uses seed Seed+i for its workload and its lottery draws.
+type ensemble struct {
+	Runs       int
+	Seed       int64
+	Generate   workload.GenerateOptions
+	Base       []workload.Process
+	Perturb    float64
+	Algorithms []string
+	Quantum    int64
+	SwitchCost int64
+	Parallel   int
+}
+
+// ensembleResult is the outcome of an ensemble: for each algorithm, in the order run, its run-wide metrics in
+// every run.
+type ensembleResult struct {
+	Algorithms []string
+	Records    [][]report.AlgorithmRecord // by algorithm, then run
+}
+
+func ensembleCommand(args []string) {
+	e := ensemble{Parallel: runtime.GOMAXPROCS(0)}
+	fs := flag.NewFlagSet("ensemble", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "ensemble [flags] [workload.csv | -example name]")
+	fs.IntVar(&e.Runs, "runs", 100, "number of workloads to simulate")
+	fs.Int64Var(&e.Seed, "seed", 0, "seed of the first workload; run i uses seed+i (default: based on the current time)")
+	fs.IntVar(&e.Generate.Count, "n", 10, "processes per random workload")
+	fs.Int64Var(&e.Generate.MaxBurst, "max-burst", 10, "longest burst of a random workload")
+	fs.Int64Var(&e.Generate.MaxArrival, "max-arrival", 20, "latest arrival of a random workload")
+	fs.Int64Var(&e.Generate.MaxPriority, "max-priority", 5, "largest (lowest) priority value of a random workload")
+	profile := fs.String("profile", "uniform", "arrival and burst pattern of a random workload ("+profileNames()+")")
+	fs.Float64Var(&e.Perturb, "perturb", 0.2,
+		"with a workload file or example, scale each burst by a random factor within this fraction of 1 in every run")
+	exampleName := fs.String("example", "", "perturb a bundled example workload instead of a file ("+exampleNames()+")")
+	selected := fs.String("algorithms", "", "comma-separated algorithms to compare (default all: "+algorithmNames()+")")
+	fs.Int64Var(&e.Quantum, "quantum", 1, "round-robin time quantum")
+	fs.Int64Var(&e.SwitchCost, "switch-cost", 0, "time units charged for every context switch between two different processes")
+	fs.IntVar(&e.Parallel, "parallel", e.Parallel, "runs to simulate at once")
+	test := fs.String("test", "wilcoxon", "paired test that decides significance: t or wilcoxon")
+	alpha := fs.Float64("alpha", 0.05, "significance level of the paired tests")
+	timeout := fs.Duration("timeout", 0,
+		"stop each simulation after this long and report the processes that finished by then (default no limit)")
+	fs.String("config", "",
+		"file of default flag values (default scheduler.toml, scheduler.yaml, or scheduler.yml if present)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyDefaults(fs); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+
+	if e.Runs < 2 || e.Parallel < 1 || e.Quantum < 1 || e.SwitchCost < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -runs must be at least 2, -parallel and -quantum at least 1, and -switch-cost not negative",
+			ErrInvalidArgs))
+	}
+	if _, ok := pairedTests[*test]; !ok || *alpha <= 0 || *alpha >= 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -test must be t or wilcoxon, and -alpha above 0 and below 1", ErrInvalidArgs))
+	}
+	run, err := parseAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	for _, a := range run {
+		e.Algorithms = append(e.Algorithms, a.Name())
+	}
+	source := fmt.Sprintf("%d random workloads of %d processes", e.Runs, e.Generate.Count)
+	if *exampleName != "" || fs.NArg() > 0 {
+		if err := validatePerturb(e.Perturb); err != nil || e.Perturb == 0 {
+			fatal(exitInvalid, fmt.Errorf("%w: perturbing a workload needs -perturb above 0 and below 1", ErrInvalidArgs))
+		}
+		e.Base = mustLoadWorkloadOrExample(*exampleName, fs.Args())
+		source = fmt.Sprintf("%d perturbations (±%g%%) of %s", e.Runs, e.Perturb*100, workloadName(workloadSource(*exampleName, fs.Args())))
+	} else if e.Generate.Count < 1 || e.Generate.MaxBurst < 1 || e.Generate.MaxArrival < 0 || e.Generate.MaxPriority < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -n, -max-burst, and -max-priority must be positive", ErrInvalidArgs))
+	} else if e.Generate.Profile, err = parseProfile(*profile); err != nil {
+		fatal(exitInvalid, err)
+	} else if e.Generate.Profile.Name != "uniform" {
+		source = fmt.Sprintf("%d random workloads of %d processes, %s", e.Runs, e.Generate.Count,
+			strings.ToLower(e.Generate.Profile.Title))
+	}
+	e.Seed = resolveSeed(e.Seed)
+
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	result := runEnsemble(sched.WithLogger(interrupted, logger), e, *timeout)
+	outputEnsemble(os.Stdout, fmt.Sprintf("%s from seed %d", source, e.Seed), result, *test, *alpha)
+	for i, records := range result.Records {
+		for _, r := range records {
+			if r.Stopped != "" {
+				fatal(exitStopped, fmt.Errorf("%s stopped before every process finished: %s", result.Algorithms[i], r.Stopped))
+			}
+		}
+	}
+}
+
+// runEnsemble simulates every run of e, e.Parallel at a time. Each simulation stops after timeout, if it is positive.
+func runEnsemble(ctx context.Context, e ensemble, timeout time.Duration) ensembleResult {
+	rows := make([][]experimentRow, e.Runs)
+	parallelFor(e.Runs, e.Parallel, func(i int) {
+		cell := experimentCell{Quantum: e.Quantum, SwitchCost: e.SwitchCost, Seed: e.Seed + int64(i)}
+		processes := e.Base
+		if processes == nil {
+			processes = workload.Generate(newRand(cell.Seed, "workload"), e.Generate)
+		} else {
+			cell.Perturb = e.Perturb
+		}
+		rows[i] = runCell(ctx, cell, e.Algorithms, processes, timeout)
+	})
+
+	result := ensembleResult{Algorithms: e.Algorithms, Records: make([][]report.AlgorithmRecord, len(e.Algorithms))}
+	for _, run := range rows {
+		for j, row := range run {
+			result.Records[j] = append(result.Records[j], row.Record)
+		}
+	}
+	return result
+}
+
+// estimate returns the 95% confidence interval of the mean of metric over the runs of algorithm i.
+func (r ensembleResult) estimate(i int, metric func(report.AlgorithmRecord) float64) report.Estimate {
+	values := make([]float64, len(r.Records[i]))
+	for j, record := range r.Records[i] {
+		values[j] = metric(record)
+	}
+	return report.Estimate95(values)
+}
+
+// differences returns the differences in metric between algorithms i and j in each run.
+func (r ensembleResult) differences(i, j int, metric func(report.AlgorithmRecord) float64) []float64 {
+	values := make([]float64, len(r.Records[i]))
+	for k := range values {
+		values[k] = metric(r.Records[i][k]) - metric(r.Records[j][k])
+	}
+	return values
+}
+
+// difference returns the 95% confidence interval of the mean difference in metric between algorithms i and j over
+// the same runs. Pairing the runs cancels out how hard each workload is, so it is far narrower than comparing the
+// two intervals.
+func (r ensembleResult) difference(i, j int, metric func(report.AlgorithmRecord) float64) report.Estimate {
+	return report.Estimate95(r.differences(i, j, metric))
+}
+
+func avgWait(r report.AlgorithmRecord) float64 { return r.AvgWait }
+
+// ensembleMetric is a run-wide metric the ensemble estimates and compares.
+type ensembleMetric struct {
+	name  string
+	value func(report.AlgorithmRecord) float64
+}
+
+var ensembleMetrics = []ensembleMetric{
+	{"Avg wait", avgWait},
+	{"Avg turnaround", func(a report.AlgorithmRecord) float64 { return a.AvgTurnaround }},
+	{"Avg norm TAT", func(a report.AlgorithmRecord) float64 { return a.AvgNormalized }},
+	{"Utilization %", func(a report.AlgorithmRecord) float64 { return a.Utilization * 100 }},
+	{"Switches", func(a report.AlgorithmRecord) float64 { return float64(a.Switches) }},
+}
+
+// pairedTests are the significance tests ensemble can decide by, by -test name.
+var pairedTests = map[string]func([]float64) float64{
+	"t":        report.PairedTTest,
+	"wilcoxon": report.WilcoxonSignedRank,
+}
+
+// formatP formats a p-value to three decimals.
+func formatP(p float64) string {
+	if p < 0.001 {
+		return "<0.001"
+	}
+	return fmt.Sprintf("%.3f", p)
+}
+
+// outputEnsemble writes each algorithm's mean metrics with their 95% confidence intervals, then for every pair of
+// algorithms and every metric the mean difference, the p-values of the paired t-test and the Wilcoxon signed-rank
+// test, and which algorithm is lower when the p-value of test is below alpha.
+func outputEnsemble(w io.Writer, source string, r ensembleResult, test string, alpha float64) {
+	outputTitle(w, "Monte Carlo ensemble")
+	_, _ = fmt.Fprintf(w, "%s; means with 95%% confidence intervals\n\n", source)
+
+	table := tablewriter.NewWriter(w)
+	header := []string{"Algorithm"}
+	for _, m := range ensembleMetrics {
+		header = append(header, m.name)
+	}
+	table.SetHeader(header)
+	for i, name := range r.Algorithms {
+		row := []string{name}
+		for _, m := range ensembleMetrics {
+			row = append(row, r.estimate(i, m.value).String())
+		}
+		table.Append(row)
+	}
+	table.Render()
+	if len(r.Algorithms) < 2 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "Paired comparisons; * marks differences significant by the %s test at p < %g\n", test, alpha)
+	table = tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Comparison", "Metric", "Difference", "t-test p", "Wilcoxon p", "Verdict"})
+	for i := range r.Algorithms {
+		for j := i + 1; j < len(r.Algorithms); j++ {
+			for _, m := range ensembleMetrics {
+				diffs := r.differences(i, j, m.value)
+				d := report.Estimate95(diffs)
+				verdict := "no significant difference"
+				if pairedTests[test](diffs) < alpha {
+					lower := r.Algorithms[i]
+					if d.Mean > 0 {
+						lower = r.Algorithms[j]
+					}
+					verdict = "* " + lower + " lower"
+				}
+				table.Append([]string{r.Algorithms[i] + " − " + r.Algorithms[j], m.name, d.String(),
+					formatP(report.PairedTTest(diffs)), formatP(report.WilcoxonSignedRank(diffs)), verdict})
+			}
+		}
+	}
+	table.Render()
+}
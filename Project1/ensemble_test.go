@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_runEnsemble(t *testing.T) {
+	t.Parallel()
+	base, err := loadExample("convoy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generate := workload.GenerateOptions{Count: 8, MaxBurst: 9, MaxArrival: 10, MaxPriority: 3}
+	tests := []struct {
+		name string
+		e    ensemble
+	}{
+		{name: "random workloads", e: ensemble{Runs: 30, Seed: 1, Generate: generate, Quantum: 2}},
+		{name: "perturbed workload", e: ensemble{Runs: 20, Seed: 5, Base: base, Perturb: 0.3, Quantum: 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.e.Algorithms = []string{"fcfs", "sjf", "rr"}
+			tt.e.Parallel = 1
+			sequential := runEnsemble(context.Background(), tt.e, 0)
+			tt.e.Parallel = 4
+			parallel := runEnsemble(context.Background(), tt.e, 0)
+			if !reflect.DeepEqual(sequential, parallel) {
+				t.Error("runEnsemble() differs when run in parallel")
+			}
+			if len(sequential.Records) != 3 || len(sequential.Records[0]) != tt.e.Runs {
+				t.Fatalf("runEnsemble() = %d algorithms of %d runs", len(sequential.Records), len(sequential.Records[0]))
+			}
+			if sequential.Records[0][0] == sequential.Records[0][1] {
+				t.Error("the first two runs are identical")
+			}
+
+			// SJF minimizes average wait, so it is never worse in any run and is significantly better overall
+			var out bytes.Buffer
+			outputEnsemble(&out, "test", sequential, "wilcoxon", 0.05)
+			if d := sequential.difference(0, 1, avgWait); d.Low() <= 0 {
+				t.Errorf("fcfs − sjf = %v, want a positive difference", d)
+			}
+			if !strings.Contains(out.String(), "| fcfs − sjf | ") || !strings.Contains(out.String(), "| Avg wait       | ") ||
+				!strings.Contains(out.String(), "* sjf lower") {
+				t.Errorf("outputEnsemble() =\n%s", out.String())
+			}
+		})
+	}
+}
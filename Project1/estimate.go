@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// estimateErrors are the distributions the relative error of a burst estimate can be drawn from, by -distribution
+// name, each given the error level: uniform within ±level, or normal with level as its standard deviation.
+var estimateErrors = map[string]func(rng *rand.Rand, level float64) float64{
+	"uniform": func(rng *rand.Rand, level float64) float64 { return level * (2*rng.Float64() - 1) },
+	"normal":  func(rng *rand.Rand, level float64) float64 { return level * rng.NormFloat64() },
+}
+
+// estimateErrorNames returns the -distribution names, comma-separated.
+func estimateErrorNames() string {
+	names := make([]string, 0, len(estimateErrors))
+	for name := range estimateErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
+
+// estimateRow is how shortest-job-first fares at one error level: the average wait under SJF and SRTF scheduling
+// by estimates, averaged over the runs.
+type estimateRow struct {
+	Level float64
+	SJF   float64
+	SRTF  float64
+}
+
+// estimateResult is the outcome of the estimation experiment: the average wait under FCFS and under SJF and SRTF
+// knowing the true bursts, and a row for every error level.
+type estimateResult struct {
+	FCFS float64
+	SJF  float64
+	SRTF float64
+	Rows []estimateRow
+}
+
+func estimateCommand(args []string) {
+	fs := flag.NewFlagSet("estimate", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "estimate [flags] (workload.csv | -example name)")
+	levels := fs.String("errors", "0,0.1,0.25,0.5,1",
+		"comma-separated error levels of the burst estimates, as fractions of the true burst")
+	distribution := fs.String("distribution", "uniform",
+		"how an estimate's error is drawn: uniform within ±level, or normal with level as its standard deviation")
+	runs := fs.Int("runs", 20, "sets of estimates drawn at each error level, to average over")
+	seed := fs.Int64("seed", 0, "random seed for the estimates (default: based on the current time)")
+	exampleName := fs.String("example", "", "estimate a bundled example workload's bursts instead of a file's ("+
+		exampleNames()+")")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	draw, ok := estimateErrors[*distribution]
+	if !ok {
+		fatal(exitInvalid, fmt.Errorf("%w: -distribution must be one of %s", ErrInvalidArgs, estimateErrorNames()))
+	}
+	errorLevels, err := parseFloats(*levels)
+	if err != nil || len(errorLevels) == 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -errors must be a comma-separated list of fractions", ErrInvalidArgs))
+	}
+	for _, level := range errorLevels {
+		if level < 0 || math.IsNaN(level) || math.IsInf(level, 0) {
+			fatal(exitInvalid, fmt.Errorf("%w: error level %g is negative", ErrInvalidArgs, level))
+		}
+	}
+	if *runs < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -runs must be at least 1", ErrInvalidArgs))
+	}
+	*seed = resolveSeed(*seed)
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+
+	result, err := runEstimates(sched.WithLogger(context.Background(), logger), processes, errorLevels, draw, *runs,
+		*seed)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	outputEstimates(os.Stdout, fmt.Sprintf("%s errors, %d runs per level from seed %d", *distribution, *runs, *seed),
+		result)
+}
+
+// estimateBursts returns an estimate of every process's burst, by PID: the true burst scaled by 1 plus an error
+// drawn at level, rounded and at least 1.
+func estimateBursts(processes []workload.Process, level float64, draw func(*rand.Rand, float64) float64,
+	rng *rand.Rand) map[int64]int64 {
+	estimates := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		estimate := float64(p.BurstDuration) * (1 + draw(rng, level))
+		estimates[p.ProcessID] = max(1, int64(math.Round(estimate)))
+	}
+
+	return estimates
+}
+
+// runEstimates simulates processes under FCFS and under SJF and SRTF knowing the true bursts, then runs times at
+// every error level under SJF and SRTF scheduling by estimated bursts, while the processes run for their true ones.
+// Run i draws its estimates from seed+i at every level, so the levels scale the same errors.
+func runEstimates(ctx context.Context, processes []workload.Process, levels []float64,
+	draw func(*rand.Rand, float64) float64, runs int, seed int64) (estimateResult, error) {
+	var result estimateResult
+	for _, baseline := range []struct {
+		wait   *float64
+		policy sched.Policy
+	}{
+		{&result.FCFS, sched.FCFSPolicy},
+		{&result.SJF, sched.EstimatedSJFPolicy(nil)},
+		{&result.SRTF, sched.SRTFPolicy},
+	} {
+		r, err := sched.RunPolicy(ctx, processes, baseline.policy)
+		if err != nil {
+			return estimateResult{}, err
+		}
+		*baseline.wait = r.Summary.Wait
+	}
+
+	for _, level := range levels {
+		row := estimateRow{Level: level}
+		for i := 0; i < runs; i++ {
+			estimates := estimateBursts(processes, level, draw, newRand(seed+int64(i), "estimates"))
+			sjf, err := sched.RunPolicy(ctx, processes, sched.EstimatedSJFPolicy(estimates))
+			if err != nil {
+				return estimateResult{}, err
+			}
+			srtf, err := sched.RunPolicy(ctx, processes, sched.EstimatedSRTFPolicy(estimates))
+			if err != nil {
+				return estimateResult{}, err
+			}
+			row.SJF += sjf.Summary.Wait / float64(runs)
+			row.SRTF += srtf.Summary.Wait / float64(runs)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result, nil
+}
+
+// advantageKept formats how much of exact's lead over fcfs in average wait estimated keeps, or "-" if there is no
+// lead to keep.
+func advantageKept(fcfs, exact, estimated float64) string {
+	if fcfs-exact < 1e-9 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.0f%%", 100*(fcfs-estimated)/(fcfs-exact))
+}
+
+// outputEstimates writes the average wait under SJF and SRTF at every error level of the estimates, with the share
+// of their advantage over FCFS each keeps.
+func outputEstimates(w io.Writer, source string, r estimateResult) {
+	outputTitle(w, "Burst estimation error")
+	_, _ = fmt.Fprintf(w, "%s; average wait %.2f under FCFS, %.2f under SJF and %.2f under SRTF with exact bursts\n\n",
+		source, r.FCFS, r.SJF, r.SRTF)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Error", "SJF avg wait", "SJF advantage kept", "SRTF avg wait", "SRTF advantage kept"})
+	for _, row := range r.Rows {
+		table.Append([]string{fmt.Sprintf("%g%%", row.Level*100), fmt.Sprintf("%.2f", row.SJF),
+			advantageKept(r.FCFS, r.SJF, row.SJF), fmt.Sprintf("%.2f", row.SRTF), advantageKept(r.FCFS, r.SRTF, row.SRTF)})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w, "Advantage kept is the share of the exact schedule's lead over FCFS in average wait that "+
+		"scheduling by estimates keeps; below 0% it does worse than FCFS.")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_estimateBursts(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 10}, {ProcessID: 2, BurstDuration: 1}}
+	tests := []struct {
+		name  string
+		level float64
+		err   float64
+		want  map[int64]int64
+	}{
+		{name: "exact", level: 0.5, want: map[int64]int64{1: 10, 2: 1}},
+		{name: "over", level: 0.5, err: 0.25, want: map[int64]int64{1: 13, 2: 1}},
+		// an estimate never falls below 1
+		{name: "under", level: 1, err: -0.95, want: map[int64]int64{1: 1, 2: 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			draw := func(*rand.Rand, float64) float64 { return tt.err }
+			if got := estimateBursts(processes, tt.level, draw, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("estimateBursts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runEstimates(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("convoy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := runEstimates(context.Background(), processes, []float64{0, 4}, estimateErrors["uniform"], 5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// exact estimates schedule as SJF and SRTF do, and wild ones lose some of their lead over FCFS
+	if exact := got.Rows[0]; exact.SJF != got.SJF || exact.SRTF != got.SRTF {
+		t.Errorf("runEstimates() without error = %+v, want %.2f and %.2f", exact, got.SJF, got.SRTF)
+	}
+	if wild := got.Rows[1]; wild.SJF <= got.SJF || wild.SRTF <= got.SRTF || got.FCFS <= got.SJF {
+		t.Errorf("runEstimates() = %+v, want wild estimates to wait longer than exact ones", got)
+	}
+}
+
+func Test_outputEstimates(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputEstimates(&w, "uniform errors", estimateResult{FCFS: 10, SJF: 4, SRTF: 10,
+		Rows: []estimateRow{{Level: 0.5, SJF: 7, SRTF: 12}}})
+	for _, want := range []string{
+		"uniform errors; average wait 10.00 under FCFS, 4.00 under SJF and 10.00 under SRTF with exact bursts",
+		// SRTF has no lead over FCFS to keep
+		"| 50%   |         7.00 | 50%                |         12.00 | -                   |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputEstimates() missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"strings"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+//go:embed examples/*.csv
+var exampleFiles embed.FS
+
+// example is a bundled textbook workload that -example loads instead of a file.
+type example struct {
+	Name        string
+	Description string
+}
+
+// examples lists the bundled workloads; each is examples/<Name>.csv.
+var examples = []example{
+	{Name: "convoy", Description: "one long job ahead of two short ones: the FCFS convoy effect"},
+	{Name: "sjf", Description: "four jobs arriving together, where shortest-job-first minimizes the average wait"},
+	{Name: "srtf", Description: "staggered arrivals where preempting for the shortest remaining time pays off"},
+	{Name: "priority", Description: "five jobs arriving together with distinct priorities"},
+	{Name: "starvation", Description: "a low-priority job that waits while high-priority jobs keep arriving"},
+	{Name: "rr-quantum", Description: "mixed bursts for comparing round-robin quanta with sweep"},
+	{Name: "deadlines", Description: "four jobs whose deadlines are too tight for any schedule to meet them all"},
+	{Name: "threads", Description: "processes of several threads that block on I/O, for comparing threading models"},
+}
+
+// loadExample parses the bundled workload called name.
+func loadExample(name string) ([]workload.Process, error) {
+	for _, e := range examples {
+		if e.Name != name {
+			continue
+		}
+		f, err := exampleFiles.Open("examples/" + name + ".csv")
+		if err != nil {
+			return nil, fmt.Errorf("%w: opening example %s", err, name)
+		}
+		defer f.Close()
+		return workload.Load(f)
+	}
+
+	return nil, fmt.Errorf("%w: unknown example %q (want one of %s)", ErrInvalidArgs, name, exampleNames())
+}
+
+func exampleNames() string {
+	names := make([]string, len(examples))
+	for i := range examples {
+		names[i] = examples[i].Name
+	}
+
+	return strings.Join(names, ",")
+}
+
+// outputExamples lists every bundled workload with its description.
+func outputExamples(w io.Writer) {
+	for _, e := range examples {
+		_, _ = fmt.Fprintf(w, "%-12s %s\n", e.Name, e.Description)
+	}
+}
+
+// mustLoadWorkloadOrExample loads the bundled example called name if it is set, or else the workload file named by
+// args, exiting on failure.
+func mustLoadWorkloadOrExample(name string, args []string) []workload.Process {
+	if name == "" {
+		return mustLoadWorkload(args)
+	}
+	if len(args) > 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: give either -example or a workload file, not both", ErrInvalidArgs))
+	}
+	processes, err := loadExample(name)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+
+	return processes
+}
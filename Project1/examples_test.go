@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func Test_examples(t *testing.T) {
+	t.Parallel()
+	for _, e := range examples {
+		e := e
+		t.Run(e.Name, func(t *testing.T) {
+			t.Parallel()
+			f, err := exampleFiles.Open("examples/" + e.Name + ".csv")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			if _, problems := validateWorkload(f); len(problems) > 0 {
+				t.Errorf("example is not a valid workload: %v", problems)
+			}
+			if _, err := loadExample(e.Name); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	if _, err := loadExample("lottery"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("loading an unknown example returned %v, want ErrInvalidArgs", err)
+	}
+}
+
+func Test_convoyExample(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("convoy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the textbook figures: FCFS waits 0, 24, and 27 behind the long job, SJF waits 6, 0, and 3
+	fcfs := scheduleWith("fcfs")(context.Background(), io.Discard, "FCFS", processes)
+	sjf := scheduleWith("sjf")(context.Background(), io.Discard, "SJF", processes)
+	if fcfs.Summary.Wait != 17 || sjf.Summary.Wait != 3 {
+		t.Errorf("average waits FCFS %.2f, SJF %.2f; want 17 and 3", fcfs.Summary.Wait, sjf.Summary.Wait)
+	}
+}
@@ -0,0 +1,497 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GolandProjects/Project1/pkg/api"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// experiment is a matrix of runs read from an experiment manifest: every algorithm on every workload under every
+// combination of the parameters.
+type experiment struct {
+	Name       string
+	Workloads  []string // files, relative to the manifest, or bundled examples as example:name
+	Algorithms []string // all of them if empty
+	Parallel   int
+	// the parameters, each with at least one value
+	Quanta      []int64
+	SwitchCosts []int64
+	Seeds       []int64
+	Perturbs    []float64
+}
+
+// experimentCell is one combination of a workload and parameter values.
+type experimentCell struct {
+	Workload   string
+	Quantum    int64
+	SwitchCost int64
+	Seed       int64
+	Perturb    float64
+}
+
+// experimentRow is the outcome of one algorithm in one cell.
+type experimentRow struct {
+	Cell      experimentCell
+	Algorithm string
+	Record    report.AlgorithmRecord
+}
+
+// loadExperiment reads an experiment manifest, in the same YAML subset as the config files:
+//
+//	name: quantum study
+//	workloads: [example_processes.csv, example:convoy]
+//	algorithms: [fcfs, sjf, rr]
+//	parallel: 4
+//	parameters:
+//	  quantum: [1, 2, 4]
+//	  switch-cost: [0, 1]
+//	  seed: [1, 2, 3]
+//	  perturb: [0, 0.2]
+//
+// Parameters left out take their single default: quantum 1, switch cost 0, seed 0, and no perturbation.
+func loadExperiment(path string) (experiment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return experiment{}, fmt.Errorf("%w: opening experiment", err)
+	}
+	defer f.Close()
+	cfg, err := parseYAMLConfig(f)
+	if err != nil {
+		return experiment{}, fmt.Errorf("%w: %s", err, path)
+	}
+
+	e := experiment{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), Parallel: 1}
+	for section, settings := range cfg {
+		if section != "" && section != "parameters" {
+			return experiment{}, fmt.Errorf("%w: %s: unknown section %q", ErrInvalidArgs, path, section)
+		}
+		for key, value := range settings {
+			switch section + "." + key {
+			case ".name":
+				e.Name = value
+			case ".workloads":
+				e.Workloads = splitList(value)
+			case ".algorithms":
+				e.Algorithms = splitList(value)
+			case ".parallel":
+				e.Parallel, err = strconv.Atoi(value)
+			case "parameters.quantum":
+				e.Quanta, err = parseInts(value)
+			case "parameters.switch-cost":
+				e.SwitchCosts, err = parseInts(value)
+			case "parameters.seed":
+				e.Seeds, err = parseInts(value)
+			case "parameters.perturb":
+				e.Perturbs, err = parseFloats(value)
+			default:
+				return experiment{}, fmt.Errorf("%w: %s: unknown setting %q", ErrInvalidArgs, path, strings.TrimPrefix(section+"."+key, "."))
+			}
+			if err != nil {
+				return experiment{}, fmt.Errorf("%w: %s: %s: %v", ErrInvalidArgs, path, key, err)
+			}
+		}
+	}
+	for i, w := range e.Workloads {
+		if !strings.HasPrefix(w, "example:") && !filepath.IsAbs(w) {
+			e.Workloads[i] = filepath.Join(filepath.Dir(path), w)
+		}
+	}
+	if e.Quanta == nil {
+		e.Quanta = []int64{1}
+	}
+	if e.SwitchCosts == nil {
+		e.SwitchCosts = []int64{0}
+	}
+	if e.Seeds == nil {
+		e.Seeds = []int64{0}
+	}
+	if e.Perturbs == nil {
+		e.Perturbs = []float64{0}
+	}
+
+	return e, e.validate()
+}
+
+func (e experiment) validate() error {
+	if len(e.Workloads) == 0 {
+		return fmt.Errorf("%w: the experiment has no workloads", ErrInvalidArgs)
+	}
+	if _, err := api.Algorithms(1, 0).Select(strings.Join(e.Algorithms, ",")); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArgs, err)
+	}
+	if e.Parallel < 1 {
+		return fmt.Errorf("%w: parallel must be at least 1", ErrInvalidArgs)
+	}
+	for _, q := range e.Quanta {
+		if q < 1 {
+			return fmt.Errorf("%w: quantum must be at least 1", ErrInvalidArgs)
+		}
+	}
+	for _, c := range e.SwitchCosts {
+		if c < 0 {
+			return fmt.Errorf("%w: switch-cost must not be negative", ErrInvalidArgs)
+		}
+	}
+	for _, p := range e.Perturbs {
+		if err := validatePerturb(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cells returns every combination of a workload and parameter values, in manifest order with the last parameter
+// varying fastest.
+func (e experiment) cells() []experimentCell {
+	var cells []experimentCell
+	for _, w := range e.Workloads {
+		for _, q := range e.Quanta {
+			for _, c := range e.SwitchCosts {
+				for _, s := range e.Seeds {
+					for _, p := range e.Perturbs {
+						cells = append(cells, experimentCell{Workload: w, Quantum: q, SwitchCost: c, Seed: s, Perturb: p})
+					}
+				}
+			}
+		}
+	}
+
+	return cells
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func parseInts(s string) ([]int64, error) {
+	var values []int64
+	for _, item := range splitList(s) {
+		v, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+func parseFloats(s string) ([]float64, error) {
+	var values []float64
+	for _, item := range splitList(s) {
+		v, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// runExperimentCommand runs every cell of an experiment manifest and prints one report comparing them all.
+func runExperimentCommand(args []string) {
+	fs := flag.NewFlagSet("run-experiment", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "run-experiment [flags] experiment.yaml")
+	parallel := fs.Int("parallel", 0, "cells to run at once (default the manifest's parallel, or 1)")
+	output := fs.String("output", "text", "report format: text or csv (a row per algorithm per cell)")
+	timeout := fs.Duration("timeout", 0,
+		"stop each simulation after this long and report the processes that finished by then (default no limit)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if fs.NArg() != 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: give one experiment manifest", ErrInvalidArgs))
+	}
+	if *output != "text" && *output != "csv" {
+		fatal(exitInvalid, fmt.Errorf("%w: -output must be text or csv", ErrInvalidArgs))
+	}
+	e, err := loadExperiment(fs.Arg(0))
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	if *parallel < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -parallel must be at least 1", ErrInvalidArgs))
+	}
+	if *parallel > 0 {
+		e.Parallel = *parallel
+	}
+
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	rows, err := runExperiment(sched.WithLogger(interrupted, logger), e, *timeout)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	if *output == "csv" {
+		err = writeExperimentCSV(os.Stdout, rows)
+	} else {
+		outputExperiment(os.Stdout, e, rows)
+	}
+	if err != nil {
+		fatal(exitFailure, err)
+	}
+	for _, r := range rows {
+		if r.Record.Stopped != "" {
+			fatal(exitStopped, fmt.Errorf("%s stopped before every process finished: %s", r.Algorithm, r.Record.Stopped))
+		}
+	}
+}
+
+// runExperiment runs the algorithms of e in every cell, e.Parallel cells at a time, and returns a row per algorithm
+// per cell in the order of e.cells. Each simulation stops after timeout, if it is positive.
+func runExperiment(ctx context.Context, e experiment, timeout time.Duration) ([]experimentRow, error) {
+	workloads := make(map[string][]workload.Process, len(e.Workloads))
+	for _, name := range e.Workloads {
+		var err error
+		if example, ok := strings.CutPrefix(name, "example:"); ok {
+			workloads[name], err = loadExample(example)
+		} else {
+			workloads[name], err = loadWorkloadFile(name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, name)
+		}
+	}
+
+	cells := e.cells()
+	rows := make([][]experimentRow, len(cells))
+	parallelFor(len(cells), e.Parallel, func(i int) {
+		rows[i] = runCell(ctx, cells[i], e.Algorithms, workloads[cells[i].Workload], timeout)
+	})
+
+	var all []experimentRow
+	for _, r := range rows {
+		all = append(all, r...)
+	}
+	return all, nil
+}
+
+// parallelFor calls f with every index from 0 to n-1, on up to parallel goroutines at once, and returns when every
+// call has.
+func parallelFor(n, parallel int, f func(i int)) {
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+// runCell runs the named algorithms on processes with the parameters of cell. It doesn't touch the global options,
+// so cells can run at the same time.
+func runCell(ctx context.Context, cell experimentCell, names []string, processes []workload.Process, timeout time.Duration) []experimentRow {
+	processes = perturbBursts(processes, cell.Perturb, newRand(cell.Seed, "perturb"))
+	run, _ := api.Algorithms(cell.Quantum, cell.Seed).Select(strings.Join(names, ","))
+	rows := make([]experimentRow, len(run))
+	for i, a := range run {
+		simulation := ctx
+		cancel := context.CancelFunc(func() {})
+		if timeout > 0 {
+			simulation, cancel = context.WithTimeout(ctx, timeout)
+		}
+		result, err := a.Schedule(simulation, processes)
+		cancel()
+		r := report.New(a.Title, sched.ChargeContextSwitches(result, cell.SwitchCost))
+		if err != nil {
+			r.Stopped = err.Error()
+		}
+		rows[i] = experimentRow{Cell: cell, Algorithm: a.Name(), Record: r.Record()}
+	}
+
+	return rows
+}
+
+// workloadName shortens a cell's workload for display.
+func workloadName(w string) string {
+	if strings.HasPrefix(w, "example:") {
+		return w
+	}
+	return filepath.Base(w)
+}
+
+// outputExperiment writes every row of the experiment, then each algorithm's averages over all cells and how many
+// cells it had the lowest average wait in.
+func outputExperiment(w io.Writer, e experiment, rows []experimentRow) {
+	outputTitle(w, e.Name)
+	_, _ = fmt.Fprintf(w, "%d workloads × %d algorithms × %d parameter combinations\n\n",
+		len(e.Workloads), len(rows)/len(e.cells()), len(e.cells())/len(e.Workloads))
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Quantum", "Switch cost", "Seed", "Perturb", "Algorithm", "Avg wait",
+		"Avg turnaround", "Avg norm TAT", "Utilization", "Switches"})
+	for _, r := range rows {
+		table.Append([]string{
+			workloadName(r.Cell.Workload),
+			fmt.Sprint(r.Cell.Quantum),
+			fmt.Sprint(r.Cell.SwitchCost),
+			fmt.Sprint(r.Cell.Seed),
+			fmt.Sprint(r.Cell.Perturb),
+			r.Algorithm,
+			fmt.Sprintf("%.2f", r.Record.AvgWait),
+			fmt.Sprintf("%.2f", r.Record.AvgTurnaround),
+			fmt.Sprintf("%.2f", r.Record.AvgNormalized),
+			fmt.Sprintf("%.2f%%", r.Record.Utilization*100),
+			fmt.Sprint(r.Record.Switches),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+
+	type totals struct {
+		cells                                 int
+		wait, turnaround, normalized, utilize float64
+		wins                                  int
+	}
+	var (
+		order []string
+		sums  = make(map[string]*totals)
+		best  = make(map[experimentCell]float64)
+	)
+	for _, r := range rows {
+		if sums[r.Algorithm] == nil {
+			sums[r.Algorithm] = &totals{}
+			order = append(order, r.Algorithm)
+		}
+		s := sums[r.Algorithm]
+		s.cells++
+		s.wait += r.Record.AvgWait
+		s.turnaround += r.Record.AvgTurnaround
+		s.normalized += r.Record.AvgNormalized
+		s.utilize += r.Record.Utilization
+		if b, ok := best[r.Cell]; !ok || r.Record.AvgWait < b {
+			best[r.Cell] = r.Record.AvgWait
+		}
+	}
+	for _, r := range rows {
+		if math.Abs(r.Record.AvgWait-best[r.Cell]) < 1e-9 {
+			sums[r.Algorithm].wins++
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Across all cells")
+	table = tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Cells", "Mean avg wait", "Mean avg turnaround", "Mean avg norm TAT",
+		"Mean utilization", "Lowest wait in"})
+	for _, name := range order {
+		s := sums[name]
+		n := float64(s.cells)
+		table.Append([]string{
+			name,
+			fmt.Sprint(s.cells),
+			fmt.Sprintf("%.2f", s.wait/n),
+			fmt.Sprintf("%.2f", s.turnaround/n),
+			fmt.Sprintf("%.2f", s.normalized/n),
+			fmt.Sprintf("%.2f%%", s.utilize/n*100),
+			fmt.Sprintf("%d of %d", s.wins, s.cells),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+
+	outputExperimentParetoFronts(w, e, rows)
+}
+
+// outputExperimentParetoFronts writes a Pareto front for every workload, across the algorithms, quanta, and switch
+// costs it ran under. Different seeds and perturbations change the workload itself, so each gets its own front.
+func outputExperimentParetoFronts(w io.Writer, e experiment, rows []experimentRow) {
+	type group struct {
+		workload string
+		seed     int64
+		perturb  float64
+	}
+	var (
+		order   []group
+		names   = make(map[group][]string)
+		records = make(map[group][]report.AlgorithmRecord)
+	)
+	for _, r := range rows {
+		g := group{r.Cell.Workload, r.Cell.Seed, r.Cell.Perturb}
+		if _, ok := names[g]; !ok {
+			order = append(order, g)
+		}
+		names[g] = append(names[g], fmt.Sprintf("%s q=%d cost=%d", r.Algorithm, r.Cell.Quantum, r.Cell.SwitchCost))
+		records[g] = append(records[g], r.Record)
+	}
+	for _, g := range order {
+		title := "Pareto front for " + workloadName(g.workload)
+		if len(e.Seeds) > 1 {
+			title += fmt.Sprintf(", seed %d", g.seed)
+		}
+		if len(e.Perturbs) > 1 {
+			title += fmt.Sprintf(", perturb %g", g.perturb)
+		}
+		outputParetoFront(w, title, names[g], records[g])
+	}
+}
+
+// writeExperimentCSV writes a row per algorithm per cell: the cell's parameters followed by the algorithm's
+// run-wide metrics.
+func writeExperimentCSV(w io.Writer, rows []experimentRow) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"workload", "quantum", "switch_cost", "seed", "perturb", "algorithm", "avg_wait",
+		"avg_turnaround", "avg_normalized_turnaround", "avg_response", "throughput", "utilization", "switches", "energy",
+		"finished", "stopped"})
+	for _, r := range rows {
+		_ = cw.Write([]string{
+			r.Cell.Workload,
+			strconv.FormatInt(r.Cell.Quantum, 10),
+			strconv.FormatInt(r.Cell.SwitchCost, 10),
+			strconv.FormatInt(r.Cell.Seed, 10),
+			strconv.FormatFloat(r.Cell.Perturb, 'g', -1, 64),
+			r.Algorithm,
+			strconv.FormatFloat(r.Record.AvgWait, 'g', -1, 64),
+			strconv.FormatFloat(r.Record.AvgTurnaround, 'g', -1, 64),
+			strconv.FormatFloat(r.Record.AvgNormalized, 'g', -1, 64),
+			strconv.FormatFloat(r.Record.AvgResponse, 'g', -1, 64),
+			strconv.FormatFloat(r.Record.Throughput, 'g', -1, 64),
+			strconv.FormatFloat(r.Record.Utilization, 'g', -1, 64),
+			strconv.Itoa(r.Record.Switches),
+			strconv.FormatFloat(r.Record.Energy, 'g', -1, 64),
+			strconv.Itoa(r.Record.Finished),
+			r.Record.Stopped,
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("%w: writing experiment CSV", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadExperiment(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		manifest string
+		want     experiment
+		wantErr  bool
+	}{
+		{
+			name:     "defaults",
+			manifest: "workloads: [a.csv, example:convoy]\n",
+			want: experiment{Name: "experiment", Workloads: []string{"DIR/a.csv", "example:convoy"}, Parallel: 1,
+				Quanta: []int64{1}, SwitchCosts: []int64{0}, Seeds: []int64{0}, Perturbs: []float64{0}},
+		},
+		{
+			name: "everything",
+			manifest: "name: study\nworkloads:\n  - /abs/a.csv\nalgorithms: [rr, sjf]\nparallel: 3\n" +
+				"parameters:\n  quantum: [2, 4]\n  switch-cost: [1]\n  seed: [5, 6]\n  perturb: [0.1]\n",
+			want: experiment{Name: "study", Workloads: []string{"/abs/a.csv"}, Algorithms: []string{"rr", "sjf"},
+				Parallel: 3, Quanta: []int64{2, 4}, SwitchCosts: []int64{1}, Seeds: []int64{5, 6}, Perturbs: []float64{0.1}},
+		},
+		{name: "no workloads", manifest: "algorithms: [rr]\n", wantErr: true},
+		{name: "unknown algorithm", manifest: "workloads: [a.csv]\nalgorithms: [nope]\n", wantErr: true},
+		{name: "unknown setting", manifest: "workloads: [a.csv]\nrepeat: 3\n", wantErr: true},
+		{name: "unknown parameter", manifest: "workloads: [a.csv]\nparameters:\n  speed: [1]\n", wantErr: true},
+		{name: "bad quantum", manifest: "workloads: [a.csv]\nparameters:\n  quantum: [0]\n", wantErr: true},
+		{name: "bad number", manifest: "workloads: [a.csv]\nparameters:\n  seed: [x]\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			path := filepath.Join(dir, "experiment.yaml")
+			if err := os.WriteFile(path, []byte(tt.manifest), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := loadExperiment(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadExperiment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for i, w := range tt.want.Workloads {
+				tt.want.Workloads[i] = strings.Replace(w, "DIR", dir, 1)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadExperiment() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runExperiment(t *testing.T) {
+	t.Parallel()
+	e, err := loadExperiment("experiment.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(e.cells()), 2*3*2; got != want {
+		t.Fatalf("cells() = %d cells, want %d", got, want)
+	}
+
+	e.Parallel = 1
+	sequential, err := runExperiment(context.Background(), e, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Parallel = 4
+	parallel, err := runExperiment(context.Background(), e, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sequential) != 12*3 || !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("runExperiment() in parallel = %v, want %v", parallel, sequential)
+	}
+	first := sequential[2]
+	if first.Algorithm != "rr" || first.Cell.Quantum != 1 || first.Record.Switches != 16 {
+		t.Errorf("first rr row = %+v", first)
+	}
+
+	var text, csv bytes.Buffer
+	outputExperiment(&text, e, sequential)
+	if !strings.Contains(text.String(), "| sjf       |    12 |") ||
+		!strings.Contains(text.String(), "Pareto front for example:rr-quantum") {
+		t.Errorf("outputExperiment() =\n%s", text.String())
+	}
+	if err := writeExperimentCSV(&csv, sequential); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(csv.String(), "\n"); lines != 1+len(sequential) {
+		t.Errorf("writeExperimentCSV() wrote %d lines, want %d", lines, 1+len(sequential))
+	}
+
+	e.Workloads = append(e.Workloads, "missing.csv")
+	if _, err := runExperiment(context.Background(), e, 0); err == nil {
+		t.Error("runExperiment() with a missing workload did not fail")
+	}
+}
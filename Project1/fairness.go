@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// outputFairness writes Jain's fairness index for the run and a breakdown per priority class.
+func outputFairness(w io.Writer, done []workload.Process) {
+	share, wait := report.Fairness(done)
+	_, _ = fmt.Fprintln(w, "Fairness")
+	_, _ = fmt.Fprintf(w, "Jain's index: CPU share %.2f, wait %.2f\n", share, wait)
+
+	classes := report.GroupByPriority(done)
+	rows := make([][]string, len(classes))
+	for i := range classes {
+		classShare, classWait := report.Fairness(classes[i].Processes)
+		rows[i] = []string{
+			fmt.Sprint(classes[i].Priority),
+			fmt.Sprint(len(classes[i].Processes)),
+			fmt.Sprintf("%.2f", classes[i].AvgWait()),
+			fmt.Sprintf("%.2f", classes[i].AvgCPUShare()),
+			fmt.Sprintf("%.2f", classShare),
+			fmt.Sprintf("%.2f", classWait),
+		}
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Priority", "Processes", "Avg wait", "Avg CPU share", "Jain share", "Jain wait"})
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputPriorityClasses compares every algorithm's average wait and turnaround per priority class, one row per
+// priority value seen in any of the reports.
+func outputPriorityClasses(w io.Writer, reports []report.Report) {
+	type cell struct {
+		wait, turnaround float64
+	}
+	var (
+		priorities = make([]int64, 0)
+		counts     = make(map[int64]int)
+		cells      = make([]map[int64]cell, len(reports))
+	)
+	for i, r := range reports {
+		cells[i] = make(map[int64]cell)
+		for _, c := range r.Classes {
+			if _, ok := counts[c.Priority]; !ok {
+				priorities = append(priorities, c.Priority)
+			}
+			counts[c.Priority] = len(c.Processes)
+			cells[i][c.Priority] = cell{wait: c.AvgWait(), turnaround: c.AvgTurnaround()}
+		}
+	}
+	sort.Slice(priorities, func(i, j int) bool {
+		return priorities[i] < priorities[j]
+	})
+
+	header := []string{"Priority", "Processes"}
+	for _, r := range reports {
+		header = append(header, r.Title)
+	}
+	rows := make([][]string, len(priorities))
+	for i, priority := range priorities {
+		rows[i] = []string{fmt.Sprint(priority), fmt.Sprint(counts[priority])}
+		for j := range reports {
+			c, ok := cells[j][priority]
+			if !ok {
+				rows[i] = append(rows[i], "-")
+				continue
+			}
+			rows[i] = append(rows[i], fmt.Sprintf("%.2f / %.2f", c.wait, c.turnaround))
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Average wait / turnaround by priority class")
+	table := tablewriter.NewWriter(w)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputArrivalCohorts compares every algorithm's average wait and turnaround for the processes arriving in each
+// window of width time units, one row per window any process arrived in.
+func outputArrivalCohorts(w io.Writer, reports []report.Report, width int64) {
+	if len(reports) == 0 {
+		return
+	}
+	// every report holds the same processes unless it stopped early, so the windows come from the fullest one
+	fullest := 0
+	for i, r := range reports {
+		if len(r.Processes) > len(reports[fullest].Processes) {
+			fullest = i
+		}
+	}
+	all := report.GroupByArrival(reports[fullest].Processes, width)
+	cohorts := make([]map[int64]report.ArrivalCohort, len(reports))
+	for i, r := range reports {
+		cohorts[i] = make(map[int64]report.ArrivalCohort)
+		for _, c := range report.GroupByArrival(r.Processes, width) {
+			cohorts[i][c.Start] = c
+		}
+	}
+
+	header := []string{"Arrival", "Processes"}
+	for _, r := range reports {
+		header = append(header, r.Title)
+	}
+	rows := make([][]string, len(all))
+	for i, c := range all {
+		rows[i] = []string{fmt.Sprintf("%d-%d", c.Start, c.Start+width-1), fmt.Sprint(len(c.Processes))}
+		for j := range reports {
+			cohort, ok := cohorts[j][c.Start]
+			if !ok {
+				rows[i] = append(rows[i], "-")
+				continue
+			}
+			rows[i] = append(rows[i], fmt.Sprintf("%.2f / %.2f", cohort.AvgWait(), cohort.AvgTurnaround()))
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Average wait / turnaround by arrival window of %d\n", width)
+	table := tablewriter.NewWriter(w)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputPriorityClasses(t *testing.T) {
+	t.Parallel()
+	fcfs := report.New("FCFS", sched.NewResult(nil, []workload.Process{
+		{ProcessID: 1, Priority: 1, Burst: 2, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, Priority: 2, Burst: 2, Wait: 2, Turnaround: 4, Completion: 4},
+	}))
+	rr := report.New("RR", sched.NewResult(nil, []workload.Process{
+		{ProcessID: 1, Priority: 1, Burst: 2, Wait: 1, Turnaround: 3, Completion: 3},
+		{ProcessID: 2, Priority: 2, Burst: 2, Wait: 1, Turnaround: 3, Completion: 4},
+	}))
+	var w bytes.Buffer
+	outputPriorityClasses(&w, []report.Report{fcfs, rr})
+	for _, want := range []string{
+		"| Priority | Processes |    FCFS     |     RR      |",
+		"|        1 |         1 | 0.00 / 2.00 | 1.00 / 3.00 |",
+		"|        2 |         1 | 2.00 / 4.00 | 1.00 / 3.00 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputPriorityClasses() missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_outputArrivalCohorts(t *testing.T) {
+	t.Parallel()
+	fcfs := report.New("FCFS", sched.NewResult(nil, []workload.Process{
+		{ProcessID: 1, Burst: 8, Turnaround: 8, Completion: 8},
+		{ProcessID: 2, ArrivalTime: 3, Burst: 1, Wait: 5, Turnaround: 6, Completion: 9},
+		{ProcessID: 3, ArrivalTime: 12, Burst: 1, Turnaround: 1, Completion: 13},
+	}))
+	// RR stopped before process 3 arrived
+	rr := report.New("RR", sched.NewResult(nil, []workload.Process{
+		{ProcessID: 1, Burst: 8, Wait: 1, Turnaround: 9, Completion: 9},
+		{ProcessID: 2, ArrivalTime: 3, Burst: 1, Turnaround: 1, Completion: 4},
+	}))
+	var w bytes.Buffer
+	outputArrivalCohorts(&w, []report.Report{fcfs, rr}, 10)
+	for _, want := range []string{
+		"by arrival window of 10",
+		"| Arrival | Processes |    FCFS     |     RR      |",
+		"| 0-9     |         2 | 2.50 / 7.00 | 0.50 / 5.00 |",
+		"| 10-19   |         1 | 0.00 / 1.00 | -           |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputArrivalCohorts() missing %q:\n%s", want, w.String())
+		}
+	}
+}
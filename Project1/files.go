@@ -0,0 +1,164 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/filealloc"
+	"github.com/olekukonko/tablewriter"
+)
+
+func fileStrategyNames() string {
+	var names []string
+	for _, s := range filealloc.Strategies {
+		names = append(names, s.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parseFileStrategies resolves a comma-separated list of file-allocation strategy names, in the order given. An empty
+// list selects every strategy.
+func parseFileStrategies(list string) ([]filealloc.Strategy, error) {
+	if list == "" {
+		return filealloc.Strategies, nil
+	}
+	var selected []filealloc.Strategy
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, s := range filealloc.Strategies {
+			if s.Name == strings.TrimSpace(name) {
+				selected, found = append(selected, s), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown file-allocation strategy %q (want one of %s)", ErrInvalidArgs, name,
+				fileStrategyNames())
+		}
+	}
+
+	return selected, nil
+}
+
+func filesCommand(args []string) {
+	fs := flag.NewFlagSet("files", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "files [flags] (trace.txt | -trace \"create A 4; grow A 2; delete A\")")
+	var d filealloc.Device
+	fs.IntVar(&d.Blocks, "blocks", 32, "blocks on the device")
+	fs.IntVar(&d.BlockSize, "block-size", 512, "bytes in a block")
+	fs.IntVar(&d.Pointer, "pointer", 4, "bytes in a block pointer")
+	inline := fs.String("trace", "", "trace of semicolon-separated \"create NAME BLOCKS\", \"grow NAME BLOCKS\", "+
+		"and \"delete NAME\" requests")
+	selected := fs.String("strategies", "",
+		"comma-separated strategies to run, in order (default all: "+fileStrategyNames()+")")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if (*inline == "") == (fs.NArg() == 0) {
+		fatal(exitInvalid, fmt.Errorf("%w: give one trace file or -trace", ErrInvalidArgs))
+	}
+	if d.Blocks < 1 || d.Pointer < 1 || d.BlockSize < 2*d.Pointer {
+		fatal(exitInvalid, fmt.Errorf("%w: -blocks and -pointer must be at least 1, and -block-size hold two pointers",
+			ErrInvalidArgs))
+	}
+	run, err := parseFileStrategies(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+
+	var in io.Reader = strings.NewReader(*inline)
+	if *inline == "" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: opening trace", err))
+		}
+		defer f.Close()
+		in = f
+	}
+	requests, err := filealloc.ParseTrace(in)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+
+	results := make([]filealloc.Result, len(run))
+	for i, s := range run {
+		results[i] = filealloc.Simulate(requests, s, d)
+		outputFiles(os.Stdout, results[i])
+	}
+	if len(run) > 1 {
+		outputFilesSummary(os.Stdout, results)
+	}
+}
+
+// formatBlocks lists block numbers, collapsing runs of consecutive ones, such as "3-5, 9".
+func formatBlocks(blocks []int) string {
+	var parts []string
+	for i := 0; i < len(blocks); {
+		j := i
+		for j+1 < len(blocks) && blocks[j+1] == blocks[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, strconv.Itoa(blocks[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", blocks[i], blocks[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// outputFiles writes every step of one strategy with the blocks it took or gave back, the device map after each,
+// where the files left lie, and what the layout costs.
+func outputFiles(w io.Writer, r filealloc.Result) {
+	outputTitle(w, r.Strategy.Title)
+	_, _ = fmt.Fprintf(w, "%s: %s\n", r.Strategy.Title, r.Strategy.Description)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Step", "Request", "Blocks", "Copied", "Map"})
+	for i, s := range r.Steps {
+		blocks := s.Failed
+		if blocks == "" {
+			blocks = formatBlocks(s.Blocks)
+		}
+		table.Append([]string{strconv.Itoa(i + 1), s.Request.String(), blocks,
+			strconv.Itoa(s.Copied), s.Map})
+	}
+	table.Render()
+	for _, f := range r.Files {
+		_, _ = fmt.Fprintf(w, "%s: data %s", f.Name, formatBlocks(f.Data))
+		if len(f.Index) > 0 {
+			_, _ = fmt.Fprintf(w, ", index %s", formatBlocks(f.Index))
+		}
+		_, _ = fmt.Fprintf(w, "; extents: %d\n", f.Extents())
+	}
+	_, _ = fmt.Fprintf(w, "Map: %c free, %c index block, otherwise the file the block holds data of\n",
+		filealloc.MapFree, filealloc.MapIndex)
+	_, _ = fmt.Fprintf(w, "Free: %d blocks in %d holes, the largest %d (%.1f%% external fragmentation)\n", r.Free,
+		len(r.Holes), r.LargestHole(), 100*r.ExternalFragmentation())
+	_, _ = fmt.Fprintf(w, "Requests that failed: %d; blocks copied moving files: %d; blocks spent on pointers "+
+		"and indexes: %.2f\n", r.Failures(), r.Copied(), r.Wasted())
+	_, _ = fmt.Fprintf(w, "Blocks read per data block: %.2f reading files through, %.2f reaching one at random\n\n",
+		r.SequentialReads(), r.RandomReads())
+}
+
+// outputFilesSummary writes what the layout of every strategy costs side by side.
+func outputFilesSummary(w io.Writer, results []filealloc.Result) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Strategy", "Failed", "Copied", "Holes", "Largest hole", "External frag",
+		"Avg extents", "Wasted blocks", "Sequential reads", "Random reads"})
+	for _, r := range results {
+		table.Append([]string{r.Strategy.Title, strconv.Itoa(r.Failures()), strconv.Itoa(r.Copied()),
+			strconv.Itoa(len(r.Holes)), strconv.Itoa(r.LargestHole()), fmt.Sprintf("%.1f%%", 100*r.ExternalFragmentation()),
+			fmt.Sprintf("%.2f", r.AverageExtents()), fmt.Sprintf("%.2f", r.Wasted()),
+			fmt.Sprintf("%.2f", r.SequentialReads()), fmt.Sprintf("%.2f", r.RandomReads())})
+	}
+	table.Render()
+}
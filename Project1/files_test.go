@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/filealloc"
+)
+
+func Test_parseFileStrategies(t *testing.T) {
+	t.Parallel()
+	got, err := parseFileStrategies("indexed, contiguous")
+	if err != nil || len(got) != 2 || got[0].Name != "indexed" || got[1].Name != "contiguous" {
+		t.Errorf("parseFileStrategies() = %v, %v", got, err)
+	}
+	if all, _ := parseFileStrategies(""); len(all) != len(filealloc.Strategies) {
+		t.Errorf("parseFileStrategies(\"\") = %d strategies, want all %d", len(all), len(filealloc.Strategies))
+	}
+	if _, err := parseFileStrategies("fat"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseFileStrategies(\"fat\") error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_formatBlocks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		blocks []int
+		want   string
+	}{
+		{blocks: nil, want: ""},
+		{blocks: []int{4}, want: "4"},
+		{blocks: []int{3, 4, 5, 9, 1, 2}, want: "3-5, 9, 1-2"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			if got := formatBlocks(tt.blocks); got != tt.want {
+				t.Errorf("formatBlocks(%v) = %q, want %q", tt.blocks, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputFiles(t *testing.T) {
+	t.Parallel()
+	requests, err := filealloc.ParseTrace(strings.NewReader("create A 3; create B 2; create C 2; delete B; grow A 3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := filealloc.Device{Blocks: 10, BlockSize: 64, Pointer: 4}
+	results := []filealloc.Result{filealloc.Simulate(requests, filealloc.Strategies[0], device),
+		filealloc.Simulate(requests, filealloc.Strategies[2], device)}
+	var w bytes.Buffer
+	outputFiles(&w, results[0])
+	outputFiles(&w, results[1])
+	outputFilesSummary(&w, results)
+	for _, want := range []string{
+		"|    5 | grow A 3   | no hole of 6 blocks; the       |      0 | AAA..CC... |",
+		"Free: 5 blocks in 2 holes, the largest 3 (40.0% external fragmentation)\n",
+		"A: data 1-6, index 0; extents: 1\n",
+		"Blocks read per data block: 1.25 reading files through, 2.00 reaching one at random\n",
+		"| Indexed    |      0 |      0 |     0 |            0 | 0.0%          |        1.00 |          2.00 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
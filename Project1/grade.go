@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// rubricWeights splits each algorithm's points between its waits, turnarounds, and Gantt chart.
+var rubricWeights = []struct {
+	Item   string
+	Weight float64
+}{
+	{Item: "waiting times", Weight: 0.4},
+	{Item: "turnaround times", Weight: 0.4},
+	{Item: "Gantt chart", Weight: 0.2},
+}
+
+// rubricItem is one scored line of a grade report.
+type rubricItem struct {
+	Algorithm string
+	Item      string
+	Score     float64
+	Max       float64
+	Detail    string
+}
+
+// gradeCommand scores a submitted result set against a reference, either a saved result set or the built-in
+// schedulers run on the workload.
+func gradeCommand(args []string) {
+	fs := flag.NewFlagSet("grade", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "grade (-expected ref.json | -workload workload.csv) submission.json")
+	expectedFile := fs.String("expected", "", "reference result set saved with -output json")
+	workload := fs.String("workload", "", "run the built-in schedulers on this workload to get the reference instead")
+	tolerance := fs.Float64("tolerance", 0, "how far a waiting or turnaround time may be from the reference and still count")
+	points := fs.Float64("points", 10, "points each algorithm is worth")
+	fs.Int64Var(&options.quantum, "quantum", 1, "round-robin time quantum for -workload references")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 || (*expectedFile == "") == (*workload == "") {
+		fs.Usage()
+		fatal(exitInvalid, fmt.Errorf("%w: give one submission and exactly one of -expected or -workload", ErrInvalidArgs))
+	}
+
+	var expected []report.Report
+	if *expectedFile != "" {
+		var err error
+		if expected, err = loadResults(*expectedFile); err != nil {
+			fatal(exitInvalid, err)
+		}
+	} else {
+		expected = runAlgorithms(context.Background(), io.Discard, algorithms.Algorithms(), mustLoadWorkload([]string{*workload}))
+	}
+	submitted, err := loadResults(fs.Arg(0))
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+
+	outputGrade(os.Stdout, gradeReports(expected, submitted, *tolerance, *points))
+}
+
+// gradeReports scores every reference algorithm against the submitted one with the same title. A process's wait or
+// turnaround counts when it is within tolerance of the reference, and a Gantt slice counts when it matches the
+// reference slice at the same position exactly.
+func gradeReports(expected, submitted []report.Report, tolerance, points float64) []rubricItem {
+	items := make([]rubricItem, 0, len(expected)*len(rubricWeights))
+	for _, want := range expected {
+		var got *report.Report
+		for i := range submitted {
+			if submitted[i].Title == want.Title {
+				got = &submitted[i]
+				break
+			}
+		}
+
+		for _, w := range rubricWeights {
+			item := rubricItem{Algorithm: want.Title, Item: w.Item, Max: points * w.Weight}
+			var right, total int
+			switch {
+			case got == nil:
+				item.Detail = "missing from submission"
+			case w.Item == "Gantt chart":
+				total = len(want.Gantt)
+				if len(got.Gantt) > total {
+					total = len(got.Gantt)
+				}
+				right = total - len(ganttDivergences(want.Gantt, got.Gantt))
+				item.Detail = fmt.Sprintf("%d of %d slices match", right, total)
+			default:
+				value := func(p workload.Process) int64 { return p.Wait }
+				if w.Item == "turnaround times" {
+					value = func(p workload.Process) int64 { return p.Turnaround }
+				}
+				right, total = matchingProcesses(want.Processes, got.Processes, value, tolerance), len(want.Processes)
+				item.Detail = fmt.Sprintf("%d of %d processes correct", right, total)
+			}
+			if total > 0 {
+				item.Score = item.Max * float64(right) / float64(total)
+			}
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// matchingProcesses counts the processes of want whose value in got, matched by PID, is within tolerance.
+func matchingProcesses(want, got []workload.Process, value func(workload.Process) int64, tolerance float64) int {
+	byPID := make(map[int64]workload.Process, len(got))
+	for _, p := range got {
+		byPID[p.ProcessID] = p
+	}
+	right := 0
+	for _, p := range want {
+		if q, ok := byPID[p.ProcessID]; ok && math.Abs(float64(value(p)-value(q))) <= tolerance {
+			right++
+		}
+	}
+
+	return right
+}
+
+// outputGrade writes the rubric with a total score.
+func outputGrade(w io.Writer, items []rubricItem) {
+	var score, max float64
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		score += item.Score
+		max += item.Max
+		rows[i] = []string{item.Algorithm, item.Item, fmt.Sprintf("%.1f / %.1f", item.Score, item.Max), item.Detail}
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Item", "Score", "Detail"})
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.AppendBulk(rows)
+	table.Render()
+	percent := 0.0
+	if max > 0 {
+		percent = score / max * 100
+	}
+	_, _ = fmt.Fprintf(w, "Total: %.1f / %.1f (%.1f%%)\n", score, max, percent)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_gradeReports(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+	}
+	reference := []report.Report{
+		scheduleWith("fcfs")(context.Background(), io.Discard, "FCFS", processes),
+		scheduleWith("sjf")(context.Background(), io.Discard, "SJF", processes),
+	}
+
+	// the submission gets FCFS right except one wait that is off by one, and leaves out SJF
+	submitted := []report.Report{scheduleWith("fcfs")(context.Background(), io.Discard, "FCFS", processes)}
+	submitted[0].Processes = append([]workload.Process(nil), submitted[0].Processes...)
+	submitted[0].Processes[1].Wait++
+
+	tests := []struct {
+		name      string
+		tolerance float64
+		want      []float64 // FCFS wait, turnaround, Gantt, then SJF's
+	}{
+		{name: "exact", want: []float64{2, 4, 2, 0, 0, 0}},
+		{name: "tolerant", tolerance: 1, want: []float64{4, 4, 2, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			items := gradeReports(reference, submitted, tt.tolerance, 10)
+			if len(items) != len(tt.want) {
+				t.Fatalf("got %d rubric items, want %d", len(items), len(tt.want))
+			}
+			for i, item := range items {
+				if item.Score != tt.want[i] {
+					t.Errorf("%s %s scored %v, want %v", item.Algorithm, item.Item, item.Score, tt.want[i])
+				}
+			}
+			if items[3].Detail != "missing from submission" {
+				t.Errorf("missing algorithm detail = %q", items[3].Detail)
+			}
+		})
+	}
+
+	var w bytes.Buffer
+	outputGrade(&w, gradeReports(reference, reference, 0, 10))
+	if !strings.Contains(w.String(), "Total: 20.0 / 20.0 (100.0%)") {
+		t.Errorf("perfect submission graded:\n%s", w.String())
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"GolandProjects/Project1/pkg/history"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// maxHistoryWorkload is the most processes a run keeps its workload for in the history; larger runs keep only its
+// hash and can't be shown again.
+const maxHistoryWorkload = 10000
+
+// defaultHistoryPath returns where runs are recorded unless -history says otherwise, or "" if the user has no
+// config directory.
+func defaultHistoryPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "scheduler", "history.jsonl")
+}
+
+// addHistoryFlag adds the -history flag naming the results database to fs.
+func addHistoryFlag(fs *flag.FlagSet, usage string) *string {
+	return fs.String("history", defaultHistoryPath(), usage)
+}
+
+// recordRun adds a run of algorithms on processes, loaded from source, to the results database at path. Failing to
+// record it is only worth a warning.
+func recordRun(path, source string, processes []workload.Process, run []sched.Algorithm, perturb float64,
+	reports []report.Report) {
+	r := history.Run{
+		Time:         time.Now().UTC(),
+		Source:       source,
+		WorkloadHash: history.Hash(processes),
+		Options:      history.Options{Quantum: options.quantum, Seed: options.seed, SwitchCost: options.switchCost, Perturb: perturb},
+	}
+	if len(processes) <= maxHistoryWorkload {
+		var csv strings.Builder
+		_ = workload.Write(&csv, processes)
+		r.Workload = csv.String()
+	}
+	for i, a := range run {
+		r.Options.Algorithms = append(r.Options.Algorithms, a.Name())
+		record := reports[i].Record()
+		record.Name = a.Name()
+		r.Results = append(r.Results, record)
+	}
+	if err := history.Open(path).Add(r); err != nil {
+		logger.Warn("recording run in history", "err", err)
+	}
+}
+
+// workloadSource names where a workload was loaded from, for the history.
+func workloadSource(exampleName string, args []string) string {
+	if exampleName != "" {
+		return "example:" + exampleName
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	if abs, err := filepath.Abs(args[0]); err == nil {
+		return abs
+	}
+
+	return args[0]
+}
+
+// historyCommand lists the runs in the results database, or shows one again.
+func historyCommand(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "history [flags]")
+	path := addHistoryFlag(fs, "results database to read")
+	var filter history.Filter
+	fs.StringVar(&filter.Workload, "workload", "", "only runs whose workload file contains this or whose hash starts with it")
+	fs.StringVar(&filter.Algorithm, "algorithm", "", "only runs of this algorithm, showing only its results")
+	since := fs.String("since", "", "only runs since this date (2006-01-02) or this long ago (e.g. 24h)")
+	limit := fs.Int("limit", 20, "list only this many of the latest runs (0 for all)")
+	show := fs.Int("show", 0, "run the run with this ID again with its workload and options, and print its full report")
+	fs.String("config", "",
+		"file of default flag values (default scheduler.toml, scheduler.yaml, or scheduler.yml if present)")
+	_ = fs.Parse(args)
+	if err := applyDefaults(fs); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *path == "" {
+		fatal(exitInvalid, fmt.Errorf("%w: no -history database", ErrInvalidArgs))
+	}
+	if fs.NArg() > 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: history takes no arguments", ErrInvalidArgs))
+	}
+	var err error
+	if filter.Since, err = parseSince(*since, time.Now()); err != nil {
+		fatal(exitInvalid, err)
+	}
+
+	db := history.Open(*path)
+	if *show != 0 {
+		r, err := db.Get(*show)
+		if err != nil {
+			fatal(exitFailure, err)
+		}
+		if err := showRun(context.Background(), os.Stdout, r); err != nil {
+			fatal(exitCode(err), err)
+		}
+		return
+	}
+	runs, err := db.Runs(filter)
+	if err != nil {
+		fatal(exitFailure, err)
+	}
+	if *limit > 0 && len(runs) > *limit {
+		runs = runs[len(runs)-*limit:]
+	}
+	outputHistory(os.Stdout, runs, filter.Algorithm)
+}
+
+// parseSince parses -since as a date or as a duration before now. An empty string is the zero time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: -since %q is neither a date nor a duration", ErrInvalidArgs, s)
+	}
+
+	return t, nil
+}
+
+// outputHistory writes a row per algorithm of each run, only those of algorithm if it isn't empty.
+func outputHistory(w io.Writer, runs []history.Run, algorithm string) {
+	var rows [][]string
+	for _, r := range runs {
+		for _, result := range r.Results {
+			if algorithm != "" && result.Name != algorithm {
+				continue
+			}
+			rows = append(rows, []string{
+				fmt.Sprint(r.ID),
+				r.Time.Local().Format("2006-01-02 15:04"),
+				filepath.Base(r.Source),
+				r.WorkloadHash[:min(len(r.WorkloadHash), 8)],
+				result.Name,
+				fmt.Sprint(r.Options.Quantum),
+				fmt.Sprint(r.Options.Seed),
+				fmt.Sprintf("%.2f", result.AvgWait),
+				fmt.Sprintf("%.2f", result.AvgTurnaround),
+				fmt.Sprintf("%.2f%%", result.Utilization*100),
+			})
+		}
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Time", "Workload", "Hash", "Algorithm", "Quantum", "Seed", "Avg wait",
+		"Avg turnaround", "Utilization"})
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// showRun runs r again with the workload and options it was recorded with and writes the full text report.
+func showRun(ctx context.Context, w io.Writer, r history.Run) error {
+	processes, err := r.Processes()
+	if err != nil {
+		return err
+	}
+	run, err := parseAlgorithms(strings.Join(r.Options.Algorithms, ","))
+	if err != nil {
+		return fmt.Errorf("%w (run %d can't be shown again)", err, r.ID)
+	}
+	options.quantum, options.seed, options.switchCost = r.Options.Quantum, r.Options.Seed, r.Options.SwitchCost
+	options.randomized = r.Options.Perturb > 0
+	options.color = isTerminal(os.Stdout)
+
+	_, _ = fmt.Fprintf(w, "Run %d of %s on %s (workload %s)\n\n", r.ID, r.Time.Local().Format("2006-01-02 15:04"),
+		r.Source, r.WorkloadHash)
+	processes = perturbBursts(processes, r.Options.Perturb, newRand(r.Options.Seed, "perturb"))
+	reports := runAlgorithms(ctx, w, run, processes)
+	outputPriorityClasses(w, reports)
+
+	return nil
+}
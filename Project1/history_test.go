@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GolandProjects/Project1/pkg/history"
+)
+
+// Test_recordRun records a run and shows it again. It sets the options the algorithms read, so it doesn't run in
+// parallel with the other tests.
+func Test_recordRun(t *testing.T) {
+	saved := options
+	t.Cleanup(func() { options = saved })
+	options.quantum, options.seed = 2, 7
+
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, _ := parseAlgorithms("fcfs,rr")
+	reports := runAlgorithms(context.Background(), io.Discard, run, processes)
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	recordRun(path, workloadSource("srtf", nil), processes, run, 0, reports)
+
+	runs, err := history.Open(path).Runs(history.Filter{})
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("history = %v, %v, want one run", runs, err)
+	}
+	r := runs[0]
+	if r.Source != "example:srtf" || r.Options.Quantum != 2 || r.Options.Seed != 7 || len(r.Results) != 2 ||
+		r.Results[1].Name != "rr" || r.Results[1].AvgWait != reports[1].Summary.Wait {
+		t.Errorf("recorded %+v", r)
+	}
+
+	var list bytes.Buffer
+	outputHistory(&list, runs, "rr")
+	if !strings.Contains(list.String(), "| example:srtf | "+r.WorkloadHash[:8]+" | rr ") ||
+		strings.Contains(list.String(), "fcfs") {
+		t.Errorf("outputHistory() =\n%s", list.String())
+	}
+
+	options.quantum, options.seed = 1, 1
+	var shown bytes.Buffer
+	if err := showRun(context.Background(), &shown, r); err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	options.quantum, options.seed = 2, 7
+	runAlgorithms(context.Background(), &want, run, processes)
+	if !strings.HasPrefix(shown.String(), "Run 1 of ") || !strings.Contains(shown.String(), want.String()) {
+		t.Errorf("showRun() =\n%s\nwant the report\n%s", shown.String(), want.String())
+	}
+}
+
+func Test_parseSince(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 2, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "36h", want: now.Add(-36 * time.Hour)},
+		{in: "2026-04-30", want: time.Date(2026, 4, 30, 0, 0, 0, 0, time.Local)},
+		{in: "last week", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSince(tt.in, now)
+			if !got.Equal(tt.want) || (err != nil) != tt.wantErr {
+				t.Errorf("parseSince(%q) = %v, %v, want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
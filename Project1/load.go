@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"GolandProjects/Project1/pkg/report"
+	"github.com/olekukonko/tablewriter"
+)
+
+// outputLoad compares how congested each algorithm kept the CPU: the average and longest ready queue, and the
+// highest each load average rose to.
+func outputLoad(w io.Writer, reports []report.Report) {
+	_, _ = fmt.Fprintln(w, "Ready queue and load averages")
+	table := tablewriter.NewWriter(w)
+	table.SetAutoFormatHeaders(false)
+	header := []string{"Algorithm", "Avg queue", "Longest queue"}
+	for _, n := range report.LoadPeriods {
+		header = append(header, fmt.Sprintf("Peak %d-unit load", n))
+	}
+	table.SetHeader(header)
+	for _, r := range reports {
+		load := report.NewLoad(r.Gantt, r.Processes)
+		longest, at := load.LongestQueue()
+		var peaks [3]float64
+		for _, a := range load.Averages {
+			for i := range peaks {
+				peaks[i] = max(peaks[i], a.Averages[i])
+			}
+		}
+		row := []string{r.Title, fmt.Sprintf("%.2f", load.AverageQueue()), fmt.Sprintf("%d at %d", longest, at)}
+		for _, p := range peaks {
+			row = append(row, fmt.Sprintf("%.2f", p))
+		}
+		table.Append(row)
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
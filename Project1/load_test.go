@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputLoad(t *testing.T) {
+	t.Parallel()
+	// both processes are runnable from 0 to 2, and P2 alone to 3
+	r := report.New("FCFS", sched.NewResult([]sched.TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 3}},
+		[]workload.Process{
+			{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2},
+			{ProcessID: 2, Burst: 1, Wait: 2, Turnaround: 3, Completion: 3},
+		}))
+	var w bytes.Buffer
+	outputLoad(&w, []report.Report{r})
+	want := "| FCFS      |      0.67 | 1 at 0        |             1.73 |             0.72 |              0.30 |"
+	if !strings.Contains(w.String(), want) {
+		t.Errorf("outputLoad() missing %q:\n%s", want, w.String())
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// logger is the program-wide logger, writing to stderr at the level chosen by -log-level.
+var logger = newLogger(os.Stderr, "text", slog.LevelInfo)
+
+func newLogger(w io.Writer, format string, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// addLogFlags adds -log-level and -log-format to fs. Call the returned function after parsing to install the logger.
+func addLogFlags(fs *flag.FlagSet) func() error {
+	level := fs.String("log-level", "info", "least severe log messages to write to stderr: debug, info, warn, or error")
+	format := fs.String("log-format", "text", "log message format: text (key=value pairs) or json")
+
+	return func() error {
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(*level)); err != nil {
+			return fmt.Errorf("%w: unknown log level %q (want debug, info, warn, or error)", ErrInvalidArgs, *level)
+		}
+		f := strings.ToLower(*format)
+		if f != "text" && f != "json" {
+			return fmt.Errorf("%w: unknown log format %q (want text or json)", ErrInvalidArgs, *format)
+		}
+		logger = newLogger(os.Stderr, f, l)
+		slog.SetDefault(logger) // what the schedulers log through unless their context carries a logger
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestSchedulerLogging(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 2},
+	}
+	tests := []struct {
+		name     string
+		schedule func(context.Context, io.Writer, string, []workload.Process) report.Report
+		want     []string // messages in order as message:pid
+	}{
+		{name: "FCFS", schedule: scheduleWith("fcfs"), want: []string{"dispatch:1", "finish:1", "dispatch:2", "finish:2"}},
+		{name: "SJF", schedule: scheduleWith("sjf"), want: []string{"dispatch:1", "finish:1", "dispatch:2", "finish:2"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			ctx := sched.WithLogger(context.Background(), newLogger(&buf, "json", slog.LevelDebug))
+			tt.schedule(ctx, io.Discard, tt.name, processes)
+
+			var got []string
+			dec := json.NewDecoder(&buf)
+			for dec.More() {
+				var rec struct {
+					Msg       string
+					Algorithm string
+					PID       int64
+				}
+				if err := dec.Decode(&rec); err != nil {
+					t.Fatalf("log is not JSON lines: %v", err)
+				}
+				if rec.Algorithm != tt.name {
+					t.Errorf("record algorithm = %q, want %q", rec.Algorithm, tt.name)
+				}
+				got = append(got, fmt.Sprintf("%s:%d", rec.Msg, rec.PID))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("logged %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("logged %v, want %v", got, tt.want)
+					break
+				}
+			}
+
+			// at the default level the schedulers are silent
+			buf.Reset()
+			ctx = sched.WithLogger(context.Background(), newLogger(&buf, "json", slog.LevelInfo))
+			tt.schedule(ctx, io.Discard, tt.name, processes)
+			if buf.Len() != 0 {
+				t.Errorf("info-level log = %q, want nothing", buf.String())
+			}
+		})
+	}
+}
+
+// Test_addLogFlags replaces the program-wide logger, so it isn't parallel.
+func Test_addLogFlags(t *testing.T) {
+	saved := logger
+	t.Cleanup(func() { logger = saved })
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "defaults"},
+		{name: "debug json", args: []string{"-log-level", "DEBUG", "-log-format", "JSON"}},
+		{name: "bad level", args: []string{"-log-level", "loud"}, wantErr: ErrInvalidArgs},
+		{name: "bad format", args: []string{"-log-format", "xml"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			apply := addLogFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := apply(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
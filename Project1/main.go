@@ -1,454 +1,340 @@
 package main
 
 import (
-	"encoding/csv"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"github.com/olekukonko/tablewriter"
 	"io"
-	"log"
 	"os"
-	"sort"
-	"strconv"
+	"os/signal"
 	"strings"
+	"text/template"
 )
 
-func main() {
-	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer closeFile()
+// options holds the command-line settings shared by the schedulers' output helpers.
+var options struct {
+	svgDir     string
+	columns    []scheduleColumn
+	sortBy     string
+	color      bool
+	template   *template.Template
+	timeline   bool
+	load       bool
+	sla        *report.SLA
+	summary    string
+	output     string
+	switchCost int64
+	syscall    func(pid int64) int64
+	quantum    int64
+	seed       int64
+	randomized bool
+	step       *stepper
+	progress   *progress
+	streamDir  string
+}
 
-	// Load and parse processes
-	processes, err := loadProcesses(f)
-	if err != nil {
-		log.Fatal(err)
+func main() {
+	name, args := "run", os.Args[1:]
+	if len(args) > 0 {
+		if _, ok := lookupCommand(args[0]); ok {
+			name, args = args[0], args[1:]
+		}
 	}
-
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
-	// Shortest-job-first scheduling
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	// Priority Scheduling
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	// Round Robin Scheduling
-	RRSchedule(os.Stdout, "Round-robin", processes)
+	c, _ := lookupCommand(name)
+	c.Run(args)
 }
 
-func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
-		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+// runCommand simulates the selected algorithms on a workload file and reports the results.
+func runCommand(args []string) {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	usage := commandUsage(fs, "run [flags] (workload.csv | -example name)")
+	fs.Usage = func() {
+		usage()
+		_, _ = fmt.Fprintln(fs.Output(), "Algorithms (select with -algorithms):")
+		outputAlgorithms(fs.Output(), "  ")
 	}
-	// Read in CSV process CSV file
-	f, err := os.Open(args[1])
-	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	fs.StringVar(&options.svgDir, "svg", "",
+		"directory to also write SVG charts (waiting-time histogram, throughput curve) per algorithm into, and a "+
+			"scatter of turnaround against burst of them all")
+	aliasFlag(fs, "histogram-svg", "svg")
+	sla := fs.String("sla", "",
+		"latency target every process should meet, as TARGET for all and PID=TARGET for one, such as 20,3=5, to "+
+			"report how each algorithm met them (default none)")
+	slaMetric := fs.String("sla-metric", "turnaround", "latency -sla targets are set on ("+latencyMetricNames()+")")
+	scatter := fs.String("scatter", "",
+		"also write every algorithm's (burst, turnaround) pairs, one per process, to this CSV file")
+	columns := fs.String("columns", "",
+		"comma-separated schedule table columns to show, in order (default all: "+columnNames()+")")
+	fs.StringVar(&options.sortBy, "sort", "",
+		"order schedule table rows by pid, arrival, burst, priority, wait, turnaround, or completion")
+	selected := fs.String("algorithms", "",
+		"comma-separated algorithms to run, in order (default all: "+algorithmNames()+")")
+	pluginCommand := fs.String("plugin", "",
+		"external scheduler command speaking the JSON plugin protocol, added to the algorithms as \"plugin\"")
+	policyScript := fs.String("policy", "",
+		"policy script choosing the process for every time unit, added to the algorithms as \"policy\"")
+	exampleName := fs.String("example", "", "run a bundled example workload instead of a file ("+exampleNames()+")")
+	listExamples := fs.Bool("list-examples", false, "list the bundled example workloads and exit")
+	listAlgorithms := fs.Bool("list-algorithms", false, "list the available algorithms and exit")
+	noColor := fs.Bool("no-color", false, "disable ANSI colors in Gantt charts")
+	fs.BoolVar(&options.timeline, "timeline", false,
+		"also draw a per-process timeline of running/ready states at every time unit")
+	fs.BoolVar(&options.load, "load", false,
+		"also report the ready-queue length and the 1, 5, and 15 time unit load averages over time")
+	cohorts := fs.Int64("cohorts", 0,
+		"also compare the algorithms' average wait and turnaround for the processes arriving in each window of this many time units")
+	fs.StringVar(&options.summary, "summary", "",
+		"finish with a single machine-readable summary line: kv (key=value pairs) or json")
+	fs.StringVar(&options.output, "output", "text",
+		"result format: text (human-readable report), csv (per-process metrics), json, msgpack, pb (see results.proto), or pdf")
+	fs.Int64Var(&options.switchCost, "switch-cost", 0,
+		"time units charged for every context switch between two different processes")
+	syscall := fs.String("syscall", "",
+		"percent of its running time each process spends again in the kernel, as PERCENT for all and PID=PERCENT "+
+			"for one, such as 10,3=40 (default none)")
+	templateFile := fs.String("template", "",
+		"render each algorithm's results with this Go text/template file instead of the built-in report")
+	fs.Int64Var(&options.quantum, "quantum", 1, "round-robin time quantum")
+	fs.Int64Var(&options.seed, "seed", 0,
+		"random seed for the lottery draws and -perturb, recorded in reports using it (default: based on the current time)")
+	perturb := fs.Float64("perturb", 0,
+		"scale each burst by a random factor within this fraction of 1 (e.g. 0.2 for ±20%) before scheduling")
+	tui := fs.Bool("tui", false,
+		"animate each algorithm's Gantt chart and ready queue in the terminal instead of printing the text report")
+	speed := fs.Float64("speed", 2, "-tui playback speed in time units per second")
+	timeout := fs.Duration("timeout", 0,
+		"stop each run after this long and report the processes that finished by then (default no limit)")
+	cpuProfile := fs.String("cpuprofile", "", "write a CPU profile of the simulation to this file")
+	memProfile := fs.String("memprofile", "", "write a heap profile to this file when the simulation ends")
+	pprofAddr := fs.String("pprof", "", "serve net/http/pprof on this address (e.g. localhost:6060) while running")
+	noProgress := fs.Bool("no-progress", false, "never show the progress bar for large simulations")
+	watch := fs.Bool("watch", false, "re-run whenever the workload file changes, until interrupted")
+	crosscheck := fs.Bool("crosscheck", false,
+		"instead of the report, run each algorithm on the policy engine too and list any difference in metrics or Gantt")
+	fs.StringVar(&options.streamDir, "stream", "",
+		"for huge workloads: stream each algorithm's Gantt chart and per-process metrics to files in this directory and report only run-wide metrics")
+	historyPath := addHistoryFlag(fs, "results database every run is recorded in, for the history command (empty to record nothing)")
+	step := fs.Bool("step", false,
+		"pause after every scheduling decision to show the time, ready queue, and Gantt chart so far")
+	fs.String("config", "",
+		"file of default flag values (default scheduler.toml, scheduler.yaml, or scheduler.yml if present)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyDefaults(fs); err != nil {
+		fatal(exitInvalid, err)
 	}
-	closeFn := func() {
-		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
-		}
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
 	}
-
-	return f, closeFn, nil
-}
-
-type (
-	Process struct {
-		ProcessID     int64
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
-		Wait          int64
-		Turnaround    int64
-		Burst         int64
-	}
-	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
+	if *pluginCommand != "" {
+		if err := algorithms.Register(pluginAlgorithm(*pluginCommand)); err != nil {
+			fatal(exitInvalid, err)
+		}
 	}
-)
-
-//region Schedulers
-
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
-// • a title for the chart
-// • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
+	if *policyScript != "" {
+		p, err := loadPolicyScript(*policyScript)
+		if err != nil {
+			fatal(exitInvalid, err)
 		}
-		totalWait += float64(waitingTime)
-
-		start := waitingTime + processes[i].ArrivalTime
-
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
-
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+		if err := algorithms.Register(scriptAlgorithm(p)); err != nil {
+			fatal(exitInvalid, err)
 		}
-		serviceTime += processes[i].BurstDuration
-
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
 	}
-
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		start           int64
-		totalWait       float64
-		totalTurnaround float64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		time            int64     //time counter
-		pCount          int       //counter for processes slice
-		readyQueue      []Process //Queue for processes ready to be executed
-		numProcesses    int       = len(processes)
-	)
-	start = time //set start for gantt chart to 0
-
-	for {
-		if numProcesses < 1 {
-			break //numProcesses is set to total number of processes, each time one is finished executing this number will decrease
+	if *listAlgorithms {
+		outputAlgorithms(os.Stdout, "")
+		return
+	}
+	if *listExamples {
+		outputExamples(os.Stdout)
+		return
+	}
+	options.color = !*noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+	run, err := parseAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	if options.columns, err = parseColumns(*columns); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if err = validateSortOrder(options.sortBy); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if err = validateSummaryFormat(options.summary); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if err = validateOutputFormat(options.output); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if options.syscall, err = parseSyscallOverhead(*syscall); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if options.sla, err = parseSLA(*sla, *slaMetric); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: quantum must be at least 1", ErrInvalidArgs))
+	}
+	if *cohorts < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -cohorts must not be negative", ErrInvalidArgs))
+	}
+	if err = validatePerturb(*perturb); err != nil {
+		fatal(exitInvalid, err)
+	}
+	options.seed = resolveSeed(options.seed)
+	options.randomized = *perturb > 0
+	if *templateFile != "" {
+		if options.template, err = parseReportTemplate(*templateFile); err != nil {
+			fatal(exitInvalid, err)
 		}
-
-		if pCount < len(processes) && time == processes[pCount].ArrivalTime { //once we have added all the processes to the ready queue we will stop using
-			//add process to queue
-			readyQueue = append(readyQueue, processes[pCount])
-			readyQueue[len(readyQueue)-1].Burst = processes[pCount].BurstDuration
-			pCount++
-
+	}
+	if *tui && (options.output != "text" || *speed <= 0) {
+		fatal(exitInvalid, fmt.Errorf("%w: -tui needs -output text and a positive -speed", ErrInvalidArgs))
+	}
+	if options.streamDir != "" {
+		if options.switchCost > 0 || options.syscall != nil || options.timeline || options.load || *tui || *step ||
+			*crosscheck {
+			fatal(exitInvalid, fmt.Errorf("%w: -stream cannot be used with -switch-cost, -syscall, -timeline, -load, "+
+				"-tui, -step, or -crosscheck", ErrInvalidArgs))
 		}
-		//for gantt to rack when a different process starts executing
-		tempPID := readyQueue[0].ProcessID
-		//sort readyQueue so shortest BurstDuration is 1st item in queue
-		sort.SliceStable(readyQueue, func(i, j int) bool {
-			return readyQueue[i].BurstDuration < readyQueue[j].BurstDuration
-		})
-		time++
-
-		readyQueue[0].BurstDuration--
-		//++wait for all processes waiting in queue
-		for i := range readyQueue {
-			if i != 0 {
-				readyQueue[i].Wait++
-			}
+		if err := os.MkdirAll(options.streamDir, 0o755); err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: creating -stream directory", err))
 		}
-		//If a new process started executing append the gantt
-		if tempPID != readyQueue[0].ProcessID {
+	}
+	if *step {
+		options.step = newStepper(os.Stdin, os.Stderr)
+	}
+	if *watch && (*tui || len(fs.Args()) != 1 || *exampleName != "") {
+		fatal(exitInvalid, fmt.Errorf("%w: -watch needs a single workload file and cannot be used with -tui",
+			ErrInvalidArgs))
+	}
 
-			gantt = append(gantt, TimeSlice{
-				PID:   readyQueue[0].ProcessID,
-				Start: start,
-				Stop:  time,
-			})
-			start = time
+	stopProfiling, err := startProfiling(*cpuProfile, *memProfile, *pprofAddr)
+	if err != nil {
+		fatal(exitFailure, err)
+	}
+	defer stopProfiling()
+	exitHooks = append(exitHooks, stopProfiling)
+
+	// an interrupt stops the current run the same way -timeout does, and ends -watch
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	simulate := func(processes []workload.Process) {
+		ctx := sched.WithLogger(interrupted, logger)
+		if *timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+			defer cancel()
 		}
-
-		if readyQueue[0].BurstDuration < 1 {
-
-			totalWait += float64(readyQueue[0].Wait)
-			turnaround := readyQueue[0].Wait + readyQueue[0].Burst
-			totalTurnaround += float64(turnaround)
-			schedule[readyQueue[0].ProcessID-1] = []string{
-				fmt.Sprint(readyQueue[0].ProcessID),
-				fmt.Sprint(readyQueue[0].Priority),
-				fmt.Sprint(readyQueue[0].Burst),
-				fmt.Sprint(readyQueue[0].ArrivalTime),
-				fmt.Sprint(readyQueue[0].Wait),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(time),
-			}
-			// if a process swapped during another process execution and reached completion modify the stop time
-			// else append the gantt
-			if len(gantt) > 1 && gantt[len(gantt)-1].PID == readyQueue[0].ProcessID {
-				gantt[len(gantt)-1].Stop = time
-			} else {
-				gantt = append(gantt, TimeSlice{
-					PID:   readyQueue[0].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
-			}
-
-			start = time
-
-			if len(readyQueue) > 1 {
-				//pop front of queue if there is more than 1 process in queue
-				var _ Process
-				_, readyQueue = readyQueue[0], readyQueue[1:]
+		if *crosscheck {
+			processes = perturbBursts(processes, *perturb, newRand(options.seed, "perturb"))
+			if outputCrosscheck(ctx, os.Stdout, run, processes) && !*watch {
+				fatal(exitDiffer, errors.New("the engines disagree"))
 			}
-			numProcesses--
+			return
 		}
-
-	}
-	avgWait := totalWait / float64(pCount)
-	avgTurnaround := totalTurnaround / float64(pCount)
-	avgThroughput := float64(pCount) / float64(time)
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, avgWait, avgTurnaround, avgThroughput)
-
-}
-
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		start           int64
-		totalWait       float64
-		totalTurnaround float64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		time            int64     //time counter
-		pCount          int       //counter for processes slice
-		readyQueue      []Process //Queue for processes ready to be executed
-		numProcesses    int       = len(processes)
-	)
-	start = time //set start for gantt chart to 0
-
-	for {
-		if numProcesses < 1 {
-			break //numProcesses is set to total number of processes, each time one is finished executing this number will decrease
+		// the text report is written as each scheduler finishes; other formats encode every result at the end
+		var out io.Writer = os.Stdout
+		if options.output != "text" || *tui {
+			out = io.Discard
 		}
-
-		if pCount < len(processes) && time == processes[pCount].ArrivalTime { //once we have added all the processes to the ready queue we will stop using
-			//add process to queue
-			readyQueue = append(readyQueue, processes[pCount])
-			readyQueue[len(readyQueue)-1].Burst = processes[pCount].BurstDuration
-			pCount++
-
+		if large, work := needsProgress(processes, len(run)); large && !*noProgress && !*tui && !*step &&
+			isTerminal(os.Stderr) {
+			options.progress = startProgress(os.Stderr, work)
 		}
-		//for gantt to rack when a different process starts executing
-		tempPID := readyQueue[0].ProcessID
-		//sort readyQueue so shortest BurstDuration is 1st item in queue
-		sort.SliceStable(readyQueue, func(i, j int) bool {
-			return readyQueue[i].Priority < readyQueue[j].Priority
-		})
-		time++
-
-		readyQueue[0].BurstDuration--
-		//++wait for all processes waiting in queue
-		for i := range readyQueue {
-			if i != 0 {
-				readyQueue[i].Wait++
+		perturbed := perturbBursts(processes, *perturb, newRand(options.seed, "perturb"))
+		reports := runAlgorithms(ctx, out, run, perturbed)
+		options.progress.finish()
+		options.progress = nil
+		if points := burstTurnarounds(reports); *scatter != "" || options.svgDir != "" {
+			if *scatter != "" {
+				if err := saveScatter(*scatter, points); err != nil {
+					fatal(exitFailure, err)
+				}
 			}
-		}
-		//If a new process started executing append the gantt
-		if tempPID != readyQueue[0].ProcessID || pCount == 1 {
-			if len(gantt) > 0 && gantt[0].PID == processes[0].ProcessID {
-				// to properly update gantt
-			} else {
-				gantt = append(gantt, TimeSlice{
-					PID:   readyQueue[0].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
-				start = time
+			if options.svgDir != "" {
+				if err := report.SaveScatterSVG(options.svgDir, points); err != nil {
+					logger.Warn("saving scatter chart", "err", err)
+				}
 			}
-
 		}
-
-		if readyQueue[0].BurstDuration < 1 {
-
-			totalWait += float64(readyQueue[0].Wait)
-			turnaround := readyQueue[0].Wait + readyQueue[0].Burst
-			totalTurnaround += float64(turnaround)
-			schedule[readyQueue[0].ProcessID-1] = []string{
-				fmt.Sprint(readyQueue[0].ProcessID),
-				fmt.Sprint(readyQueue[0].Priority),
-				fmt.Sprint(readyQueue[0].Burst),
-				fmt.Sprint(readyQueue[0].ArrivalTime),
-				fmt.Sprint(readyQueue[0].Wait),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(time),
+		if *historyPath != "" {
+			recordRun(*historyPath, workloadSource(*exampleName, fs.Args()), processes, run, *perturb, reports)
+		}
+		switch {
+		case *tui:
+			animate(os.Stdout, reports, readControls(os.Stdin), playback{Speed: *speed})
+		case options.output == "text" && options.template == nil:
+			outputPriorityClasses(os.Stdout, reports)
+			if *cohorts > 0 {
+				outputArrivalCohorts(os.Stdout, reports, *cohorts)
 			}
-			// if a process swapped during another process execution and reached completion modify the stop time
-			// else append the gantt
-			if len(gantt) > 1 && gantt[len(gantt)-1].PID == readyQueue[0].ProcessID {
-				gantt[len(gantt)-1].Stop = time
-			} else {
-				gantt = append(gantt, TimeSlice{
-					PID:   readyQueue[0].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
+			outputConvoys(os.Stdout, run, reports)
+			outputDeadlineMisses(os.Stdout, reports)
+			if options.sla != nil {
+				outputSLAs(os.Stdout, *options.sla, reports)
 			}
-
-			start = time
-
-			if len(readyQueue) > 1 {
-				//pop front of queue if there is more than 1 process in queue
-				var _ Process
-				_, readyQueue = readyQueue[0], readyQueue[1:]
+			if options.load {
+				outputLoad(os.Stdout, reports)
 			}
-			numProcesses--
+			outputReportsParetoFront(os.Stdout, reports)
 		}
-
-	}
-	avgWait := totalWait / float64(pCount)
-	avgTurnaround := totalTurnaround / float64(pCount)
-	avgThroughput := float64(pCount) / float64(time)
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, avgWait, avgTurnaround, avgThroughput)
-
-}
-
-func RRSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		start           int64
-		totalWait       float64
-		totalTurnaround float64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		time            int64     //time counter
-		pCount          int       //counter for processes slice
-		readyQueue      []Process //Queue for processes ready to be executed
-		numProcesses    int       = len(processes)
-	)
-	start = time              //set start for gantt chart to 0
-	var timeQuantum int64 = 1 // change this to modify the time quantum
-	var skip bool = false
-	qCount := 0
-	for {
-		if numProcesses < 1 {
-			break //numProcesses is set to total number of processes, each time one is finished executing this number will decrease
+		if encode, ok := resultEncoders[options.output]; ok {
+			if err := encode(os.Stdout, reports); err != nil {
+				fatal(exitFailure, err)
+			}
 		}
-
-		if pCount < len(processes) && time == processes[pCount].ArrivalTime { //once we have added all the processes to the ready queue we will stop using
-			//add process to queue
-			readyQueue = append(readyQueue, processes[pCount])
-			readyQueue[len(readyQueue)-1].Burst = processes[pCount].BurstDuration
-			pCount++
+		if err := outputSummaryLine(os.Stdout, options.summary, reports); err != nil {
+			fatal(exitFailure, err)
 		}
-		tempPID := readyQueue[qCount].ProcessID
-		time++
-		readyQueue[qCount].BurstDuration--
-		//inc wait for items in readyQueue
-		for i := range readyQueue {
-			if i != qCount {
-				readyQueue[i].Wait++
+		for _, r := range reports {
+			if r.Stopped != "" && !*watch {
+				fatal(exitStopped, fmt.Errorf("%s stopped before every process finished: %s", r.Title, r.Stopped))
 			}
 		}
+		if err := deadlineMisses(reports); err != nil && !*watch {
+			fatal(exitDeadlineMiss, err)
+		}
+	}
+	if *watch {
+		watchWorkload(os.Stderr, fs.Arg(0), watchInterval, interrupted.Done(), simulate)
+		return
+	}
+	// Load and parse processes
+	simulate(mustLoadWorkloadOrExample(*exampleName, fs.Args()))
+}
 
-		if readyQueue[qCount].BurstDuration < 1 {
-			totalWait += float64(readyQueue[qCount].Wait)
-			turnaround := readyQueue[qCount].Wait + readyQueue[qCount].Burst
-			totalTurnaround += float64(turnaround)
-			schedule[readyQueue[qCount].ProcessID-1] = []string{
-				fmt.Sprint(readyQueue[qCount].ProcessID),
-				fmt.Sprint(readyQueue[qCount].Priority),
-				fmt.Sprint(readyQueue[qCount].Burst),
-				fmt.Sprint(readyQueue[qCount].ArrivalTime),
-				fmt.Sprint(readyQueue[qCount].Wait),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(time),
-			}
-			// if a process swapped during another process execution and reached completion modify the stop time
-			// else append the gantt
-			if len(gantt) > 1 && gantt[len(gantt)-1].PID == readyQueue[qCount].ProcessID {
-				gantt[len(gantt)-1].Stop = time
-			} else {
-				gantt = append(gantt, TimeSlice{
-					PID:   readyQueue[qCount].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
-			}
+// isTerminal reports whether f is attached to a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
 
-			start = time
-			skip = true                                            // set flag to skip qCount inc
-			if len(readyQueue) > 1 && qCount < len(readyQueue)-1 { //if item is in middle or front of queue delete
-				readyQueue[qCount] = readyQueue[len(readyQueue)-1]
-				readyQueue = readyQueue[:len(readyQueue)-1]
-				qCount++
-			} else if len(readyQueue) > 1 && qCount == len(readyQueue)-1 { //process is at end of queue so must pop
-				var _ Process
-				_, readyQueue = readyQueue[len(readyQueue)-1], readyQueue[:len(readyQueue)-1]
-				qCount = 0
-			}
-			numProcesses--
-		}
-		if len(readyQueue) > 1 && time%timeQuantum == 0 && !skip { // we have finished current time slice time to move to next process in queue
-			qCount++
-		}
-		if qCount > len(readyQueue)-1 { //if qCount reaches end of array set to 0, so we go back to front of queue
-			qCount = 0
-		}
-		//for proper adding to gantt chart
-		if tempPID != readyQueue[qCount].ProcessID && !skip {
-			if len(readyQueue) > 1 && qCount != 0 {
-				gantt = append(gantt, TimeSlice{
-					PID:   readyQueue[qCount-1].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
-				start = time
-			} else if len(readyQueue) > 1 && qCount == 0 {
-				gantt = append(gantt, TimeSlice{
-					PID:   readyQueue[len(readyQueue)-1].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
-				start = time
-			} else {
-				gantt = append(gantt, TimeSlice{
-					PID:   readyQueue[qCount].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
-				start = time
-			}
+	return info.Mode()&os.ModeCharDevice != 0
+}
 
+func openProcessingFile(args ...string) (*os.File, func(), error) {
+	if len(args) != 2 {
+		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+	}
+	// Read in CSV process CSV file
+	f, err := os.Open(args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: error closing scheduling file", err))
 		}
-		skip = false //reset flag
-
 	}
-	avgWait := totalWait / float64(pCount)
-	avgTurnaround := totalTurnaround / float64(pCount)
-	avgThroughput := float64(pCount) / float64(time)
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, avgWait, avgTurnaround, avgThroughput)
-}
 
-//endregion
+	return f, closeFn, nil
+}
 
 //region Output helpers
 
@@ -458,69 +344,48 @@ func outputTitle(w io.Writer, title string) {
 	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
-	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+func outputSchedule(w io.Writer, done []workload.Process, summary sched.Metrics) {
+	columns := options.columns
+	if len(columns) == 0 {
+		columns = scheduleColumns
+	}
+	var (
+		header = make([]string, len(columns))
+		footer = make([]string, len(columns))
+		rows   = make([][]string, len(done))
+	)
+	for i, c := range columns {
+		header[i] = c.Header
+		if c.Footer != nil {
+			footer[i] = c.Footer(summary)
+		}
+	}
+	for i := range done {
+		rows[i] = make([]string, len(columns))
+		for j, c := range columns {
+			rows[i][j] = c.Value(done[i])
 		}
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
-}
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
 	_, _ = fmt.Fprintln(w, "Schedule table")
 	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	table.SetHeader(header)
 	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
+	table.SetFooter(footer)
 	table.Render()
 }
 
+// outputUtilization writes the share of the run the CPU spent executing processes, as opposed to sitting idle or
+// paying for context switches.
+func outputUtilization(w io.Writer, busy, idle, overhead int64) {
+	_, _ = fmt.Fprintf(w, "CPU utilization: %.2f%% (busy %d, idle %d, switch overhead %d)\n\n",
+		report.CPUUtilization(busy, idle, overhead)*100, busy, idle, overhead)
+}
+
 //endregion
 
 //region Loading processes.
 
 var ErrInvalidArgs = errors.New("invalid args")
 
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
-	}
-
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
-		}
-	}
-
-	return processes, nil
-}
-
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
-	return i
-}
-
 //endregion
@@ -2,31 +2,33 @@ package main
 
 import (
 	"bytes"
-	"errors"
+	"context"
 	"io"
 	"os"
-	"path"
-	"reflect"
 	"strings"
 	"testing"
-	"testing/iotest"
+
+	"GolandProjects/Project1/pkg/golden"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
 )
 
 func TestFCFSSchedule(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		processes []Process
+		processes []workload.Process
 		title     string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		wantOut string
+		name   string
+		args   args
+		golden string
 	}{
 		{
 			name: "default",
 			args: args{
-				processes: []Process{
+				processes: []workload.Process{
 					{
 						ProcessID:     1,
 						ArrivalTime:   0,
@@ -48,7 +50,7 @@ func TestFCFSSchedule(t *testing.T) {
 				},
 				title: "First-come, First-serve",
 			},
-			wantOut: loadFixture(t, "fcfs_test.txt"),
+			golden: "fcfs_test.txt",
 		},
 	}
 	for _, tt := range tests {
@@ -56,85 +58,12 @@ func TestFCFSSchedule(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			var w bytes.Buffer
-			FCFSSchedule(&w, tt.args.title, tt.args.processes)
-			if got := w.String(); got != tt.wantOut {
-				t.Errorf("FCFSSchedule() = %v, want %v", got, tt.wantOut)
-			}
+			scheduleWith("fcfs")(context.Background(), &w, tt.args.title, tt.args.processes)
+			golden.Check(t, tt.golden, w.Bytes())
 		})
 	}
 }
 
-func Test_loadProcesses(t *testing.T) {
-	t.Parallel()
-	type args struct {
-		r io.Reader
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []Process
-		wantErr error
-	}{
-		{
-			name: "bad CSV",
-			args: args{
-				r: iotest.ErrReader(io.ErrUnexpectedEOF),
-			},
-			wantErr: io.ErrUnexpectedEOF,
-		},
-		{
-			name: "success",
-			args: args{
-				r: strings.NewReader(`1,5,0,2
-2,9,3,1
-3,6,3,3`),
-			},
-			want: []Process{
-				{
-					ProcessID:     1,
-					ArrivalTime:   0,
-					BurstDuration: 5,
-					Priority:      2,
-				},
-				{
-					ProcessID:     2,
-					ArrivalTime:   3,
-					BurstDuration: 9,
-					Priority:      1,
-				},
-				{
-					ProcessID:     3,
-					ArrivalTime:   3,
-					BurstDuration: 6,
-					Priority:      3,
-				},
-			},
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got, err := loadProcesses(tt.args.r)
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("loadProcesses() = %v, want %v", got, tt.want)
-			}
-			if !errors.Is(err, tt.wantErr) {
-				t.Errorf("error = %v, want %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func loadFixture(t *testing.T, p ...string) string {
-	b, err := os.ReadFile(path.Join(p...))
-	if err != nil {
-		t.Fail()
-	}
-
-	return string(b)
-}
-
 func Test_openProcessingFile1(t *testing.T) {
 	tmpFile, tErr := os.CreateTemp(t.TempDir(), "")
 	if tErr != nil {
@@ -205,3 +134,102 @@ func Test_openProcessingFile1(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulersIdleGap(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 2, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 10, BurstDuration: 4, Priority: 1},
+	}
+	tests := []struct {
+		name     string
+		schedule func(context.Context, io.Writer, string, []workload.Process) report.Report
+	}{
+		{name: "FCFS", schedule: scheduleWith("fcfs")},
+		{name: "SJF", schedule: scheduleWith("sjf")},
+		{name: "Priority", schedule: scheduleWith("priority")},
+		{name: "RR", schedule: scheduleWith("rr")},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.schedule(context.Background(), &w, tt.name, processes)
+			want := "CPU utilization: 64.29% (busy 9, idle 5, switch overhead 0)"
+			if !strings.Contains(w.String(), want) {
+				t.Errorf("%s output missing %q:\n%s", tt.name, want, w.String())
+			}
+		})
+	}
+}
+
+// countdownContext is a context that ends after its Err has been checked n times, stopping a scheduler at a
+// predictable point.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n > 0 {
+		c.n--
+		return nil
+	}
+
+	return context.Canceled
+}
+
+func TestSchedulersStopped(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 6, Priority: 2},
+	}
+	tests := []struct {
+		name     string
+		schedule func(context.Context, io.Writer, string, []workload.Process) report.Report
+		checks   int // how many scheduling steps run before the context ends
+	}{
+		{name: "FCFS", schedule: scheduleWith("fcfs"), checks: 1},
+		{name: "SJF", schedule: scheduleWith("sjf"), checks: 2},
+		{name: "Priority", schedule: scheduleWith("priority"), checks: 2},
+		{name: "RR", schedule: scheduleWith("rr"), checks: 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			r := tt.schedule(&countdownContext{Context: context.Background(), n: tt.checks}, &w, tt.name, processes)
+			if r.Stopped != context.Canceled.Error() {
+				t.Errorf("Stopped = %q, want %q", r.Stopped, context.Canceled)
+			}
+			if len(r.Processes) != 1 || r.Processes[0].ProcessID != 1 {
+				t.Errorf("reported processes %+v, want only P1", r.Processes)
+			}
+			want := "Stopped early (context canceled): only the 1 processes that finished are shown"
+			if !strings.Contains(w.String(), want) {
+				t.Errorf("%s output missing %q:\n%s", tt.name, want, w.String())
+			}
+
+			// a run stopped before anything finished still reports numbers rather than NaNs
+			r = tt.schedule(&countdownContext{Context: context.Background()}, io.Discard, tt.name, processes)
+			if len(r.Processes) != 0 || r.Summary != (sched.Metrics{}) {
+				t.Errorf("run stopped at once reported %+v and %+v", r.Processes, r.Summary)
+			}
+		})
+	}
+}
+
+// scheduleWith returns a function running the registered algorithm named name and outputting its report.
+func scheduleWith(name string) func(ctx context.Context, w io.Writer, title string, processes []workload.Process) report.Report {
+	return func(ctx context.Context, w io.Writer, title string, processes []workload.Process) report.Report {
+		a, ok := algorithms.Lookup(name)
+		if !ok {
+			panic("no algorithm " + name)
+		}
+		return schedule(ctx, w, a, title, processes)
+	}
+}
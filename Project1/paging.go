@@ -0,0 +1,455 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/paging"
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	// pagingChartWidth is the number of references one row of a frame chart holds before it wraps.
+	pagingChartWidth = 20
+	// pagingBarWidth is the longest bar of a faults against frames chart.
+	pagingBarWidth = 40
+)
+
+func pagingAlgorithmNames() string {
+	var names []string
+	for _, a := range paging.Algorithms(paging.DefaultWindow) {
+		names = append(names, a.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parsePagingAlgorithms resolves a comma-separated list of replacement algorithm names, in the order given, the
+// working-set ones looking back window references. An empty list selects every algorithm.
+func parsePagingAlgorithms(list string, window int) ([]paging.Algorithm, error) {
+	all := paging.Algorithms(window)
+	if list == "" {
+		return all, nil
+	}
+	var selected []paging.Algorithm
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, a := range all {
+			if a.Name == strings.TrimSpace(name) {
+				selected, found = append(selected, a), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown page replacement algorithm %q (want one of %s)", ErrInvalidArgs, name,
+				pagingAlgorithmNames())
+		}
+	}
+
+	return selected, nil
+}
+
+func pageTableNames() string {
+	var names []string
+	for _, o := range paging.Organizations {
+		names = append(names, o.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parsePageTables resolves a comma-separated list of page table organization names, in the order given, into page
+// tables shaped like table. An empty list selects none.
+func parsePageTables(list string, table paging.PageTable) ([]paging.PageTable, error) {
+	if list == "" {
+		return nil, nil
+	}
+	var selected []paging.PageTable
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, o := range paging.Organizations {
+			if o.Name == strings.TrimSpace(name) {
+				table.Organization = o
+				selected, found = append(selected, table), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown page table organization %q (want one of %s)", ErrInvalidArgs, name,
+				pageTableNames())
+		}
+	}
+
+	return selected, nil
+}
+
+func pagingCommand(args []string) {
+	fs := flag.NewFlagSet("paging", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "paging [flags] (references.txt | -refs \"7 0 1 ...\" | -random n)")
+	frames := fs.Int("frames", 3, "number of page frames")
+	selected := fs.String("algorithms", "",
+		"comma-separated page replacement algorithms to run, in order (default all: "+pagingAlgorithmNames()+")")
+	list := fs.String("refs", "",
+		"reference string of page numbers separated by spaces or commas, with w after a write")
+	random := fs.Int("random", 0, "simulate this many random references instead")
+	pages := fs.Int64("pages", 10, "number of distinct pages for -random")
+	writes := fs.Float64("writes", 0, "fraction of -random references that are writes")
+	window := fs.Int("window", paging.DefaultWindow, "working-set window of ws and wsclock, in references")
+	chart := fs.Bool("chart", true, "show the frames after every reference")
+	var tlb paging.TLB
+	var latencies paging.Latencies
+	fs.IntVar(&tlb.Entries, "tlb", 0, "entries of a TLB in front of the page table (default: no TLB)")
+	fs.IntVar(&tlb.Ways, "tlb-ways", 0, "associativity of the TLB, the entries per set (default: fully associative)")
+	fs.StringVar(&tlb.Policy, "tlb-policy", "lru", "replacement policy of a full TLB set (lru, fifo)")
+	fs.Float64Var(&latencies.TLB, "tlb-latency", 1, "time of a TLB lookup")
+	fs.Float64Var(&latencies.Memory, "memory-latency", 100, "time of a memory access")
+	fs.Float64Var(&latencies.Fault, "fault-latency", 0, "time to service a page fault")
+	var table paging.PageTable
+	tables := fs.String("page-tables", "",
+		"comma-separated page table organizations to compare the lookups of ("+pageTableNames()+")")
+	fs.IntVar(&table.EntrySize, "pte-size", 8, "bytes of a page table entry")
+	fs.Int64Var(&table.Pages, "address-space", 0,
+		"pages of the address space a linear page table covers (default: up to the highest referenced)")
+	fs.IntVar(&table.Buckets, "buckets", 0, "buckets of a hashed page table (default: one per frame)")
+	sweep := fs.String("sweep", "",
+		"count the faults with every number of frames in this from:to range instead, looking for Belady's anomaly")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for -random (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *frames < 1 || *window < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -frames and -window must be at least 1", ErrInvalidArgs))
+	}
+	run, err := parsePagingAlgorithms(*selected, *window)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	if tlb.Entries > 0 {
+		if err := tlb.Validate(); err != nil {
+			fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+		}
+	}
+	if table.Buckets == 0 {
+		table.Buckets = *frames
+	}
+	pageTables, err := parsePageTables(*tables, table)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	for _, t := range pageTables {
+		if err := t.Validate(); err != nil {
+			fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+		}
+	}
+	sources := fs.NArg()
+	if *list != "" {
+		sources++
+	}
+	if *random > 0 {
+		sources++
+	}
+	if sources != 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: give one reference string file, -refs, or -random", ErrInvalidArgs))
+	}
+
+	var refs []paging.Reference
+	switch {
+	case *random > 0:
+		options.seed = resolveSeed(options.seed)
+		refs = paging.Generate(newRand(options.seed, "paging"), *random, *pages, *writes)
+		logger.Info("generated references", "seed", options.seed)
+	case *list != "":
+		refs, err = paging.ParseReferences(*list)
+	default:
+		var b []byte
+		if b, err = os.ReadFile(fs.Arg(0)); err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: reading reference string", err))
+		}
+		refs, err = paging.ParseReferences(string(b))
+	}
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+
+	if *sweep != "" {
+		from, to, err := parseRange(*sweep)
+		if err != nil {
+			fatal(exitInvalid, err)
+		}
+		curves := make([]paging.FaultCurve, len(run))
+		for i, a := range run {
+			curves[i] = paging.Sweep(a.Run, refs, int(from), int(to))
+		}
+		outputFaultCurves(os.Stdout, run, curves)
+		return
+	}
+
+	var (
+		results = make([]paging.Result, len(run))
+		tlbs    []paging.TLBResult
+	)
+	for i, a := range run {
+		results[i] = a.Run(refs, *frames)
+		var hits *paging.TLBResult
+		if tlb.Entries > 0 {
+			tlbs = append(tlbs, paging.SimulateTLB(results[i], tlb))
+			hits = &tlbs[i]
+		}
+		outputPaging(os.Stdout, a.Title, results[i], hits, *chart)
+		if hits != nil {
+			outputTLB(os.Stdout, tlb, latencies, results[i], *hits)
+		}
+		if pageTables != nil {
+			outputPageTables(os.Stdout, pageTables, results[i], hits, latencies)
+		}
+	}
+	if len(run) > 1 {
+		outputPagingSummary(os.Stdout, run, results, paging.HasWrites(refs), tlbs, latencies)
+	}
+}
+
+// outputPaging writes the fault count of r under title and, if chart is set, the frame chart: the page in every frame
+// after each reference, with the faults starred and the pages they evicted. For an algorithm with a clock hand, each
+// frame also shows its reference (r) and dirty (d) bits, and > marks the frame the hand points at. With the TLB
+// lookups tlb, the TLB misses are starred too.
+func outputPaging(w io.Writer, title string, r paging.Result, tlb *paging.TLBResult, chart bool) {
+	_, _ = fmt.Fprintf(w, "%s: %d faults and %d hits in %d references with %d frames (%.1f%% faults)", title,
+		r.Faults, r.Hits(), len(r.Steps), r.Frames, 100*r.FaultRate())
+	if r.WriteBacks > 0 {
+		_, _ = fmt.Fprintf(w, ", dirty pages written back: %d", r.WriteBacks)
+	}
+	_, _ = fmt.Fprintln(w)
+	if avg, peak := workingSetSize(r); peak > 0 {
+		_, _ = fmt.Fprintf(w, "Working set averages %.2f pages and peaks at %d\n", avg, peak)
+	}
+	if !chart || len(r.Steps) == 0 {
+		_, _ = fmt.Fprintln(w)
+		return
+	}
+	if r.Steps[0].Hand >= 0 {
+		_, _ = fmt.Fprintln(w, "> clock hand, r referenced, d dirty")
+	}
+	for from := 0; from < len(r.Steps); from += pagingChartWidth {
+		steps := r.Steps[from:min(from+pagingChartWidth, len(r.Steps))]
+		header := []string{"Reference"}
+		rows := make([][]string, r.Frames+2, r.Frames+4)
+		for i := range rows[:r.Frames] {
+			rows[i] = []string{fmt.Sprintf("Frame %d", i+1)}
+		}
+		rows[r.Frames] = []string{"Fault"}
+		rows[r.Frames+1] = []string{"Evicted"}
+		if steps[0].WorkingSet > 0 {
+			rows = append(rows, []string{"Working set"})
+		}
+		if tlb != nil {
+			rows = append(rows, []string{"TLB miss"})
+		}
+		for j, s := range steps {
+			if s.WorkingSet > 0 {
+				rows[r.Frames+2] = append(rows[r.Frames+2], strconv.Itoa(s.WorkingSet))
+			}
+			if tlb != nil {
+				miss := "*"
+				if tlb.Hit[from+j] {
+					miss = ""
+				}
+				rows[len(rows)-1] = append(rows[len(rows)-1], miss)
+			}
+			header = append(header, s.Ref.String())
+			for i := range s.Frames {
+				rows[i] = append(rows[i], formatFrame(s, i))
+			}
+			fault := ""
+			if s.Fault {
+				fault = "*"
+			}
+			rows[r.Frames] = append(rows[r.Frames], fault)
+			rows[r.Frames+1] = append(rows[r.Frames+1], formatPage(s.Evicted))
+		}
+		table := tablewriter.NewWriter(w)
+		table.SetHeader(header)
+		table.SetAutoFormatHeaders(false)
+		table.AppendBulk(rows)
+		table.Render()
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputTLB writes how the TLB t fared on run with the lookups r, and the effective memory access time it gives.
+func outputTLB(w io.Writer, t paging.TLB, l paging.Latencies, run paging.Result, r paging.TLBResult) {
+	ways := fmt.Sprintf("%d-way set associative", t.Ways)
+	if t.Ways == 0 || t.Ways == t.Entries {
+		ways = "fully associative"
+	}
+	_, _ = fmt.Fprintf(w, "TLB of %d entries, %s, %s: %d hits in %d lookups (%.1f%%)\n", t.Entries, ways,
+		strings.ToUpper(t.Policy), r.Hits, len(r.Hit), 100*r.HitRatio())
+	_, _ = fmt.Fprintf(w, "Effective access time %.2f (TLB %g, memory %g, page fault %g)\n\n",
+		r.EffectiveAccessTime(run, l), l.TLB, l.Memory, l.Fault)
+}
+
+// outputPageTables writes the size of every page table of tables and what looking up the references of run in each
+// costs, behind the TLB lookups tlb if any.
+func outputPageTables(w io.Writer, tables []paging.PageTable, run paging.Result, tlb *paging.TLBResult,
+	l paging.Latencies) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Page table", "Bytes", "Lookups", "Avg reads", "Longest", "Access time"})
+	var results []paging.PageTableResult
+	for _, t := range tables {
+		r := paging.SimulatePageTable(run, t, tlb)
+		results = append(results, r)
+		table.Append([]string{t.Organization.Title, strconv.FormatInt(r.Size, 10), strconv.Itoa(r.Lookups),
+			fmt.Sprintf("%.2f", r.AverageReads()), strconv.Itoa(r.Longest),
+			fmt.Sprintf("%.2f", r.EffectiveAccessTime(run, tlb != nil, l))})
+	}
+	table.Render()
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "%s: %s\n", r.Table.Organization.Title, r.Table.Organization.Description)
+	}
+	_, _ = fmt.Fprintf(w, "Entries of %d bytes, %d pages of address space, %d hash buckets; reads are memory reads "+
+		"per lookup\n\n", tables[0].EntrySize, results[0].Table.Pages, tables[0].Buckets)
+}
+
+// workingSetSize returns the average and peak working-set size over the steps of r, or 0 and 0 if its algorithm has
+// no window.
+func workingSetSize(r paging.Result) (float64, int) {
+	var total, peak int
+	for _, s := range r.Steps {
+		total += s.WorkingSet
+		peak = max(peak, s.WorkingSet)
+	}
+	if peak == 0 {
+		return 0, 0
+	}
+
+	return float64(total) / float64(len(r.Steps)), peak
+}
+
+// formatFrame formats frame i after step s: its page, and for an algorithm with a clock hand, the hand and bits.
+func formatFrame(s paging.Step, i int) string {
+	page := formatPage(s.Frames[i])
+	if s.Hand < 0 || s.Frames[i] == paging.Empty {
+		return page
+	}
+	if s.Hand == i {
+		page = ">" + page
+	}
+	if s.Referenced[i] {
+		page += "r"
+	}
+	if s.Dirty[i] {
+		page += "d"
+	}
+
+	return page
+}
+
+// formatPage formats a page number, leaving paging.Empty blank.
+func formatPage(page int64) string {
+	if page == paging.Empty {
+		return ""
+	}
+
+	return strconv.FormatInt(page, 10)
+}
+
+// outputPagingSummary writes the faults of every algorithm of run side by side, and the write-backs too if writes is
+// set. The frames each held are shown too when they differ, as they do for the working-set algorithm, and with the
+// TLB lookups tlbs, the TLB hit ratio and effective access time under l.
+func outputPagingSummary(w io.Writer, run []paging.Algorithm, results []paging.Result, writes bool,
+	tlbs []paging.TLBResult, l paging.Latencies) {
+	frames := false
+	for _, r := range results {
+		frames = frames || r.Frames != results[0].Frames
+	}
+	table := tablewriter.NewWriter(w)
+	header := []string{"Algorithm", "Faults", "Hits", "Fault rate"}
+	if writes {
+		header = append(header, "Write-backs")
+	}
+	if frames {
+		header = append(header, "Frames")
+	}
+	if tlbs != nil {
+		header = append(header, "TLB hits", "Access time")
+	}
+	table.SetHeader(header)
+	for i, a := range run {
+		row := []string{a.Title, strconv.Itoa(results[i].Faults), strconv.Itoa(results[i].Hits()),
+			fmt.Sprintf("%.1f%%", 100*results[i].FaultRate())}
+		if writes {
+			row = append(row, strconv.Itoa(results[i].WriteBacks))
+		}
+		if frames {
+			row = append(row, strconv.Itoa(results[i].Frames))
+		}
+		if tlbs != nil {
+			row = append(row, fmt.Sprintf("%.1f%%", 100*tlbs[i].HitRatio()),
+				fmt.Sprintf("%.2f", tlbs[i].EffectiveAccessTime(results[i], l)))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+// outputFaultCurves writes the faults of every algorithm of run with each number of frames side by side, then a bar
+// chart of each algorithm's faults against frames, saying whether it shows Belady's anomaly.
+func outputFaultCurves(w io.Writer, run []paging.Algorithm, curves []paging.FaultCurve) {
+	header := []string{"Frames"}
+	for _, a := range run {
+		header = append(header, a.Title)
+	}
+	_, _ = fmt.Fprintln(w, "Faults by number of frames")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+	for i := range curves[0].Faults {
+		row := []string{strconv.Itoa(curves[0].From + i)}
+		for _, c := range curves {
+			row = append(row, strconv.Itoa(c.Faults[i]))
+		}
+		table.Append(row)
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+
+	most := 0
+	for _, c := range curves {
+		for _, faults := range c.Faults {
+			most = max(most, faults)
+		}
+	}
+	labelWidth := len(strconv.Itoa(curves[0].From + len(curves[0].Faults) - 1))
+	for i, a := range run {
+		c := curves[i]
+		anomalies := c.Anomalies()
+		switch {
+		case len(anomalies) > 0:
+			var at []string
+			for _, frames := range anomalies {
+				at = append(at, fmt.Sprintf("%d faults with %d frames against %d with %d", c.Faults[frames-c.From],
+					frames, c.Faults[frames-c.From-1], frames-1))
+			}
+			_, _ = fmt.Fprintf(w, "%s shows Belady's anomaly: %s\n", a.Title, strings.Join(at, "; "))
+		default:
+			_, _ = fmt.Fprintf(w, "%s: faults never rise with more frames\n", a.Title)
+		}
+		for j, faults := range c.Faults {
+			bar := 0
+			if most > 0 {
+				bar = faults * pagingBarWidth / most
+			}
+			mark := ""
+			if j > 0 && faults > c.Faults[j-1] {
+				mark = " <- more than with one frame fewer"
+			}
+			_, _ = fmt.Fprintf(w, "%*d | %s %d%s\n", labelWidth, c.From+j, strings.Repeat("#", bar), faults, mark)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
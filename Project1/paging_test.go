@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/paging"
+)
+
+func Test_parsePagingAlgorithms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		list    string
+		want    []string
+		wantErr bool
+	}{
+		{list: "", want: []string{"fifo", "lru", "optimal", "clock", "esc", "lfu", "mfu", "ws", "wsclock"}},
+		{list: "optimal,fifo", want: []string{"optimal", "fifo"}},
+		{list: "fifo,mru", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.list, func(t *testing.T) {
+			t.Parallel()
+			got, err := parsePagingAlgorithms(tt.list, paging.DefaultWindow)
+			if tt.wantErr != errors.Is(err, ErrInvalidArgs) {
+				t.Fatalf("parsePagingAlgorithms(%q) error = %v", tt.list, err)
+			}
+			var names []string
+			for _, a := range got {
+				names = append(names, a.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parsePagingAlgorithms(%q) = %v, want %v", tt.list, names, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parsePageTables(t *testing.T) {
+	t.Parallel()
+	got, err := parsePageTables("hashed, linear", paging.PageTable{EntrySize: 4, Buckets: 2})
+	if err != nil || len(got) != 2 || got[0].Organization.Name != "hashed" || got[1].Organization.Name != "linear" ||
+		got[0].EntrySize != 4 || got[1].Buckets != 2 {
+		t.Errorf("parsePageTables() = %+v, %v", got, err)
+	}
+	if none, err := parsePageTables("", paging.PageTable{}); none != nil || err != nil {
+		t.Errorf("parsePageTables(\"\") = %v, %v, want none", none, err)
+	}
+	if _, err := parsePageTables("multilevel", paging.PageTable{}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parsePageTables(\"multilevel\") error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_outputPageTables(t *testing.T) {
+	t.Parallel()
+	refs, err := paging.ParseReferences("1 2 1 3 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables, err := parsePageTables("linear,inverted,hashed", paging.PageTable{EntrySize: 8, Pages: 1024, Buckets: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	outputPageTables(&w, tables, paging.FIFO(refs, 2), nil, paging.Latencies{Memory: 100})
+	for _, want := range []string{
+		"| Linear     |  8192 |       5 |      1.00 |       1 |      200.00 |",
+		"| Inverted   |    16 |       5 |      1.80 |       2 |      280.00 |",
+		"| Hashed     |    40 |       5 |      2.40 |       3 |      340.00 |",
+		"Entries of 8 bytes, 1024 pages of address space, 1 hash buckets; reads are memory reads per lookup\n",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_outputPaging(t *testing.T) {
+	t.Parallel()
+	refs, _ := paging.ParseReferences("1 2 3 1 4")
+	r := paging.LRU(refs, 3)
+	var w bytes.Buffer
+	outputPaging(&w, "LRU", r, nil, true)
+	for _, want := range []string{
+		"LRU: 4 faults and 1 hits in 5 references with 3 frames (80.0% faults)",
+		"| Reference | 1 | 2 | 3 | 1 | 4 |",
+		"| Frame 2   |   | 2 | 2 | 2 | 4 |",
+		"| Fault     | * | * | * |   | * |",
+		"| Evicted   |   |   |   |   | 2 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputPaging() missing %q:\n%s", want, w.String())
+		}
+	}
+
+	// a long reference string wraps
+	w.Reset()
+	outputPaging(&w, "LRU", paging.LRU(paging.Generate(newRand(1, "paging"), 2*pagingChartWidth+1, 5, 0), 3), nil, true)
+	if got := strings.Count(w.String(), "| Reference |"); got != 3 {
+		t.Errorf("outputPaging() of %d references has %d chart rows, want 3", 2*pagingChartWidth+1, got)
+	}
+}
+
+func Test_outputPaging_clock(t *testing.T) {
+	t.Parallel()
+	refs, _ := paging.ParseReferences("1w 2 3 4")
+	var w bytes.Buffer
+	outputPaging(&w, "Clock", paging.Clock(refs, 3), nil, true)
+	for _, want := range []string{
+		"Clock: 4 faults and 0 hits in 4 references with 3 frames (100.0% faults), dirty pages written back: 1",
+		"> clock hand, r referenced, d dirty",
+		"| Reference |  1w  |  2   |  3   | 4  |",
+		"| Frame 1   | >1rd | >1rd | >1rd | 4r |",
+		"| Frame 2   |      | 2r   | 2r   | >2 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputPaging() missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_outputPaging_workingSet(t *testing.T) {
+	t.Parallel()
+	refs, _ := paging.ParseReferences("1 2 1 3 4 4")
+	var w bytes.Buffer
+	outputPaging(&w, "Working set", paging.WorkingSet(refs, 3), nil, true)
+	for _, want := range []string{
+		"Working set: 4 faults and 2 hits in 6 references with 3 frames (66.7% faults)",
+		"Working set averages 2.17 pages and peaks at 3",
+		"| Evicted     |   |   |   |   | 2 | 1 |",
+		"| Working set | 1 | 2 | 2 | 3 | 3 | 2 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputPaging() missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_outputTLB(t *testing.T) {
+	t.Parallel()
+	refs, _ := paging.ParseReferences("1 2 1 3 1 2 4 1")
+	run := paging.LRU(refs, 3)
+	tlb := paging.TLB{Entries: 2, Policy: "lru"}
+	hits := paging.SimulateTLB(run, tlb)
+	var w bytes.Buffer
+	outputPaging(&w, "LRU", run, &hits, true)
+	outputTLB(&w, tlb, paging.Latencies{TLB: 1, Memory: 100, Fault: 1000}, run, hits)
+	for _, want := range []string{
+		"| TLB miss  | * | * |   | * |   | * | * | * |",
+		"TLB of 2 entries, fully associative, LRU: 2 hits in 8 lookups (25.0%)",
+		// 1 + 100 + 0.75*100 + 0.5*1000
+		"Effective access time 676.00 (TLB 1, memory 100, page fault 1000)",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputTLB() missing %q:\n%s", want, w.String())
+		}
+	}
+	w.Reset()
+	outputTLB(&w, paging.TLB{Entries: 4, Ways: 2, Policy: "fifo"}, paging.Latencies{}, run, hits)
+	if !strings.Contains(w.String(), "TLB of 4 entries, 2-way set associative, FIFO") {
+		t.Errorf("outputTLB() of a set-associative TLB:\n%s", w.String())
+	}
+}
+
+func Test_outputPagingSummary(t *testing.T) {
+	t.Parallel()
+	refs, _ := paging.ParseReferences("7 0 1 2 0 3 0 4 2 3 0 3 2 1 2 0 1 7 0 1")
+	run, _ := parsePagingAlgorithms("fifo,lru,optimal", paging.DefaultWindow)
+	results := make([]paging.Result, len(run))
+	for i, a := range run {
+		results[i] = a.Run(refs, 3)
+	}
+	var w bytes.Buffer
+	outputPagingSummary(&w, run, results, false, nil, paging.Latencies{})
+	for _, want := range []string{"| FIFO      |     15 |    5 | 75.0%      |", "| Optimal   |      9 |   11 | 45.0%      |"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputPagingSummary() missing %q:\n%s", want, w.String())
+		}
+	}
+
+	// the working set holds a number of frames of its own, so the frames get a column
+	run, _ = parsePagingAlgorithms("lru,ws", 4)
+	results = []paging.Result{run[0].Run(refs, 3), run[1].Run(refs, 3)}
+	w.Reset()
+	outputPagingSummary(&w, run, results, false, nil, paging.Latencies{})
+	if !strings.Contains(w.String(), "| FRAMES |") || !strings.Contains(w.String(), "|      3 |") {
+		t.Errorf("outputPagingSummary() without frames:\n%s", w.String())
+	}
+}
+
+func Test_outputFaultCurves(t *testing.T) {
+	t.Parallel()
+	refs, _ := paging.ParseReferences("1 2 3 4 1 2 5 1 2 3 4 5")
+	run, _ := parsePagingAlgorithms("fifo,optimal", paging.DefaultWindow)
+	curves := []paging.FaultCurve{paging.Sweep(run[0].Run, refs, 3, 5), paging.Sweep(run[1].Run, refs, 3, 5)}
+	var w bytes.Buffer
+	outputFaultCurves(&w, run, curves)
+	for _, want := range []string{
+		"| Frames | FIFO | Optimal |",
+		"|      4 |   10 |       6 |",
+		"FIFO shows Belady's anomaly: 10 faults with 4 frames against 9 with 3",
+		"4 | " + strings.Repeat("#", pagingBarWidth) + " 10 <- more than with one frame fewer",
+		"Optimal: faults never rise with more frames",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputFaultCurves() missing %q:\n%s", want, w.String())
+		}
+	}
+}
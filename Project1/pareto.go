@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"GolandProjects/Project1/pkg/report"
+	"github.com/olekukonko/tablewriter"
+)
+
+// paretoMetric is one of the metrics the Pareto front trades off, all of them better lower.
+type paretoMetric struct {
+	Name   string
+	Value  func(report.AlgorithmRecord) float64
+	Format string
+}
+
+var paretoMetrics = []paretoMetric{
+	{Name: "Avg wait", Value: func(r report.AlgorithmRecord) float64 { return r.AvgWait }, Format: "%.2f"},
+	{Name: "Avg response", Value: func(r report.AlgorithmRecord) float64 { return r.AvgResponse }, Format: "%.2f"},
+	{Name: "Switches", Value: func(r report.AlgorithmRecord) float64 { return float64(r.Switches) }, Format: "%.0f"},
+	{Name: "Energy", Value: func(r report.AlgorithmRecord) float64 { return r.Energy }, Format: "%.1f"},
+}
+
+// outputParetoFront writes the paretoMetrics of each configuration, named by names, and whether it is on the Pareto
+// front or, in parentheses, which configuration on the front beats it. It writes nothing for fewer than two
+// configurations.
+func outputParetoFront(w io.Writer, title string, names []string, records []report.AlgorithmRecord) {
+	if len(records) < 2 {
+		return
+	}
+	points := make([][]float64, len(records))
+	for i, r := range records {
+		points[i] = make([]float64, len(paretoMetrics))
+		for j, m := range paretoMetrics {
+			points[i][j] = m.Value(r)
+		}
+	}
+	dominated := report.ParetoFront(points)
+
+	header := []string{"Configuration"}
+	for _, m := range paretoMetrics {
+		header = append(header, m.Name)
+	}
+	header = append(header, "Pareto-optimal")
+	_, _ = fmt.Fprintln(w, title)
+	table := tablewriter.NewWriter(w)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader(header)
+	for i, r := range records {
+		row := []string{names[i]}
+		for _, m := range paretoMetrics {
+			row = append(row, fmt.Sprintf(m.Format, m.Value(r)))
+		}
+		if j := dominated[i]; j >= 0 {
+			row = append(row, "no ("+names[j]+")")
+		} else {
+			row = append(row, "yes")
+		}
+		table.Append(row)
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputReportsParetoFront writes the Pareto front of the algorithms of a run.
+func outputReportsParetoFront(w io.Writer, reports []report.Report) {
+	names := make([]string, len(reports))
+	records := make([]report.AlgorithmRecord, len(reports))
+	for i, r := range reports {
+		names[i], records[i] = r.Title, r.Record()
+	}
+	outputParetoFront(w, "Pareto front (lower is better in every column)", names, records)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+)
+
+func Test_outputParetoFront(t *testing.T) {
+	t.Parallel()
+	records := []report.AlgorithmRecord{
+		{AvgWait: 4, AvgResponse: 4, Switches: 2, Energy: 20},
+		{AvgWait: 4, AvgResponse: 2, Switches: 3, Energy: 20},
+		{AvgWait: 8, AvgResponse: 4, Switches: 3, Energy: 20},
+		{AvgWait: 9, AvgResponse: 1, Switches: 9, Energy: 25},
+	}
+	var w bytes.Buffer
+	outputParetoFront(&w, "Front", []string{"fcfs", "sjf", "priority", "rr"}, records)
+	for _, want := range []string{
+		"Front\n",
+		"| Configuration | Avg wait | Avg response | Switches | Energy | Pareto-optimal |",
+		"| fcfs          |     4.00 |         4.00 |        2 |   20.0 | yes            |",
+		"| priority      |     8.00 |         4.00 |        3 |   20.0 | no (fcfs)      |",
+		"| rr            |     9.00 |         1.00 |        9 |   25.0 | yes            |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputParetoFront() missing %q:\n%s", want, w.String())
+		}
+	}
+
+	w.Reset()
+	outputParetoFront(&w, "Front", []string{"fcfs"}, records[:1])
+	if w.Len() != 0 {
+		t.Errorf("outputParetoFront() of one configuration =\n%s, want nothing", w.String())
+	}
+}
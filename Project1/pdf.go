@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+)
+
+// Page geometry in PDF points, US Letter portrait.
+const (
+	pdfPageWidth  = 612
+	pdfPageHeight = 792
+	pdfMargin     = 50
+)
+
+// pdfColors are the fill colors cycled through by PID, matching the spirit of the terminal palette.
+var pdfColors = [][3]float64{
+	{0.90, 0.40, 0.40}, {0.45, 0.75, 0.45}, {0.95, 0.80, 0.35}, {0.40, 0.55, 0.85}, {0.75, 0.45, 0.80},
+	{0.40, 0.80, 0.80}, {0.95, 0.60, 0.45}, {0.65, 0.85, 0.50}, {0.85, 0.85, 0.55}, {0.55, 0.70, 0.95},
+}
+
+// pdfDocument is a minimal PDF 1.4 writer: pages of text in Helvetica or Courier plus filled rectangles and lines,
+// which is all the report needs.
+type pdfDocument struct {
+	pages []*pdfPage
+}
+
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+func (d *pdfDocument) newPage() *pdfPage {
+	p := &pdfPage{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// text draws s with its baseline starting at (x, y). font is "F1" for Helvetica or "F2" for Courier.
+func (p *pdfPage) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+func (p *pdfPage) rect(x, y, w, h float64, fill [3]float64) {
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f 0 g\n", fill[0], fill[1], fill[2], x, y, w, h)
+	fmt.Fprintf(&p.content, "0.5 w %.2f %.2f %.2f %.2f re S\n", x, y, w, h)
+}
+
+func (p *pdfPage) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+var pdfReplacer = strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+
+// pdfEscape escapes a string literal, replacing anything outside printable ASCII since the standard fonts are used
+// without a custom encoding.
+func pdfEscape(s string) string {
+	b := []byte(pdfReplacer.Replace(s))
+	for i := range b {
+		if b[i] < 0x20 || b[i] > 0x7e {
+			b[i] = '?'
+		}
+	}
+
+	return string(b)
+}
+
+// WriteTo serializes the document with a cross-reference table so readers can seek to every object.
+func (d *pdfDocument) WriteTo(w io.Writer) (int64, error) {
+	var (
+		bw      = bufio.NewWriter(w)
+		offset  int64
+		offsets []int64
+		err     error
+	)
+	write := func(format string, args ...interface{}) {
+		if err != nil {
+			return
+		}
+		var n int
+		n, err = fmt.Fprintf(bw, format, args...)
+		offset += int64(n)
+	}
+	object := func(body string) {
+		offsets = append(offsets, offset)
+		write("%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	write("%%PDF-1.4\n")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>")
+	for i, p := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()))
+	}
+
+	xref := offset
+	write("xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, o := range offsets {
+		write("%010d 00000 n \n", o)
+	}
+	write("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	if err != nil {
+		return offset, err
+	}
+
+	return offset, bw.Flush()
+}
+
+//region Report composition
+
+// encodePDF lays out a title page, one or more pages per algorithm with its Gantt chart, schedule table, and
+// metrics, and a final page comparing the algorithms.
+func encodePDF(w io.Writer, reports []report.Report) error {
+	var doc pdfDocument
+	pdfTitlePage(doc.newPage(), reports)
+	for _, r := range reports {
+		pdfReportPages(&doc, r)
+	}
+	pdfComparisonPage(doc.newPage(), reports)
+
+	if _, err := doc.WriteTo(w); err != nil {
+		return fmt.Errorf("%w: writing PDF report", err)
+	}
+
+	return nil
+}
+
+func pdfTitlePage(p *pdfPage, reports []report.Report) {
+	y := float64(pdfPageHeight - 200)
+	p.text(pdfMargin, y, "F1", 28, "Process scheduling report")
+	y -= 40
+	processes := 0
+	if len(reports) > 0 {
+		processes = len(reports[0].Processes)
+	}
+	p.text(pdfMargin, y, "F1", 14, fmt.Sprintf("%d processes, %d algorithms", processes, len(reports)))
+	y -= 30
+	for _, r := range reports {
+		p.text(pdfMargin+20, y, "F1", 12, r.Title)
+		y -= 18
+	}
+}
+
+func pdfReportPages(doc *pdfDocument, r report.Report) {
+	p := doc.newPage()
+	y := float64(pdfPageHeight - pdfMargin)
+	p.text(pdfMargin, y, "F1", 20, r.Title)
+	y -= 40
+
+	p.text(pdfMargin, y, "F1", 12, "Gantt chart")
+	y -= 45
+	pdfGantt(p, pdfMargin, y, pdfPageWidth-2*pdfMargin, 30, r.Gantt)
+	y -= 40
+
+	p.text(pdfMargin, y, "F1", 12, "Schedule table")
+	y -= 16
+	p.text(pdfMargin, y, "F2", 9, fmt.Sprintf("%6s %8s %6s %8s %6s %10s %8s %6s",
+		"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Norm TAT", "Exit"))
+	y -= 12
+	for _, proc := range sortedRows(r.Processes, options.sortBy) {
+		if y < pdfMargin+80 {
+			p = doc.newPage()
+			y = float64(pdfPageHeight - pdfMargin)
+		}
+		p.text(pdfMargin, y, "F2", 9, fmt.Sprintf("%6d %8d %6d %8d %6d %10d %8.2f %6d",
+			proc.ProcessID, proc.Priority, proc.Burst, proc.ArrivalTime, proc.Wait, proc.Turnaround,
+			sched.NormalizedTurnaround(proc), proc.Completion))
+		y -= 12
+	}
+
+	y -= 16
+	for _, line := range []string{
+		fmt.Sprintf("Average wait %.2f, average turnaround %.2f, average normalized turnaround %.2f",
+			r.Summary.Wait, r.Summary.Turnaround, r.Summary.Normalized),
+		fmt.Sprintf("Throughput %.2f/t, CPU utilization %.2f%%, %d context switches",
+			r.Summary.Throughput, r.Utilization()*100, r.Switches),
+		fmt.Sprintf("Jain's fairness index: CPU share %.2f, wait %.2f", r.Fairness.Share, r.Fairness.Wait),
+	} {
+		p.text(pdfMargin, y, "F1", 10, line)
+		y -= 14
+	}
+}
+
+// pdfGantt draws gantt scaled to width with its bottom-left corner at (x, y), labeling slices wide enough to hold
+// their PID and marking the time of every boundary underneath.
+func pdfGantt(p *pdfPage, x, y, width, height float64, gantt []sched.TimeSlice) {
+	if len(gantt) == 0 {
+		return
+	}
+	var end int64
+	for _, s := range gantt {
+		if s.Stop > end {
+			end = s.Stop
+		}
+	}
+	scale := width / float64(end)
+	lastLabel := -1.0
+	for _, s := range gantt {
+		sx := x + float64(s.Start)*scale
+		sw := float64(s.Stop-s.Start) * scale
+		fill := [3]float64{0.6, 0.6, 0.6}
+		if !s.Switch {
+			fill = pdfColors[int(s.PID%int64(len(pdfColors))+int64(len(pdfColors)))%len(pdfColors)]
+		}
+		p.rect(sx, y, sw, height, fill)
+		if label := fmt.Sprint(s.PID); !s.Switch && sw >= float64(len(label))*6+4 {
+			p.text(sx+sw/2-float64(len(label))*3, y+height/2-4, "F1", 10, label)
+		}
+		if sx >= lastLabel {
+			p.text(sx, y-12, "F1", 7, fmt.Sprint(s.Start))
+			lastLabel = sx + float64(len(fmt.Sprint(s.Start)))*4 + 2
+		}
+	}
+	p.text(x+width, y-12, "F1", 7, fmt.Sprint(end))
+}
+
+// pdfComparisonPage charts average wait and turnaround side by side for every algorithm.
+func pdfComparisonPage(p *pdfPage, reports []report.Report) {
+	y := float64(pdfPageHeight - pdfMargin)
+	p.text(pdfMargin, y, "F1", 20, "Comparison")
+	if len(reports) == 0 {
+		return
+	}
+
+	var most float64
+	for _, r := range reports {
+		if r.Summary.Turnaround > most {
+			most = r.Summary.Turnaround
+		}
+		if r.Summary.Wait > most {
+			most = r.Summary.Wait
+		}
+	}
+	if most == 0 {
+		most = 1
+	}
+
+	const chartHeight = 300
+	base := y - 60 - chartHeight
+	group := float64(pdfPageWidth-2*pdfMargin) / float64(len(reports))
+	bar := group / 3
+	p.line(pdfMargin, base, pdfPageWidth-pdfMargin, base)
+	for i, r := range reports {
+		gx := pdfMargin + float64(i)*group + bar/2
+		waitHeight := r.Summary.Wait / most * chartHeight
+		turnaroundHeight := r.Summary.Turnaround / most * chartHeight
+		p.rect(gx, base, bar, waitHeight, pdfColors[3])
+		p.rect(gx+bar, base, bar, turnaroundHeight, pdfColors[6])
+		p.text(gx, base+waitHeight+4, "F1", 8, fmt.Sprintf("%.2f", r.Summary.Wait))
+		p.text(gx+bar, base+turnaroundHeight+4, "F1", 8, fmt.Sprintf("%.2f", r.Summary.Turnaround))
+		p.text(gx, base-14, "F1", 9, r.Title)
+	}
+
+	ly := base - 50
+	p.rect(pdfMargin, ly, 10, 10, pdfColors[3])
+	p.text(pdfMargin+16, ly+1, "F1", 10, "Average wait")
+	p.rect(pdfMargin+120, ly, 10, 10, pdfColors[6])
+	p.text(pdfMargin+136, ly+1, "F1", 10, "Average turnaround")
+}
+
+//endregion
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_encodePDF(t *testing.T) {
+	t.Parallel()
+	r := report.New("Round-robin", sched.NewResult([]sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}}, []workload.Process{
+		{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, Burst: 1, ArrivalTime: 1, Wait: 1, Turnaround: 2, Completion: 3},
+	}))
+	var b bytes.Buffer
+	if err := encodePDF(&b, []report.Report{r}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.Bytes()
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("not framed as a PDF: %q...", out[:20])
+	}
+	if !bytes.Contains(out, []byte("/Count 3")) {
+		t.Error("want a title, report, and comparison page")
+	}
+
+	// every xref entry must point at the object it names
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out[xref:], -1)
+	for i, e := range entries {
+		offset, _ := strconv.Atoi(string(e[1]))
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, out[offset:offset+10], want)
+		}
+	}
+}
+
+func Test_pdfEscape(t *testing.T) {
+	t.Parallel()
+	if got, want := pdfEscape(`a(b)\c→`), `a\(b\)\\c???`; got != want {
+		t.Errorf("pdfEscape() = %q, want %q", got, want)
+	}
+}
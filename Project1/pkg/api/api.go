@@ -0,0 +1,272 @@
+// Package api is the simulator as a function from one JSON document to another: a Request carries a workload and
+// the algorithms to run on it, and a Response carries their results as the versioned records of package report. It
+// is the entry point for callers that are not the CLI, such as the C library in capi and the REST API of NewHandler,
+// so they all accept and return the same documents.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ErrBadRequest marks a request that isn't valid JSON or asks for something the simulator can't do.
+var ErrBadRequest = errors.New("bad request")
+
+// ErrNondeterministic marks a deterministic request whose runs were stopped before they finished, so that what they
+// did finish depends on timing.
+var ErrNondeterministic = errors.New("result is not deterministic")
+
+// DeterminismVersion is the version of the determinism guarantee. A deterministic request, one that sets
+// Deterministic, gets a response that is the same to the byte from every release that reports the same
+// DeterminismVersion, given the same processes, algorithms, quantum, seed, and switch cost. The version goes up
+// whenever a release has to break that, for example to fix a scheduler bug, and the reference runs in
+// testdata/determinism are recorded afresh under the new version.
+const DeterminismVersion = 2
+
+// Process is one process of a request's workload.
+type Process struct {
+	PID      int64 `json:"pid"`
+	Arrival  int64 `json:"arrival"`
+	Burst    int64 `json:"burst"`
+	Priority int64 `json:"priority"`
+}
+
+// Request asks for the algorithms named in Algorithms, all of them if it is empty, to be run on Processes. As in the
+// CLI, PIDs must run from 1 to the number of processes and the processes must be in arrival order.
+type Request struct {
+	Processes  []Process `json:"processes"`
+	Algorithms []string  `json:"algorithms,omitempty"`
+	// Quantum is the round-robin time quantum, 1 if it is left out.
+	Quantum int64 `json:"quantum,omitempty"`
+	// Seed seeds the lottery draws as -seed does; leaving it out is the same as seed 0, not a random seed.
+	Seed       int64 `json:"seed,omitempty"`
+	SwitchCost int64 `json:"switch_cost,omitempty"`
+	// Deterministic asks for the guarantee of DeterminismVersion. Runs that stop early, such as when the context
+	// ends, then fail with ErrNondeterministic instead of returning what finished.
+	Deterministic bool `json:"deterministic,omitempty"`
+}
+
+// Slice is one slice of a Gantt chart.
+type Slice struct {
+	PID    int64 `json:"pid"`
+	Start  int64 `json:"start"`
+	Stop   int64 `json:"stop"`
+	Switch bool  `json:"switch,omitempty"`
+}
+
+// Result is the outcome of one algorithm.
+type Result struct {
+	Algorithm string                 `json:"algorithm"`
+	Summary   report.AlgorithmRecord `json:"summary"`
+	Processes []report.ProcessRecord `json:"processes"`
+	Gantt     []Slice                `json:"gantt"`
+}
+
+// Response is the answer to a Request: a Result per algorithm in the order requested, or an Error saying why there
+// are none.
+type Response struct {
+	SchemaVersion int `json:"schema_version"`
+	// Determinism is the DeterminismVersion a deterministic request was answered under, and 0 otherwise.
+	Determinism int      `json:"determinism,omitempty"`
+	Results     []Result `json:"results,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// Algorithms returns the algorithms a request can name, configured by its quantum and seed, in their default order.
+func Algorithms(quantum, seed int64) *sched.Registry {
+	if quantum < 1 {
+		quantum = 1
+	}
+	return sched.NewRegistry(
+		sched.Algorithm{
+			Scheduler:   sched.New("fcfs", sched.FCFS),
+			Title:       "First-come, first-serve",
+			Description: "non-preemptive, runs processes in arrival order",
+		},
+		sched.Algorithm{
+			Scheduler:   sched.New("sjf", sched.SJF),
+			Title:       "Shortest-job-first",
+			Description: "preemptive, always runs the process with the least remaining burst",
+		},
+		sched.Algorithm{
+			Scheduler:   sched.New("priority", sched.Priority),
+			Title:       "Priority",
+			Description: "preemptive, always runs the highest-priority (lowest value) process",
+		},
+		sched.Algorithm{
+			Scheduler: sched.New("rr", func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+				return sched.RR(ctx, processes, quantum)
+			}),
+			Title:       "Round-robin",
+			Description: "preemptive, cycles through ready processes one time quantum at a time",
+		},
+		sched.Algorithm{
+			Scheduler: sched.New("lottery", func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+				return sched.Lottery(ctx, processes, sched.NewRand(seed, "lottery"))
+			}),
+			Title:       "Lottery",
+			Description: "preemptive, draws the process for every time unit at random, weighted towards higher priority",
+		},
+	)
+}
+
+// Run runs req. A run that ctx stops early still has a Result, with the reason in its summary's Stopped field, unless
+// req is deterministic.
+func Run(ctx context.Context, req Request) (Response, error) {
+	resp, _, err := run(ctx, req)
+	return resp, err
+}
+
+// run runs req, returning its Response along with the sched.Result behind each of its Results, context switches
+// charged.
+func run(ctx context.Context, req Request) (Response, []sched.Result, error) {
+	if err := validate(req); err != nil {
+		return Response{}, nil, err
+	}
+	selected, err := Algorithms(req.Quantum, req.Seed).Select(strings.Join(req.Algorithms, ","))
+	if err != nil {
+		return Response{}, nil, fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+	processes := workloadProcesses(req.Processes)
+
+	schedulers := make([]sched.Scheduler, len(selected))
+	for i, a := range selected {
+		schedulers[i] = a
+	}
+	results, errs := sched.RunAll(ctx, schedulers, processes)
+	resp := Response{SchemaVersion: report.SchemaVersion, Results: make([]Result, len(selected))}
+	if req.Deterministic {
+		for i, err := range errs {
+			if err != nil {
+				return Response{}, nil, fmt.Errorf("%w: %s stopped: %w", ErrNondeterministic, selected[i].Name(), err)
+			}
+		}
+		resp.Determinism = DeterminismVersion
+	}
+	for i, a := range selected {
+		results[i] = sched.ChargeContextSwitches(results[i], req.SwitchCost)
+		r := report.New(a.Title, results[i])
+		if errs[i] != nil {
+			r.Stopped = errs[i].Error()
+		}
+		gantt := make([]Slice, len(r.Gantt))
+		for j, s := range r.Gantt {
+			gantt[j] = Slice{PID: s.PID, Start: s.Start, Stop: s.Stop, Switch: s.Switch}
+		}
+		resp.Results[i] = Result{Algorithm: a.Name(), Summary: r.Record(), Processes: r.ProcessRecords(), Gantt: gantt}
+	}
+
+	return resp, results, nil
+}
+
+// RunJSON runs the JSON-encoded Request in and returns the JSON-encoded Response. It always returns a Response:
+// when the request can't be run, its Error says why.
+func RunJSON(ctx context.Context, in []byte) []byte {
+	var resp Response
+	req, err := decodeRequest(bytes.NewReader(in))
+	if err == nil {
+		resp, err = Run(ctx, req)
+	}
+	if err != nil {
+		resp = errorResponse(err)
+	}
+
+	return encodeResponse(resp)
+}
+
+// SimulateJSON runs the JSON array of Process workload under the JSON options, a Request without its processes,
+// and returns the JSON-encoded Response. Empty options select the defaults. Like RunJSON, it always returns a
+// Response, with Error set when the request can't be run.
+func SimulateJSON(ctx context.Context, workload, options []byte) []byte {
+	var resp Response
+	req, err := decodeRequest(bytes.NewReader(options))
+	if len(bytes.TrimSpace(options)) == 0 {
+		req, err = Request{}, nil
+	}
+	if err == nil && req.Processes != nil {
+		err = fmt.Errorf("%w: give the processes as the workload, not in the options", ErrBadRequest)
+	}
+	if err == nil {
+		dec := json.NewDecoder(bytes.NewReader(workload))
+		dec.DisallowUnknownFields()
+		if err = dec.Decode(&req.Processes); err != nil {
+			err = fmt.Errorf("%w: workload: %w", ErrBadRequest, err)
+		}
+	}
+	if err == nil {
+		resp, err = Run(ctx, req)
+	}
+	if err != nil {
+		resp = errorResponse(err)
+	}
+
+	return encodeResponse(resp)
+}
+
+// decodeRequest reads a JSON Request from r, rejecting fields it doesn't know so that a misspelled option isn't
+// silently ignored.
+func decodeRequest(r io.Reader) (Request, error) {
+	var req Request
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return Request{}, fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+
+	return req, nil
+}
+
+// errorResponse is the Response to a request that failed with err.
+func errorResponse(err error) Response {
+	return Response{SchemaVersion: report.SchemaVersion, Error: err.Error()}
+}
+
+// encodeResponse returns resp as JSON, or a Response saying why it can't be encoded.
+func encodeResponse(resp Response) []byte {
+	out, err := json.Marshal(resp)
+	if err != nil {
+		out, _ = json.Marshal(errorResponse(fmt.Errorf("encoding response: %w", err)))
+	}
+
+	return out
+}
+
+// workloadProcesses returns processes as the schedulers take them.
+func workloadProcesses(processes []Process) []workload.Process {
+	converted := make([]workload.Process, len(processes))
+	for i, p := range processes {
+		converted[i] = workload.Process{ProcessID: p.PID, ArrivalTime: p.Arrival, BurstDuration: p.Burst,
+			Priority: p.Priority}
+	}
+
+	return converted
+}
+
+// validate checks req the way the schedulers rely on it being, with the workload.Check the CLI's validate command and
+// workload files go through too.
+func validate(req Request) error {
+	if len(req.Processes) == 0 {
+		return fmt.Errorf("%w: no processes", ErrBadRequest)
+	}
+	if req.SwitchCost < 0 {
+		return fmt.Errorf("%w: switch_cost must not be negative", ErrBadRequest)
+	}
+	var problems []error
+	for _, p := range workload.Check(workloadProcesses(req.Processes)) {
+		problems = append(problems, fmt.Errorf("process %d: %s", p.Index, p.Message))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %w: %w", ErrBadRequest, workload.ErrInvalidWorkload, errors.Join(problems...))
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+var processes = []Process{
+	{PID: 1, Arrival: 0, Burst: 5, Priority: 2},
+	{PID: 2, Arrival: 1, Burst: 3, Priority: 1},
+	{PID: 3, Arrival: 2, Burst: 1, Priority: 3},
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	resp, err := Run(context.Background(), Request{Processes: processes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range resp.Results {
+		names = append(names, r.Algorithm)
+	}
+	if want := []string{"fcfs", "sjf", "priority", "rr", "lottery"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Run() ran %v, want %v by default", names, want)
+	}
+	if resp.SchemaVersion != report.SchemaVersion {
+		t.Errorf("Run() schema version = %d, want %d", resp.SchemaVersion, report.SchemaVersion)
+	}
+
+	fcfs := resp.Results[0]
+	wantGantt := []Slice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}, {PID: 3, Start: 8, Stop: 9}}
+	if !reflect.DeepEqual(fcfs.Gantt, wantGantt) {
+		t.Errorf("fcfs Gantt = %v, want %v", fcfs.Gantt, wantGantt)
+	}
+	if fcfs.Summary.Name != "First-come, first-serve" || fcfs.Summary.Finished != 3 || fcfs.Summary.Switches != 2 {
+		t.Errorf("fcfs summary = %+v", fcfs.Summary)
+	}
+	if len(fcfs.Processes) != 3 || fcfs.Processes[1].Wait != 4 || fcfs.Processes[2].Completion != 9 {
+		t.Errorf("fcfs processes = %+v", fcfs.Processes)
+	}
+}
+
+func TestRun_options(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		req  Request
+		want func(t *testing.T, r Result)
+	}{
+		{
+			name: "quantum",
+			req:  Request{Processes: processes, Algorithms: []string{"rr"}, Quantum: 2},
+			want: func(t *testing.T, r Result) {
+				if r.Gantt[0] != (Slice{PID: 1, Start: 0, Stop: 2}) {
+					t.Errorf("rr with quantum 2 starts with %v", r.Gantt[0])
+				}
+			},
+		},
+		{
+			name: "switch cost",
+			req:  Request{Processes: processes, Algorithms: []string{"fcfs"}, SwitchCost: 1},
+			want: func(t *testing.T, r Result) {
+				if r.Summary.Overhead != 2 || !r.Gantt[1].Switch {
+					t.Errorf("fcfs with switch cost 1 = %+v", r)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp, err := Run(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.want(t, resp.Results[0])
+		})
+	}
+}
+
+func TestRun_stopped(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := Run(ctx, Request{Processes: processes, Algorithms: []string{"sjf"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Results[0].Summary.Stopped == "" {
+		t.Errorf("Run() of a canceled context has no stopped reason: %+v", resp.Results[0].Summary)
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		req  Request
+		want error
+	}{
+		{name: "no processes", req: Request{}, want: ErrBadRequest},
+		{name: "unknown algorithm", req: Request{Processes: processes, Algorithms: []string{"mlfq"}}, want: sched.ErrUnknownAlgorithm},
+		{name: "negative switch cost", req: Request{Processes: processes, SwitchCost: -1}, want: ErrBadRequest},
+		{
+			name: "pids not 1 to n",
+			req:  Request{Processes: []Process{{PID: 2, Burst: 1}, {PID: 3, Burst: 1}}},
+			want: workload.ErrInvalidWorkload,
+		},
+		{
+			name: "out of arrival order",
+			req:  Request{Processes: []Process{{PID: 1, Arrival: 3, Burst: 1}, {PID: 2, Arrival: 1, Burst: 1}}},
+			want: workload.ErrInvalidWorkload,
+		},
+		{name: "empty burst", req: Request{Processes: []Process{{PID: 1}}}, want: workload.ErrInvalidWorkload},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := Run(context.Background(), tt.req); !errors.Is(err, tt.want) || !errors.Is(err, ErrBadRequest) {
+				t.Errorf("Run() error = %v, want %v and %v", err, tt.want, ErrBadRequest)
+			}
+		})
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		in    string
+		error string // part of the response's error, or "" for success
+	}{
+		{name: "valid", in: `{"processes": [{"pid": 1, "arrival": 0, "burst": 2}], "algorithms": ["fcfs"]}`},
+		{name: "not JSON", in: `pid,burst,arrival`, error: "bad request"},
+		{name: "unknown field", in: `{"processes": [{"pid": 1, "burst": 2}], "quantom": 2}`, error: "quantom"},
+		{name: "unknown algorithm", in: `{"processes": [{"pid": 1, "burst": 2}], "algorithms": ["mlfq"]}`, error: "unknown algorithm"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var resp Response
+			if err := json.Unmarshal(RunJSON(context.Background(), []byte(tt.in)), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.SchemaVersion != report.SchemaVersion {
+				t.Errorf("RunJSON() schema version = %d", resp.SchemaVersion)
+			}
+			if tt.error == "" {
+				if resp.Error != "" || len(resp.Results) != 1 {
+					t.Errorf("RunJSON() = %+v, want one result", resp)
+				}
+				return
+			}
+			if !strings.Contains(resp.Error, tt.error) || len(resp.Results) != 0 {
+				t.Errorf("RunJSON() = %+v, want an error containing %q", resp, tt.error)
+			}
+		})
+	}
+}
+
+func TestAlgorithms_seed(t *testing.T) {
+	t.Parallel()
+	run := func(seed int64) Result {
+		resp, err := Run(context.Background(), Request{Processes: processes, Algorithms: []string{"lottery"}, Seed: seed})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Results[0]
+	}
+	if a, b := run(7), run(7); !reflect.DeepEqual(a, b) {
+		t.Errorf("lottery with the same seed differs:\n%+v\n%+v", a, b)
+	}
+}
+
+func TestSimulateJSON(t *testing.T) {
+	t.Parallel()
+	const workload = `[{"pid": 1, "arrival": 0, "burst": 2}, {"pid": 2, "arrival": 1, "burst": 1}]`
+	tests := []struct {
+		name     string
+		workload string
+		options  string
+		results  int
+		error    string // part of the response's error, or "" for success
+	}{
+		{name: "default options", workload: workload, results: 5},
+		{name: "options", workload: workload, options: `{"algorithms": ["rr", "fcfs"], "quantum": 2}`, results: 2},
+		{name: "processes in the options", workload: workload, options: `{"processes": []}`, error: "not in the options"},
+		{name: "bad workload", workload: `{"pid": 1}`, error: "workload"},
+		{name: "bad options", workload: workload, options: `{"quantum": "two"}`, error: "bad request"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var resp Response
+			if err := json.Unmarshal(SimulateJSON(context.Background(), []byte(tt.workload), []byte(tt.options)), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Results) != tt.results || !strings.Contains(resp.Error, tt.error) || (tt.error == "") != (resp.Error == "") {
+				t.Errorf("SimulateJSON() = %+v, want %d results and error %q", resp, tt.results, tt.error)
+			}
+		})
+	}
+}
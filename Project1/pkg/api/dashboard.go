@@ -0,0 +1,26 @@
+package api
+
+import (
+	"embed"
+	"net/http"
+)
+
+// dashboard is the single-page web UI served at /: it uploads or edits a workload, runs the selected algorithms
+// through /simulate, draws their Gantt charts and a comparison of their averages, and animates a run over /events.
+//
+//go:embed dashboard/index.html
+var dashboard embed.FS
+
+// serveDashboard answers GET / with the dashboard, and any other path the mux leaves unmatched with 404 Not Found.
+func serveDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	page, _ := dashboard.ReadFile("dashboard/index.html")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/golden"
+)
+
+// TestDeterminism replays the reference runs recorded under the current DeterminismVersion and requires the same
+// response to the byte. Responses are only ever recorded for new requests: one that no longer matches means the
+// guarantee is broken, and the fix is a new DeterminismVersion with its runs recorded afresh, not an updated file.
+func TestDeterminism(t *testing.T) {
+	t.Parallel()
+	requests, err := filepath.Glob(filepath.Join("testdata", "determinism",
+		fmt.Sprintf("v%d", DeterminismVersion), "*.request.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) == 0 {
+		t.Fatalf("no reference runs recorded for determinism version %d", DeterminismVersion)
+	}
+	for _, path := range requests {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			t.Parallel()
+			in, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := RunJSON(context.Background(), in)
+			if again := RunJSON(context.Background(), in); !bytes.Equal(out, again) {
+				t.Fatalf("two runs of the same request differ:\n%s", golden.Diff(string(out), string(again)))
+			}
+			var got bytes.Buffer
+			if err := json.Indent(&got, out, "", "  "); err != nil {
+				t.Fatal(err)
+			}
+			got.WriteByte('\n')
+
+			responsePath := strings.TrimSuffix(path, ".request.json") + ".response.json"
+			want, err := os.ReadFile(responsePath)
+			if errors.Is(err, os.ErrNotExist) && os.Getenv(golden.UpdateEnv) == "1" {
+				if err := os.WriteFile(responsePath, got.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%v (run with %s=1 to record it)", err, golden.UpdateEnv)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("response differs from the reference run %s, breaking determinism version %d (-want +got):\n%s",
+					responsePath, DeterminismVersion, golden.Diff(string(want), got.String()))
+			}
+		})
+	}
+}
+
+func TestRun_deterministic(t *testing.T) {
+	t.Parallel()
+	resp, err := Run(context.Background(), Request{Processes: processes, Deterministic: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Determinism != DeterminismVersion {
+		t.Errorf("Run() determinism = %d, want %d", resp.Determinism, DeterminismVersion)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Run(ctx, Request{Processes: processes, Deterministic: true}); !errors.Is(err, ErrNondeterministic) ||
+		!errors.Is(err, context.Canceled) {
+		t.Errorf("Run() of a canceled deterministic request error = %v, want %v and %v", err, ErrNondeterministic,
+			context.Canceled)
+	}
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// StreamRequest is the first message a client sends on /events: a Request to run, and how fast to play its events.
+type StreamRequest struct {
+	Request
+	// Speed is how many time units play per second. Without it, every event is sent at once.
+	Speed float64 `json:"speed,omitempty"`
+}
+
+// StreamControl is a message a client may send while events play: Speed changes how many time units play per
+// second, and Paused pauses or resumes. Either can be left out.
+type StreamControl struct {
+	Speed  float64 `json:"speed,omitempty"`
+	Paused *bool   `json:"paused,omitempty"`
+}
+
+// EventMessage is one message the server sends on /events. For each algorithm in turn, it sends every event of its
+// run as it plays, with Kind "arrival", "dispatch", "preempt", or "complete", then its Result with Kind "result". A
+// request that can't be run gets a single message with Kind "error".
+type EventMessage struct {
+	Algorithm string `json:"algorithm,omitempty"`
+	Kind      string `json:"kind"`
+	Time      int64  `json:"time"`
+	PID       int64  `json:"pid,omitempty"`
+	// Remaining is the burst the process has left, and Wait the time it has spent ready so far.
+	Remaining int64   `json:"remaining"`
+	Wait      int64   `json:"wait"`
+	Result    *Result `json:"result,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// streamEvents answers an /events connection: it reads a StreamRequest, runs it, and plays the events of every
+// algorithm over c, taking StreamControl messages as they come. Each simulation is stopped after timeout, if it is
+// positive; playing its events takes as long as it takes.
+func streamEvents(ctx context.Context, c *wsConn, timeout time.Duration) {
+	msg, err := c.ReadText(MaxRequestBytes)
+	if err != nil {
+		_ = c.Close(closeNormal)
+		return
+	}
+	var req StreamRequest
+	dec := json.NewDecoder(bytes.NewReader(msg))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		streamError(c, fmt.Errorf("%w: %w", ErrBadRequest, err))
+		return
+	}
+	if req.Speed < 0 {
+		streamError(c, fmt.Errorf("%w: speed must not be negative", ErrBadRequest))
+		return
+	}
+	simulation := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		simulation, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	resp, results, err := run(simulation, req.Request)
+	if err != nil {
+		streamError(c, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	controls := &playControls{speed: req.Speed}
+	go func() {
+		// the client closing the connection, or breaking the protocol, ends the stream
+		for {
+			msg, err := c.ReadText(MaxRequestBytes)
+			if err != nil {
+				cancel(err)
+				return
+			}
+			var control StreamControl
+			if json.Unmarshal(msg, &control) == nil {
+				controls.apply(control)
+			}
+		}
+	}()
+
+	write := func(m EventMessage) error {
+		out, _ := json.Marshal(m)
+		return c.WriteText(out)
+	}
+	for i, result := range results {
+		out := &eventSender{write: write, algorithm: resp.Results[i].Algorithm, cancel: cancel}
+		if req.Speed == 0 {
+			for _, e := range sched.Events(result) {
+				e.Deliver(out)
+			}
+		} else if err := controls.play(ctx, result, out); err != nil {
+			return
+		}
+		out.send(EventMessage{Algorithm: out.algorithm, Kind: "result", Result: &resp.Results[i]})
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	_ = c.Close(closeNormal)
+}
+
+// streamError sends err as the only message of a stream and closes it.
+func streamError(c *wsConn, err error) {
+	out, _ := json.Marshal(EventMessage{Kind: "error", Error: err.Error()})
+	_ = c.WriteText(out)
+	_ = c.Close(closeNormal)
+}
+
+// playControls holds the speed and pause state a client sets, and applies it to the Player of whichever algorithm is
+// playing.
+type playControls struct {
+	mu     sync.Mutex
+	speed  float64
+	paused bool
+	player *sched.Player
+}
+
+func (p *playControls) apply(c StreamControl) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c.Speed > 0 {
+		p.speed = c.Speed
+		if p.player != nil {
+			p.player.SetSpeed(c.Speed)
+		}
+	}
+	if c.Paused != nil {
+		p.paused = *c.Paused
+		if p.player != nil {
+			p.player.SetPaused(p.paused)
+		}
+	}
+}
+
+// play plays the events of r to out at the current speed.
+func (p *playControls) play(ctx context.Context, r sched.Result, out sched.Observer) error {
+	p.mu.Lock()
+	p.player = sched.NewPlayer(r, p.speed)
+	p.player.SetPaused(p.paused)
+	player := p.player
+	p.mu.Unlock()
+
+	return player.Play(ctx, out)
+}
+
+// eventSender is a sched.Observer sending every event as an EventMessage with write. The first failed write cancels
+// the stream.
+type eventSender struct {
+	write     func(EventMessage) error
+	algorithm string
+	cancel    context.CancelCauseFunc
+}
+
+func (s *eventSender) send(m EventMessage) {
+	if err := s.write(m); err != nil {
+		s.cancel(err)
+	}
+}
+
+func (s *eventSender) event(kind sched.EventKind, time int64, p workload.Process) {
+	s.send(EventMessage{Algorithm: s.algorithm, Kind: kind.String(), Time: time, PID: p.ProcessID,
+		Remaining: p.BurstDuration, Wait: p.Wait})
+}
+
+func (s *eventSender) OnArrival(time int64, p workload.Process) {
+	s.event(sched.EventArrival, time, p)
+}
+
+func (s *eventSender) OnDispatch(time int64, p workload.Process) {
+	s.event(sched.EventDispatch, time, p)
+}
+
+func (s *eventSender) OnPreempt(time int64, p workload.Process) {
+	s.event(sched.EventPreempt, time, p)
+}
+
+func (s *eventSender) OnComplete(time int64, p workload.Process) {
+	s.event(sched.EventComplete, time, p)
+}
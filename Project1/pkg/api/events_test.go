@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+// receiveStream reads the EventMessages of a stream until the server closes it.
+func receiveStream(t *testing.T, c *wsClient) []EventMessage {
+	t.Helper()
+	var messages []EventMessage
+	for {
+		opcode, payload, err := c.receive()
+		if err != nil {
+			t.Fatalf("stream ended without a close frame: %v", err)
+		}
+		if opcode == opClose {
+			return messages
+		}
+		var m EventMessage
+		if err := json.Unmarshal(payload, &m); err != nil {
+			t.Fatalf("bad message %s: %v", payload, err)
+		}
+		messages = append(messages, m)
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(NewHandler(time.Minute))
+	t.Cleanup(server.Close)
+	resp, results, err := run(context.Background(), Request{Processes: processes, Algorithms: []string{"fcfs", "rr"}, Quantum: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		request  string
+		controls []string
+	}{
+		{name: "at once", request: `{"processes": [{"pid": 1, "arrival": 0, "burst": 5, "priority": 2}, {"pid": 2, "arrival": 1, "burst": 3, "priority": 1}, {"pid": 3, "arrival": 2, "burst": 1, "priority": 3}], "algorithms": ["fcfs", "rr"], "quantum": 2}`},
+		{
+			name:     "played and sped up",
+			request:  `{"processes": [{"pid": 1, "arrival": 0, "burst": 5, "priority": 2}, {"pid": 2, "arrival": 1, "burst": 3, "priority": 1}, {"pid": 3, "arrival": 2, "burst": 1, "priority": 3}], "algorithms": ["fcfs", "rr"], "quantum": 2, "speed": 0.01}`,
+			controls: []string{`{"paused": true}`, `{"speed": 1000000, "paused": false}`},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := dialWebSocket(t, server, "/events")
+			c.send(t, opText, tt.request)
+			for _, control := range tt.controls {
+				c.send(t, opText, control)
+			}
+			messages := receiveStream(t, c)
+
+			var want []EventMessage
+			for i, r := range results {
+				for _, e := range sched.Events(r) {
+					want = append(want, EventMessage{Algorithm: resp.Results[i].Algorithm, Kind: e.Kind.String(),
+						Time: e.Time, PID: e.Process.ProcessID, Remaining: e.Process.BurstDuration, Wait: e.Process.Wait})
+				}
+				want = append(want, EventMessage{Algorithm: resp.Results[i].Algorithm, Kind: "result", Result: &resp.Results[i]})
+			}
+			got, _ := json.Marshal(messages)
+			wantJSON, _ := json.Marshal(want)
+			if string(got) != string(wantJSON) {
+				t.Errorf("stream sent\n%s\nwant\n%s", got, wantJSON)
+			}
+		})
+	}
+}
+
+func TestStreamEvents_errors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(NewHandler(time.Minute))
+	t.Cleanup(server.Close)
+	tests := []struct {
+		name    string
+		request string
+		want    string
+	}{
+		{name: "not JSON", request: "hello", want: "bad request: invalid character 'h' looking for beginning of value"},
+		{name: "no processes", request: `{"speed": 2}`, want: "bad request: no processes"},
+		{name: "negative speed", request: `{"processes": [{"pid": 1, "burst": 1}], "speed": -1}`, want: "bad request: speed must not be negative"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := dialWebSocket(t, server, "/events")
+			c.send(t, opText, tt.request)
+			messages := receiveStream(t, c)
+			if len(messages) != 1 || messages[0].Kind != "error" || messages[0].Error != tt.want {
+				t.Errorf("stream sent %+v, want only the error %q", messages, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,475 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+// ErrUnknownWorkload marks a simulation naming a workload ID the server doesn't keep, because it was never submitted
+// or has since been forgotten.
+var ErrUnknownWorkload = errors.New("unknown workload")
+
+var (
+	errUnimplemented = errors.New("unimplemented")
+	errTooLarge      = errors.New("message too large")
+)
+
+// MaxWorkloads bounds how many submitted workloads a gRPC handler keeps; submitting another forgets the oldest.
+const MaxWorkloads = 1024
+
+// gRPC status codes, as listed in the gRPC documentation.
+const (
+	grpcOK                = 0
+	grpcCanceled          = 1
+	grpcInvalidArgument   = 3
+	grpcDeadlineExceeded  = 4
+	grpcNotFound          = 5
+	grpcResourceExhausted = 8
+	grpcUnimplemented     = 12
+	grpcInternal          = 13
+	grpcUnavailable       = 14
+)
+
+// grpcService is the name of the service of simulator.proto in request paths.
+const grpcService = "/scheduler.Simulator/"
+
+// NewGRPCHandler returns the HTTP handler of the simulator's gRPC service, Simulator in simulator.proto. gRPC runs
+// over HTTP/2, which net/http serves only over TLS, so the handler must be served with ServeTLS or
+// ListenAndServeTLS. Messages must not be compressed.
+//
+// As with NewHandler, each simulation is stopped after timeout, if it is positive, and answers with what finished by
+// then; a grpc-timeout the client sends bounds the whole call as well.
+func NewGRPCHandler(timeout time.Duration) http.Handler {
+	return &grpcServer{timeout: timeout, workloads: make(map[string][]Process)}
+}
+
+// grpcServer serves the Simulator service, keeping the workloads submitted to it.
+type grpcServer struct {
+	timeout   time.Duration
+	mu        sync.Mutex
+	workloads map[string][]Process
+	order     []string // IDs of workloads, oldest first
+}
+
+func (s *grpcServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	if r.ProtoMajor != 2 || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC needs HTTP/2 and a Content-Type of application/grpc", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Grpc-Accept-Encoding", "identity")
+
+	ctx := r.Context()
+	if timeout, ok := parseGRPCTimeout(r.Header.Get("Grpc-Timeout")); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var err error
+	switch method := strings.TrimPrefix(r.URL.Path, grpcService); method {
+	case "SubmitWorkload":
+		err = s.submitWorkload(w, r.Body)
+	case "RunSimulation":
+		err = s.runSimulation(ctx, w, r.Body)
+	case "StreamEvents":
+		err = s.streamEvents(ctx, w, r.Body)
+	default:
+		err = fmt.Errorf("%w: unknown method %s", errUnimplemented, r.URL.Path)
+	}
+
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(grpcCode(err)))
+	if err != nil {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", percentEncode(err.Error()))
+	}
+}
+
+func (s *grpcServer) submitWorkload(w io.Writer, body io.Reader) error {
+	msg, err := readGRPCMessage(body)
+	if err != nil {
+		return err
+	}
+	processes, err := decodeWorkload(msg)
+	if err != nil {
+		return err
+	}
+	if err := validate(Request{Processes: processes}); err != nil {
+		return err
+	}
+
+	// the ID hashes the processes as encoded here, so it doesn't depend on how the client encoded them
+	var canonical protoWriter
+	encodeWorkload(&canonical, processes)
+	sum := sha256.Sum256(canonical.b)
+	id := hex.EncodeToString(sum[:16])
+	s.mu.Lock()
+	if _, ok := s.workloads[id]; !ok {
+		if len(s.order) == MaxWorkloads {
+			delete(s.workloads, s.order[0])
+			s.order = s.order[1:]
+		}
+		s.workloads[id] = processes
+		s.order = append(s.order, id)
+	}
+	s.mu.Unlock()
+
+	var ref protoWriter
+	ref.string(1, id)
+	return writeGRPCMessage(w, ref.b)
+}
+
+func (s *grpcServer) runSimulation(ctx context.Context, w io.Writer, body io.Reader) error {
+	msg, err := readGRPCMessage(body)
+	if err != nil {
+		return err
+	}
+	req, err := s.decodeSimulationRequest(msg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.simulation(ctx)
+	defer cancel()
+	resp, err := Run(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var out protoWriter
+	out.int64(1, int64(resp.SchemaVersion))
+	out.int64(2, int64(resp.Determinism))
+	for _, r := range resp.Results {
+		out.message(3, func(p *protoWriter) { encodeResult(p, r) })
+	}
+	return writeGRPCMessage(w, out.b)
+}
+
+// streamEvents answers StreamEvents as streamEvents answers /events, less the controls: the events play at the speed
+// requested throughout.
+func (s *grpcServer) streamEvents(ctx context.Context, w io.Writer, body io.Reader) error {
+	msg, err := readGRPCMessage(body)
+	if err != nil {
+		return err
+	}
+	var (
+		simulation []byte
+		speed      float64
+	)
+	err = decodeMessage(msg, func(r *protoReader, num, wire int) (err error) {
+		switch num {
+		case 1:
+			simulation, err = r.bytes(wire)
+		case 2:
+			speed, err = r.double(wire)
+		default:
+			err = r.skip(wire)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+	if speed < 0 {
+		return fmt.Errorf("%w: speed must not be negative", ErrBadRequest)
+	}
+	req, err := s.decodeSimulationRequest(simulation)
+	if err != nil {
+		return err
+	}
+	simulated, cancelSimulation := s.simulation(ctx)
+	defer cancelSimulation()
+	resp, results, err := run(simulated, req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	write := func(m EventMessage) error {
+		var event protoWriter
+		encodeEvent(&event, m)
+		return writeGRPCMessage(w, event.b)
+	}
+	for i, result := range results {
+		out := &eventSender{write: write, algorithm: resp.Results[i].Algorithm, cancel: cancel}
+		if speed == 0 {
+			for _, e := range sched.Events(result) {
+				e.Deliver(out)
+			}
+		} else if err := sched.NewPlayer(result, speed).Play(ctx, out); err != nil {
+			return err
+		}
+		out.send(EventMessage{Algorithm: out.algorithm, Kind: "result", Result: &resp.Results[i]})
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+	}
+
+	return nil
+}
+
+// simulation returns ctx bounded by the server's timeout.
+func (s *grpcServer) simulation(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.timeout > 0 {
+		return context.WithTimeout(ctx, s.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// decodeSimulationRequest decodes a SimulationRequest message, looking up the workload it names.
+func (s *grpcServer) decodeSimulationRequest(b []byte) (Request, error) {
+	var (
+		req Request
+		id  string
+	)
+	err := decodeMessage(b, func(r *protoReader, num, wire int) (err error) {
+		switch num {
+		case 1:
+			id, err = r.string(wire)
+			req.Processes = nil
+		case 2:
+			var workload []byte
+			if workload, err = r.bytes(wire); err == nil {
+				req.Processes, err = decodeWorkload(workload)
+			}
+			id = ""
+		case 3:
+			var algorithm string
+			algorithm, err = r.string(wire)
+			req.Algorithms = append(req.Algorithms, algorithm)
+		case 4:
+			req.Quantum, err = r.int64(wire)
+		case 5:
+			req.Seed, err = r.int64(wire)
+		case 6:
+			req.SwitchCost, err = r.int64(wire)
+		case 7:
+			req.Deterministic, err = r.bool(wire)
+		default:
+			err = r.skip(wire)
+		}
+		return err
+	})
+	if err != nil {
+		return Request{}, fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+	if id != "" {
+		s.mu.Lock()
+		processes, ok := s.workloads[id]
+		s.mu.Unlock()
+		if !ok {
+			return Request{}, fmt.Errorf("%w: %q", ErrUnknownWorkload, id)
+		}
+		req.Processes = processes
+	}
+
+	return req, nil
+}
+
+// grpcCode returns the gRPC status code of a call that ended with err.
+func grpcCode(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case err == nil:
+		return grpcOK
+	case errors.Is(err, ErrUnknownWorkload):
+		return grpcNotFound
+	case errors.Is(err, errUnimplemented):
+		return grpcUnimplemented
+	case errors.Is(err, errTooLarge), errors.As(err, &tooLarge):
+		return grpcResourceExhausted
+	case errors.Is(err, ErrBadRequest):
+		return grpcInvalidArgument
+	case errors.Is(err, ErrNondeterministic):
+		return grpcUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return grpcDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return grpcCanceled
+	default:
+		return grpcInternal
+	}
+}
+
+// readGRPCMessage reads the one length-prefixed message of a unary or server-streaming call.
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, fmt.Errorf("%w: reading the request message: %w", ErrBadRequest, err)
+	}
+	if prefix[0] != 0 {
+		return nil, fmt.Errorf("%w: compressed messages", errUnimplemented)
+	}
+	n := binary.BigEndian.Uint32(prefix[1:])
+	if n > MaxRequestBytes {
+		return nil, fmt.Errorf("%w: request message of %d bytes is over %d", errTooLarge, n, MaxRequestBytes)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, fmt.Errorf("%w: reading the request message: %w", ErrBadRequest, err)
+	}
+
+	return msg, nil
+}
+
+// writeGRPCMessage writes msg length-prefixed to w and flushes it to the client.
+func writeGRPCMessage(w io.Writer, msg []byte) error {
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	if _, err := w.Write(append(frame, msg...)); err != nil {
+		return fmt.Errorf("%w: writing a response message", err)
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// parseGRPCTimeout parses a grpc-timeout header, such as "250m" for 250 milliseconds.
+func parseGRPCTimeout(s string) (time.Duration, bool) {
+	if len(s) < 2 || len(s) > 9 {
+		return 0, false
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second, 'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+	}
+	unit, ok := units[s[len(s)-1]]
+	n, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if !ok || err != nil || n < 0 {
+		return 0, false
+	}
+
+	return time.Duration(n) * unit, true
+}
+
+// percentEncode encodes s for a grpc-message trailer, which is limited to printable ASCII.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < ' ' || c > '~' || c == '%' {
+			_, _ = fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+//region Messages
+
+func decodeWorkload(b []byte) ([]Process, error) {
+	var processes []Process
+	err := decodeMessage(b, func(r *protoReader, num, wire int) error {
+		if num != 1 {
+			return r.skip(wire)
+		}
+		msg, err := r.bytes(wire)
+		if err != nil {
+			return err
+		}
+		var p Process
+		err = decodeMessage(msg, func(r *protoReader, num, wire int) (err error) {
+			switch num {
+			case 1:
+				p.PID, err = r.int64(wire)
+			case 2:
+				p.Arrival, err = r.int64(wire)
+			case 3:
+				p.Burst, err = r.int64(wire)
+			case 4:
+				p.Priority, err = r.int64(wire)
+			default:
+				err = r.skip(wire)
+			}
+			return err
+		})
+		processes = append(processes, p)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+
+	return processes, nil
+}
+
+func encodeWorkload(w *protoWriter, processes []Process) {
+	for _, p := range processes {
+		w.message(1, func(m *protoWriter) {
+			m.int64(1, p.PID)
+			m.int64(2, p.Arrival)
+			m.int64(3, p.Burst)
+			m.int64(4, p.Priority)
+		})
+	}
+}
+
+func encodeResult(w *protoWriter, r Result) {
+	w.string(1, r.Algorithm)
+	w.message(2, func(m *protoWriter) {
+		s := r.Summary
+		m.string(1, s.Name)
+		m.double(2, s.AvgWait)
+		m.double(3, s.AvgTurnaround)
+		m.double(4, s.AvgNormalized)
+		m.double(5, s.Throughput)
+		m.double(6, s.Utilization)
+		m.double(7, s.FairnessShare)
+		m.double(8, s.FairnessWait)
+		m.int64(9, s.Busy)
+		m.int64(10, s.Idle)
+		m.int64(11, s.Overhead)
+		m.int64(12, int64(s.Switches))
+		m.int64(13, int64(s.Finished))
+		m.string(14, s.Stopped)
+	})
+	for _, p := range r.Processes {
+		w.message(3, func(m *protoWriter) {
+			m.int64(1, p.PID)
+			m.int64(2, p.Arrival)
+			m.int64(3, p.Burst)
+			m.int64(4, p.Priority)
+			m.int64(5, p.Wait)
+			m.int64(6, p.Turnaround)
+			m.int64(7, p.Completion)
+			m.double(8, p.Normalized)
+		})
+	}
+	for _, s := range r.Gantt {
+		w.message(4, func(m *protoWriter) {
+			m.int64(1, s.PID)
+			m.int64(2, s.Start)
+			m.int64(3, s.Stop)
+			m.bool(4, s.Switch)
+		})
+	}
+}
+
+func encodeEvent(w *protoWriter, e EventMessage) {
+	w.string(1, e.Algorithm)
+	w.string(2, e.Kind)
+	w.int64(3, e.Time)
+	w.int64(4, e.PID)
+	w.int64(5, e.Remaining)
+	w.int64(6, e.Wait)
+	if e.Result != nil {
+		w.message(7, func(m *protoWriter) { encodeResult(m, *e.Result) })
+	}
+}
+
+//endregion
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// grpcCall makes a call to the gRPC handler at url, returning the messages of the response and its grpc-status and
+// grpc-message trailers.
+func grpcCall(t *testing.T, client *http.Client, url, method string, msg []byte) ([][]byte, string, string) {
+	t.Helper()
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	req, err := http.NewRequest(http.MethodPost, url+grpcService+method, bytes.NewReader(append(frame, msg...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var messages [][]byte
+	for len(body) >= 5 {
+		n := binary.BigEndian.Uint32(body[1:5])
+		messages = append(messages, body[5:5+n])
+		body = body[5+n:]
+	}
+	return messages, resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+}
+
+// stringFields returns the values of every string field numbered num in the message b.
+func stringFields(t *testing.T, b []byte, num int) []string {
+	t.Helper()
+	var values []string
+	err := decodeMessage(b, func(r *protoReader, n, wire int) error {
+		if n != num {
+			return r.skip(wire)
+		}
+		v, err := r.string(wire)
+		values = append(values, v)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return values
+}
+
+func TestNewGRPCHandler(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewUnstartedServer(NewGRPCHandler(time.Minute))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	var workload protoWriter
+	encodeWorkload(&workload, []Process{{PID: 1, Burst: 3}, {PID: 2, Arrival: 1, Burst: 1}})
+	refs, status, message := grpcCall(t, server.Client(), server.URL, "SubmitWorkload", workload.b)
+	if status != "0" || len(refs) != 1 {
+		t.Fatalf("SubmitWorkload = %d messages, status %s %s", len(refs), status, message)
+	}
+	id := stringFields(t, refs[0], 1)[0]
+
+	simulation := func(id string, algorithms ...string) []byte {
+		var w protoWriter
+		if id != "" {
+			w.string(1, id)
+		} else {
+			w.message(2, func(m *protoWriter) { m.b = append(m.b, workload.b...) })
+		}
+		for _, a := range algorithms {
+			w.string(3, a)
+		}
+		return w.b
+	}
+	var stream protoWriter
+	stream.message(1, func(m *protoWriter) { m.b = append(m.b, simulation(id, "fcfs", "rr")...) })
+
+	tests := []struct {
+		name        string
+		method      string
+		msg         []byte
+		wantStatus  string
+		wantMessage string
+		// want is the value of field 1 of every message of a RunSimulation's results (field 3) or StreamEvents's
+		// events (field 2)
+		want []string
+	}{
+		{name: "run on a submitted workload", method: "RunSimulation", msg: simulation(id, "sjf", "fcfs"), wantStatus: "0",
+			want: []string{"sjf", "fcfs"}},
+		{name: "run on a workload in the request", method: "RunSimulation", msg: simulation("", "priority"),
+			wantStatus: "0", want: []string{"priority"}},
+		{name: "unknown workload", method: "RunSimulation", msg: simulation("feed"), wantStatus: "5",
+			wantMessage: `unknown workload: "feed"`},
+		{name: "unknown algorithm", method: "RunSimulation", msg: simulation(id, "nope"), wantStatus: "3"},
+		{name: "malformed message", method: "RunSimulation", msg: []byte{0x0a, 0x05}, wantStatus: "3"},
+		{name: "invalid workload", method: "SubmitWorkload", msg: nil, wantStatus: "3",
+			wantMessage: "bad request: no processes"},
+		{name: "stream events", method: "StreamEvents", msg: stream.b, wantStatus: "0", want: []string{
+			"arrival", "dispatch", "arrival", "complete", "dispatch", "complete", "result",
+			"arrival", "dispatch", "arrival", "preempt", "dispatch", "complete", "dispatch", "complete", "result",
+		}},
+		{name: "unknown method", method: "Nope", wantStatus: "12"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			messages, status, message := grpcCall(t, server.Client(), server.URL, tt.method, tt.msg)
+			if status != tt.wantStatus || !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("%s status = %s %q, want %s containing %q", tt.method, status, message, tt.wantStatus,
+					tt.wantMessage)
+			}
+			var got []string
+			for _, m := range messages {
+				if tt.method == "StreamEvents" {
+					got = append(got, stringFields(t, m, 2)...)
+					continue
+				}
+				var results [][]byte
+				_ = decodeMessage(m, func(r *protoReader, num, wire int) error {
+					if num != 3 {
+						return r.skip(wire)
+					}
+					b, err := r.bytes(wire)
+					results = append(results, b)
+					return err
+				})
+				for _, r := range results {
+					got = append(got, stringFields(t, r, 1)...)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("%s = %v, want %v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGRPCHandler_http1(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, grpcService+"RunSimulation", nil)
+	req.Header.Set("Content-Type", "application/grpc")
+	NewGRPCHandler(0).ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("HTTP/1.1 call = %d, want %d", rec.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func Test_parseGRPCTimeout(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in     string
+		want   time.Duration
+		wantOK bool
+	}{
+		{in: "250m", want: 250 * time.Millisecond, wantOK: true},
+		{in: "3S", want: 3 * time.Second, wantOK: true},
+		{in: "1H", want: time.Hour, wantOK: true},
+		{in: ""},
+		{in: "5x"},
+		{in: "123456789S"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			if got, ok := parseGRPCTimeout(tt.in); got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseGRPCTimeout(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_percentEncode(t *testing.T) {
+	t.Parallel()
+	if got, want := percentEncode("100% sure\nnaïve"), "100%25 sure%0Ana%C3%AFve"; got != want {
+		t.Errorf("percentEncode() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// errBadProtobuf marks a message that isn't valid protobuf wire format.
+var errBadProtobuf = errors.New("malformed protobuf message")
+
+// Wire types of the protobuf encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoWriter appends proto3 fields to a byte slice, omitting fields that hold their zero value as proto3 does.
+type protoWriter struct {
+	b []byte
+}
+
+func (p *protoWriter) tag(field, wire int) {
+	p.b = binary.AppendUvarint(p.b, uint64(field)<<3|uint64(wire))
+}
+
+func (p *protoWriter) int64(field int, v int64) {
+	if v == 0 {
+		return
+	}
+	p.tag(field, wireVarint)
+	p.b = binary.AppendUvarint(p.b, uint64(v))
+}
+
+func (p *protoWriter) bool(field int, v bool) {
+	if v {
+		p.int64(field, 1)
+	}
+}
+
+func (p *protoWriter) double(field int, v float64) {
+	if v == 0 {
+		return
+	}
+	p.tag(field, wireFixed64)
+	p.b = binary.LittleEndian.AppendUint64(p.b, math.Float64bits(v))
+}
+
+func (p *protoWriter) string(field int, s string) {
+	if s == "" {
+		return
+	}
+	p.tag(field, wireBytes)
+	p.b = binary.AppendUvarint(p.b, uint64(len(s)))
+	p.b = append(p.b, s...)
+}
+
+// message appends the message encode writes as field, even when it is empty, as is done for repeated fields.
+func (p *protoWriter) message(field int, encode func(*protoWriter)) {
+	var m protoWriter
+	encode(&m)
+	p.tag(field, wireBytes)
+	p.b = binary.AppendUvarint(p.b, uint64(len(m.b)))
+	p.b = append(p.b, m.b...)
+}
+
+// decodeMessage calls field with the number and wire type of every field of the message b in turn. field reads the
+// value from r, or skips it.
+func decodeMessage(b []byte, field func(r *protoReader, num, wire int) error) error {
+	r := &protoReader{b: b}
+	for {
+		num, wire, ok, err := r.next()
+		if err != nil || !ok {
+			return err
+		}
+		if err := field(r, num, wire); err != nil {
+			return err
+		}
+	}
+}
+
+// protoReader reads the fields of one message in turn.
+type protoReader struct {
+	b []byte
+}
+
+// next returns the number and wire type of the next field, or reports false once the message is done.
+func (p *protoReader) next() (field, wire int, ok bool, err error) {
+	if len(p.b) == 0 {
+		return 0, 0, false, nil
+	}
+	key, err := p.uvarint()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if key>>3 == 0 || key>>3 > math.MaxInt32 {
+		return 0, 0, false, fmt.Errorf("%w: field number %d", errBadProtobuf, key>>3)
+	}
+
+	return int(key >> 3), int(key & 7), true, nil
+}
+
+func (p *protoReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(p.b)
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: bad varint", errBadProtobuf)
+	}
+	p.b = p.b[n:]
+
+	return v, nil
+}
+
+func (p *protoReader) int64(wire int) (int64, error) {
+	if wire != wireVarint {
+		return 0, fmt.Errorf("%w: wire type %d for an integer", errBadProtobuf, wire)
+	}
+	v, err := p.uvarint()
+	return int64(v), err
+}
+
+func (p *protoReader) bool(wire int) (bool, error) {
+	v, err := p.int64(wire)
+	return v != 0, err
+}
+
+func (p *protoReader) double(wire int) (float64, error) {
+	if wire != wireFixed64 || len(p.b) < 8 {
+		return 0, fmt.Errorf("%w: bad double", errBadProtobuf)
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(p.b))
+	p.b = p.b[8:]
+
+	return v, nil
+}
+
+func (p *protoReader) bytes(wire int) ([]byte, error) {
+	if wire != wireBytes {
+		return nil, fmt.Errorf("%w: wire type %d for a string or message", errBadProtobuf, wire)
+	}
+	n, err := p.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(p.b)) {
+		return nil, fmt.Errorf("%w: field runs past the end of the message", errBadProtobuf)
+	}
+	v := p.b[:n]
+	p.b = p.b[n:]
+
+	return v, nil
+}
+
+func (p *protoReader) string(wire int) (string, error) {
+	v, err := p.bytes(wire)
+	return string(v), err
+}
+
+// skip passes over a field of a type the reader doesn't know, as protobuf requires.
+func (p *protoReader) skip(wire int) error {
+	switch wire {
+	case wireVarint:
+		_, err := p.uvarint()
+		return err
+	case wireFixed64, wireFixed32:
+		n := 8
+		if wire == wireFixed32 {
+			n = 4
+		}
+		if len(p.b) < n {
+			return fmt.Errorf("%w: field runs past the end of the message", errBadProtobuf)
+		}
+		p.b = p.b[n:]
+		return nil
+	case wireBytes:
+		_, err := p.bytes(wire)
+		return err
+	default:
+		return fmt.Errorf("%w: unsupported wire type %d", errBadProtobuf, wire)
+	}
+}
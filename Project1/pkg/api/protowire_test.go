@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_decodeMessage(t *testing.T) {
+	t.Parallel()
+	var known protoWriter
+	known.int64(1, -7)
+	known.double(2, 0.5)
+	known.string(3, "rr")
+	known.bool(4, true)
+	unknown := append([]byte{}, known.b...)
+	unknown = append(unknown, 0x2d, 1, 2, 3, 4)             // field 5, fixed32
+	unknown = append(unknown, 0x32, 2, 0x08, 0x01)          // field 6, a message
+	unknown = append(unknown, 0x39, 1, 2, 3, 4, 5, 6, 7, 8) // field 7, fixed64
+
+	tests := []struct {
+		name    string
+		in      []byte
+		wantErr bool
+	}{
+		{name: "known fields", in: known.b},
+		{name: "unknown fields are skipped", in: unknown},
+		{name: "truncated string", in: []byte{0x1a, 0x05, 'r'}, wantErr: true},
+		{name: "field zero", in: []byte{0x00, 0x01}, wantErr: true},
+		{name: "bad varint", in: []byte{0x08, 0xff}, wantErr: true},
+		{name: "group wire type", in: []byte{0x0b}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				i int64
+				d float64
+				s string
+				b bool
+			)
+			err := decodeMessage(tt.in, func(r *protoReader, num, wire int) (err error) {
+				switch num {
+				case 1:
+					i, err = r.int64(wire)
+				case 2:
+					d, err = r.double(wire)
+				case 3:
+					s, err = r.string(wire)
+				case 4:
+					b, err = r.bool(wire)
+				default:
+					err = r.skip(wire)
+				}
+				return err
+			})
+			if tt.wantErr {
+				if !errors.Is(err, errBadProtobuf) {
+					t.Errorf("decodeMessage() error = %v, want %v", err, errBadProtobuf)
+				}
+				return
+			}
+			if err != nil || i != -7 || d != 0.5 || s != "rr" || !b {
+				t.Errorf("decodeMessage() = %d, %v, %q, %v, %v", i, d, s, b, err)
+			}
+		})
+	}
+}
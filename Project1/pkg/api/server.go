@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// MaxRequestBytes bounds the size of a request body the server reads.
+const MaxRequestBytes = 16 << 20
+
+// AlgorithmInfo describes one algorithm for GET /algorithms.
+type AlgorithmInfo struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// NewHandler returns the HTTP handler of the simulator's REST API:
+//
+//	GET  /            the dashboard, a single-page web UI over the endpoints below
+//	GET  /algorithms  lists the algorithms a request can name, as AlgorithmInfo
+//	POST /simulate    runs the Request in the body and answers with its Response
+//	GET  /events      a WebSocket that takes a StreamRequest and plays its events back as EventMessages
+//
+// Each simulation is stopped after timeout, if it is positive, and answers with what finished by then. A request the
+// simulator can't run is answered 400 Bad Request, or 413 if its body is over MaxRequestBytes, with the reason in the
+// Response's Error.
+func NewHandler(timeout time.Duration) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", serveDashboard)
+	mux.HandleFunc("/algorithms", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		var infos []AlgorithmInfo
+		for _, a := range Algorithms(1, 0).Algorithms() {
+			infos = append(infos, AlgorithmInfo{Name: a.Name(), Title: a.Title, Description: a.Description})
+		}
+		out, _ := json.Marshal(infos)
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("/simulate", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		ctx := r.Context()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		req, err := decodeRequest(http.MaxBytesReader(w, r.Body, MaxRequestBytes))
+		var resp Response
+		if err == nil {
+			resp, err = Run(ctx, req)
+		}
+		if err != nil {
+			writeJSON(w, statusOf(err), encodeResponse(errorResponse(err)))
+			return
+		}
+		writeJSON(w, http.StatusOK, encodeResponse(resp))
+	})
+
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgradeWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		streamEvents(r.Context(), c, timeout)
+	})
+
+	return mux
+}
+
+// statusOf returns the HTTP status of a request that failed with err.
+func statusOf(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrNondeterministic):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// allowMethod answers 405 Method Not Allowed and reports false unless r uses method.
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeJSON(w, http.StatusMethodNotAllowed,
+		encodeResponse(errorResponse(errors.New("method not allowed: use "+method))))
+
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHandler(t *testing.T) {
+	t.Parallel()
+	handler := NewHandler(time.Minute)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		want       string // part of the body
+	}{
+		{name: "algorithms", method: http.MethodGet, path: "/algorithms", wantStatus: http.StatusOK,
+			want: `{"name":"rr","title":"Round-robin"`},
+		{
+			name: "simulate", method: http.MethodPost, path: "/simulate",
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 2}], "algorithms": ["fcfs"]}`,
+			wantStatus: http.StatusOK, want: `"gantt":[{"pid":1,"start":0,"stop":2}]`,
+		},
+		{name: "bad request", method: http.MethodPost, path: "/simulate", body: `{"processes": []}`,
+			wantStatus: http.StatusBadRequest, want: `"error":"bad request: no processes"`},
+		{name: "too large", method: http.MethodPost, path: "/simulate",
+			body:       `{"processes": [` + strings.Repeat(`{"pid": 1, "burst": 1},`, MaxRequestBytes/20) + `]}`,
+			wantStatus: http.StatusRequestEntityTooLarge, want: `"error"`},
+		{name: "wrong method", method: http.MethodGet, path: "/simulate", wantStatus: http.StatusMethodNotAllowed,
+			want: "use POST"},
+		{name: "dashboard", method: http.MethodGet, path: "/", wantStatus: http.StatusOK, want: "<title>CPU scheduling simulator</title>"},
+		{name: "unknown path", method: http.MethodGet, path: "/nope", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			resp, body := rec.Result(), rec.Body.Bytes()
+			if resp.StatusCode != tt.wantStatus || !strings.Contains(string(body), tt.want) {
+				t.Errorf("%s %s = %d %s, want %d containing %s", tt.method, tt.path, resp.StatusCode, string(body),
+					tt.wantStatus, tt.want)
+			}
+			if resp.Header.Get("Content-Type") == "application/json" && !json.Valid(body) {
+				t.Errorf("%s %s answered with invalid JSON: %s", tt.method, tt.path, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// The server side of the WebSocket protocol (RFC 6455), as much of it as streaming events needs: the opening
+// handshake, unfragmented text messages, ping, and close.
+
+// ErrWebSocket marks a WebSocket handshake or frame that breaks the protocol.
+var ErrWebSocket = errors.New("websocket protocol error")
+
+// websocketGUID is appended to the client's key to compute the handshake's accept key.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Frame opcodes.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// Close status codes.
+const (
+	closeNormal      = 1000
+	closeProtocol    = 1002
+	closeUnsupported = 1003
+	closeTooBig      = 1009
+)
+
+// wsConn is a server-side WebSocket connection. Reading is for one goroutine; writing is safe from any.
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	mu   sync.Mutex // guards writes
+}
+
+// upgradeWebSocket answers the WebSocket opening handshake of r and takes over its connection. When r isn't a valid
+// handshake, it answers 400 Bad Request and returns an ErrWebSocket.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || !headerHas(r.Header, "Connection", "upgrade") ||
+		!headerHas(r.Header, "Upgrade", "websocket") || r.Header.Get("Sec-WebSocket-Version") != "13" || key == "" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "expected a WebSocket handshake", http.StatusBadRequest)
+		return nil, fmt.Errorf("%w: not a handshake", ErrWebSocket)
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection cannot be upgraded", http.StatusInternalServerError)
+		return nil, fmt.Errorf("%w: connection cannot be hijacked", ErrWebSocket)
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("%w: hijacking connection", err)
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%w: writing handshake", err)
+	}
+
+	return &wsConn{conn: conn, r: rw.Reader}, nil
+}
+
+// headerHas reports whether the comma-separated header key of h lists token, ignoring case.
+func headerHas(h http.Header, key, token string) bool {
+	for _, v := range h.Values(key) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// WriteText sends msg as a text message.
+func (c *wsConn) WriteText(msg []byte) error {
+	return c.writeFrame(opText, msg)
+}
+
+// writeFrame sends one unfragmented, unmasked frame, as a server must.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | opcode // FIN
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = byte(n)
+	case n <= 0xFFFF:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return fmt.Errorf("%w: writing WebSocket frame", err)
+	}
+
+	return nil
+}
+
+// ReadText returns the next text message, answering pings along the way. It returns io.EOF once the client closes
+// the connection, and an ErrWebSocket, after closing it, if the client breaks the protocol or sends a message of
+// more than limit bytes.
+func (c *wsConn) ReadText(limit int64) ([]byte, error) {
+	for {
+		opcode, payload, err := c.readFrame(limit)
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case opText:
+			return payload, nil
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			_ = c.Close(closeNormal)
+			return nil, io.EOF
+		default:
+			_ = c.Close(closeUnsupported)
+			return nil, fmt.Errorf("%w: unsupported opcode %#x", ErrWebSocket, opcode)
+		}
+	}
+}
+
+// readFrame reads one frame from the client and unmasks its payload.
+func (c *wsConn) readFrame(limit int64) (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		return 0, nil, fmt.Errorf("%w: reading WebSocket frame", err)
+	}
+	fin, opcode, masked := head[0]&0x80 != 0, head[0]&0x0F, head[1]&0x80 != 0
+	if !fin || opcode == opContinuation || !masked || head[0]&0x70 != 0 {
+		_ = c.Close(closeProtocol)
+		return 0, nil, fmt.Errorf("%w: fragmented, unmasked, or extended frame", ErrWebSocket)
+	}
+
+	length := int64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return 0, nil, fmt.Errorf("%w: reading WebSocket frame", err)
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return 0, nil, fmt.Errorf("%w: reading WebSocket frame", err)
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]) & (1<<63 - 1))
+	}
+	if length > limit {
+		_ = c.Close(closeTooBig)
+		return 0, nil, fmt.Errorf("%w: message of %d bytes is over the limit of %d", ErrWebSocket, length, limit)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+		return 0, nil, fmt.Errorf("%w: reading WebSocket frame", err)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return 0, nil, fmt.Errorf("%w: reading WebSocket frame", err)
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return opcode, payload, nil
+}
+
+// Close sends a close frame with code and closes the connection.
+func (c *wsConn) Close(code uint16) error {
+	_ = c.writeFrame(opClose, binary.BigEndian.AppendUint16(nil, code))
+	return c.conn.Close()
+}
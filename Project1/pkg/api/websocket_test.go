@@ -0,0 +1,177 @@
+package api
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// wsClient is the client side of a WebSocket connection, for tests.
+type wsClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// dialWebSocket opens a WebSocket connection to path on server.
+func dialWebSocket(t *testing.T, server *httptest.Server, path string) *wsClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_, _ = io.WriteString(conn, "GET "+path+" HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the accept key for this nonce given in RFC 6455
+	if resp.StatusCode != http.StatusSwitchingProtocols ||
+		resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("handshake answered %s %v", resp.Status, resp.Header)
+	}
+
+	return &wsClient{conn: conn, r: r}
+}
+
+// send sends a masked frame, as a client must.
+func (c *wsClient) send(t *testing.T, opcode byte, payload string) {
+	t.Helper()
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xFFFF:
+		frame = binary.BigEndian.AppendUint16(append(frame, 0x80|126), uint16(n))
+	default:
+		frame = binary.BigEndian.AppendUint64(append(frame, 0x80|127), uint64(n))
+	}
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for i := range payload {
+		frame = append(frame, payload[i]^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// receive reads the next frame from the server.
+func (c *wsClient) receive() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		return 0, nil, err
+	}
+	if head[1]&0x80 != 0 {
+		return 0, nil, errors.New("server frame is masked")
+	}
+	length := uint64(head[1])
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	payload := make([]byte, length)
+	_, err := io.ReadFull(c.r, payload)
+
+	return head[0] & 0x0F, payload, err
+}
+
+func echoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgradeWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		for {
+			msg, err := c.ReadText(1 << 20)
+			if err != nil {
+				return
+			}
+			_ = c.WriteText(msg)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWebSocket(t *testing.T) {
+	t.Parallel()
+	server := echoServer(t)
+	for _, size := range []int{0, 125, 126, 70000} {
+		c := dialWebSocket(t, server, "/")
+		msg := strings.Repeat("x", size)
+		c.send(t, opPing, "are you there")
+		c.send(t, opText, msg)
+
+		opcode, payload, err := c.receive()
+		if err != nil || opcode != opPong || string(payload) != "are you there" {
+			t.Fatalf("ping answered %#x %q %v, want a pong", opcode, payload, err)
+		}
+		opcode, payload, err = c.receive()
+		if err != nil || opcode != opText || string(payload) != msg {
+			t.Fatalf("%d-byte message echoed as %#x of %d bytes, %v", size, opcode, len(payload), err)
+		}
+		c.send(t, opClose, "")
+		if opcode, _, err := c.receive(); err != nil || opcode != opClose {
+			t.Errorf("close answered %#x %v, want a close", opcode, err)
+		}
+	}
+}
+
+func TestWebSocket_errors(t *testing.T) {
+	t.Parallel()
+	server := echoServer(t)
+	tests := []struct {
+		name  string
+		frame []byte
+		code  uint16
+	}{
+		{name: "unmasked", frame: []byte{0x81, 0x01, 'x'}, code: closeProtocol},
+		{name: "fragmented", frame: []byte{0x01, 0x81, 0, 0, 0, 0, 'x'}, code: closeProtocol},
+		{name: "binary", frame: []byte{0x82, 0x81, 0, 0, 0, 0, 'x'}, code: closeUnsupported},
+		{name: "too big", frame: []byte{0x81, 0xFF, 0, 0, 0, 0, 0, 0x20, 0, 0}, code: closeTooBig},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := dialWebSocket(t, server, "/")
+			if _, err := c.conn.Write(tt.frame); err != nil {
+				t.Fatal(err)
+			}
+			opcode, payload, err := c.receive()
+			if err != nil || opcode != opClose || len(payload) != 2 || binary.BigEndian.Uint16(payload) != tt.code {
+				t.Errorf("server answered %#x %v %v, want close %d", opcode, payload, err, tt.code)
+			}
+		})
+	}
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("plain GET answered %s, want 400", resp.Status)
+	}
+}
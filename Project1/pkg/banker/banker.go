@@ -0,0 +1,400 @@
+// Package banker simulates the banker's algorithm for deadlock avoidance: every process declares the most of each
+// resource it may ever hold, and a request is granted only if the state it leads to is safe, one in which every
+// process can still finish in some order however much of its claim it goes on to ask for. It checks the safety of a
+// state, or runs a sequence of requests against it, tracing the work and finish vectors of every check.
+package banker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrBadState marks a state or request that cannot be read, or that does not add up, such as a process holding more
+// than its maximum claim.
+var ErrBadState = errors.New("bad banker's state")
+
+// Vector is an amount of each resource, in the order of State.Resources.
+type Vector []int64
+
+// String writes v as its amounts separated by spaces, as in "3 3 2".
+func (v Vector) String() string {
+	s := make([]string, len(v))
+	for i, n := range v {
+		s[i] = strconv.FormatInt(n, 10)
+	}
+
+	return strings.Join(s, " ")
+}
+
+// fits reports whether v is no more than u of every resource.
+func (v Vector) fits(u Vector) bool {
+	for i := range v {
+		if v[i] > u[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// plus returns v with u added.
+func (v Vector) plus(u Vector) Vector {
+	sum := make(Vector, len(v))
+	for i := range v {
+		sum[i] = v[i] + u[i]
+	}
+
+	return sum
+}
+
+// minus returns v with u taken away.
+func (v Vector) minus(u Vector) Vector {
+	diff := make(Vector, len(v))
+	for i := range v {
+		diff[i] = v[i] - u[i]
+	}
+
+	return diff
+}
+
+// State is what the banker knows: the resources there are, what is still available of each, and for every process
+// what it holds and the most it may ever hold, its maximum claim.
+type State struct {
+	Resources  []string
+	Processes  []string
+	Available  Vector
+	Allocation []Vector
+	Max        []Vector
+}
+
+// Need returns what process i may still ask for, its maximum claim less what it holds.
+func (s State) Need(i int) Vector {
+	return s.Max[i].minus(s.Allocation[i])
+}
+
+// clone returns a copy of s that shares none of its vectors.
+func (s State) clone() State {
+	c := s
+	c.Available = append(Vector(nil), s.Available...)
+	c.Allocation = make([]Vector, len(s.Allocation))
+	for i, a := range s.Allocation {
+		c.Allocation[i] = append(Vector(nil), a...)
+	}
+
+	return c
+}
+
+// Validate reports whether s adds up: a vector of every resource for the available amounts and every process's
+// allocation and claim, none of them negative, and no process holding more than its claim.
+func (s State) Validate() error {
+	n := len(s.Resources)
+	if n == 0 {
+		return fmt.Errorf("%w: there are no resources", ErrBadState)
+	}
+	if len(s.Available) != n {
+		return fmt.Errorf("%w: available has %d amounts for %d resources", ErrBadState, len(s.Available), n)
+	}
+	if len(s.Allocation) != len(s.Processes) || len(s.Max) != len(s.Processes) {
+		return fmt.Errorf("%w: %d processes with %d allocations and %d maximum claims", ErrBadState,
+			len(s.Processes), len(s.Allocation), len(s.Max))
+	}
+	if negative(s.Available) {
+		return fmt.Errorf("%w: available %v is negative", ErrBadState, s.Available)
+	}
+	for i, name := range s.Processes {
+		switch {
+		case len(s.Allocation[i]) != n || len(s.Max[i]) != n:
+			return fmt.Errorf("%w: %s needs an allocation and maximum claim of %d resources", ErrBadState, name, n)
+		case negative(s.Allocation[i]) || negative(s.Max[i]):
+			return fmt.Errorf("%w: %s has a negative allocation or maximum claim", ErrBadState, name)
+		case !s.Allocation[i].fits(s.Max[i]):
+			return fmt.Errorf("%w: %s holds %v, more than its maximum claim %v", ErrBadState, name, s.Allocation[i],
+				s.Max[i])
+		}
+	}
+
+	return nil
+}
+
+func negative(v Vector) bool {
+	for _, n := range v {
+		if n < 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// process returns the index of the process named name, or -1.
+func (s State) process(name string) int {
+	for i, p := range s.Processes {
+		if p == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Step is one step of a safety check: process Process could finish with what it needs no more than Work, after which
+// it gives back its allocation, leaving Work plus that, and Finish marks the processes that could finish so far.
+type Step struct {
+	Process int
+	Need    Vector
+	Work    Vector
+	After   Vector
+	Finish  []bool
+}
+
+// Safety is the outcome of a safety check: whether the state is safe, the steps taken, the order the processes could
+// finish in, and if unsafe, those that never could.
+type Safety struct {
+	Safe     bool
+	Steps    []Step
+	Sequence []int
+	Stuck    []int
+}
+
+// Check runs the safety algorithm over s: starting with Work as what is available, it passes over the processes in
+// order, again and again, finishing any not yet finished whose need is no more than Work and adding its allocation
+// back to Work, until all have finished, and s is safe, or a whole pass finishes none, and it is not.
+func (s State) Check() Safety {
+	work := append(Vector(nil), s.Available...)
+	finish := make([]bool, len(s.Processes))
+	var safety Safety
+	for progress := true; progress && len(safety.Sequence) < len(s.Processes); {
+		progress = false
+		for i := range s.Processes {
+			if finish[i] || !s.Need(i).fits(work) {
+				continue
+			}
+			after := work.plus(s.Allocation[i])
+			finish[i] = true
+			safety.Steps = append(safety.Steps, Step{Process: i, Need: s.Need(i), Work: work, After: after,
+				Finish: append([]bool(nil), finish...)})
+			safety.Sequence = append(safety.Sequence, i)
+			work, progress = after, true
+		}
+	}
+	for i, done := range finish {
+		if !done {
+			safety.Stuck = append(safety.Stuck, i)
+		}
+	}
+	safety.Safe = len(safety.Stuck) == 0
+
+	return safety
+}
+
+// Request is a process asking for Amount more of each resource.
+type Request struct {
+	Process string
+	Amount  Vector
+}
+
+// String writes r as it is read, as in "P1 1 0 2".
+func (r Request) String() string {
+	return r.Process + " " + r.Amount.String()
+}
+
+// Outcome is what the banker does with a request.
+type Outcome int
+
+const (
+	// Granted requests leave the state safe, and are made.
+	Granted Outcome = iota
+	// OverClaim requests ask for more than the process's maximum claim lets it, an error of the process.
+	OverClaim
+	// MustWait requests ask for more than is available, so the process waits.
+	MustWait
+	// Unsafe requests would leave the state unsafe, so the process waits though the resources are there.
+	Unsafe
+)
+
+// String describes o for reports.
+func (o Outcome) String() string {
+	switch o {
+	case Granted:
+		return "granted"
+	case OverClaim:
+		return "denied: exceeds its maximum claim"
+	case MustWait:
+		return "denied: must wait, not enough available"
+	case Unsafe:
+		return "denied: would leave the state unsafe"
+	}
+
+	return fmt.Sprintf("Outcome(%d)", int(o))
+}
+
+// Decision is the banker's answer to one request, and for one that fit what is available, the safety check of the
+// state granting it would lead to.
+type Decision struct {
+	Request Request
+	Outcome Outcome
+	Safety  *Safety
+}
+
+// Decide answers every one of requests in order against s, making those granted, so that each is decided against
+// the state the ones before it left. It returns the decisions and the state after the last.
+func Decide(s State, requests []Request) ([]Decision, State, error) {
+	s = s.clone()
+	decisions := make([]Decision, 0, len(requests))
+	for _, r := range requests {
+		i := s.process(r.Process)
+		if i < 0 {
+			return nil, s, fmt.Errorf("%w: request %q: there is no process %s", ErrBadState, r, r.Process)
+		}
+		if len(r.Amount) != len(s.Resources) || negative(r.Amount) {
+			return nil, s, fmt.Errorf("%w: request %q needs an amount of each of %d resources", ErrBadState, r,
+				len(s.Resources))
+		}
+		d := Decision{Request: r}
+		switch {
+		case !r.Amount.fits(s.Need(i)):
+			d.Outcome = OverClaim
+		case !r.Amount.fits(s.Available):
+			d.Outcome = MustWait
+		default:
+			// pretend to grant it, and keep it only if the state is safe
+			next := s.clone()
+			next.Available = next.Available.minus(r.Amount)
+			next.Allocation[i] = next.Allocation[i].plus(r.Amount)
+			safety := next.Check()
+			d.Safety = &safety
+			if d.Outcome = Unsafe; safety.Safe {
+				d.Outcome, s = Granted, next
+			}
+		}
+		decisions = append(decisions, d)
+	}
+
+	return decisions, s, nil
+}
+
+// Parse reads a banker's state and any requests to run against it, one entry per line or per semicolon-separated
+// entry:
+//
+//	resources A B C            the names of the resources, if not A, B, C, and so on
+//	available 3 3 2            what is available of each resource
+//	process P0 0 1 0 / 7 5 3   a process, what it holds, and its maximum claim
+//	request P1 1 0 2           a request, decided in the order given
+//
+// Blank lines and anything after a # are ignored. The state is checked with Validate.
+func Parse(r io.Reader) (State, []Request, error) {
+	var (
+		s        State
+		requests []Request
+	)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, entry := range strings.Split(text, ";") {
+			fields := strings.Fields(entry)
+			if len(fields) == 0 {
+				continue
+			}
+			if err := parseEntry(&s, &requests, fields); err != nil {
+				return State{}, nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return State{}, nil, fmt.Errorf("%w: reading banker's state", err)
+	}
+	if s.Resources == nil {
+		for i := range s.Available {
+			s.Resources = append(s.Resources, resourceName(i))
+		}
+	}
+	if err := s.Validate(); err != nil {
+		return State{}, nil, err
+	}
+
+	return s, requests, nil
+}
+
+// resourceName returns the default name of resource i: A to Z, then R27 and on.
+func resourceName(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+
+	return "R" + strconv.Itoa(i+1)
+}
+
+// parseEntry parses the fields of one entry into s or requests.
+func parseEntry(s *State, requests *[]Request, fields []string) error {
+	switch fields[0] {
+	case "resources":
+		if s.Resources != nil || len(fields) < 2 {
+			return fmt.Errorf("%w: want one \"resources NAME...\"", ErrBadState)
+		}
+		s.Resources = fields[1:]
+	case "available":
+		v, err := parseVector(fields[1:])
+		if err != nil || s.Available != nil {
+			return fmt.Errorf("%w: want one \"available AMOUNT...\"", ErrBadState)
+		}
+		s.Available = v
+	case "process":
+		holds, claims, ok := cut(fields[1:], "/")
+		if !ok || len(holds) < 2 || s.process(holds[0]) >= 0 {
+			return fmt.Errorf("%w: want \"process NAME ALLOCATION... / MAX...\" with a new name, got %q", ErrBadState,
+				strings.Join(fields, " "))
+		}
+		allocation, err := parseVector(holds[1:])
+		if err != nil {
+			return err
+		}
+		claim, err := parseVector(claims)
+		if err != nil {
+			return err
+		}
+		s.Processes = append(s.Processes, holds[0])
+		s.Allocation, s.Max = append(s.Allocation, allocation), append(s.Max, claim)
+	case "request":
+		if len(fields) < 3 {
+			return fmt.Errorf("%w: want \"request NAME AMOUNT...\", got %q", ErrBadState, strings.Join(fields, " "))
+		}
+		amount, err := parseVector(fields[2:])
+		if err != nil {
+			return err
+		}
+		*requests = append(*requests, Request{Process: fields[1], Amount: amount})
+	default:
+		return fmt.Errorf("%w: %q is not resources, available, process, or request", ErrBadState, fields[0])
+	}
+
+	return nil
+}
+
+// cut splits fields around the first sep.
+func cut(fields []string, sep string) (before, after []string, found bool) {
+	for i, f := range fields {
+		if f == sep {
+			return fields[:i], fields[i+1:], true
+		}
+	}
+
+	return fields, nil, false
+}
+
+// parseVector parses an amount of each resource.
+func parseVector(fields []string) (Vector, error) {
+	v := make(Vector, len(fields))
+	for i, f := range fields {
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: %q is not an amount", ErrBadState, f)
+		}
+		v[i] = n
+	}
+
+	return v, nil
+}
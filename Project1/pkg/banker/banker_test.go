@@ -0,0 +1,140 @@
+package banker
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// textbook is the banker's example of Silberschatz, Galvin, and Gagne: five processes sharing three resources.
+const textbook = `
+available 3 3 2
+process P0 0 1 0 / 7 5 3
+process P1 2 0 0 / 3 2 2
+process P2 3 0 2 / 9 0 2
+process P3 2 1 1 / 2 2 2
+process P4 0 0 2 / 4 3 3
+`
+
+func mustParse(t *testing.T, in string) (State, []Request) {
+	t.Helper()
+	s, requests, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, requests
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	s, requests := mustParse(t, "resources tape disk; available 1 2 # spare\nprocess X 1 0 / 2 2; request X 1 0")
+	want := State{Resources: []string{"tape", "disk"}, Processes: []string{"X"}, Available: Vector{1, 2},
+		Allocation: []Vector{{1, 0}}, Max: []Vector{{2, 2}}}
+	if !reflect.DeepEqual(s, want) || !reflect.DeepEqual(requests, []Request{{Process: "X", Amount: Vector{1, 0}}}) {
+		t.Errorf("Parse() = %+v, %v", s, requests)
+	}
+	if s, _ := mustParse(t, textbook); !reflect.DeepEqual(s.Resources, []string{"A", "B", "C"}) {
+		t.Errorf("default resources = %v, want A B C", s.Resources)
+	}
+
+	for _, in := range []string{
+		"available 3 3 2; process P0 0 1 0 7 5 3",
+		"available 3 3 2; process P0 0 1 0 / 7 5",
+		"available 3 3 2; process P0 4 1 0 / 3 5 3",
+		"available 3 3 2; process P0 0 1 0 / 7 5 3; process P0 0 1 0 / 7 5 3",
+		"available 3 x 2",
+		"resources A B; available 3 3 2",
+		"allocate P0 1",
+		"",
+	} {
+		if _, _, err := Parse(strings.NewReader(in)); !errors.Is(err, ErrBadState) {
+			t.Errorf("Parse(%q) error = %v, want %v", in, err, ErrBadState)
+		}
+	}
+}
+
+func TestState_Check(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		in       string
+		safe     bool
+		sequence []int
+		stuck    []int
+	}{
+		{name: "textbook", in: textbook, safe: true, sequence: []int{1, 3, 4, 0, 2}},
+		{
+			name:     "unsafe",
+			in:       "available 1; process P0 1 / 4; process P1 1 / 2; process P2 1 / 5",
+			sequence: []int{1},
+			stuck:    []int{0, 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, _ := mustParse(t, tt.in)
+			got := s.Check()
+			if got.Safe != tt.safe || !reflect.DeepEqual(got.Sequence, tt.sequence) ||
+				!reflect.DeepEqual(got.Stuck, tt.stuck) {
+				t.Errorf("Check() = safe %v, sequence %v, stuck %v, want %v, %v, %v", got.Safe, got.Sequence, got.Stuck,
+					tt.safe, tt.sequence, tt.stuck)
+			}
+			if len(got.Steps) != len(got.Sequence) {
+				t.Fatalf("Check() took %d steps for %d finished", len(got.Steps), len(got.Sequence))
+			}
+		})
+	}
+
+	s, _ := mustParse(t, textbook)
+	first := s.Check().Steps[0]
+	want := Step{Process: 1, Need: Vector{1, 2, 2}, Work: Vector{3, 3, 2}, After: Vector{5, 3, 2},
+		Finish: []bool{false, true, false, false, false}}
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("first step = %+v, want %+v", first, want)
+	}
+}
+
+func TestDecide(t *testing.T) {
+	t.Parallel()
+	s, _ := mustParse(t, textbook)
+	requests := []Request{
+		{Process: "P1", Amount: Vector{1, 0, 2}},
+		{Process: "P4", Amount: Vector{3, 3, 0}},
+		{Process: "P0", Amount: Vector{0, 2, 0}},
+		{Process: "P3", Amount: Vector{1, 1, 1}},
+	}
+	decisions, after, err := Decide(s, requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outcomes []Outcome
+	for _, d := range decisions {
+		outcomes = append(outcomes, d.Outcome)
+	}
+	if want := []Outcome{Granted, MustWait, Unsafe, OverClaim}; !reflect.DeepEqual(outcomes, want) {
+		t.Errorf("outcomes = %v, want %v", outcomes, want)
+	}
+	if decisions[0].Safety == nil || !reflect.DeepEqual(decisions[0].Safety.Sequence, []int{1, 3, 4, 0, 2}) {
+		t.Errorf("granting P1 checked %+v", decisions[0].Safety)
+	}
+	if decisions[1].Safety != nil {
+		t.Error("a request that must wait was checked for safety")
+	}
+	// only the granted request is made, and s itself is left alone
+	if !reflect.DeepEqual(after.Available, Vector{2, 3, 0}) || !reflect.DeepEqual(after.Allocation[1], Vector{3, 0, 2}) {
+		t.Errorf("after = %+v", after)
+	}
+	if !reflect.DeepEqual(s.Available, Vector{3, 3, 2}) {
+		t.Errorf("Decide changed the state it was given: %+v", s)
+	}
+
+	for _, r := range []Request{{Process: "P9", Amount: Vector{1, 0, 0}}, {Process: "P1", Amount: Vector{1}}} {
+		if _, _, err := Decide(s, []Request{r}); !errors.Is(err, ErrBadState) {
+			t.Errorf("Decide(%v) error = %v, want %v", r, err, ErrBadState)
+		}
+	}
+}
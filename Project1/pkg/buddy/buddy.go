@@ -0,0 +1,246 @@
+// Package buddy simulates the buddy-system memory allocator: memory of a power-of-two size is handed out in
+// power-of-two blocks, split in halves until a block just fits a request and merged with its buddy, the other half
+// of the block it was split from, as soon as both are free again. It reads a trace of allocations and frees and
+// records the memory map after every one.
+package buddy
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Op is the kind of a Request.
+type Op int
+
+const (
+	Alloc Op = iota
+	Free
+)
+
+// Request is one line of a trace: an allocation of Size units named Name, or the free of the allocation named Name.
+type Request struct {
+	Op   Op
+	Name string
+	Size int64
+}
+
+// String formats r as a trace line.
+func (r Request) String() string {
+	if r.Op == Free {
+		return "free " + r.Name
+	}
+
+	return fmt.Sprintf("alloc %s %d", r.Name, r.Size)
+}
+
+var (
+	// ErrBadTrace marks a trace line that is not an allocation or a free.
+	ErrBadTrace = errors.New("bad trace")
+	// ErrBadMemory marks memory or block sizes that are not powers of two, or a smallest block larger than memory.
+	ErrBadMemory = errors.New("bad memory size")
+)
+
+// ParseTrace reads a trace of one request per line or per semicolon-separated entry: "alloc NAME SIZE" allocates
+// SIZE units under NAME, and "free NAME" frees them. Blank lines and anything after a # are ignored.
+func ParseTrace(r io.Reader) ([]Request, error) {
+	var requests []Request
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, entry := range strings.Split(text, ";") {
+			fields := strings.Fields(entry)
+			switch {
+			case len(fields) == 0:
+				continue
+			case len(fields) == 3 && fields[0] == "alloc":
+				size, err := strconv.ParseInt(fields[2], 10, 64)
+				if err != nil || size < 1 {
+					return nil, fmt.Errorf("%w: line %d: %q is not a positive size", ErrBadTrace, line, fields[2])
+				}
+				requests = append(requests, Request{Op: Alloc, Name: fields[1], Size: size})
+			case len(fields) == 2 && fields[0] == "free":
+				requests = append(requests, Request{Op: Free, Name: fields[1]})
+			default:
+				return nil, fmt.Errorf("%w: line %d: want \"alloc NAME SIZE\" or \"free NAME\", got %q", ErrBadTrace,
+					line, strings.TrimSpace(entry))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading trace", err)
+	}
+
+	return requests, nil
+}
+
+// Block is a block of memory: free, or allocated to Name, which asked for Requested of its Size units.
+type Block struct {
+	Offset    int64
+	Size      int64
+	Name      string
+	Requested int64
+}
+
+// Free reports whether b is free.
+func (b Block) Free() bool {
+	return b.Name == ""
+}
+
+// Step is what one request did: the block it allocated or freed, how many times a block was split in half to make
+// it or merged with its buddy after it, and the memory map afterwards, in address order. Failed is the reason a
+// request could not be met, if it couldn't.
+type Step struct {
+	Request Request
+	Block   Block
+	Splits  int
+	Merges  int
+	Failed  string
+	Map     []Block
+	// Internal is the internal fragmentation afterwards: the units allocated beyond what was requested.
+	Internal int64
+}
+
+// Result is a run of the allocator over a trace.
+type Result struct {
+	Memory   int64
+	MinBlock int64
+	Steps    []Step
+}
+
+// PeakInternal returns the most internal fragmentation at any step.
+func (r Result) PeakInternal() int64 {
+	var peak int64
+	for _, s := range r.Steps {
+		peak = max(peak, s.Internal)
+	}
+
+	return peak
+}
+
+// Failures returns the number of requests that could not be met.
+func (r Result) Failures() int {
+	failed := 0
+	for _, s := range r.Steps {
+		if s.Failed != "" {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// isPowerOfTwo reports whether n is a positive power of two.
+func isPowerOfTwo(n int64) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// blockSize returns the smallest power of two at least size and minBlock.
+func blockSize(size, minBlock int64) int64 {
+	if size <= minBlock {
+		return minBlock
+	}
+
+	return 1 << bits.Len64(uint64(size-1))
+}
+
+// Simulate runs requests through a buddy allocator of memory units whose smallest block is minBlock units. Both
+// must be powers of two. An allocation that doesn't fit, a name allocated twice, or a free of a name not allocated
+// fails that step alone and the trace carries on.
+func Simulate(requests []Request, memory, minBlock int64) (Result, error) {
+	if !isPowerOfTwo(memory) || !isPowerOfTwo(minBlock) || minBlock > memory {
+		return Result{}, fmt.Errorf("%w: memory %d and smallest block %d must be powers of two, the block no larger",
+			ErrBadMemory, memory, minBlock)
+	}
+	var (
+		r      = Result{Memory: memory, MinBlock: minBlock, Steps: make([]Step, 0, len(requests))}
+		blocks = []Block{{Size: memory}} // the memory map, in address order
+	)
+	for _, req := range requests {
+		step := Step{Request: req}
+		switch req.Op {
+		case Alloc:
+			step.Block, step.Splits, step.Failed = allocate(&blocks, req, minBlock)
+		case Free:
+			step.Block, step.Merges, step.Failed = free(&blocks, req.Name)
+		}
+		step.Map = append([]Block(nil), blocks...)
+		for _, b := range blocks {
+			if !b.Free() {
+				step.Internal += b.Size - b.Requested
+			}
+		}
+		r.Steps = append(r.Steps, step)
+	}
+
+	return r, nil
+}
+
+// allocate takes the smallest free block of blocks that fits req, the lowest addressed of them, splitting it in half
+// until it is the block size req needs.
+func allocate(blocks *[]Block, req Request, minBlock int64) (Block, int, string) {
+	for _, b := range *blocks {
+		if b.Name == req.Name {
+			return Block{}, 0, fmt.Sprintf("%s is already allocated", req.Name)
+		}
+	}
+	size := blockSize(req.Size, minBlock)
+	best := -1
+	for i, b := range *blocks {
+		if b.Free() && b.Size >= size && (best < 0 || b.Size < (*blocks)[best].Size) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Block{}, 0, fmt.Sprintf("no free block of %d units", size)
+	}
+	splits := 0
+	for (*blocks)[best].Size > size {
+		half := (*blocks)[best].Size / 2
+		(*blocks)[best].Size = half
+		upper := Block{Offset: (*blocks)[best].Offset + half, Size: half}
+		*blocks = append((*blocks)[:best+1], append([]Block{upper}, (*blocks)[best+1:]...)...)
+		splits++
+	}
+	(*blocks)[best].Name, (*blocks)[best].Requested = req.Name, req.Size
+
+	return (*blocks)[best], splits, ""
+}
+
+// free releases the block allocated to name and merges it with its buddy for as long as the buddy is free and whole.
+func free(blocks *[]Block, name string) (Block, int, string) {
+	i := -1
+	for j, b := range *blocks {
+		if b.Name == name {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return Block{}, 0, fmt.Sprintf("%s is not allocated", name)
+	}
+	freed := (*blocks)[i]
+	(*blocks)[i].Name, (*blocks)[i].Requested = "", 0
+	merges := 0
+	for {
+		b := (*blocks)[i]
+		buddyOffset := b.Offset ^ b.Size
+		j := sort.Search(len(*blocks), func(k int) bool { return (*blocks)[k].Offset >= buddyOffset })
+		if j == len(*blocks) || (*blocks)[j].Offset != buddyOffset || (*blocks)[j].Size != b.Size ||
+			!(*blocks)[j].Free() {
+			break
+		}
+		lower := min(i, j)
+		(*blocks)[lower] = Block{Offset: min(b.Offset, buddyOffset), Size: 2 * b.Size}
+		*blocks = append((*blocks)[:lower+1], (*blocks)[lower+2:]...)
+		i = lower
+		merges++
+	}
+
+	return freed, merges, ""
+}
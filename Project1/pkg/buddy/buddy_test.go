@@ -0,0 +1,112 @@
+package buddy
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// wikipedia is the example trace of the Wikipedia article on the buddy memory allocation, in 1 KiB units.
+const wikipedia = `alloc A 34
+alloc B 66
+alloc C 35
+alloc D 67
+free C; free A
+free B # leaves D alone in the second quarter
+free D`
+
+func TestParseTrace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    []Request
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "alloc A 10; free A\n\n# done", want: []Request{{Op: Alloc, Name: "A", Size: 10}, {Op: Free, Name: "A"}}},
+		{in: "alloc A", wantErr: true},
+		{in: "alloc A 0", wantErr: true},
+		{in: "release A", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTrace(strings.NewReader(tt.in))
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadTrace) {
+					t.Errorf("ParseTrace(%q) error = %v, want %v", tt.in, err, ErrBadTrace)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTrace(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+	requests, err := ParseTrace(strings.NewReader(wikipedia))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Simulate(requests, 1024, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		offset, size   int64
+		splits, merges int
+		internal       int64
+	}{
+		{offset: 0, size: 64, splits: 4, internal: 30},
+		{offset: 128, size: 128, internal: 92},
+		{offset: 64, size: 64, internal: 121},
+		{offset: 256, size: 128, splits: 1, internal: 182},
+		{offset: 64, size: 64, internal: 153},
+		{offset: 0, size: 64, merges: 1, internal: 123},
+		{offset: 128, size: 128, merges: 1, internal: 61},
+		{offset: 256, size: 128, merges: 3, internal: 0},
+	}
+	for i, s := range r.Steps {
+		if s.Block.Offset != want[i].offset || s.Block.Size != want[i].size || s.Splits != want[i].splits ||
+			s.Merges != want[i].merges || s.Internal != want[i].internal || s.Failed != "" {
+			t.Errorf("step %d (%v) = %+v, want %+v", i, s.Request, s, want[i])
+		}
+	}
+	if got := r.Steps[3].Map; len(got) != 6 || got[5].Offset != 512 || !got[5].Free() {
+		t.Errorf("map after D = %+v, want six blocks ending with the free upper half", got)
+	}
+	if last := r.Steps[len(r.Steps)-1].Map; !reflect.DeepEqual(last, []Block{{Size: 1024}}) {
+		t.Errorf("map at the end = %+v, want all memory merged back into one block", last)
+	}
+	if r.PeakInternal() != 182 || r.Failures() != 0 {
+		t.Errorf("PeakInternal() = %d, Failures() = %d, want 182 and 0", r.PeakInternal(), r.Failures())
+	}
+}
+
+func TestSimulate_failures(t *testing.T) {
+	t.Parallel()
+	requests, _ := ParseTrace(strings.NewReader("alloc A 600; alloc B 600; alloc A 10; free C; free A"))
+	r, err := Simulate(requests, 1024, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failed []string
+	for _, s := range r.Steps {
+		failed = append(failed, s.Failed)
+	}
+	want := []string{"", "no free block of 1024 units", "A is already allocated", "C is not allocated", ""}
+	if !reflect.DeepEqual(failed, want) || r.Failures() != 3 {
+		t.Errorf("failures = %q, want %q", failed, want)
+	}
+
+	for _, sizes := range [][2]int64{{1000, 16}, {1024, 24}, {64, 128}} {
+		if _, err := Simulate(nil, sizes[0], sizes[1]); !errors.Is(err, ErrBadMemory) {
+			t.Errorf("Simulate(%d, %d) error = %v, want %v", sizes[0], sizes[1], err, ErrBadMemory)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package buddy
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// Allocated returns the units of the blocks allocated after s, including their internal fragmentation.
+func (s Step) Allocated() int64 {
+	var units int64
+	for _, b := range s.Map {
+		if !b.Free() {
+			units += b.Size
+		}
+	}
+
+	return units
+}
+
+// MapLine draws the memory map m of memory units as one line about width characters wide, each block between bars
+// in proportion to its size but at least one character: an allocated block as its name padded with #, a free one as
+// dots.
+func MapLine(m []Block, memory int64, width int) string {
+	var b strings.Builder
+	b.WriteByte('|')
+	for _, block := range m {
+		n := max(int(block.Size*int64(width)/memory), 1)
+		cell := strings.Repeat(".", n)
+		if !block.Free() {
+			cell = (block.Name + strings.Repeat("#", n))[:n]
+		}
+		b.WriteString(cell)
+		b.WriteByte('|')
+	}
+
+	return b.String()
+}
+
+// WriteMapSVG draws the memory map after every step of r as one row of a chart, addresses running left to right and
+// time top to bottom, allocated blocks shaded and labelled with their names.
+func WriteMapSVG(w io.Writer, r Result) error {
+	const (
+		labelWidth = 160
+		mapWidth   = 640
+		rowHeight  = 24
+		margin     = 20
+	)
+	width := margin*2 + labelWidth + mapWidth
+	height := margin*3 + rowHeight*len(r.Steps)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">Buddy allocator memory map, %d units</text>`+"\n",
+		width/2, margin, r.Memory)
+	for i, s := range r.Steps {
+		y := margin*2 + i*rowHeight
+		label := s.Request.String()
+		if s.Failed != "" {
+			label += " (failed)"
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", margin, y+rowHeight*2/3, html.EscapeString(label))
+		for _, block := range s.Map {
+			x := margin + labelWidth + int(block.Offset*mapWidth/r.Memory)
+			bw := max(int(block.Size*mapWidth/r.Memory), 1)
+			fill := "white"
+			if !block.Free() {
+				fill = "steelblue"
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="black"/>`+"\n",
+				x, y, bw, rowHeight-4, fill)
+			if !block.Free() {
+				fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" fill="white">%s</text>`+"\n",
+					x+bw/2, y+rowHeight*2/3, html.EscapeString(block.Name))
+			}
+		}
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
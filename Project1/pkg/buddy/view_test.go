@@ -0,0 +1,54 @@
+package buddy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMapLine(t *testing.T) {
+	t.Parallel()
+	m := []Block{
+		{Offset: 0, Size: 64, Name: "A", Requested: 34},
+		{Offset: 64, Size: 64},
+		{Offset: 128, Size: 128, Name: "Bee", Requested: 66},
+		{Offset: 256, Size: 768},
+	}
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{width: 64, want: "|A###|....|Bee#####|................................................|"},
+		// blocks too small for a character still get one
+		{width: 8, want: "|A|.|B|......|"},
+	}
+	for _, tt := range tests {
+		if got := MapLine(m, 1024, tt.width); got != tt.want {
+			t.Errorf("MapLine(%d) = %q, want %q", tt.width, got, tt.want)
+		}
+	}
+	if got := (Step{Map: m}).Allocated(); got != 192 {
+		t.Errorf("Allocated() = %d, want 192", got)
+	}
+}
+
+func TestWriteMapSVG(t *testing.T) {
+	t.Parallel()
+	requests, _ := ParseTrace(strings.NewReader("alloc A<1> 100; alloc B 5000; free A<1>"))
+	r, _ := Simulate(requests, 1024, 16)
+	var w bytes.Buffer
+	if err := WriteMapSVG(&w, r); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<svg ",
+		">alloc A&lt;1&gt; 100</text>",
+		">alloc B 5000 (failed)</text>",
+		`<rect x="180" y="40" width="80" height="20" fill="steelblue" stroke="black"/>`,
+		"</svg>",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("WriteMapSVG() missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// Package deadlock detects deadlock in a resource-allocation graph: processes request resources, which are assigned
+// to them and released again, and a set of processes each waiting for a resource only the others hold is deadlocked.
+// It replays a log of those events, keeping the graph up to date, and after each finds the deadlocked processes, the
+// resources they are stuck over, a cycle of the graph through them, and the process to abort to recover.
+package deadlock
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrBadEvent marks an event that cannot be read, or that the graph cannot take, such as releasing a resource the
+// process does not hold.
+var ErrBadEvent = errors.New("bad event")
+
+// Kind is what happens in an event.
+type Kind int
+
+const (
+	// Request adds a request edge from a process to a resource it waits for.
+	Request Kind = iota
+	// Assign gives a process an instance of a resource, turning its request, if it made one, into an assignment edge.
+	Assign
+	// Release gives back an instance of a resource the process holds.
+	Release
+)
+
+var kinds = []string{"request", "assign", "release"}
+
+// String returns the name k is written with.
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kinds) {
+		return kinds[k]
+	}
+
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Event is a process requesting, being assigned, or releasing one instance of a resource at a time.
+type Event struct {
+	Time     int64
+	Kind     Kind
+	Process  string
+	Resource string
+}
+
+// String writes e as it is read, without its time, as in "request P1 R1".
+func (e Event) String() string {
+	return fmt.Sprintf("%s %s %s", e.Kind, e.Process, e.Resource)
+}
+
+// Graph is a resource-allocation graph: the instances of every resource, and the instances each process holds, its
+// assignment edges, and waits for, its request edges. Processes and resources are kept in the order they first
+// appeared, which is the order reports list them in.
+type Graph struct {
+	Resources []string
+	Instances map[string]int
+	Processes []string
+	Held      map[string]map[string]int
+	Waiting   map[string]map[string]int
+}
+
+// NewGraph returns a graph of the resources with the given instances, in order, and no processes.
+func NewGraph(resources []string, instances map[string]int) *Graph {
+	g := &Graph{Instances: make(map[string]int), Held: make(map[string]map[string]int),
+		Waiting: make(map[string]map[string]int)}
+	for _, r := range resources {
+		g.addResource(r, instances[r])
+	}
+
+	return g
+}
+
+func (g *Graph) addResource(r string, instances int) {
+	if _, ok := g.Instances[r]; !ok {
+		g.Resources = append(g.Resources, r)
+		g.Instances[r] = max(instances, 1)
+	}
+}
+
+func (g *Graph) addProcess(p string) {
+	if _, ok := g.Held[p]; !ok {
+		g.Processes = append(g.Processes, p)
+		g.Held[p], g.Waiting[p] = make(map[string]int), make(map[string]int)
+	}
+}
+
+// Free returns the instances of r no process holds.
+func (g *Graph) Free(r string) int {
+	free := g.Instances[r]
+	for _, p := range g.Processes {
+		free -= g.Held[p][r]
+	}
+
+	return free
+}
+
+// Apply changes g by e. A resource seen for the first time has one instance.
+func (g *Graph) Apply(e Event) error {
+	g.addResource(e.Resource, 1)
+	g.addProcess(e.Process)
+	switch e.Kind {
+	case Request:
+		g.Waiting[e.Process][e.Resource]++
+	case Assign:
+		if g.Free(e.Resource) < 1 {
+			return fmt.Errorf("%w: %s: %s has no free instance", ErrBadEvent, e, e.Resource)
+		}
+		if g.Waiting[e.Process][e.Resource] > 0 {
+			g.Waiting[e.Process][e.Resource]--
+		}
+		g.Held[e.Process][e.Resource]++
+	case Release:
+		if g.Held[e.Process][e.Resource] < 1 {
+			return fmt.Errorf("%w: %s: %s does not hold %s", ErrBadEvent, e, e.Process, e.Resource)
+		}
+		g.Held[e.Process][e.Resource]--
+	default:
+		return fmt.Errorf("%w: %s", ErrBadEvent, e)
+	}
+
+	return nil
+}
+
+// without returns a copy of g with process p aborted: everything it holds released and its requests withdrawn.
+func (g *Graph) without(p string) *Graph {
+	c := NewGraph(g.Resources, g.Instances)
+	for _, q := range g.Processes {
+		c.addProcess(q)
+		if q == p {
+			continue
+		}
+		for r, n := range g.Held[q] {
+			c.Held[q][r] = n
+		}
+		for r, n := range g.Waiting[q] {
+			c.Waiting[q][r] = n
+		}
+	}
+
+	return c
+}
+
+// Deadlock is the processes of a graph deadlocked, none of which can ever get what it waits for, the resources they
+// wait for, and a cycle of the graph through them, written process, resource, process, and so on back to the start.
+type Deadlock struct {
+	Processes []string
+	Resources []string
+	Cycle     []string
+}
+
+// Detect finds the deadlocked processes of g by reducing the graph: any process whose requests could all be granted
+// from the free instances is taken to finish and release what it holds, until no more can. Those left are
+// deadlocked. With one instance of every resource they are exactly the processes on cycles of the graph; with more,
+// a cycle is needed for deadlock but not enough.
+func (g *Graph) Detect() Deadlock {
+	free := make(map[string]int, len(g.Resources))
+	for _, r := range g.Resources {
+		free[r] = g.Free(r)
+	}
+	finished := make(map[string]bool, len(g.Processes))
+	for progress := true; progress; {
+		progress = false
+		for _, p := range g.Processes {
+			if finished[p] || !g.satisfiable(p, free) {
+				continue
+			}
+			for r, n := range g.Held[p] {
+				free[r] += n
+			}
+			finished[p], progress = true, true
+		}
+	}
+
+	var d Deadlock
+	stuck := make(map[string]bool)
+	for _, p := range g.Processes {
+		if !finished[p] {
+			d.Processes = append(d.Processes, p)
+			for r, n := range g.Waiting[p] {
+				if n > 0 {
+					stuck[r] = true
+				}
+			}
+		}
+	}
+	for _, r := range g.Resources {
+		if stuck[r] {
+			d.Resources = append(d.Resources, r)
+		}
+	}
+	d.Cycle = g.cycle(d.Processes)
+
+	return d
+}
+
+// satisfiable reports whether every request of p could be granted from free.
+func (g *Graph) satisfiable(p string, free map[string]int) bool {
+	for r, n := range g.Waiting[p] {
+		if n > free[r] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cycle returns a cycle of the graph through processes, following each process's requests in resource order to the
+// processes of processes holding them, or nil if there is none.
+func (g *Graph) cycle(processes []string) []string {
+	among := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		among[p] = true
+	}
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int, len(processes))
+	var path []string // process, resource, process, ...
+	var visit func(p string) []string
+	visit = func(p string) []string {
+		state[p] = onPath
+		path = append(path, p)
+		for _, r := range g.Resources {
+			if g.Waiting[p][r] == 0 {
+				continue
+			}
+			for _, q := range g.Processes {
+				if !among[q] || g.Held[q][r] == 0 {
+					continue
+				}
+				path = append(path, r)
+				switch state[q] {
+				case onPath:
+					// the cycle runs from q's place on the path back round to q
+					for i := range path {
+						if path[i] == q && i%2 == 0 {
+							return append(append([]string(nil), path[i:]...), q)
+						}
+					}
+				case unvisited:
+					if c := visit(q); c != nil {
+						return c
+					}
+				}
+				path = path[:len(path)-1]
+			}
+		}
+		state[p] = done
+		path = path[:len(path)-1]
+		return nil
+	}
+	for _, p := range processes {
+		if state[p] == unvisited {
+			if c := visit(p); c != nil {
+				return c
+			}
+		}
+	}
+
+	return nil
+}
+
+// Victim suggests which process of d to abort to recover: the one that leaves the fewest processes deadlocked once
+// it has released everything it holds, then of those the one holding the fewest instances, so the least work is
+// lost, then the one that appeared last. It returns the victim and how many processes would still be deadlocked.
+func (g *Graph) Victim(d Deadlock) (string, int) {
+	victim, left, held := "", 0, 0
+	order := make(map[string]int, len(g.Processes))
+	for i, p := range g.Processes {
+		order[p] = i
+	}
+	candidates := append([]string(nil), d.Processes...)
+	sort.SliceStable(candidates, func(i, j int) bool { return order[candidates[i]] > order[candidates[j]] })
+	for _, p := range candidates {
+		n := 0
+		for _, h := range g.Held[p] {
+			n += h
+		}
+		l := len(g.without(p).Detect().Processes)
+		if victim == "" || l < left || (l == left && n < held) {
+			victim, left, held = p, l, n
+		}
+	}
+
+	return victim, left
+}
+
+// Snapshot is the graph's deadlock after an event, with the suggested victim if there is one.
+type Snapshot struct {
+	Event    Event
+	Deadlock Deadlock
+	Victim   string
+	Left     int
+}
+
+// Replay applies events to g in order, finding the deadlock after each.
+func Replay(g *Graph, events []Event) ([]Snapshot, error) {
+	snapshots := make([]Snapshot, 0, len(events))
+	for _, e := range events {
+		if err := g.Apply(e); err != nil {
+			return nil, fmt.Errorf("at time %d: %w", e.Time, err)
+		}
+		s := Snapshot{Event: e, Deadlock: g.Detect()}
+		if len(s.Deadlock.Processes) > 0 {
+			s.Victim, s.Left = g.Victim(s.Deadlock)
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, nil
+}
+
+// Parse reads a log of events, one per line or per semicolon-separated entry, "TIME request|assign|release PROCESS
+// RESOURCE", in time order. "resource NAME INSTANCES" declares a resource with more than one instance; any other
+// has one. Blank lines and anything after a # are ignored. It returns the graph before the first event.
+func Parse(r io.Reader) (*Graph, []Event, error) {
+	g := NewGraph(nil, nil)
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, entry := range strings.Split(text, ";") {
+			fields := strings.Fields(entry)
+			switch {
+			case len(fields) == 0:
+				continue
+			case fields[0] == "resource":
+				n, err := 1, error(nil)
+				if len(fields) == 3 {
+					n, err = strconv.Atoi(fields[2])
+				}
+				if len(fields) < 2 || len(fields) > 3 || err != nil || n < 1 {
+					return nil, nil, fmt.Errorf("%w: line %d: want \"resource NAME [INSTANCES]\", got %q", ErrBadEvent,
+						line, strings.TrimSpace(entry))
+				}
+				if _, ok := g.Instances[fields[1]]; ok {
+					return nil, nil, fmt.Errorf("%w: line %d: resource %s declared twice", ErrBadEvent, line, fields[1])
+				}
+				g.addResource(fields[1], n)
+			default:
+				e, err := parseEvent(fields)
+				if err != nil {
+					return nil, nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				if len(events) > 0 && e.Time < events[len(events)-1].Time {
+					return nil, nil, fmt.Errorf("%w: line %d: time %d is before time %d", ErrBadEvent, line, e.Time,
+						events[len(events)-1].Time)
+				}
+				events = append(events, e)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: reading events", err)
+	}
+
+	return g, events, nil
+}
+
+// parseEvent parses the fields of a "TIME KIND PROCESS RESOURCE" entry.
+func parseEvent(fields []string) (Event, error) {
+	if len(fields) != 4 {
+		return Event{}, fmt.Errorf("%w: want \"TIME request|assign|release PROCESS RESOURCE\", got %q", ErrBadEvent,
+			strings.Join(fields, " "))
+	}
+	time, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || time < 0 {
+		return Event{}, fmt.Errorf("%w: %q is not a time", ErrBadEvent, fields[0])
+	}
+	for k, name := range kinds {
+		if fields[1] == name {
+			return Event{Time: time, Kind: Kind(k), Process: fields[2], Resource: fields[3]}, nil
+		}
+	}
+
+	return Event{}, fmt.Errorf("%w: %q is not request, assign, or release", ErrBadEvent, fields[1])
+}
@@ -0,0 +1,138 @@
+package deadlock
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustReplay(t *testing.T, in string) (*Graph, []Snapshot) {
+	t.Helper()
+	g, events, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := Replay(g, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g, snapshots
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	g, events, err := Parse(strings.NewReader("resource R2 2 # a pair\n1 request P1 R1; 1 assign P1 R1\n3 release P1 R1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Event{{Time: 1, Kind: Request, Process: "P1", Resource: "R1"},
+		{Time: 1, Kind: Assign, Process: "P1", Resource: "R1"}, {Time: 3, Kind: Release, Process: "P1", Resource: "R1"}}
+	if !reflect.DeepEqual(events, want) || g.Instances["R2"] != 2 {
+		t.Errorf("Parse() = %+v with %v, want %+v with 2 instances of R2", events, g.Instances, want)
+	}
+
+	for _, in := range []string{
+		"1 request P1",
+		"1 take P1 R1",
+		"x request P1 R1",
+		"2 request P1 R1; 1 request P2 R1",
+		"resource R1 0",
+		"resource R1; resource R1 2",
+	} {
+		if _, _, err := Parse(strings.NewReader(in)); !errors.Is(err, ErrBadEvent) {
+			t.Errorf("Parse(%q) error = %v, want %v", in, err, ErrBadEvent)
+		}
+	}
+}
+
+func TestGraph_Apply(t *testing.T) {
+	t.Parallel()
+	for _, in := range []string{
+		"1 assign P1 R1; 2 assign P2 R1",
+		"1 release P1 R1",
+	} {
+		g, events, err := Parse(strings.NewReader(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Replay(g, events); !errors.Is(err, ErrBadEvent) {
+			t.Errorf("Replay(%q) error = %v, want %v", in, err, ErrBadEvent)
+		}
+	}
+
+	g, _ := mustReplay(t, "resource R1 2; 1 request P1 R1; 2 assign P1 R1; 3 assign P2 R1")
+	if g.Waiting["P1"]["R1"] != 0 || g.Held["P1"]["R1"] != 1 || g.Free("R1") != 0 {
+		t.Errorf("assigning turned no request into an assignment: %+v", g)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		in        string
+		at        int // the snapshot to check
+		processes []string
+		resources []string
+		cycle     []string
+		victim    string
+		left      int
+	}{
+		{
+			name:      "two processes",
+			in:        "1 assign P1 R1; 2 assign P2 R2; 3 request P1 R2; 4 request P2 R1",
+			at:        3,
+			processes: []string{"P1", "P2"},
+			resources: []string{"R1", "R2"},
+			cycle:     []string{"P1", "R2", "P2", "R1", "P1"},
+			victim:    "P2",
+		},
+		{
+			name: "not yet",
+			in:   "1 assign P1 R1; 2 assign P2 R2; 3 request P1 R2; 4 request P2 R1",
+			at:   2,
+		},
+		{
+			name: "recovered",
+			in:   "1 assign P1 R1; 2 assign P2 R2; 3 request P1 R2; 4 request P2 R1; 5 release P2 R2",
+			at:   4,
+		},
+		{
+			// P3 can finish and free an instance of R2 for P1, so the cycle is no deadlock
+			name: "cycle without deadlock",
+			in: "resource R2 2; 1 assign P1 R1; 1 assign P2 R2; 1 assign P3 R2; 2 request P1 R2; " +
+				"3 request P2 R1",
+			at: 4,
+		},
+		{
+			// aborting P2 ends both cycles, and aborting either other process only one
+			name: "shared victim",
+			in: "1 assign P1 R1; 1 assign P2 R2; 1 assign P3 R3; 2 request P1 R2; 2 request P3 R2; " +
+				"3 request P2 R1; 3 request P2 R3",
+			at:        6,
+			processes: []string{"P1", "P2", "P3"},
+			resources: []string{"R1", "R2", "R3"},
+			cycle:     []string{"P1", "R2", "P2", "R1", "P1"},
+			victim:    "P2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, snapshots := mustReplay(t, tt.in)
+			s := snapshots[tt.at]
+			if !reflect.DeepEqual(s.Deadlock.Processes, tt.processes) ||
+				!reflect.DeepEqual(s.Deadlock.Resources, tt.resources) || !reflect.DeepEqual(s.Deadlock.Cycle, tt.cycle) {
+				t.Errorf("after %s: deadlock %+v, want %v over %v with cycle %v", s.Event, s.Deadlock, tt.processes,
+					tt.resources, tt.cycle)
+			}
+			if s.Victim != tt.victim || s.Left != tt.left {
+				t.Errorf("after %s: victim %q leaving %d, want %q leaving %d", s.Event, s.Victim, s.Left, tt.victim,
+					tt.left)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+// Package dining simulates the dining philosophers: philosophers around a table think, grow hungry, and eat, each
+// needing both the fork on its left and the one on its right to eat, one fork between every two. How they pick up
+// forks decides whether they can deadlock, each holding one fork and waiting forever for the next, or starve. It runs
+// the philosophers a time unit at a time under a strategy, recording every philosopher's state over time.
+package dining
+
+import (
+	"math/rand"
+)
+
+// State is what a philosopher is doing.
+type State int
+
+const (
+	Thinking State = iota
+	Hungry
+	Eating
+)
+
+// table is the forks and philosophers being simulated: the philosopher holding each fork, or -1, where fork p is on
+// the left of philosopher p and the right of philosopher p-1.
+type table struct {
+	n      int
+	holder []int
+	state  []State
+	dirty  []bool // for Chandy–Misra, whether each fork has been eaten with since it was handed over
+	seated []bool // for the waiter, whether each philosopher has a seat
+	seats  int
+}
+
+func (t *table) left(p int) int  { return p }
+func (t *table) right(p int) int { return (p + 1) % t.n }
+
+// holds returns how many of its forks p holds.
+func (t *table) holds(p int) int {
+	n := 0
+	for _, f := range []int{t.left(p), t.right(p)} {
+		if t.holder[f] == p {
+			n++
+		}
+	}
+
+	return n
+}
+
+// take gives fork f to p if it is free, reporting whether it did.
+func (t *table) take(p, f int) bool {
+	if t.holder[f] != -1 {
+		return false
+	}
+	t.holder[f] = p
+
+	return true
+}
+
+// putDown frees every fork p holds.
+func (t *table) putDown(p int) {
+	for _, f := range []int{t.left(p), t.right(p)} {
+		if t.holder[f] == p {
+			t.holder[f] = -1
+		}
+	}
+}
+
+// strategy is how hungry philosophers get their forks.
+type strategy interface {
+	// setup readies t before the first time unit.
+	setup(t *table)
+	// try makes one move of hungry philosopher p toward holding both its forks, reporting whether it made one.
+	try(t *table, p int) bool
+	// done is called as p finishes eating.
+	done(t *table, p int)
+}
+
+// Strategy is a way for the philosophers to pick up forks along with the name it is selected by, the title its
+// reports carry, and a one-line description for listings.
+type Strategy struct {
+	Name        string
+	Title       string
+	Description string
+	strategy    strategy
+}
+
+// Strategies lists every strategy in the order they run by default.
+var Strategies = []Strategy{
+	{Name: "naive", Title: "Naive", Description: "pick up the left fork, then the right; can deadlock",
+		strategy: naive{}},
+	{Name: "ordering", Title: "Resource ordering",
+		Description: "pick up the lower-numbered fork first, so no cycle of waiting can form", strategy: ordering{}},
+	{Name: "waiter", Title: "Waiter",
+		Description: "a waiter seats at most all but one philosopher at once, like a semaphore", strategy: waiter{}},
+	{Name: "chandy-misra", Title: "Chandy–Misra",
+		Description: "forks go to whoever asks for them once dirty from eating, and stay while clean",
+		strategy:    chandyMisra{}},
+}
+
+// naive picks up the left fork, then the right, holding on to the left while it waits.
+type naive struct{}
+
+func (naive) setup(*table) {}
+
+func (naive) try(t *table, p int) bool {
+	if t.holder[t.left(p)] != p {
+		return t.take(p, t.left(p))
+	}
+
+	return t.take(p, t.right(p))
+}
+
+func (naive) done(t *table, p int) { t.putDown(p) }
+
+// ordering picks up the lower-numbered of its forks first, so the last philosopher reaches right before left and the
+// philosophers can never all hold one fork each.
+type ordering struct{}
+
+func (ordering) setup(*table) {}
+
+func (ordering) try(t *table, p int) bool {
+	first, second := min(t.left(p), t.right(p)), max(t.left(p), t.right(p))
+	if t.holder[first] != p {
+		return t.take(p, first)
+	}
+
+	return t.take(p, second)
+}
+
+func (ordering) done(t *table, p int) { t.putDown(p) }
+
+// waiter has a hungry philosopher take one of n-1 seats before picking up forks as naive does, so at least one
+// seated philosopher can always get both.
+type waiter struct{}
+
+func (waiter) setup(t *table) { t.seats = t.n - 1 }
+
+func (waiter) try(t *table, p int) bool {
+	if !t.seated[p] {
+		if t.seats == 0 {
+			return false
+		}
+		t.seated[p], t.seats = true, t.seats-1
+		return true
+	}
+
+	return naive{}.try(t, p)
+}
+
+func (waiter) done(t *table, p int) {
+	t.putDown(p)
+	t.seated[p], t.seats = false, t.seats+1
+}
+
+// chandyMisra is the Chandy–Misra solution: every fork always belongs to one of its two philosophers, at first the
+// lower-numbered, and is dirty or clean. A hungry philosopher asks for a fork it lacks, and its holder hands it over,
+// cleaned, if it is dirty and the holder is not eating; a clean fork stays until the holder has eaten with it, which
+// dirties it. The first placement orders the philosophers so none can deadlock, and the cleaning so none starves.
+type chandyMisra struct{}
+
+func (chandyMisra) setup(t *table) {
+	// fork f lies between philosophers f-1 and f, and fork 0 between n-1 and 0
+	for f := range t.holder {
+		t.holder[f], t.dirty[f] = max(f-1, 0), true
+	}
+}
+
+func (chandyMisra) try(t *table, p int) bool {
+	for _, f := range []int{t.left(p), t.right(p)} {
+		q := t.holder[f]
+		if q != p && t.dirty[f] && t.state[q] != Eating {
+			t.holder[f], t.dirty[f] = p, false
+			return true
+		}
+	}
+
+	return false
+}
+
+func (chandyMisra) done(t *table, p int) {
+	t.dirty[t.left(p)], t.dirty[t.right(p)] = true, true
+}
+
+// Config is how long the philosophers think and eat, each time drawn uniformly from a range, and how long the
+// simulation runs.
+type Config struct {
+	Philosophers int
+	Duration     int64
+	ThinkMin     int64
+	ThinkMax     int64
+	EatMin       int64
+	EatMax       int64
+}
+
+// Timeline marks, one per time unit of a philosopher's timeline.
+const (
+	MarkThinking = '.'
+	MarkHungry   = '-'
+	MarkOneFork  = '='
+	MarkEating   = '#'
+)
+
+// Result is a run of one strategy: what each philosopher did every time unit, as a timeline of marks, the meals it
+// ate, the time it spent hungry, and the longest it went hungry at once. Deadlock is the time the philosophers
+// deadlocked, which ends the run, or -1.
+type Result struct {
+	Strategy  Strategy
+	Config    Config
+	Timelines [][]byte
+	Meals     []int
+	Hungry    []int64
+	Longest   []int64
+	Deadlock  int64
+}
+
+// Duration returns how many time units the run lasted.
+func (r Result) Duration() int64 {
+	if len(r.Timelines) == 0 {
+		return 0
+	}
+
+	return int64(len(r.Timelines[0]))
+}
+
+// TotalMeals returns the meals of every philosopher together.
+func (r Result) TotalMeals() int {
+	total := 0
+	for _, m := range r.Meals {
+		total += m
+	}
+
+	return total
+}
+
+// Throughput returns the meals eaten per 100 time units of the whole run asked for, so a deadlock that cut it short
+// counts as the philosophers eating nothing more.
+func (r Result) Throughput() float64 {
+	if r.Config.Duration <= 0 {
+		return 0
+	}
+
+	return 100 * float64(r.TotalMeals()) / float64(r.Config.Duration)
+}
+
+// Starved returns the philosophers that went hungry longer than limit time units at once. A deadlock starves them
+// all.
+func (r Result) Starved(limit int64) []int {
+	var starved []int
+	for p, l := range r.Longest {
+		if l > limit || r.Deadlock >= 0 {
+			starved = append(starved, p)
+		}
+	}
+
+	return starved
+}
+
+// Simulate runs c.Philosophers philosophers under s for c.Duration time units, or until they deadlock, drawing how
+// long they think and eat from rng. Every time unit, philosophers done eating put their forks down, those done
+// thinking grow hungry, and then, in an order drawn from rng, each hungry philosopher makes one move toward its
+// forks, such as picking one up, and starts eating once it holds both.
+func Simulate(rng *rand.Rand, s Strategy, c Config) Result {
+	n := max(c.Philosophers, 2)
+	t := &table{n: n, holder: make([]int, n), state: make([]State, n), dirty: make([]bool, n),
+		seated: make([]bool, n)}
+	for f := range t.holder {
+		t.holder[f] = -1
+	}
+	s.strategy.setup(t)
+	draw := func(lo, hi int64) int64 { return lo + rng.Int63n(max(hi-lo, 0)+1) }
+
+	r := Result{Strategy: s, Config: c, Timelines: make([][]byte, n), Meals: make([]int, n),
+		Hungry: make([]int64, n), Longest: make([]int64, n), Deadlock: -1}
+	left := make([]int64, n)  // time units left thinking or eating
+	since := make([]int64, n) // when each hungry philosopher grew hungry
+	for p := range left {
+		left[p] = draw(c.ThinkMin, c.ThinkMax)
+	}
+	for time := int64(0); time < c.Duration; time++ {
+		for p := 0; p < n; p++ {
+			if left[p] > 0 {
+				continue
+			}
+			switch t.state[p] {
+			case Eating:
+				s.strategy.done(t, p)
+				t.state[p], left[p] = Thinking, draw(c.ThinkMin, c.ThinkMax)
+			case Thinking:
+				t.state[p], since[p] = Hungry, time
+			}
+		}
+		moved := false
+		for _, p := range rng.Perm(n) {
+			if t.state[p] != Hungry {
+				continue
+			}
+			if s.strategy.try(t, p) {
+				moved = true
+			}
+			if t.holds(p) == 2 {
+				t.state[p], left[p] = Eating, draw(max(c.EatMin, 1), max(c.EatMax, 1))
+				r.Meals[p]++
+			}
+		}
+		if !moved && everyone(t, Hungry) {
+			// nobody can move, so nobody ever will
+			r.Deadlock = time
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			mark := byte(MarkThinking)
+			switch t.state[p] {
+			case Hungry:
+				mark = MarkHungry
+				if t.holds(p) == 1 {
+					mark = MarkOneFork
+				}
+				r.Hungry[p]++
+				r.Longest[p] = max(r.Longest[p], time+1-since[p])
+			case Eating:
+				mark = MarkEating
+			}
+			if t.state[p] != Hungry && left[p] > 0 {
+				left[p]--
+			}
+			r.Timelines[p] = append(r.Timelines[p], mark)
+		}
+	}
+	return r
+}
+
+// everyone reports whether every philosopher at t is in state.
+func everyone(t *table, state State) bool {
+	for _, s := range t.state {
+		if s != state {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package dining
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSimulate_deadlock(t *testing.T) {
+	t.Parallel()
+	// everyone grows hungry at time 1 and picks up its left fork, so at time 2 nobody can pick up a right one
+	c := Config{Philosophers: 5, Duration: 50, ThinkMin: 1, ThinkMax: 1, EatMin: 1, EatMax: 1}
+	r := Simulate(rand.New(rand.NewSource(1)), Strategies[0], c)
+	if r.Deadlock != 2 || r.TotalMeals() != 0 || r.Duration() != 2 {
+		t.Errorf("naive deadlock at %d after %d units with %d meals, want at 2 after 2 with none", r.Deadlock,
+			r.Duration(), r.TotalMeals())
+	}
+	for p, timeline := range r.Timelines {
+		if string(timeline) != ".=" {
+			t.Errorf("P%d timeline %q, want \".=\"", p+1, timeline)
+		}
+	}
+	if got := len(r.Starved(100)); got != 5 {
+		t.Errorf("a deadlock starved %d philosophers, want all 5", got)
+	}
+	if r.Throughput() != 0 {
+		t.Errorf("Throughput() = %v after a deadlock with no meals", r.Throughput())
+	}
+}
+
+func TestSimulate_strategies(t *testing.T) {
+	t.Parallel()
+	for _, s := range Strategies[1:] {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			t.Parallel()
+			for seed := int64(0); seed < 30; seed++ {
+				c := Config{Philosophers: 2 + int(seed%6), Duration: 200, ThinkMin: 1, ThinkMax: 1 + seed%3,
+					EatMin: 1, EatMax: 1 + seed%4}
+				r := Simulate(rand.New(rand.NewSource(seed)), s, c)
+				if r.Deadlock >= 0 || r.Duration() != c.Duration {
+					t.Fatalf("seed %d, %+v: deadlock at %d after %d units", seed, c, r.Deadlock, r.Duration())
+				}
+				for p, timeline := range r.Timelines {
+					// every meal is one run of eating, and neighbours never eat at once
+					meals := 0
+					next := r.Timelines[(p+1)%len(r.Timelines)]
+					for i := range timeline {
+						if timeline[i] == MarkEating && (i == 0 || timeline[i-1] != MarkEating) {
+							meals++
+						}
+						if timeline[i] == MarkEating && next[i] == MarkEating {
+							t.Fatalf("seed %d: P%d and its neighbour both eat at %d", seed, p+1, i)
+						}
+					}
+					if meals != r.Meals[p] {
+						t.Fatalf("seed %d: P%d timeline %s has %d meals, want %d", seed, p+1, timeline, meals,
+							r.Meals[p])
+					}
+				}
+				if r.TotalMeals() == 0 {
+					t.Fatalf("seed %d: nobody ate", seed)
+				}
+			}
+		})
+	}
+}
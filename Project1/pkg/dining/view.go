@@ -0,0 +1,41 @@
+package dining
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTimeline writes the timeline of every philosopher of r, one row each numbered from P1 and one mark per time
+// unit, in blocks of at most width time units under a ruler marking every tenth, and an X where the philosophers
+// deadlocked.
+func WriteTimeline(w io.Writer, r Result, width int) error {
+	width = max(width, 10)
+	var b strings.Builder
+	label := len(fmt.Sprintf("P%d", len(r.Timelines)))
+	duration := int(r.Duration())
+	if r.Deadlock >= 0 {
+		duration++
+	}
+	for start := 0; start < duration; start += width {
+		end := min(start+width, duration)
+		ruler := []byte(strings.Repeat(" ", end-start))
+		for t := start; t < end; t++ {
+			if t%10 == 0 {
+				n := fmt.Sprint(t)
+				copy(ruler[t-start:], n[:min(len(n), end-t)])
+			}
+		}
+		fmt.Fprintf(&b, "%*s %s\n", label, "", strings.TrimRight(string(ruler), " "))
+		for p, timeline := range r.Timelines {
+			row := string(timeline[min(start, len(timeline)):min(end, len(timeline))])
+			if r.Deadlock >= 0 && end == duration {
+				row += "X"
+			}
+			fmt.Fprintf(&b, "%*s %s\n", label, fmt.Sprintf("P%d", p+1), row)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,47 @@
+package dining
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTimeline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		r     Result
+		width int
+		want  string
+	}{
+		{
+			name:  "blocks",
+			r:     Result{Timelines: [][]byte{[]byte("..-=##......"), []byte("##..........")}, Deadlock: -1},
+			width: 10,
+			want: "   0\n" +
+				"P1 ..-=##....\n" +
+				"P2 ##........\n" +
+				"   10\n" +
+				"P1 ..\n" +
+				"P2 ..\n",
+		},
+		{
+			name:  "deadlock",
+			r:     Result{Timelines: [][]byte{[]byte(".="), []byte(".=")}, Deadlock: 2},
+			width: 10,
+			want:  "   0\nP1 .=X\nP2 .=X\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := WriteTimeline(&w, tt.r, tt.width); err != nil {
+				t.Fatal(err)
+			}
+			if w.String() != tt.want {
+				t.Errorf("WriteTimeline() =\n%s\nwant\n%s", w.String(), tt.want)
+			}
+		})
+	}
+}
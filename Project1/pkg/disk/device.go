@@ -0,0 +1,156 @@
+package disk
+
+// Pick chooses which of pending, the cylinders of the requests waiting on d in the order they arrived, the head serves
+// next, how far it travels to get there, and which way it is moving once there. It is how an algorithm runs on a
+// queue that keeps changing as requests arrive; pending is never empty.
+type Pick func(pending []int64, d Disk) (next int, travel int64, up bool)
+
+// PickFCFS serves the request that arrived first.
+func PickFCFS(pending []int64, d Disk) (int, int64, bool) {
+	return 0, distance(pending[0], d.Head), d.Up
+}
+
+// PickSSTF serves the request nearest the head, the earliest arrived of two as near.
+func PickSSTF(pending []int64, d Disk) (int, int64, bool) {
+	best := 0
+	for i, c := range pending {
+		if distance(c, d.Head) < distance(pending[best], d.Head) {
+			best = i
+		}
+	}
+
+	return best, distance(pending[best], d.Head), d.Up
+}
+
+// PickSCAN serves the nearest request ahead of the head, or with none, goes on to the edge of the disk and turns
+// back to the nearest behind it.
+func PickSCAN(pending []int64, d Disk) (int, int64, bool) {
+	ahead, behind := sides(pending, d)
+	if len(ahead) > 0 {
+		return ahead[0], distance(pending[ahead[0]], d.Head), d.Up
+	}
+	edge := d.edge(d.Up)
+
+	return behind[0], distance(edge, d.Head) + distance(edge, pending[behind[0]]), !d.Up
+}
+
+// PickCSCAN serves the nearest request ahead of the head, or with none, goes on to the edge of the disk, returns to
+// the other edge, and sweeps the same way again to the furthest request behind it.
+func PickCSCAN(pending []int64, d Disk) (int, int64, bool) {
+	ahead, behind := sides(pending, d)
+	if len(ahead) > 0 {
+		return ahead[0], distance(pending[ahead[0]], d.Head), d.Up
+	}
+	furthest := behind[len(behind)-1]
+
+	return furthest, distance(d.edge(d.Up), d.Head) + d.Cylinders - 1 + distance(d.edge(!d.Up), pending[furthest]),
+		d.Up
+}
+
+// PickLOOK serves the nearest request ahead of the head, or with none, turns back to the nearest behind it.
+func PickLOOK(pending []int64, d Disk) (int, int64, bool) {
+	ahead, behind := sides(pending, d)
+	if len(ahead) > 0 {
+		return ahead[0], distance(pending[ahead[0]], d.Head), d.Up
+	}
+
+	return behind[0], distance(pending[behind[0]], d.Head), !d.Up
+}
+
+// PickCLOOK serves the nearest request ahead of the head, or with none, returns straight to the furthest request
+// behind it.
+func PickCLOOK(pending []int64, d Disk) (int, int64, bool) {
+	ahead, behind := sides(pending, d)
+	if len(ahead) > 0 {
+		return ahead[0], distance(pending[ahead[0]], d.Head), d.Up
+	}
+	furthest := behind[len(behind)-1]
+
+	return furthest, distance(pending[furthest], d.Head), d.Up
+}
+
+// Job is a request made of a Device: ID identifies who made it, and Issued, Started, and Done are when it was made,
+// when the head set off to serve it, and when it was served. Seek is how far the head travelled for it.
+type Job struct {
+	ID       int64
+	Cylinder int64
+	Issued   int64
+	Started  int64
+	Seek     int64
+	Done     int64
+}
+
+// Device is a disk serving requests one at a time as they are made, picking the next from those waiting whenever it
+// finishes one, so what it picks depends on when requests arrive as well as where. Serving a request takes a time
+// unit for every Speed cylinders the head travels, rounded up, and Transfer more to read or write it.
+type Device struct {
+	// Disk is where the head is now and which way it is moving.
+	Disk     Disk
+	Pick     Pick
+	Speed    int64
+	Transfer int64
+	// Movement is how far the head has travelled, and Served every job finished, in the order finished.
+	Movement int64
+	Served   []Job
+
+	waiting []Job // in the order issued
+	current *Job
+	free    int64 // when the device last finished a job
+}
+
+// NewDevice returns an idle device with its head where d has it, serving requests by pick.
+func NewDevice(d Disk, pick Pick, speed, transfer int64) *Device {
+	return &Device{Disk: d, Pick: pick, Speed: max(speed, 1), Transfer: transfer}
+}
+
+// Submit makes a request for cylinder at time, identified by id. Requests must be submitted in time order.
+func (v *Device) Submit(id, cylinder, time int64) {
+	v.waiting = append(v.waiting, Job{ID: id, Cylinder: cylinder, Issued: time})
+}
+
+// Busy reports whether the device is serving a request or has some waiting.
+func (v *Device) Busy() bool {
+	return v.current != nil || len(v.waiting) > 0
+}
+
+// Advance runs the device up to time now and returns the jobs it finished by then, in the order finished. It only
+// picks a job to start before now, since more requests may yet be submitted at now itself.
+func (v *Device) Advance(now int64) []Job {
+	var finished []Job
+	for {
+		if v.current != nil {
+			if v.current.Done > now {
+				break
+			}
+			v.free = v.current.Done
+			finished, v.Served = append(finished, *v.current), append(v.Served, *v.current)
+			v.current = nil
+			continue
+		}
+		if len(v.waiting) == 0 {
+			break
+		}
+		start := max(v.free, v.waiting[0].Issued)
+		if start >= now {
+			break
+		}
+		n := 0 // the jobs waiting when the device picks
+		for n < len(v.waiting) && v.waiting[n].Issued <= start {
+			n++
+		}
+		pending := make([]int64, n)
+		for i := range pending {
+			pending[i] = v.waiting[i].Cylinder
+		}
+		next, travel, up := v.Pick(pending, v.Disk)
+		job := v.waiting[next]
+		v.waiting = append(v.waiting[:next], v.waiting[next+1:]...)
+		job.Started, job.Seek = start, travel
+		job.Done = start + (travel+v.Speed-1)/v.Speed + v.Transfer
+		v.Disk.Head, v.Disk.Up = job.Cylinder, up
+		v.Movement += travel
+		v.current = &job
+	}
+
+	return finished
+}
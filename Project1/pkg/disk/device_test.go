@@ -0,0 +1,85 @@
+package disk
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDevice(t *testing.T) {
+	t.Parallel()
+	// job 1 keeps the head busy until 6, by when 2 and 3 are waiting; only FCFS serves them in the order made
+	tests := []struct {
+		name string
+		pick Pick
+		want []Job
+	}{
+		{name: "fcfs", pick: PickFCFS, want: []Job{
+			{ID: 1, Cylinder: 100, Issued: 0, Started: 0, Seek: 50, Done: 6},
+			{ID: 2, Cylinder: 190, Issued: 2, Started: 6, Seek: 90, Done: 16},
+			{ID: 3, Cylinder: 110, Issued: 3, Started: 16, Seek: 80, Done: 25},
+		}},
+		{name: "sstf", pick: PickSSTF, want: []Job{
+			{ID: 1, Cylinder: 100, Issued: 0, Started: 0, Seek: 50, Done: 6},
+			{ID: 3, Cylinder: 110, Issued: 3, Started: 6, Seek: 10, Done: 8},
+			{ID: 2, Cylinder: 190, Issued: 2, Started: 8, Seek: 80, Done: 17},
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewDevice(Disk{Cylinders: 200, Head: 50, Up: true}, tt.pick, 10, 1)
+			var finished []Job
+			for now := int64(0); now < 30; now++ {
+				switch now {
+				case 0:
+					v.Submit(1, 100, now)
+				case 2:
+					v.Submit(2, 190, now)
+				case 3:
+					v.Submit(3, 110, now)
+				}
+				finished = append(finished, v.Advance(now+1)...)
+			}
+			if !reflect.DeepEqual(finished, tt.want) || !reflect.DeepEqual(v.Served, tt.want) {
+				t.Errorf("finished %+v, want %+v", finished, tt.want)
+			}
+			if v.Busy() {
+				t.Error("Busy() after every job finished")
+			}
+		})
+	}
+}
+
+func TestAlgorithm_Pick(t *testing.T) {
+	t.Parallel()
+	// with the whole queue there from the start, picking one at a time serves it as running it at once does
+	rng := rand.New(rand.NewSource(5))
+	for trial := 0; trial < 50; trial++ {
+		d := Disk{Cylinders: 1 + rng.Int63n(300), Up: trial%2 == 0}
+		d.Head = rng.Int63n(d.Cylinders)
+		requests := Generate(rng, rng.Intn(20), d.Cylinders)
+		for _, a := range Algorithms {
+			want := a.Run(requests, d)
+			v := NewDevice(d, a.Pick, 1, 0)
+			for i, c := range requests {
+				v.Submit(int64(i), c, 0)
+			}
+			for now := int64(1); v.Busy(); now++ {
+				v.Advance(now)
+			}
+			served := want.Served()
+			if v.Movement != want.Movement || len(v.Served) != len(served) {
+				t.Fatalf("%s on %+v with %v moved %d serving %d, want %d serving %d", a.Name, d, requests,
+					v.Movement, len(v.Served), want.Movement, len(served))
+			}
+			for i, j := range v.Served {
+				if j.Cylinder != served[i].Cylinder {
+					t.Fatalf("%s on %+v with %v served %+v, want cylinder %d", a.Name, d, requests, j,
+						served[i].Cylinder)
+				}
+			}
+		}
+	}
+}
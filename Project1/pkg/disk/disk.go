@@ -0,0 +1,319 @@
+// Package disk simulates disk scheduling: the order a disk serves a queue of requests for cylinders in, and how far
+// its head travels to serve them. It is the I/O companion of sched, reading a request queue and reporting every
+// algorithm's head movement request by request.
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrBadRequest marks a request queue with an entry that is not a cylinder number.
+	ErrBadRequest = errors.New("bad request")
+	// ErrBadDisk marks a disk whose head or requests fall outside its cylinders.
+	ErrBadDisk = errors.New("bad disk")
+)
+
+// Disk is the disk a queue is served on: Cylinders cylinders numbered from 0, with the head over cylinder Head and
+// moving toward higher cylinders if Up, or lower ones if not.
+type Disk struct {
+	Cylinders int64
+	Head      int64
+	Up        bool
+}
+
+// Validate reports whether the head and every one of requests lie on d.
+func (d Disk) Validate(requests []int64) error {
+	if d.Cylinders < 1 {
+		return fmt.Errorf("%w: a disk needs at least 1 cylinder", ErrBadDisk)
+	}
+	if d.Head < 0 || d.Head >= d.Cylinders {
+		return fmt.Errorf("%w: head at %d is not one of the cylinders 0-%d", ErrBadDisk, d.Head, d.Cylinders-1)
+	}
+	for i, c := range requests {
+		if c < 0 || c >= d.Cylinders {
+			return fmt.Errorf("%w: request %d for cylinder %d is not one of the cylinders 0-%d", ErrBadDisk, i+1, c,
+				d.Cylinders-1)
+		}
+	}
+
+	return nil
+}
+
+// ParseRequests parses a request queue of cylinder numbers separated by spaces, commas, or newlines.
+func ParseRequests(s string) ([]int64, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	requests := make([]int64, 0, len(fields))
+	for i, f := range fields {
+		c, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || c < 0 {
+			return nil, fmt.Errorf("%w: request %d: %q is not a cylinder number", ErrBadRequest, i+1, f)
+		}
+		requests = append(requests, c)
+	}
+
+	return requests, nil
+}
+
+// Generate returns count requests drawn uniformly at random from rng over the cylinders 0 to cylinders-1.
+func Generate(rng *rand.Rand, count int, cylinders int64) []int64 {
+	requests := make([]int64, count)
+	for i := range requests {
+		requests[i] = rng.Int63n(max(cylinders, 1))
+	}
+
+	return requests
+}
+
+// Stop is one place the head stopped: to serve the request at index Request of the queue, or at the edge of the disk
+// with Request -1. Jump marks a stop the head returned to without serving anything on the way, as the circular
+// algorithms do. Seek is how far the head moved to get there, and Time how far it had moved since the start, which
+// is how long the request waited with one time unit per cylinder.
+type Stop struct {
+	Cylinder int64
+	Request  int
+	Jump     bool
+	Seek     int64
+	Time     int64
+}
+
+// Result is a run of one algorithm over a request queue: every stop of the head in order, starting where it was.
+type Result struct {
+	Disk     Disk
+	Stops    []Stop
+	Movement int64
+}
+
+// Served returns the stops that served requests, in the order served.
+func (r Result) Served() []Stop {
+	var served []Stop
+	for _, s := range r.Stops {
+		if s.Request >= 0 {
+			served = append(served, s)
+		}
+	}
+
+	return served
+}
+
+// AvgWait returns the mean time the requests waited to be served, or 0 for an empty queue.
+func (r Result) AvgWait() float64 {
+	served := r.Served()
+	if len(served) == 0 {
+		return 0
+	}
+	var total int64
+	for _, s := range served {
+		total += s.Time
+	}
+
+	return float64(total) / float64(len(served))
+}
+
+// MaxWait returns the longest time a request waited to be served.
+func (r Result) MaxWait() int64 {
+	var longest int64
+	for _, s := range r.Served() {
+		longest = max(longest, s.Time)
+	}
+
+	return longest
+}
+
+// Func runs a disk-scheduling algorithm over requests on d.
+type Func func(requests []int64, d Disk) Result
+
+// Algorithm is a disk-scheduling algorithm along with the name it is selected by, the title its reports carry, and a
+// one-line description for listings. Run serves a whole queue at once, and Pick the same way one request at a time
+// for a Device.
+type Algorithm struct {
+	Name        string
+	Title       string
+	Description string
+	Run         Func
+	Pick        Pick
+}
+
+// Algorithms lists every disk-scheduling algorithm in the order they run by default.
+var Algorithms = []Algorithm{
+	{Name: "fcfs", Title: "FCFS", Description: "serves requests in the order they arrived", Run: FCFS,
+		Pick: PickFCFS},
+	{Name: "sstf", Title: "SSTF", Description: "serves the request nearest the head next", Run: SSTF,
+		Pick: PickSSTF},
+	{Name: "scan", Title: "SCAN", Description: "the elevator: sweeps to the edge of the disk, then back", Run: SCAN,
+		Pick: PickSCAN},
+	{Name: "cscan", Title: "C-SCAN",
+		Description: "sweeps one way to the edge, then returns to the other edge and sweeps again", Run: CSCAN,
+		Pick: PickCSCAN},
+	{Name: "look", Title: "LOOK", Description: "SCAN turning back at the last request instead of the edge", Run: LOOK,
+		Pick: PickLOOK},
+	{Name: "clook", Title: "C-LOOK",
+		Description: "C-SCAN returning from the last request to the first instead of edge to edge", Run: CLOOK,
+		Pick: PickCLOOK},
+}
+
+// mover moves the head from stop to stop, accumulating the result.
+type mover struct {
+	r Result
+}
+
+func newMover(d Disk) *mover {
+	return &mover{r: Result{Disk: d, Stops: []Stop{{Cylinder: d.Head, Request: -1}}}}
+}
+
+// moveTo moves the head to cylinder, serving the request at index request unless it is -1.
+func (m *mover) moveTo(cylinder int64, request int, jump bool) {
+	from := m.r.Stops[len(m.r.Stops)-1].Cylinder
+	seek := cylinder - from
+	if seek < 0 {
+		seek = -seek
+	}
+	m.r.Movement += seek
+	m.r.Stops = append(m.r.Stops, Stop{Cylinder: cylinder, Request: request, Jump: jump, Seek: seek,
+		Time: m.r.Movement})
+}
+
+// FCFS serves requests in the order they arrived, however far apart they are.
+func FCFS(requests []int64, d Disk) Result {
+	m := newMover(d)
+	for i, c := range requests {
+		m.moveTo(c, i, false)
+	}
+
+	return m.r
+}
+
+// SSTF serves the pending request nearest the head next, the earliest queued of two as near. It keeps seeks short
+// but can starve requests far from a busy part of the disk.
+func SSTF(requests []int64, d Disk) Result {
+	m := newMover(d)
+	served := make([]bool, len(requests))
+	head := d.Head
+	for range requests {
+		best := -1
+		for i, c := range requests {
+			if !served[i] && (best < 0 || distance(c, head) < distance(requests[best], head)) {
+				best = i
+			}
+		}
+		served[best], head = true, requests[best]
+		m.moveTo(head, best, false)
+	}
+
+	return m.r
+}
+
+func distance(a, b int64) int64 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
+
+// sides splits the indexes of requests into those ahead of the head in the direction it moves, nearest first, and
+// those behind it, nearest first. A request under the head is ahead of it.
+func sides(requests []int64, d Disk) (ahead, behind []int) {
+	for i, c := range requests {
+		if c == d.Head || (c > d.Head) == d.Up {
+			ahead = append(ahead, i)
+		} else {
+			behind = append(behind, i)
+		}
+	}
+	nearest := func(indexes []int) {
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return distance(requests[indexes[i]], d.Head) < distance(requests[indexes[j]], d.Head)
+		})
+	}
+	nearest(ahead)
+	nearest(behind)
+
+	return ahead, behind
+}
+
+// edge returns the last cylinder of d in the direction up.
+func (d Disk) edge(up bool) int64 {
+	if up {
+		return d.Cylinders - 1
+	}
+
+	return 0
+}
+
+// sweep serves the requests at indexes in order.
+func (m *mover) sweep(requests []int64, indexes []int) {
+	for _, i := range indexes {
+		m.moveTo(requests[i], i, false)
+	}
+}
+
+// reverse returns indexes in reverse order.
+func reverse(indexes []int) []int {
+	r := make([]int, len(indexes))
+	for i, x := range indexes {
+		r[len(indexes)-1-i] = x
+	}
+
+	return r
+}
+
+// SCAN is the elevator: the head sweeps in its direction serving every request it passes, on to the edge of the disk,
+// then turns back and sweeps the other way. It only goes on to the edge when there are requests left behind it.
+func SCAN(requests []int64, d Disk) Result {
+	m := newMover(d)
+	ahead, behind := sides(requests, d)
+	m.sweep(requests, ahead)
+	if len(behind) > 0 {
+		m.moveTo(d.edge(d.Up), -1, false)
+		m.sweep(requests, behind)
+	}
+
+	return m.r
+}
+
+// CSCAN is circular SCAN: the head sweeps in its direction to the edge of the disk, then returns straight to the
+// other edge and sweeps the same way again, so every cylinder waits about as long. The return counts toward the head
+// movement. It only goes on to the edge when there are requests left behind it.
+func CSCAN(requests []int64, d Disk) Result {
+	m := newMover(d)
+	ahead, behind := sides(requests, d)
+	m.sweep(requests, ahead)
+	if len(behind) > 0 {
+		m.moveTo(d.edge(d.Up), -1, false)
+		m.moveTo(d.edge(!d.Up), -1, true)
+		m.sweep(requests, reverse(behind))
+	}
+
+	return m.r
+}
+
+// LOOK is SCAN turning back at the last request in its direction rather than going on to the edge of the disk.
+func LOOK(requests []int64, d Disk) Result {
+	m := newMover(d)
+	ahead, behind := sides(requests, d)
+	m.sweep(requests, ahead)
+	m.sweep(requests, behind)
+
+	return m.r
+}
+
+// CLOOK is C-SCAN returning from the last request in its direction straight to the furthest request behind it,
+// rather than travelling edge to edge. The return counts toward the head movement.
+func CLOOK(requests []int64, d Disk) Result {
+	m := newMover(d)
+	ahead, behind := sides(requests, d)
+	m.sweep(requests, ahead)
+	if len(behind) > 0 {
+		behind = reverse(behind)
+		m.moveTo(requests[behind[0]], behind[0], true)
+		m.sweep(requests, behind[1:])
+	}
+
+	return m.r
+}
@@ -0,0 +1,150 @@
+package disk
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// textbook is the request queue of the disk-scheduling examples in Silberschatz, Galvin, and Gagne, on a disk of 200
+// cylinders with the head at 53.
+var textbook = []int64{98, 183, 37, 122, 14, 124, 65, 67}
+
+func TestParseRequests(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    []int64
+		wantErr bool
+	}{
+		{in: "98, 183 37\n122", want: []int64{98, 183, 37, 122}},
+		{in: "", want: []int64{}},
+		{in: "98 -1", wantErr: true},
+		{in: "98 x", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRequests(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadRequest) {
+					t.Errorf("ParseRequests(%q) error = %v, want %v", tt.in, err, ErrBadRequest)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRequests(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisk_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		disk     Disk
+		requests []int64
+		wantErr  bool
+	}{
+		{disk: Disk{Cylinders: 200, Head: 53}, requests: textbook},
+		{disk: Disk{Cylinders: 200, Head: 200}, wantErr: true},
+		{disk: Disk{Cylinders: 100, Head: 53}, requests: textbook, wantErr: true},
+		{disk: Disk{Cylinders: 0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := tt.disk.Validate(tt.requests); tt.wantErr != errors.Is(err, ErrBadDisk) {
+			t.Errorf("%+v.Validate(%v) = %v", tt.disk, tt.requests, err)
+		}
+	}
+}
+
+func TestAlgorithms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		run      Func
+		up       bool
+		order    []int64 // the cylinders the head stops at after the start
+		movement int64
+	}{
+		{name: "fcfs", run: FCFS, up: true, order: textbook, movement: 640},
+		{name: "sstf", run: SSTF, up: true, order: []int64{65, 67, 37, 14, 98, 122, 124, 183}, movement: 236},
+		{name: "scan down", run: SCAN, order: []int64{37, 14, 0, 65, 67, 98, 122, 124, 183}, movement: 236},
+		{name: "scan up", run: SCAN, up: true, order: []int64{65, 67, 98, 122, 124, 183, 199, 37, 14}, movement: 331},
+		{name: "cscan up", run: CSCAN, up: true, order: []int64{65, 67, 98, 122, 124, 183, 199, 0, 14, 37},
+			movement: 382},
+		{name: "look up", run: LOOK, up: true, order: []int64{65, 67, 98, 122, 124, 183, 37, 14}, movement: 299},
+		{name: "clook up", run: CLOOK, up: true, order: []int64{65, 67, 98, 122, 124, 183, 14, 37}, movement: 322},
+		{name: "clook down", run: CLOOK, order: []int64{37, 14, 183, 124, 122, 98, 67, 65}, movement: 326},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := tt.run(textbook, Disk{Cylinders: 200, Head: 53, Up: tt.up})
+			var order []int64
+			for _, s := range r.Stops[1:] {
+				order = append(order, s.Cylinder)
+			}
+			if !reflect.DeepEqual(order, tt.order) || r.Movement != tt.movement {
+				t.Errorf("head stops at %v moving %d, want %v moving %d", order, r.Movement, tt.order, tt.movement)
+			}
+			if got := len(r.Served()); got != len(textbook) {
+				t.Errorf("served %d requests, want %d", got, len(textbook))
+			}
+		})
+	}
+}
+
+func TestResult_waits(t *testing.T) {
+	t.Parallel()
+	r := FCFS([]int64{60, 50, 50}, Disk{Cylinders: 100, Head: 40})
+	// 60 waits 20 cylinders of movement, and both 50s 30
+	if r.AvgWait() != 80.0/3 || r.MaxWait() != 30 {
+		t.Errorf("AvgWait() = %v, MaxWait() = %d, want %v and 30", r.AvgWait(), r.MaxWait(), 80.0/3)
+	}
+	if r := FCFS(nil, Disk{Cylinders: 100}); r.AvgWait() != 0 || r.MaxWait() != 0 || r.Movement != 0 {
+		t.Errorf("empty queue = %+v", r)
+	}
+}
+
+func TestAlgorithms_serveEveryRequest(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 50; trial++ {
+		d := Disk{Cylinders: 1 + rng.Int63n(300), Up: trial%2 == 0}
+		d.Head = rng.Int63n(d.Cylinders)
+		requests := Generate(rng, rng.Intn(20), d.Cylinders)
+		lower := int64(-1) // the least any algorithm moved
+		for _, a := range Algorithms {
+			r := a.Run(requests, d)
+			seen := make([]bool, len(requests))
+			for _, s := range r.Served() {
+				if seen[s.Request] || requests[s.Request] != s.Cylinder {
+					t.Fatalf("%s on %+v with %v: bad stop %+v", a.Name, d, requests, s)
+				}
+				seen[s.Request] = true
+			}
+			if len(r.Served()) != len(requests) {
+				t.Errorf("%s on %+v with %v served %d", a.Name, d, requests, len(r.Served()))
+			}
+			if lower < 0 || r.Movement < lower {
+				lower = r.Movement
+			}
+		}
+		// every algorithm must at least cover the requests from the head
+		span := int64(0)
+		if len(requests) > 0 {
+			lo, hi := d.Head, d.Head
+			for _, c := range requests {
+				lo, hi = min(lo, c), max(hi, c)
+			}
+			span = hi - lo + min(d.Head-lo, hi-d.Head)
+		}
+		if lower < span {
+			t.Errorf("%+v with %v: an algorithm moved %d, less than the %d needed", d, requests, lower, span)
+		}
+	}
+}
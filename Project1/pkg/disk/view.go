@@ -0,0 +1,97 @@
+package disk
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// column returns the column of cylinder c on a chart width characters or units wide.
+func (d Disk) column(c int64, width int) int {
+	if d.Cylinders < 2 {
+		return 0
+	}
+
+	return int(c * int64(width-1) / (d.Cylinders - 1))
+}
+
+// WriteChart draws the head movement of r as text about width characters wide, one row per stop, with time running
+// down the page: o where the head started, * for a request served, | at the edge of the disk, and the path it moved
+// along as - or, for the return of a circular algorithm, as ~.
+func WriteChart(w io.Writer, r Result, width int) error {
+	width = max(width, 2)
+	label := len(strconv.FormatInt(r.Disk.Cylinders-1, 10))
+	var b strings.Builder
+	last := strconv.FormatInt(r.Disk.Cylinders-1, 10)
+	fmt.Fprintf(&b, "%*s 0%s%s\n", label, "", strings.Repeat(" ", max(width-1-len(last), 1)), last)
+	for i, s := range r.Stops {
+		row := []byte(strings.Repeat(" ", width))
+		at := r.Disk.column(s.Cylinder, width)
+		if i > 0 {
+			from := r.Disk.column(r.Stops[i-1].Cylinder, width)
+			fill := byte('-')
+			if s.Jump {
+				fill = '~'
+			}
+			for col := min(from, at); col <= max(from, at); col++ {
+				row[col] = fill
+			}
+		}
+		switch {
+		case i == 0:
+			row[at] = 'o'
+		case s.Request >= 0:
+			row[at] = '*'
+		default:
+			row[at] = '|'
+		}
+		fmt.Fprintf(&b, "%*d %s\n", label, s.Cylinder, strings.TrimRight(string(row), " "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteChartSVG draws the head movement of r as an SVG line chart under title, cylinders across and time down, with
+// a dot at every request served and the return of a circular algorithm dashed.
+func WriteChartSVG(w io.Writer, title string, r Result) error {
+	const (
+		plotWidth = 600
+		rowHeight = 24
+		margin    = 40
+	)
+	x := func(c int64) int { return margin + r.Disk.column(c, plotWidth+1) }
+	y := func(i int) int { return margin + 20 + i*rowHeight }
+	height := y(len(r.Stops)) + margin
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n",
+		plotWidth+margin*2, height)
+	fmt.Fprintf(&b, `<text x="%d" y="20" text-anchor="middle">%s head movement: %d cylinders</text>`+"\n",
+		margin+plotWidth/2, html.EscapeString(title), r.Movement)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="gray"/>`+"\n", x(0), margin, x(r.Disk.Cylinders-1),
+		margin)
+	for _, c := range []int64{0, r.Disk.Cylinders - 1} {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%d</text>`+"\n", x(c), margin-6, c)
+	}
+	for i, s := range r.Stops {
+		if i > 0 {
+			dash := ""
+			if s.Jump {
+				dash = ` stroke-dasharray="4 4"`
+			}
+			fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="steelblue"%s/>`+"\n",
+				x(r.Stops[i-1].Cylinder), y(i-1), x(s.Cylinder), y(i), dash)
+		}
+		if s.Request >= 0 {
+			fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="3" fill="steelblue"/>`+"\n", x(s.Cylinder), y(i))
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%d</text>`+"\n", x(s.Cylinder)+6, y(i)+4, s.Cylinder)
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,44 @@
+package disk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteChart(t *testing.T) {
+	t.Parallel()
+	r := CSCAN([]int64{6, 2}, Disk{Cylinders: 10, Head: 4, Up: true})
+	var w bytes.Buffer
+	if err := WriteChart(&w, r, 10); err != nil {
+		t.Fatal(err)
+	}
+	want := `  0        9
+4     o
+6     --*
+9       ---|
+0 |~~~~~~~~~
+2 --*
+`
+	if w.String() != want {
+		t.Errorf("WriteChart() =\n%s\nwant\n%s", w.String(), want)
+	}
+}
+
+func TestWriteChartSVG(t *testing.T) {
+	t.Parallel()
+	r := CLOOK([]int64{150, 20}, Disk{Cylinders: 200, Head: 100, Up: true})
+	var w bytes.Buffer
+	if err := WriteChartSVG(&w, "C-LOOK <1>", r); err != nil {
+		t.Fatal(err)
+	}
+	svg := w.String()
+	for _, want := range []string{"C-LOOK &lt;1&gt; head movement: 180 cylinders", `stroke-dasharray="4 4"`, "</svg>"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("WriteChartSVG() missing %q:\n%s", want, svg)
+		}
+	}
+	if got := strings.Count(svg, "<circle"); got != 2 {
+		t.Errorf("WriteChartSVG() has %d request dots, want 2", got)
+	}
+}
@@ -0,0 +1,494 @@
+// Package filealloc simulates how a file system lays files out on a block device. It runs a trace of file create,
+// grow, and delete operations under contiguous, linked, and indexed allocation and measures what each costs: the
+// free space contiguous allocation fragments into holes too small to use, the blocks linked and indexed allocation
+// spend on pointers, how scattered files end up, and how many blocks reading a file sequentially or one block of it at
+// random takes.
+package filealloc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrBadTrace marks a trace that cannot be read, or that names a file it has not created.
+var ErrBadTrace = errors.New("bad trace")
+
+// Op is what a request does to a file.
+type Op int
+
+const (
+	Create Op = iota
+	Grow
+	Delete
+)
+
+var opNames = []string{"create", "grow", "delete"}
+
+// Request is one operation of a trace: creating the file Name with Blocks blocks, growing it by Blocks more, or
+// deleting it.
+type Request struct {
+	Op     Op
+	Name   string
+	Blocks int
+}
+
+// String returns r as written in a trace.
+func (r Request) String() string {
+	if r.Op == Delete {
+		return fmt.Sprintf("%s %s", opNames[r.Op], r.Name)
+	}
+
+	return fmt.Sprintf("%s %s %d", opNames[r.Op], r.Name, r.Blocks)
+}
+
+// ParseTrace reads a trace of one request per line or per semicolon-separated entry: "create NAME BLOCKS",
+// "grow NAME BLOCKS", or "delete NAME". Blank lines and anything after a # are ignored. Every file must be created
+// before it is grown or deleted, and deleted before it is created again.
+func ParseTrace(r io.Reader) ([]Request, error) {
+	var requests []Request
+	exists := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, entry := range strings.Split(text, ";") {
+			fields := strings.Fields(entry)
+			if len(fields) == 0 {
+				continue
+			}
+			var req Request
+			switch {
+			case len(fields) == 3 && (fields[0] == opNames[Create] || fields[0] == opNames[Grow]):
+				n, err := strconv.Atoi(fields[2])
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("%w: line %d: %q is not a positive number of blocks", ErrBadTrace, line,
+						fields[2])
+				}
+				req = Request{Op: Create, Name: fields[1], Blocks: n}
+				if fields[0] == opNames[Grow] {
+					req.Op = Grow
+				}
+			case len(fields) == 2 && fields[0] == opNames[Delete]:
+				req = Request{Op: Delete, Name: fields[1]}
+			default:
+				return nil, fmt.Errorf("%w: line %d: want \"create NAME BLOCKS\", \"grow NAME BLOCKS\", or "+
+					"\"delete NAME\", got %q", ErrBadTrace, line, strings.TrimSpace(entry))
+			}
+			switch {
+			case req.Op == Create && exists[req.Name]:
+				return nil, fmt.Errorf("%w: line %d: %s already exists", ErrBadTrace, line, req.Name)
+			case req.Op != Create && !exists[req.Name]:
+				return nil, fmt.Errorf("%w: line %d: there is no file %s", ErrBadTrace, line, req.Name)
+			}
+			exists[req.Name] = req.Op != Delete
+			requests = append(requests, req)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading trace", err)
+	}
+
+	return requests, nil
+}
+
+// Device is the block device files are allocated on: Blocks blocks of BlockSize bytes, with block pointers, for
+// linked and indexed allocation, of Pointer bytes.
+type Device struct {
+	Blocks    int
+	BlockSize int
+	Pointer   int
+}
+
+// pointersPerBlock returns how many block pointers fit in a block of d, at least 2.
+func (d Device) pointersPerBlock() int {
+	return max(d.BlockSize/max(d.Pointer, 1), 2)
+}
+
+// indexBlocks returns how many index blocks a file of n blocks needs on d, each holding the pointers to as many data
+// blocks as it has room for but one, which points to the next index block.
+func (d Device) indexBlocks(n int) int {
+	per := d.pointersPerBlock() - 1
+	return (n + per - 1) / per
+}
+
+// File is a file as laid out: Data are its blocks in file order, and Index its index blocks in order, if any.
+type File struct {
+	Name  string
+	Data  []int
+	Index []int
+}
+
+// Extents returns how many runs of consecutive blocks the data of f lies in.
+func (f File) Extents() int {
+	n := 0
+	for i, b := range f.Data {
+		if i == 0 || b != f.Data[i-1]+1 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// disk is the state of a device as a strategy allocates on it.
+type disk struct {
+	Device
+	owner []string // the file each block belongs to, or ""
+	files map[string]*File
+}
+
+func (d *disk) free() int {
+	n := 0
+	for _, o := range d.owner {
+		if o == "" {
+			n++
+		}
+	}
+
+	return n
+}
+
+// lowestFree takes the n lowest-numbered free blocks for name.
+func (d *disk) lowestFree(name string, n int) []int {
+	var taken []int
+	for b := 0; b < len(d.owner) && len(taken) < n; b++ {
+		if d.owner[b] == "" {
+			d.owner[b] = name
+			taken = append(taken, b)
+		}
+	}
+
+	return taken
+}
+
+// allocator lays out n more blocks of data for f on d, returning how many blocks it copied to make room, or the
+// reason it could not.
+type allocator func(d *disk, f *File, n int) (copied int, failed string)
+
+// Strategy is an allocation strategy along with the name it is selected by, the title its reports carry, and a
+// one-line description for listings.
+type Strategy struct {
+	Name        string
+	Title       string
+	Description string
+	allocate    allocator
+}
+
+// Strategies lists every strategy in the order they run by default.
+var Strategies = []Strategy{
+	{Name: "contiguous", Title: "Contiguous",
+		Description: "each file in one run of blocks, first fit, moved to a bigger hole when it cannot grow in place",
+		allocate:    contiguous},
+	{Name: "linked", Title: "Linked",
+		Description: "each block anywhere, pointing to the next, so reaching block i reads the i before it",
+		allocate:    linked},
+	{Name: "indexed", Title: "Indexed",
+		Description: "each block anywhere, listed in index blocks chained one to the next", allocate: indexed},
+}
+
+// contiguous grows f in place if the blocks after it are free, and otherwise moves it to the first hole, counting its
+// own blocks as free, that fits all of it.
+func contiguous(d *disk, f *File, n int) (int, string) {
+	if len(f.Data) > 0 {
+		end := f.Data[len(f.Data)-1] + 1
+		inPlace := end+n <= len(d.owner)
+		for b := end; inPlace && b < end+n; b++ {
+			inPlace = d.owner[b] == ""
+		}
+		if inPlace {
+			for b := end; b < end+n; b++ {
+				d.owner[b] = f.Name
+				f.Data = append(f.Data, b)
+			}
+			return 0, ""
+		}
+	}
+
+	size := len(f.Data) + n
+	run, largest := 0, 0
+	for b := 0; b < len(d.owner); b++ {
+		if d.owner[b] != "" && d.owner[b] != f.Name {
+			run = 0
+			continue
+		}
+		run++
+		largest = max(largest, run)
+		if run < size {
+			continue
+		}
+		start := b - size + 1
+		copied := len(f.Data)
+		for _, old := range f.Data {
+			d.owner[old] = ""
+		}
+		f.Data = f.Data[:0]
+		for c := start; c < start+size; c++ {
+			d.owner[c] = f.Name
+			f.Data = append(f.Data, c)
+		}
+		return copied, ""
+	}
+
+	return 0, fmt.Sprintf("no hole of %d blocks; the largest is %d of %d free", size, largest,
+		d.free()+len(f.Data))
+}
+
+// linked takes the lowest-numbered free blocks.
+func linked(d *disk, f *File, n int) (int, string) {
+	if free := d.free(); free < n {
+		return 0, fmt.Sprintf("needs %d blocks, %d free", n, free)
+	}
+	f.Data = append(f.Data, d.lowestFree(f.Name, n)...)
+
+	return 0, ""
+}
+
+// indexed takes the lowest-numbered free blocks for any index blocks f needs to grow, then for its data.
+func indexed(d *disk, f *File, n int) (int, string) {
+	more := d.indexBlocks(len(f.Data)+n) - len(f.Index)
+	if free := d.free(); free < n+more {
+		return 0, fmt.Sprintf("needs %d blocks with %d for its index, %d free", n+more, more, free)
+	}
+	f.Index = append(f.Index, d.lowestFree(f.Name, more)...)
+	f.Data = append(f.Data, d.lowestFree(f.Name, n)...)
+
+	return 0, ""
+}
+
+// Step is what one request did: the blocks it gave the file, or took back on delete, how many blocks it copied to
+// move the file, and the map of the device afterwards, as MapLine draws it. Failed is the reason a request could not
+// be met, if it couldn't.
+type Step struct {
+	Request Request
+	Blocks  []int
+	Copied  int
+	Failed  string
+	Map     string
+}
+
+// Result is a run of one strategy over a trace: every step, and every file left at the end in the order created.
+type Result struct {
+	Strategy Strategy
+	Device   Device
+	Steps    []Step
+	Files    []File
+	Free     int
+	// Holes are the runs of free blocks left at the end, by length in block order.
+	Holes []int
+}
+
+// Simulate runs requests under s on a device d, which starts empty. A request that cannot be met leaves the device as
+// it was; growing a file that failed to be created creates it.
+func Simulate(requests []Request, s Strategy, d Device) Result {
+	dk := &disk{Device: d, owner: make([]string, max(d.Blocks, 0)), files: make(map[string]*File)}
+	var order []string // files in the order created
+	symbols := make(map[string]byte)
+	r := Result{Strategy: s, Device: d}
+	for _, req := range requests {
+		step := Step{Request: req}
+		f := dk.files[req.Name]
+		switch {
+		case req.Op == Delete && f != nil:
+			step.Blocks = append(append([]int(nil), f.Index...), f.Data...)
+			for _, b := range step.Blocks {
+				dk.owner[b] = ""
+			}
+			delete(dk.files, req.Name)
+		case req.Op == Delete:
+			step.Failed = "it was never created"
+		default:
+			if f == nil {
+				f = &File{Name: req.Name}
+			}
+			before := *f
+			before.Data = append([]int(nil), f.Data...)
+			owner := append([]string(nil), dk.owner...)
+			step.Copied, step.Failed = s.allocate(dk, f, req.Blocks)
+			if step.Failed != "" {
+				*f, dk.owner, step.Copied = before, owner, 0
+				break
+			}
+			if _, ok := dk.files[req.Name]; !ok {
+				dk.files[req.Name] = f
+			}
+			step.Blocks = newBlocks(before, *f)
+		}
+		if _, ok := symbols[req.Name]; !ok && dk.files[req.Name] != nil {
+			symbols[req.Name] = mapSymbols[len(symbols)%len(mapSymbols)]
+			order = append(order, req.Name)
+		}
+		step.Map = dk.draw(symbols)
+		r.Steps = append(r.Steps, step)
+	}
+
+	for _, name := range order {
+		if f, ok := dk.files[name]; ok {
+			r.Files = append(r.Files, *f)
+		}
+	}
+	run := 0
+	for b := 0; b <= len(dk.owner); b++ {
+		if b < len(dk.owner) && dk.owner[b] == "" {
+			r.Free, run = r.Free+1, run+1
+			continue
+		}
+		if run > 0 {
+			r.Holes = append(r.Holes, run)
+		}
+		run = 0
+	}
+
+	return r
+}
+
+// newBlocks returns the blocks after holds that before did not, index blocks first.
+func newBlocks(before, after File) []int {
+	had := make(map[int]bool, len(before.Data)+len(before.Index))
+	for _, b := range append(append([]int(nil), before.Index...), before.Data...) {
+		had[b] = true
+	}
+	var added []int
+	for _, b := range append(append([]int(nil), after.Index...), after.Data...) {
+		if !had[b] {
+			added = append(added, b)
+		}
+	}
+
+	return added
+}
+
+// Map symbols: a file's data blocks are drawn with a symbol of its own, given out in the order files are created.
+const (
+	MapFree  = '.'
+	MapIndex = '*'
+)
+
+const mapSymbols = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// draw returns one symbol per block of d: MapFree, MapIndex, or the symbol of the file it holds data of.
+func (d *disk) draw(symbols map[string]byte) string {
+	line := []byte(strings.Repeat(string(rune(MapFree)), len(d.owner)))
+	for name, f := range d.files {
+		for _, b := range f.Data {
+			line[b] = symbols[name]
+		}
+		for _, b := range f.Index {
+			line[b] = MapIndex
+		}
+	}
+
+	return string(line)
+}
+
+// Failures returns how many requests of r could not be met.
+func (r Result) Failures() int {
+	n := 0
+	for _, s := range r.Steps {
+		if s.Failed != "" {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Copied returns how many blocks r copied moving files.
+func (r Result) Copied() int {
+	n := 0
+	for _, s := range r.Steps {
+		n += s.Copied
+	}
+
+	return n
+}
+
+// LargestHole returns the longest run of free blocks left at the end.
+func (r Result) LargestHole() int {
+	largest := 0
+	for _, h := range r.Holes {
+		largest = max(largest, h)
+	}
+
+	return largest
+}
+
+// ExternalFragmentation returns the fraction of the free blocks outside the largest hole, which a contiguous file
+// could not use all of: 0 when the free space is one hole, or there is none.
+func (r Result) ExternalFragmentation() float64 {
+	if r.Free == 0 {
+		return 0
+	}
+
+	return 1 - float64(r.LargestHole())/float64(r.Free)
+}
+
+// AverageExtents returns the mean number of runs of consecutive blocks the files left lie in.
+func (r Result) AverageExtents() float64 {
+	if len(r.Files) == 0 {
+		return 0
+	}
+	total := 0
+	for _, f := range r.Files {
+		total += f.Extents()
+	}
+
+	return float64(total) / float64(len(r.Files))
+}
+
+// Wasted returns the blocks the files left spend on anything but data: the pointer in every block under linked
+// allocation, as a fraction of a block each, and index blocks under indexed allocation.
+func (r Result) Wasted() float64 {
+	var wasted float64
+	for _, f := range r.Files {
+		wasted += float64(len(f.Index))
+		if r.Strategy.Name == "linked" {
+			wasted += float64(len(f.Data)*r.Device.Pointer) / float64(max(r.Device.BlockSize, 1))
+		}
+	}
+
+	return wasted
+}
+
+// SequentialReads returns the mean blocks read per data block reading every file left from start to end: its data
+// blocks, and its index blocks along the way.
+func (r Result) SequentialReads() float64 {
+	var data, read int
+	for _, f := range r.Files {
+		data += len(f.Data)
+		read += len(f.Data) + len(f.Index)
+	}
+	if data == 0 {
+		return 0
+	}
+
+	return float64(read) / float64(data)
+}
+
+// RandomReads returns the mean blocks read to reach one data block, chosen uniformly from every file left, directly:
+// one under contiguous allocation, which computes where it is, the blocks before it and itself under linked, and the
+// index blocks up to the one pointing at it and itself under indexed.
+func (r Result) RandomReads() float64 {
+	var data, read int
+	per := r.Device.pointersPerBlock() - 1
+	for _, f := range r.Files {
+		for i := range f.Data {
+			data++
+			switch r.Strategy.Name {
+			case "linked":
+				read += i + 1
+			case "indexed":
+				read += i/per + 2
+			default:
+				read++
+			}
+		}
+	}
+	if data == 0 {
+		return 0
+	}
+
+	return float64(read) / float64(data)
+}
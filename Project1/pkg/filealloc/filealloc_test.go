@@ -0,0 +1,131 @@
+package filealloc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// squeeze leaves B's hole too small for A to grow into contiguously, with C in the way of growing in place.
+const squeeze = `create A 3
+create B 2; create C 2
+delete B # frees two blocks between A and C
+grow A 3`
+
+func TestParseTrace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    []Request
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "create A 2; grow A 1\n\ndelete A # done", want: []Request{{Op: Create, Name: "A", Blocks: 2},
+			{Op: Grow, Name: "A", Blocks: 1}, {Op: Delete, Name: "A"}}},
+		{in: "create A 1; delete A; create A 2", want: []Request{{Op: Create, Name: "A", Blocks: 1},
+			{Op: Delete, Name: "A"}, {Op: Create, Name: "A", Blocks: 2}}},
+		{in: "create A", wantErr: true},
+		{in: "create A 0", wantErr: true},
+		{in: "grow A 1", wantErr: true},
+		{in: "create A 1; create A 1", wantErr: true},
+		{in: "create A 1; delete A; delete A", wantErr: true},
+		{in: "truncate A 1", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTrace(strings.NewReader(tt.in))
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadTrace) {
+					t.Errorf("ParseTrace(%q) error = %v, want %v", tt.in, err, ErrBadTrace)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTrace(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+	requests, err := ParseTrace(strings.NewReader(squeeze))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := Device{Blocks: 10, BlockSize: 64, Pointer: 4}
+	tests := []struct {
+		strategy   Strategy
+		grown      []int
+		failures   int
+		final      string
+		holes      []int
+		wasted     float64
+		extents    float64
+		sequential float64
+		random     float64
+	}{
+		{strategy: Strategies[0], failures: 1, final: "AAA..CC...", holes: []int{2, 3}, extents: 1, sequential: 1,
+			random: 1},
+		{strategy: Strategies[1], grown: []int{3, 4, 7}, final: "AAAAACCA..", holes: []int{2}, wasted: 0.5,
+			extents: 1.5, sequential: 1, random: 3},
+		{strategy: Strategies[2], grown: []int{4, 5, 6}, final: "*AAAAAA*CC", wasted: 2, extents: 1,
+			sequential: 1.25, random: 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.strategy.Name, func(t *testing.T) {
+			t.Parallel()
+			r := Simulate(requests, tt.strategy, device)
+			last := r.Steps[len(r.Steps)-1]
+			if !reflect.DeepEqual(last.Blocks, tt.grown) || r.Failures() != tt.failures || last.Map != tt.final {
+				t.Errorf("growing A = %+v with %d failures, want blocks %v, %d failures, and map %s", last,
+					r.Failures(), tt.grown, tt.failures, tt.final)
+			}
+			if !reflect.DeepEqual(r.Holes, tt.holes) || r.Wasted() != tt.wasted || r.AverageExtents() != tt.extents ||
+				r.SequentialReads() != tt.sequential || r.RandomReads() != tt.random {
+				t.Errorf("holes %v, wasted %v, extents %v, reads %v and %v, want %v, %v, %v, %v and %v", r.Holes,
+					r.Wasted(), r.AverageExtents(), r.SequentialReads(), r.RandomReads(), tt.holes, tt.wasted,
+					tt.extents, tt.sequential, tt.random)
+			}
+		})
+	}
+
+	r := Simulate(requests, Strategies[0], device)
+	if r.LargestHole() != 3 || r.Free != 5 || r.ExternalFragmentation() != 0.4 {
+		t.Errorf("largest hole %d of %d free, fragmentation %v, want 3 of 5 and 0.4", r.LargestHole(), r.Free,
+			r.ExternalFragmentation())
+	}
+	if want := "no hole of 6 blocks; the largest is 5 of 8 free"; r.Steps[4].Failed != want {
+		t.Errorf("growing A failed with %q, want %q", r.Steps[4].Failed, want)
+	}
+}
+
+func TestSimulate_relocate(t *testing.T) {
+	t.Parallel()
+	requests := []Request{{Op: Create, Name: "A", Blocks: 2}, {Op: Create, Name: "B", Blocks: 1},
+		{Op: Grow, Name: "A", Blocks: 2}, {Op: Grow, Name: "A", Blocks: 1}}
+	r := Simulate(requests, Strategies[0], Device{Blocks: 10, BlockSize: 64, Pointer: 4})
+	if r.Copied() != 2 || r.Steps[2].Map != "..BAAAA..." || r.Steps[3].Map != "..BAAAAA.." {
+		t.Errorf("copied %d, maps %s and %s, want A moved past B copying 2, then grown in place", r.Copied(),
+			r.Steps[2].Map, r.Steps[3].Map)
+	}
+}
+
+func TestSimulate_indexChain(t *testing.T) {
+	t.Parallel()
+	// three pointers to a block: two to data, one to the next index block
+	device := Device{Blocks: 10, BlockSize: 12, Pointer: 4}
+	r := Simulate([]Request{{Op: Create, Name: "A", Blocks: 5}, {Op: Grow, Name: "A", Blocks: 3}}, Strategies[2],
+		device)
+	if r.Steps[0].Map != "***AAAAA.." || r.SequentialReads() != 1.6 || r.RandomReads() != 2.8 {
+		t.Errorf("map %s, reads %v and %v, want ***AAAAA.., 1.6, and 2.8", r.Steps[0].Map, r.SequentialReads(),
+			r.RandomReads())
+	}
+	if want := "needs 4 blocks with 1 for its index, 2 free"; r.Steps[1].Failed != want || len(r.Files[0].Data) != 5 {
+		t.Errorf("growing A = %+v, want it to fail with %q and leave A alone", r.Steps[1], want)
+	}
+}
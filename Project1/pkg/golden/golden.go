@@ -0,0 +1,158 @@
+// Package golden regression-tests scheduler output against golden files: it renders results in a stable text form
+// and compares output with the files, showing a line diff on a mismatch. Set UPDATE_GOLDEN=1 to rewrite the files
+// from the current output instead, then review the change with git diff.
+package golden
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+)
+
+// UpdateEnv is the environment variable that, when set to 1, makes Check write golden files rather than compare
+// against them.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Render writes r under title in a stable text form: the Gantt chart, the finished processes by PID, and the
+// averages at fixed precision. The same result always renders to the same bytes.
+func Render(w io.Writer, title string, r sched.Result) error {
+	var b bytes.Buffer
+	_, _ = fmt.Fprintln(&b, title)
+	report.WriteGantt(&b, r.Slices, false)
+
+	done := append([]sched.ProcessMetrics(nil), r.PerProcess...)
+	sort.SliceStable(done, func(i, j int) bool { return done[i].ProcessID < done[j].ProcessID })
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "PID\tARRIVAL\tBURST\tPRIORITY\tWAIT\tTURNAROUND\tCOMPLETION\t")
+	for _, p := range done {
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			p.ProcessID, p.ArrivalTime, p.Burst, p.Priority, p.Wait, p.Turnaround, p.Completion)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(&b, "\nAverages: wait %.2f, turnaround %.2f, normalized turnaround %.2f, throughput %.4f\n",
+		r.Summary.Wait, r.Summary.Turnaround, r.Summary.Normalized, r.Summary.Throughput)
+
+	if _, err := b.WriteTo(w); err != nil {
+		return fmt.Errorf("%w: rendering %s", err, title)
+	}
+
+	return nil
+}
+
+// Check fails t with a line diff if got differs from the golden file at path. With UPDATE_GOLDEN=1 it writes got to
+// path instead, creating its directory if needed.
+func Check(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if os.Getenv(UpdateEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with %s=1 to create it)", err, UpdateEnv)
+	}
+	if !bytes.Equal(got, want) {
+		diff := Diff(string(want), string(got))
+		if diff == "" {
+			diff = "only the final newline differs\n"
+		}
+		t.Errorf("output differs from %s (-want +got):\n%s", path, diff)
+	}
+}
+
+// CheckResult renders r under title and checks it against the golden file at path.
+func CheckResult(t testing.TB, path, title string, r sched.Result) {
+	t.Helper()
+	var b bytes.Buffer
+	if err := Render(&b, title, r); err != nil {
+		t.Fatal(err)
+	}
+	Check(t, path, b.Bytes())
+}
+
+// Diff returns a line diff turning want into got: lines only in want start with "-", lines only in got with "+",
+// and unchanged lines with " ". Runs of unchanged lines more than a few lines from a change are elided, and it is
+// empty if the lines are the same.
+func Diff(want, got string) string {
+	a, b := strings.Split(strings.TrimSuffix(want, "\n"), "\n"), strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{' ', a[i]})
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, line{'+', b[j]})
+			j++
+		}
+	}
+
+	const context = 3
+	near := make([]bool, len(lines))
+	changed := false
+	for k, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		changed = true
+		for n := max(0, k-context); n <= min(len(lines)-1, k+context); n++ {
+			near[n] = true
+		}
+	}
+	if !changed {
+		return ""
+	}
+	var out strings.Builder
+	for k, l := range lines {
+		if !near[k] {
+			if k == 0 || near[k-1] {
+				out.WriteString("  ...\n")
+			}
+			continue
+		}
+		out.WriteByte(l.op)
+		out.WriteByte(' ')
+		out.WriteString(l.text)
+		out.WriteByte('\n')
+	}
+
+	return out.String()
+}
@@ -0,0 +1,92 @@
+package golden
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// TestSchedulers pins the output of every built-in scheduler on one workload.
+func TestSchedulers(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+		{ProcessID: 4, ArrivalTime: 8, BurstDuration: 2, Priority: 1},
+		{ProcessID: 5, ArrivalTime: 25, BurstDuration: 3, Priority: 2},
+	}
+	tests := []struct {
+		name     string
+		schedule sched.Func
+	}{
+		{name: "fcfs", schedule: sched.FCFS},
+		{name: "sjf", schedule: sched.SJF},
+		{name: "priority", schedule: sched.Priority},
+		{name: "rr", schedule: func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+			return sched.RR(ctx, processes, 4)
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := tt.schedule(context.Background(), processes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			CheckResult(t, filepath.Join("testdata", tt.name+".golden"), tt.name, r)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	r := sched.NewResult([]sched.TimeSlice{{PID: 2, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 3}}, []workload.Process{
+		{ProcessID: 2, Burst: 1, Turnaround: 1, Completion: 1},
+		{ProcessID: 1, Burst: 2, Wait: 1, Turnaround: 3, Completion: 3},
+	})
+	var a, b bytes.Buffer
+	if err := Render(&a, "test", r); err != nil {
+		t.Fatal(err)
+	}
+	// processes are listed by PID whatever order they finished in
+	r.PerProcess[0], r.PerProcess[1] = r.PerProcess[1], r.PerProcess[0]
+	if err := Render(&b, "test", r); err != nil {
+		t.Fatal(err)
+	}
+	if a.String() != b.String() {
+		t.Errorf("rendering depends on finish order:\n%s", Diff(a.String(), b.String()))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		want, got string
+		diff      string
+	}{
+		{name: "equal", want: "a\nb\n", got: "a\nb\n", diff: ""},
+		{name: "changed line", want: "a\nb\nc\n", got: "a\nB\nc\n", diff: "  a\n- b\n+ B\n  c\n"},
+		{
+			name: "far context elided",
+			want: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			got:  "1\n2\n3\n4\n5\n6\n7\n8\nnine\n",
+			diff: "  ...\n  6\n  7\n  8\n- 9\n+ nine\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Diff(tt.want, tt.got); got != tt.diff {
+				t.Errorf("Diff() =\n%q, want\n%q", got, tt.diff)
+			}
+		})
+	}
+}
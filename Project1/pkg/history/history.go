@@ -0,0 +1,182 @@
+// Package history is the results database: a file that every run is appended to, one JSON line per run, holding the
+// hash of its workload, the options it ran under, and each algorithm's run-wide metrics. Runs are numbered by their
+// position in the file, from 1, so concurrent runs can append to it without coordinating.
+package history
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ErrBadHistory marks a database file with a line that isn't a Run.
+var ErrBadHistory = errors.New("malformed history file")
+
+// ErrNoRun marks a lookup of a run ID that isn't in the database.
+var ErrNoRun = errors.New("no such run")
+
+// ErrNoWorkload marks a run whose workload was too large to keep.
+var ErrNoWorkload = errors.New("workload not kept")
+
+// Options are the options a run was made with that change its results.
+type Options struct {
+	Algorithms []string `json:"algorithms"`
+	Quantum    int64    `json:"quantum"`
+	Seed       int64    `json:"seed"`
+	SwitchCost int64    `json:"switch_cost,omitempty"`
+	Perturb    float64  `json:"perturb,omitempty"`
+}
+
+// Run is one past run.
+type Run struct {
+	// ID is the run's position in the database, from 1. It is not stored.
+	ID   int       `json:"-"`
+	Time time.Time `json:"time"`
+	// Source is the file or bundled example the workload was loaded from.
+	Source       string `json:"source"`
+	WorkloadHash string `json:"workload_hash"`
+	// Workload is the workload as CSV, left empty for workloads too large to keep.
+	Workload string                   `json:"workload,omitempty"`
+	Options  Options                  `json:"options"`
+	Results  []report.AlgorithmRecord `json:"results"`
+}
+
+// Processes parses the workload of r.
+func (r Run) Processes() ([]workload.Process, error) {
+	if r.Workload == "" {
+		return nil, fmt.Errorf("%w: run %d", ErrNoWorkload, r.ID)
+	}
+	return workload.Load(strings.NewReader(r.Workload))
+}
+
+// Hash returns the SHA-256 of processes in the workload CSV format, in hex, so the same workload has the same hash
+// wherever it was loaded from.
+func Hash(processes []workload.Process) string {
+	h := sha256.New()
+	_ = workload.Write(h, processes)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DB is a results database file.
+type DB struct {
+	path string
+}
+
+// Open returns the database at path, which is created with its directory when the first run is added.
+func Open(path string) *DB {
+	return &DB{path: path}
+}
+
+// Add appends r, less its ID, to the database.
+func (db *DB) Add(r Run) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("%w: encoding run", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(db.path), 0o755); err != nil {
+		return fmt.Errorf("%w: creating history directory", err)
+	}
+	f, err := os.OpenFile(db.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("%w: opening history", err)
+	}
+	// a single write keeps the line whole when other runs append at the same time
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%w: adding run to history", err)
+	}
+
+	return nil
+}
+
+// Runs returns the runs matching f, oldest first. A database that doesn't exist yet has none.
+func (db *DB) Runs(f Filter) ([]Run, error) {
+	file, err := os.Open(db.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: opening history", err)
+	}
+	defer file.Close()
+
+	var runs []Run
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<30)
+	for id := 1; scanner.Scan(); id++ {
+		var r Run
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%w: %s line %d: %v", ErrBadHistory, db.path, id, err)
+		}
+		r.ID = id
+		if f.Match(r) {
+			runs = append(runs, r)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading history", err)
+	}
+
+	return runs, nil
+}
+
+// Get returns the run with ID id.
+func (db *DB) Get(id int) (Run, error) {
+	runs, err := db.Runs(Filter{ID: id})
+	if err != nil {
+		return Run{}, err
+	}
+	if len(runs) == 0 {
+		return Run{}, fmt.Errorf("%w: %d", ErrNoRun, id)
+	}
+
+	return runs[0], nil
+}
+
+// Filter selects runs. Its zero value selects every run.
+type Filter struct {
+	ID int
+	// Workload matches runs whose source contains it or whose workload hash starts with it.
+	Workload string
+	// Algorithm matches runs that ran the algorithm of that name.
+	Algorithm string
+	// Since matches runs made at or after it.
+	Since time.Time
+}
+
+// Match reports whether f selects r.
+func (f Filter) Match(r Run) bool {
+	if f.ID != 0 && r.ID != f.ID {
+		return false
+	}
+	if f.Workload != "" && !strings.Contains(r.Source, f.Workload) && !strings.HasPrefix(r.WorkloadHash, f.Workload) {
+		return false
+	}
+	if !f.Since.IsZero() && r.Time.Before(f.Since) {
+		return false
+	}
+	if f.Algorithm == "" {
+		return true
+	}
+	for _, a := range r.Options.Algorithms {
+		if a == f.Algorithm {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestDB(t *testing.T) {
+	t.Parallel()
+	db := Open(filepath.Join(t.TempDir(), "nested", "history.jsonl"))
+	if runs, err := db.Runs(Filter{}); err != nil || len(runs) != 0 {
+		t.Fatalf("Runs() of a new database = %v, %v, want none", runs, err)
+	}
+
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}}
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	added := []Run{
+		{Time: day, Source: "a.csv", WorkloadHash: Hash(processes), Workload: "1,3,0,0\n2,2,1,0\n",
+			Options: Options{Algorithms: []string{"fcfs", "rr"}, Quantum: 2},
+			Results: []report.AlgorithmRecord{{Name: "fcfs", AvgWait: 1}, {Name: "rr", AvgWait: 1.5}}},
+		{Time: day.Add(48 * time.Hour), Source: "example:srtf", WorkloadHash: "0123abcd",
+			Options: Options{Algorithms: []string{"sjf"}, Quantum: 1}},
+	}
+	for _, r := range added {
+		if err := db.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []int
+	}{
+		{name: "all", want: []int{1, 2}},
+		{name: "by id", filter: Filter{ID: 2}, want: []int{2}},
+		{name: "by source", filter: Filter{Workload: "a.csv"}, want: []int{1}},
+		{name: "by hash prefix", filter: Filter{Workload: "0123"}, want: []int{2}},
+		{name: "by algorithm", filter: Filter{Algorithm: "rr"}, want: []int{1}},
+		{name: "since", filter: Filter{Since: day.Add(time.Hour)}, want: []int{2}},
+		{name: "nothing", filter: Filter{Algorithm: "lottery"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			runs, err := db.Runs(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int
+			for _, r := range runs {
+				got = append(got, r.ID)
+			}
+			if len(got) != len(tt.want) || len(got) > 0 && got[0] != tt.want[0] {
+				t.Errorf("Runs(%+v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+
+	r, err := db.Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Processes()
+	if err != nil || len(got) != 2 || got[1] != processes[1] || r.Results[1].AvgWait != 1.5 {
+		t.Errorf("Get(1) = %+v with processes %v, %v", r, got, err)
+	}
+	r, _ = db.Get(2)
+	if _, err := r.Processes(); !errors.Is(err, ErrNoWorkload) {
+		t.Errorf("Processes() of a run without its workload error = %v, want %v", err, ErrNoWorkload)
+	}
+	if _, err := db.Get(3); !errors.Is(err, ErrNoRun) {
+		t.Errorf("Get(3) error = %v, want %v", err, ErrNoRun)
+	}
+}
+
+func TestDB_malformed(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	if err := os.WriteFile(path, []byte("{}\nnot json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path).Runs(Filter{}); !errors.Is(err, ErrBadHistory) {
+		t.Errorf("Runs() error = %v, want %v", err, ErrBadHistory)
+	}
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+	a := []workload.Process{{ProcessID: 1, BurstDuration: 3}}
+	b := []workload.Process{{ProcessID: 1, BurstDuration: 4}}
+	if Hash(a) != Hash(append([]workload.Process(nil), a...)) || Hash(a) == Hash(b) || len(Hash(a)) != 64 {
+		t.Errorf("Hash() = %s, %s", Hash(a), Hash(b))
+	}
+}
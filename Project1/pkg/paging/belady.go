@@ -0,0 +1,31 @@
+package paging
+
+// FaultCurve is the faults of one algorithm over a reference string with each of a range of frame counts.
+type FaultCurve struct {
+	// From is the frame count of Faults[0]; Faults[i] is the faults with From+i frames.
+	From   int
+	Faults []int
+}
+
+// Sweep runs run over refs with every frame count from from to to.
+func Sweep(run Func, refs []Reference, from, to int) FaultCurve {
+	c := FaultCurve{From: from, Faults: make([]int, 0, max(to-from+1, 0))}
+	for frames := from; frames <= to; frames++ {
+		c.Faults = append(c.Faults, run(refs, frames).Faults)
+	}
+
+	return c
+}
+
+// Anomalies returns the frame counts at which c faults more than with one frame fewer: Belady's anomaly, which FIFO
+// can show and stack algorithms such as LRU and Optimal never do.
+func (c FaultCurve) Anomalies() []int {
+	var frames []int
+	for i := 1; i < len(c.Faults); i++ {
+		if c.Faults[i] > c.Faults[i-1] {
+			frames = append(frames, c.From+i)
+		}
+	}
+
+	return frames
+}
@@ -0,0 +1,34 @@
+package paging
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFaultCurve_Anomalies(t *testing.T) {
+	t.Parallel()
+	belady, _ := ParseReferences("1 2 3 4 1 2 5 1 2 3 4 5")
+	tests := []struct {
+		name          string
+		run           Func
+		wantFaults    []int
+		wantAnomalies []int
+	}{
+		{name: "fifo", run: FIFO, wantFaults: []int{12, 12, 9, 10, 5, 5}, wantAnomalies: []int{4}},
+		{name: "lru", run: LRU, wantFaults: []int{12, 12, 10, 8, 5, 5}},
+		{name: "optimal", run: Optimal, wantFaults: []int{12, 9, 7, 6, 5, 5}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := Sweep(tt.run, belady, 1, 6)
+			if c.From != 1 || !reflect.DeepEqual(c.Faults, tt.wantFaults) {
+				t.Errorf("Sweep() = %+v, want faults %v", c, tt.wantFaults)
+			}
+			if got := c.Anomalies(); !reflect.DeepEqual(got, tt.wantAnomalies) {
+				t.Errorf("Anomalies() = %v, want %v", got, tt.wantAnomalies)
+			}
+		})
+	}
+}
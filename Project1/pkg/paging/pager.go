@@ -0,0 +1,319 @@
+package paging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooFewFrames marks a Pager with fewer frames than processes, one of which could never hold a page long enough
+// to run.
+var ErrTooFewFrames = errors.New("too few frames")
+
+// ErrBadPFF marks page-fault frequency bounds that are out of order or outside 0 to 1, or a window of no references.
+var ErrBadPFF = errors.New("bad page-fault frequency bounds")
+
+// page is a page of one process.
+type page struct {
+	pid  int64
+	page int64
+}
+
+// PFF is page-fault frequency control of how many frames each process may hold: after every Window references a
+// process makes, it gets another frame, if there is one no process may hold, when more than Upper of them faulted,
+// and gives one up, if it has more than one, when fewer than Lower did.
+type PFF struct {
+	Window int
+	Lower  float64
+	Upper  float64
+}
+
+// Validate reports whether c describes page-fault frequency control that can be simulated.
+func (c PFF) Validate() error {
+	if c.Window < 1 || c.Lower < 0 || c.Upper > 1 || c.Lower > c.Upper {
+		return fmt.Errorf("%w: %g to %g over %d references", ErrBadPFF, c.Lower, c.Upper, c.Window)
+	}
+
+	return nil
+}
+
+// Allocation is a change to the frames a process may hold, at Time.
+type Allocation struct {
+	Time   int64
+	Frames int
+}
+
+// frameAllocation tells the ways of allocating frames among processes apart.
+type frameAllocation int
+
+const (
+	global frameAllocation = iota
+	equal
+	proportional
+)
+
+// FrameAllocation is a way of allocating frames among the processes sharing them along with the name it is selected
+// by, the title its reports carry, and a one-line description for listings.
+type FrameAllocation struct {
+	Name        string
+	Title       string
+	Description string
+	kind        frameAllocation
+}
+
+// FrameAllocations lists every frame allocation in the order they run by default.
+var FrameAllocations = []FrameAllocation{
+	{Name: "global", Title: "Global",
+		Description: "any process takes any frame, replacing the least recently used page of all", kind: global},
+	{Name: "equal", Title: "Local, equal",
+		Description: "each process replaces within an equal share of the frames", kind: equal},
+	{Name: "proportional", Title: "Local, proportional",
+		Description: "each process replaces within a share proportional to its size, the pages it references",
+		kind:        proportional},
+}
+
+// Local reports whether a replaces pages within each process's own share of the frames.
+func (a FrameAllocation) Local() bool {
+	return a.kind != global
+}
+
+// recentPages is how many of a process's most recently used pages a Pager remembers to prepage.
+const recentPages = 64
+
+// Pager is demand paging as it happens, one reference at a time, for processes sharing a set of frames, to drive a
+// CPU scheduler rather than replay a whole reference string as the algorithms do. A full set of frames gives up its
+// least recently used page: any process's under global replacement, or under local replacement only the faulting
+// process's own, each process then holding a share of the frames, equal or proportional to its size, or under PFF as
+// many as its fault rate earns it.
+type Pager struct {
+	frames []page
+	used   []int64       // when each frame's page was last used, or will be once it is loaded
+	quota  map[int64]int // frames each process may hold under local replacement, or nil for global
+	held   map[int64]int
+	// recent holds each process's distinct pages, most recently used first, and prepaged the pages loaded ahead of
+	// a fault and not yet used.
+	recent   map[int64][]int64
+	prepaged map[page]bool
+	window   map[int64][]bool // whether each reference since the allocation was last reviewed faulted, under PFF
+
+	// Prepage is how many of a faulting process's recently used pages that are no longer in a frame are loaded back
+	// along with the page it faulted on, serviced by the same fault. Set it and PFF before the first Access.
+	Prepage int
+	// PFF, if set, moves frames between processes by their page-fault frequency. It needs local replacement.
+	PFF *PFF
+
+	// Faults counts the page faults of each process, and References all its references.
+	Faults     map[int64]int
+	References map[int64]int
+	// Prepaged counts the pages each process had prepaged, and PrepageHits those it used before they were evicted.
+	Prepaged    map[int64]int
+	PrepageHits map[int64]int
+	// Allocations lists, for each process under local replacement, the frames it may hold from the start and every
+	// change to them since.
+	Allocations map[int64][]Allocation
+}
+
+// NewPager returns a pager of frames frames, empty, for the processes sizes lists with the pages each references,
+// allocating the frames among them by allocation. A proportional share is a frame and, of the frames left once every
+// process has one, the part the process's size is of all of theirs, rounded down; an equal share is the same with
+// every size alike. There must be a frame for every process.
+func NewPager(frames int, allocation FrameAllocation, sizes map[int64]int64) (*Pager, error) {
+	if frames < len(sizes) || frames < 1 {
+		return nil, fmt.Errorf("%w: %d frames for %d processes", ErrTooFewFrames, frames, len(sizes))
+	}
+	pids := make([]int64, 0, len(sizes))
+	var total int64
+	for pid, size := range sizes {
+		pids = append(pids, pid)
+		total += size
+	}
+	p := &Pager{
+		frames:      make([]page, 0, frames),
+		used:        make([]int64, 0, frames),
+		held:        make(map[int64]int, len(pids)),
+		recent:      make(map[int64][]int64, len(pids)),
+		prepaged:    make(map[page]bool),
+		window:      make(map[int64][]bool, len(pids)),
+		Faults:      make(map[int64]int, len(pids)),
+		References:  make(map[int64]int, len(pids)),
+		Prepaged:    make(map[int64]int, len(pids)),
+		PrepageHits: make(map[int64]int, len(pids)),
+		Allocations: make(map[int64][]Allocation, len(pids)),
+	}
+	if allocation.Local() {
+		p.quota = make(map[int64]int, len(pids))
+		spare := int64(frames - len(pids))
+		for _, pid := range pids {
+			share := spare / int64(len(pids))
+			if allocation.kind == proportional && total > 0 {
+				share = spare * sizes[pid] / total
+			}
+			p.quota[pid] = 1 + int(share)
+			p.Allocations[pid] = []Allocation{{Frames: p.quota[pid]}}
+		}
+	}
+
+	return p, nil
+}
+
+// Access references ref for the process pid at time and reports whether it faulted. A faulting page is loaded at
+// once, but counts as used at ready, when its fault will have been serviced, so it is not evicted while its process
+// waits for it; so do the pages prepaged along with it, which only replace pages used before time.
+func (p *Pager) Access(pid int64, ref Reference, time, ready int64) bool {
+	return p.access(pid, ref, time, ready, false)
+}
+
+// Retry references ref again for pid at time, once the fault it took on it has been serviced, and reports whether it
+// faulted again, the page having been evicted in the meantime. A retry is not another reference of the process.
+func (p *Pager) Retry(pid int64, ref Reference, time, ready int64) bool {
+	return p.access(pid, ref, time, ready, true)
+}
+
+func (p *Pager) access(pid int64, ref Reference, time, ready int64, retry bool) bool {
+	want := page{pid: pid, page: ref.Page}
+	if !retry {
+		p.References[pid]++
+		p.remember(want)
+	}
+	fault := true
+	if i := p.find(want); i >= 0 {
+		p.used[i] = max(p.used[i], time)
+		fault = false
+		if p.prepaged[want] {
+			delete(p.prepaged, want)
+			p.PrepageHits[pid]++
+		}
+	}
+	if fault {
+		p.Faults[pid]++
+		p.place(want, ready, func(int) bool { return true })
+		loaded := 0
+		for _, pg := range p.recent[pid] {
+			ahead := page{pid: pid, page: pg}
+			if loaded >= p.Prepage {
+				break
+			}
+			if ahead == want || p.find(ahead) >= 0 {
+				continue
+			}
+			if !p.place(ahead, ready, func(i int) bool { return p.used[i] < time }) {
+				break
+			}
+			p.prepaged[ahead] = true
+			p.Prepaged[pid]++
+			loaded++
+		}
+	}
+	if p.PFF != nil && p.quota != nil && !retry {
+		p.control(pid, fault, time)
+	}
+
+	return fault
+}
+
+// remember moves the page want to the front of its process's recently used pages.
+func (p *Pager) remember(want page) {
+	recent := p.recent[want.pid]
+	for i, pg := range recent {
+		if pg == want.page {
+			recent = append(recent[:i], recent[i+1:]...)
+			break
+		}
+	}
+	if len(recent) >= recentPages {
+		recent = recent[:recentPages-1]
+	}
+	p.recent[want.pid] = append([]int64{want.page}, recent...)
+}
+
+// find returns the frame holding want, or -1.
+func (p *Pager) find(want page) int {
+	for i, f := range p.frames {
+		if f == want {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// place loads want, used at ready, into a free frame, or if there is none or its process holds all the frames it
+// may, in place of the least recently used page of those evictable: its own under local replacement. It reports
+// whether there was a frame to load it into.
+func (p *Pager) place(want page, ready int64, evictable func(i int) bool) bool {
+	own := p.quota != nil && p.held[want.pid] >= p.quota[want.pid]
+	if !own && len(p.frames) < cap(p.frames) {
+		p.frames, p.used = append(p.frames, want), append(p.used, ready)
+		p.held[want.pid]++
+		return true
+	}
+	i := p.victim(func(i int) bool { return (!own || p.frames[i].pid == want.pid) && evictable(i) })
+	if i < 0 {
+		return false
+	}
+	p.load(i, want, ready)
+
+	return true
+}
+
+// victim returns the frame holding the least recently used page of those eligible, or -1 if none is.
+func (p *Pager) victim(eligible func(i int) bool) int {
+	best := -1
+	for i := range p.frames {
+		if eligible(i) && (best < 0 || p.used[i] < p.used[best]) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// load replaces the page in frame i with want, used at ready.
+func (p *Pager) load(i int, want page, ready int64) {
+	delete(p.prepaged, p.frames[i])
+	p.held[p.frames[i].pid]--
+	p.held[want.pid]++
+	p.frames[i], p.used[i] = want, ready
+}
+
+// drop frees frame i.
+func (p *Pager) drop(i int) {
+	delete(p.prepaged, p.frames[i])
+	p.held[p.frames[i].pid]--
+	last := len(p.frames) - 1
+	p.frames[i], p.used[i] = p.frames[last], p.used[last]
+	p.frames, p.used = p.frames[:last], p.used[:last]
+}
+
+// control reviews the frames pid may hold under PFF once it has made a window of references, fault reporting
+// whether the latest of them faulted.
+func (p *Pager) control(pid int64, fault bool, time int64) {
+	window := append(p.window[pid], fault)
+	if len(window) < p.PFF.Window {
+		p.window[pid] = window
+		return
+	}
+	p.window[pid] = nil
+	faults := 0
+	for _, f := range window {
+		if f {
+			faults++
+		}
+	}
+	rate := float64(faults) / float64(len(window))
+	promised := 0
+	for _, q := range p.quota {
+		promised += q
+	}
+	switch {
+	case rate > p.PFF.Upper && promised < cap(p.frames):
+		p.quota[pid]++
+	case rate < p.PFF.Lower && p.quota[pid] > 1:
+		p.quota[pid]--
+		for p.held[pid] > p.quota[pid] {
+			p.drop(p.victim(func(i int) bool { return p.frames[i].pid == pid }))
+		}
+	default:
+		return
+	}
+	p.Allocations[pid] = append(p.Allocations[pid], Allocation{Time: time, Frames: p.quota[pid]})
+}
@@ -0,0 +1,157 @@
+package paging
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewPager(t *testing.T) {
+	t.Parallel()
+	sizes := map[int64]int64{1: 2, 2: 6, 3: 8}
+	tests := []struct {
+		name       string
+		frames     int
+		allocation FrameAllocation
+		want       map[int64]int
+		wantErr    error
+	}{
+		{name: "too few frames", frames: 2, allocation: FrameAllocations[0], wantErr: ErrTooFewFrames},
+		{name: "global", frames: 3, allocation: FrameAllocations[0]},
+		{name: "equal", frames: 10, allocation: FrameAllocations[1], want: map[int64]int{1: 3, 2: 3, 3: 3}},
+		// the 7 frames left once each process has one split 2:6:8, rounded down
+		{name: "proportional", frames: 10, allocation: FrameAllocations[2], want: map[int64]int{1: 1, 2: 3, 3: 4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := NewPager(tt.frames, tt.allocation, sizes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewPager() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(p.quota, tt.want) {
+				t.Errorf("NewPager() shares = %v, want %v", p.quota, tt.want)
+			}
+		})
+	}
+}
+
+func TestPager_Access(t *testing.T) {
+	t.Parallel()
+	type access struct {
+		pid, page, time int64
+	}
+	tests := []struct {
+		name     string
+		local    bool
+		accesses []access
+		want     []bool
+		faults   map[int64]int
+	}{
+		{
+			name: "global",
+			// P1's page 1 takes the frame of its own page 0, used at 10, over P2's page 0, loaded for 11
+			accesses: []access{{1, 0, 0}, {2, 0, 1}, {1, 0, 10}, {1, 1, 11}, {2, 0, 12}, {1, 0, 22}},
+			want:     []bool{true, true, false, true, false, true},
+			faults:   map[int64]int{1: 3, 2: 1},
+		},
+		{
+			name: "global evicts another process",
+			// P2's page 0, loaded for 11 and used at 20, is older than P1's page 1, loaded for 21
+			accesses: []access{{1, 0, 0}, {2, 0, 1}, {1, 1, 11}, {2, 0, 20}, {1, 2, 30}, {2, 0, 31}},
+			want:     []bool{true, true, true, false, true, true},
+			faults:   map[int64]int{1: 3, 2: 2},
+		},
+		{
+			name:  "local",
+			local: true,
+			// with one frame each, P1 can only replace its own page
+			accesses: []access{{1, 0, 0}, {1, 1, 10}, {2, 0, 20}, {2, 0, 30}, {1, 0, 31}},
+			want:     []bool{true, true, true, false, true},
+			faults:   map[int64]int{1: 3, 2: 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			allocation := FrameAllocations[0]
+			if tt.local {
+				allocation = FrameAllocations[1]
+			}
+			p, err := NewPager(2, allocation, map[int64]int64{1: 4, 2: 4})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []bool
+			for _, a := range tt.accesses {
+				got = append(got, p.Access(a.pid, Reference{Page: a.page}, a.time, a.time+10))
+			}
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(p.Faults, tt.faults) {
+				t.Errorf("Access() faults = %v %v, want %v %v", got, p.Faults, tt.want, tt.faults)
+			}
+		})
+	}
+}
+
+func TestPager_Prepage(t *testing.T) {
+	t.Parallel()
+	p, err := NewPager(3, FrameAllocations[0], map[int64]int64{1: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Prepage = 1
+	var got []bool
+	for i, page := range []int64{0, 1, 2, 3, 1, 0} {
+		got = append(got, p.Access(1, Reference{Page: page}, int64(10*i), int64(10*i+5)))
+	}
+	// 3 evicts 0 and brings it back in place of 1, whose fault then brings 2 back in place of 3, and 0 hits
+	want := []bool{true, true, true, true, true, false}
+	if !reflect.DeepEqual(got, want) || p.Prepaged[1] != 2 || p.PrepageHits[1] != 1 {
+		t.Errorf("Access() faults = %v with %d prepaged and %d used, want %v with 2 and 1", got, p.Prepaged[1],
+			p.PrepageHits[1], want)
+	}
+}
+
+func TestPager_Retry(t *testing.T) {
+	t.Parallel()
+	p, err := NewPager(2, FrameAllocations[0], map[int64]int64{1: 1, 2: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Access(1, Reference{Page: 0}, 0, 10)
+	hit := p.Retry(1, Reference{Page: 0}, 10, 20)
+	p.Access(2, Reference{Page: 0}, 11, 21)
+	p.Access(2, Reference{Page: 1}, 12, 22)
+	// P2's second fault evicts P1's page, so retrying it faults again
+	again := p.Retry(1, Reference{Page: 0}, 13, 23)
+	if hit || !again || p.References[1] != 1 || p.Faults[1] != 2 {
+		t.Errorf("Retry() faults = %v %v with %d references and %d faults, want false true with 1 and 2", hit, again,
+			p.References[1], p.Faults[1])
+	}
+}
+
+func TestPager_PFF(t *testing.T) {
+	t.Parallel()
+	if err := (PFF{Window: 4, Lower: 0.5, Upper: 0.2}).Validate(); !errors.Is(err, ErrBadPFF) {
+		t.Errorf("Validate() of bounds out of order = %v, want %v", err, ErrBadPFF)
+	}
+	p, err := NewPager(4, FrameAllocations[1], map[int64]int64{1: 3, 2: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.PFF = &PFF{Window: 2, Lower: 0.25, Upper: 0.75}
+	type access struct {
+		pid, page, time int64
+	}
+	// P2 faults once in four references and gives up a frame, which P1, faulting on everything, takes
+	for _, a := range []access{{2, 0, 0}, {2, 0, 1}, {2, 0, 2}, {2, 0, 3}, {1, 0, 4}, {1, 1, 5}, {1, 2, 6}} {
+		p.Access(a.pid, Reference{Page: a.page}, a.time, a.time)
+	}
+	want := map[int64][]Allocation{1: {{Frames: 2}, {Time: 5, Frames: 3}}, 2: {{Frames: 2}, {Time: 3, Frames: 1}}}
+	if !reflect.DeepEqual(p.Allocations, want) || p.held[1] != 3 || p.Faults[1] != 3 {
+		t.Errorf("Allocations = %v with P1 holding %d after %d faults, want %v with 3 after 3", p.Allocations,
+			p.held[1], p.Faults[1], want)
+	}
+}
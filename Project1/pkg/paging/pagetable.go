@@ -0,0 +1,201 @@
+package paging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBadPageTable marks a page table that cannot be simulated: no entry size, a negative address space, or a hashed
+// table without buckets.
+var ErrBadPageTable = errors.New("bad page table")
+
+// PointerSize is the bytes of a pointer in a page table: a hash bucket's head or the link to the next entry of its
+// chain.
+const PointerSize = 8
+
+// organization tells the ways of organizing a page table apart.
+type organization int
+
+const (
+	linear organization = iota
+	inverted
+	hashed
+)
+
+// Organization is a way of organizing a page table along with the name it is selected by, the title its reports
+// carry, and a one-line description for listings.
+type Organization struct {
+	Name        string
+	Title       string
+	Description string
+	kind        organization
+}
+
+// Organizations lists every page table organization in the order they run by default.
+var Organizations = []Organization{
+	{Name: "linear", Title: "Linear",
+		Description: "an entry for every page of the address space, indexed by page number", kind: linear},
+	{Name: "inverted", Title: "Inverted",
+		Description: "an entry for every frame, searched in frame order for the page", kind: inverted},
+	{Name: "hashed", Title: "Hashed",
+		Description: "an entry for every resident page, chained in the bucket its page number hashes to",
+		kind:        hashed},
+}
+
+// PageTable describes a page table: how it is organized, the bytes of each entry, the pages of the address space a
+// linear table covers, and the buckets of a hashed one, a page hashing to the bucket numbered page modulo their
+// number.
+type PageTable struct {
+	Organization Organization
+	EntrySize    int
+	Pages        int64
+	Buckets      int
+}
+
+// Validate reports whether t describes a page table that can be simulated.
+func (t PageTable) Validate() error {
+	switch {
+	case t.EntrySize < 1:
+		return fmt.Errorf("%w: an entry needs at least 1 byte", ErrBadPageTable)
+	case t.Pages < 0:
+		return fmt.Errorf("%w: an address space of %d pages", ErrBadPageTable, t.Pages)
+	case t.Organization.kind == hashed && t.Buckets < 1:
+		return fmt.Errorf("%w: a hashed page table needs at least 1 bucket", ErrBadPageTable)
+	}
+
+	return nil
+}
+
+// Size returns the bytes t takes with frames frames: a linear table an entry for every page, an inverted one an
+// entry for every frame, and a hashed one a head for every bucket and, once every frame is in use, an entry and a
+// link for each.
+func (t PageTable) Size(frames int) int64 {
+	switch t.Organization.kind {
+	case inverted:
+		return int64(frames * t.EntrySize)
+	case hashed:
+		return int64(t.Buckets*PointerSize + frames*(t.EntrySize+PointerSize))
+	}
+
+	return t.Pages * int64(t.EntrySize)
+}
+
+// PageTableResult is a page table's view of a run: the memory reads each reference's lookup took, 0 for a reference
+// whose translation came from the TLB, and the table's size.
+type PageTableResult struct {
+	Table   PageTable
+	Reads   []int
+	Lookups int
+	Total   int
+	Longest int
+	Size    int64
+}
+
+// AverageReads returns the mean memory reads of a lookup, or 0 for none.
+func (r PageTableResult) AverageReads() float64 {
+	if r.Lookups == 0 {
+		return 0
+	}
+
+	return float64(r.Total) / float64(r.Lookups)
+}
+
+// EffectiveAccessTime returns the average time of a memory access in run: a TLB lookup first if tlb is set, then
+// the memory reads of the page table lookup, if any, and the access itself, and the time to service any page fault.
+func (r PageTableResult) EffectiveAccessTime(run Result, tlb bool, l Latencies) float64 {
+	if len(run.Steps) == 0 {
+		return 0
+	}
+	t := l.Memory + float64(r.Total)/float64(len(run.Steps))*l.Memory + run.FaultRate()*l.Fault
+	if tlb {
+		t += l.TLB
+	}
+
+	return t
+}
+
+// SimulatePageTable looks up every reference of run in t, or with the TLB lookups tlb, only those that missed the
+// TLB. A linear table reads the one entry of the page. An inverted table reads the entries of the frames in order
+// until it finds the page, or all of them when it faults. A hashed table reads the bucket's head and then its chain,
+// newest entries first, until it finds the page, or to the end when it faults. A t with no Pages covers the pages up
+// to the highest referenced. t must be valid.
+func SimulatePageTable(run Result, t PageTable, tlb *TLBResult) PageTableResult {
+	if t.Pages == 0 {
+		for _, s := range run.Steps {
+			t.Pages = max(t.Pages, s.Ref.Page+1)
+		}
+	}
+	r := PageTableResult{Table: t, Reads: make([]int, len(run.Steps)), Size: t.Size(run.Frames)}
+	var (
+		frames = make([]int64, run.Frames) // the frames before the reference
+		chains = map[int][]int64{}         // the pages chained in each bucket, newest first
+	)
+	for i := range frames {
+		frames[i] = Empty
+	}
+	for i, s := range run.Steps {
+		if tlb == nil || !tlb.Hit[i] {
+			reads := 1
+			switch t.Organization.kind {
+			case inverted:
+				reads = len(frames)
+				for j, p := range frames {
+					if p == s.Ref.Page {
+						reads = j + 1
+						break
+					}
+				}
+			case hashed:
+				chain := chains[int(s.Ref.Page%int64(t.Buckets))]
+				reads = 1 + len(chain)
+				for j, p := range chain {
+					if p == s.Ref.Page {
+						reads = j + 2
+						break
+					}
+				}
+			}
+			r.Reads[i] = reads
+			r.Lookups++
+			r.Total += reads
+			r.Longest = max(r.Longest, reads)
+		}
+
+		if t.Organization.kind == hashed {
+			rehash(chains, frames, s.Frames, t.Buckets)
+		}
+		frames = s.Frames
+	}
+
+	return r
+}
+
+// rehash updates chains from the resident pages before to those after, dropping pages no longer resident and
+// putting newly resident ones at the head of their bucket's chain.
+func rehash(chains map[int][]int64, before, after []int64, buckets int) {
+	resident := make(map[int64]bool, len(after))
+	for _, p := range after {
+		if p != Empty {
+			resident[p] = true
+		}
+	}
+	for _, p := range before {
+		if p == Empty || resident[p] {
+			delete(resident, p)
+			continue
+		}
+		b := int(p % int64(buckets))
+		for j, q := range chains[b] {
+			if q == p {
+				chains[b] = append(chains[b][:j:j], chains[b][j+1:]...)
+				break
+			}
+		}
+	}
+	for _, p := range after {
+		if resident[p] {
+			b := int(p % int64(buckets))
+			chains[b] = append([]int64{p}, chains[b]...)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package paging
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPageTable_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		table   PageTable
+		wantErr bool
+	}{
+		{table: PageTable{Organization: Organizations[0], EntrySize: 8}},
+		{table: PageTable{Organization: Organizations[2], EntrySize: 8, Buckets: 4}},
+		{table: PageTable{Organization: Organizations[1], EntrySize: 0}, wantErr: true},
+		{table: PageTable{Organization: Organizations[2], EntrySize: 8}, wantErr: true},
+		{table: PageTable{Organization: Organizations[0], EntrySize: 8, Pages: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := tt.table.Validate(); tt.wantErr != errors.Is(err, ErrBadPageTable) {
+			t.Errorf("%+v.Validate() = %v", tt.table, err)
+		}
+	}
+}
+
+func TestSimulatePageTable(t *testing.T) {
+	t.Parallel()
+	refs, err := ParseReferences("1 2 1 3 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// FIFO leaves frames [1 -], [1 2], [1 2], [3 2], and [3 1]
+	run := FIFO(refs, 2)
+	tests := []struct {
+		org       Organization
+		wantReads []int
+		wantSize  int64
+	}{
+		{org: Organizations[0], wantReads: []int{1, 1, 1, 1, 1}, wantSize: 32},
+		// every fault searches both frames
+		{org: Organizations[1], wantReads: []int{2, 2, 1, 2, 2}, wantSize: 16},
+		// one bucket chains every resident page, newest first
+		{org: Organizations[2], wantReads: []int{1, 2, 3, 3, 3}, wantSize: 40},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.org.Name, func(t *testing.T) {
+			t.Parallel()
+			r := SimulatePageTable(run, PageTable{Organization: tt.org, EntrySize: 8, Buckets: 1}, nil)
+			if !reflect.DeepEqual(r.Reads, tt.wantReads) || r.Size != tt.wantSize {
+				t.Errorf("reads %v, size %d, want %v and %d", r.Reads, r.Size, tt.wantReads, tt.wantSize)
+			}
+		})
+	}
+
+	tlb := SimulateTLB(run, TLB{Entries: 2, Policy: "lru"})
+	r := SimulatePageTable(run, PageTable{Organization: Organizations[1], EntrySize: 8}, &tlb)
+	if want := []int{2, 2, 0, 2, 2}; !reflect.DeepEqual(r.Reads, want) || r.Lookups != 4 || r.AverageReads() != 2 {
+		t.Errorf("reads behind the TLB %v in %d lookups, want %v in 4", r.Reads, r.Lookups, want)
+	}
+	l := Latencies{TLB: 1, Memory: 100}
+	if got := r.EffectiveAccessTime(run, true, l); got != 261 {
+		t.Errorf("EffectiveAccessTime() = %v, want 261", got)
+	}
+}
@@ -0,0 +1,359 @@
+// Package paging simulates page replacement: which page a full set of frames gives up when a reference misses. It is
+// the memory-management companion of sched, reading a reference string and reporting every algorithm's faults
+// access by access.
+package paging
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Reference is one access of a reference string: a read of Page, or a write that leaves it dirty.
+type Reference struct {
+	Page  int64
+	Write bool
+}
+
+// Empty marks a frame that holds no page yet, and a Step that evicted nothing.
+const Empty int64 = -1
+
+// Step is what one reference did: whether it faulted, the page it evicted, if any, and whether that page had to be
+// written back, and the page and bits of every frame afterwards.
+type Step struct {
+	Ref       Reference
+	Fault     bool
+	Evicted   int64
+	WriteBack bool
+	Frames    []int64
+	// Referenced and Dirty hold the reference and dirty bit of every frame.
+	Referenced []bool
+	Dirty      []bool
+	// Hand is the frame the clock hand points at, or -1 for an algorithm without one.
+	Hand int
+	// WorkingSet is the number of distinct pages in the window of references ending here, for an algorithm with a
+	// window, or 0.
+	WorkingSet int
+}
+
+// Result is a run of one algorithm over a reference string.
+type Result struct {
+	Frames int
+	Steps  []Step
+	Faults int
+	// WriteBacks counts the evicted pages that were dirty and so had to be written back.
+	WriteBacks int
+}
+
+// Hits returns the number of references that found their page in a frame.
+func (r Result) Hits() int {
+	return len(r.Steps) - r.Faults
+}
+
+// FaultRate returns the fraction of references that faulted, or 0 for an empty reference string.
+func (r Result) FaultRate() float64 {
+	if len(r.Steps) == 0 {
+		return 0
+	}
+
+	return float64(r.Faults) / float64(len(r.Steps))
+}
+
+// ErrBadReference marks a reference string with an entry that is not a page number.
+var ErrBadReference = errors.New("bad reference")
+
+// ParseReferences parses a reference string of page numbers separated by spaces, commas, or newlines. A page number
+// followed by w, as in 3w, is a write.
+func ParseReferences(s string) ([]Reference, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	refs := make([]Reference, 0, len(fields))
+	for i, f := range fields {
+		page, write := strings.CutSuffix(strings.ToLower(f), "w")
+		n, err := strconv.ParseInt(page, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: reference %d: %q is not a page number", ErrBadReference, i+1, f)
+		}
+		refs = append(refs, Reference{Page: n, Write: write})
+	}
+
+	return refs, nil
+}
+
+// String formats r as ParseReferences reads it.
+func (r Reference) String() string {
+	if r.Write {
+		return strconv.FormatInt(r.Page, 10) + "w"
+	}
+
+	return strconv.FormatInt(r.Page, 10)
+}
+
+// HasWrites reports whether any of refs is a write.
+func HasWrites(refs []Reference) bool {
+	for _, r := range refs {
+		if r.Write {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatReferences writes refs in the form ParseReferences reads.
+func FormatReferences(refs []Reference) string {
+	fields := make([]string, len(refs))
+	for i, r := range refs {
+		fields[i] = r.String()
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// Generate returns count references drawn uniformly at random from rng over the pages 0 to pages-1, each a write
+// with probability writes.
+func Generate(rng *rand.Rand, count int, pages int64, writes float64) []Reference {
+	refs := make([]Reference, count)
+	for i := range refs {
+		refs[i].Page = rng.Int63n(max(pages, 1))
+		refs[i].Write = rng.Float64() < writes
+	}
+
+	return refs
+}
+
+// Func runs a replacement algorithm over refs with frames frames.
+type Func func(refs []Reference, frames int) Result
+
+// Algorithm is a replacement algorithm along with the name it is selected by, the title its reports carry, and a
+// one-line description for listings.
+type Algorithm struct {
+	Name        string
+	Title       string
+	Description string
+	Run         Func
+}
+
+// DefaultWindow is the working-set window of the examples, in references.
+const DefaultWindow = 10
+
+// Algorithms returns every replacement algorithm in the order they run by default, the working-set ones looking back
+// window references.
+func Algorithms(window int) []Algorithm {
+	return []Algorithm{
+		{Name: "fifo", Title: "FIFO", Description: "evicts the page that has been in memory longest", Run: FIFO},
+		{Name: "lru", Title: "LRU", Description: "evicts the page used least recently", Run: LRU},
+		{Name: "optimal", Title: "Optimal", Description: "evicts the page not needed for the longest time, the lower bound",
+			Run: Optimal},
+		{Name: "clock", Title: "Clock",
+			Description: "second chance: the hand skips and clears pages referenced since it passed", Run: Clock},
+		{Name: "esc", Title: "Enhanced second chance", Description: "clock preferring pages neither referenced nor dirty",
+			Run: EnhancedSecondChance},
+		{Name: "lfu", Title: "LFU", Description: "evicts the page used least often since it was loaded", Run: LFU},
+		{Name: "mfu", Title: "MFU", Description: "evicts the page used most often since it was loaded", Run: MFU},
+		{Name: "ws", Title: "Working set",
+			Description: "keeps exactly the pages used in the window, however many frames that takes",
+			Run:         func(refs []Reference, _ int) Result { return WorkingSet(refs, window) }},
+		{Name: "wsclock", Title: "WSClock",
+			Description: "clock evicting pages unused for longer than the window, clean ones first",
+			Run:         func(refs []Reference, frames int) Result { return WSClock(refs, frames, window) }},
+	}
+}
+
+// frameTable is the state of the frames during a run: the page each holds, when it was loaded and last used, and
+// its reference and dirty bits, along with the clock hand of the algorithms that have one. uses counts the
+// references to each page since it was loaded, and stamp is when the hand last found it referenced, or when it was
+// loaded.
+type frameTable struct {
+	pages      []int64
+	loaded     []int
+	used       []int
+	uses       []int
+	stamp      []int
+	referenced []bool
+	dirty      []bool
+	hand       int
+	// writeBacks counts the dirty pages written back without being evicted.
+	writeBacks int
+}
+
+// find returns the frame holding page, or -1.
+func (f *frameTable) find(page int64) int {
+	for i, p := range f.pages {
+		if p == page {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// victimFunc chooses the frame to evict when the reference at index t faults with every frame full.
+type victimFunc func(f *frameTable, refs []Reference, t int) int
+
+// replace runs refs over frames frames, loading faulting pages into the first empty frame while there is one and
+// into the frame victim chooses after that. Every reference sets its frame's reference bit, and every write its dirty
+// bit. Fewer than one frame is treated as one. clock marks the algorithms whose victim moves f.hand, so the steps
+// record it.
+func replace(refs []Reference, frames int, clock bool, victim victimFunc) Result {
+	frames = max(frames, 1)
+	f := &frameTable{
+		pages:      make([]int64, frames),
+		loaded:     make([]int, frames),
+		used:       make([]int, frames),
+		uses:       make([]int, frames),
+		stamp:      make([]int, frames),
+		referenced: make([]bool, frames),
+		dirty:      make([]bool, frames),
+	}
+	for i := range f.pages {
+		f.pages[i] = Empty
+	}
+	r := Result{Frames: frames, Steps: make([]Step, 0, len(refs))}
+	for t, ref := range refs {
+		step := Step{Ref: ref, Evicted: Empty, Hand: -1}
+		i := f.find(ref.Page)
+		if i < 0 {
+			step.Fault = true
+			r.Faults++
+			if i = f.find(Empty); i < 0 {
+				i = victim(f, refs, t)
+				step.Evicted, step.WriteBack = f.pages[i], f.dirty[i]
+				if step.WriteBack {
+					r.WriteBacks++
+				}
+			}
+			f.pages[i], f.loaded[i], f.stamp[i], f.uses[i], f.dirty[i] = ref.Page, t, t, 0, false
+		}
+		f.used[i], f.referenced[i] = t, true
+		f.uses[i]++
+		f.dirty[i] = f.dirty[i] || ref.Write
+		step.Frames = append([]int64(nil), f.pages...)
+		step.Referenced = append([]bool(nil), f.referenced...)
+		step.Dirty = append([]bool(nil), f.dirty...)
+		if clock {
+			step.Hand = f.hand
+		}
+		r.Steps = append(r.Steps, step)
+	}
+	r.WriteBacks += f.writeBacks
+
+	return r
+}
+
+// oldest returns the frame with the smallest of keys, the first of them on a tie.
+func oldest(keys []int) int {
+	best := 0
+	for i := range keys {
+		if keys[i] < keys[best] {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// FIFO evicts the page that was loaded longest ago, whether or not it is still in use.
+func FIFO(refs []Reference, frames int) Result {
+	return replace(refs, frames, false, func(f *frameTable, _ []Reference, _ int) int { return oldest(f.loaded) })
+}
+
+// LRU evicts the page whose last use was longest ago.
+func LRU(refs []Reference, frames int) Result {
+	return replace(refs, frames, false, func(f *frameTable, _ []Reference, _ int) int { return oldest(f.used) })
+}
+
+// LFU evicts the page referenced the fewest times since it was loaded, the one loaded longest ago on a tie. A page
+// used heavily once stays long after it has stopped being used.
+func LFU(refs []Reference, frames int) Result {
+	return replace(refs, frames, false, func(f *frameTable, _ []Reference, _ int) int {
+		return mostBy(f, func(i int) int { return -f.uses[i] })
+	})
+}
+
+// MFU evicts the page referenced the most times since it was loaded, the one loaded longest ago on a tie, on the
+// argument that the page with the fewest uses was probably just loaded and has yet to be used.
+func MFU(refs []Reference, frames int) Result {
+	return replace(refs, frames, false, func(f *frameTable, _ []Reference, _ int) int {
+		return mostBy(f, func(i int) int { return f.uses[i] })
+	})
+}
+
+// mostBy returns the frame with the largest key, the one loaded longest ago on a tie.
+func mostBy(f *frameTable, key func(frame int) int) int {
+	best := 0
+	for i := range f.pages {
+		if k := key(i); k > key(best) || k == key(best) && f.loaded[i] < f.loaded[best] {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// Optimal evicts the page whose next use is furthest in the future, or one never used again, preferring the page
+// loaded longest ago among those. It needs the whole reference string in advance, so it can only be simulated, but no
+// algorithm faults less, which makes it the yardstick for the others.
+func Optimal(refs []Reference, frames int) Result {
+	return replace(refs, frames, false, func(f *frameTable, refs []Reference, t int) int {
+		best, bestNext := 0, -1
+		for i, page := range f.pages {
+			next := len(refs) // never used again
+			for u := t + 1; u < len(refs); u++ {
+				if refs[u].Page == page {
+					next = u
+					break
+				}
+			}
+			if next > bestNext || next == bestNext && f.loaded[i] < f.loaded[best] {
+				best, bestNext = i, next
+			}
+		}
+
+		return best
+	})
+}
+
+// Clock is second chance: the frames form a circle with a hand at the oldest page, and on a fault the hand sweeps
+// forward, clearing the reference bit of every page that has one and evicting the first that doesn't. A page used
+// since the hand last passed is spared once, so Clock approximates LRU with one bit per frame. The hand starts at the
+// first frame and moves past each page it loads.
+func Clock(refs []Reference, frames int) Result {
+	return replace(refs, frames, true, func(f *frameTable, _ []Reference, _ int) int {
+		for f.referenced[f.hand] {
+			f.referenced[f.hand] = false
+			f.hand = (f.hand + 1) % len(f.pages)
+		}
+		victim := f.hand
+		f.hand = (f.hand + 1) % len(f.pages)
+
+		return victim
+	})
+}
+
+// EnhancedSecondChance is Clock with the dirty bit as well, preferring to evict a page that doesn't have to be
+// written back. From the hand it looks for a page neither referenced nor dirty, leaving the bits alone; failing that,
+// it goes round again for a page not referenced but dirty, clearing reference bits as it passes, and repeats until
+// one turns up.
+func EnhancedSecondChance(refs []Reference, frames int) Result {
+	return replace(refs, frames, true, func(f *frameTable, _ []Reference, _ int) int {
+		n := len(f.pages)
+		for {
+			for k := 0; k < n; k++ {
+				if i := (f.hand + k) % n; !f.referenced[i] && !f.dirty[i] {
+					f.hand = (i + 1) % n
+					return i
+				}
+			}
+			for k := 0; k < n; k++ {
+				i := (f.hand + k) % n
+				if !f.referenced[i] && f.dirty[i] {
+					f.hand = (i + 1) % n
+					return i
+				}
+				f.referenced[i] = false
+			}
+		}
+	})
+}
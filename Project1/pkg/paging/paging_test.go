@@ -0,0 +1,196 @@
+package paging
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// textbook is the reference string of Silberschatz's Operating System Concepts, 9.4.
+const textbook = "7 0 1 2 0 3 0 4 2 3 0 3 2 1 2 0 1 7 0 1"
+
+func TestParseReferences(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    []Reference
+		wantErr bool
+	}{
+		{in: "", want: []Reference{}},
+		{in: "1 2,3\n 4", want: []Reference{{Page: 1}, {Page: 2}, {Page: 3}, {Page: 4}}},
+		{in: "1w 2W 3", want: []Reference{{Page: 1, Write: true}, {Page: 2, Write: true}, {Page: 3}}},
+		{in: "w", wantErr: true},
+		{in: "1 x", wantErr: true},
+		{in: "1 -2", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseReferences(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadReference) {
+					t.Errorf("ParseReferences(%q) error = %v, want %v", tt.in, err, ErrBadReference)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseReferences(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+			if again, _ := ParseReferences(FormatReferences(got)); !reflect.DeepEqual(again, got) {
+				t.Errorf("FormatReferences() does not round-trip: %v", again)
+			}
+		})
+	}
+}
+
+func TestAlgorithms(t *testing.T) {
+	t.Parallel()
+	refs, _ := ParseReferences(textbook)
+	belady, _ := ParseReferences("1 2 3 4 1 2 5 1 2 3 4 5")
+	frequent, _ := ParseReferences("1 1 2 3 4")
+	tests := []struct {
+		name       string
+		run        Func
+		refs       []Reference
+		frames     int
+		wantFaults int
+		// wantEvicted is the first page evicted, if not 0
+		wantEvicted int64
+	}{
+		{name: "fifo", run: FIFO, refs: refs, frames: 3, wantFaults: 15},
+		{name: "lru", run: LRU, refs: refs, frames: 3, wantFaults: 12},
+		{name: "optimal", run: Optimal, refs: refs, frames: 3, wantFaults: 9},
+		// Belady's anomaly: FIFO faults more with more frames
+		{name: "fifo belady 3", run: FIFO, refs: belady, frames: 3, wantFaults: 9},
+		{name: "fifo belady 4", run: FIFO, refs: belady, frames: 4, wantFaults: 10},
+		{name: "lru belady 4", run: LRU, refs: belady, frames: 4, wantFaults: 8},
+		{name: "no frames", run: LRU, refs: refs, frames: 0, wantFaults: 20},
+		// 1 has two uses when 4 faults, 2 and 3 one each
+		{name: "lfu", run: LFU, refs: frequent, frames: 3, wantFaults: 4, wantEvicted: 2},
+		{name: "mfu", run: MFU, refs: frequent, frames: 3, wantFaults: 4, wantEvicted: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := tt.run(tt.refs, tt.frames)
+			if r.Faults != tt.wantFaults || r.Hits() != len(tt.refs)-tt.wantFaults {
+				t.Errorf("faults = %d, hits = %d, want %d faults", r.Faults, r.Hits(), tt.wantFaults)
+			}
+			faults := 0
+			evicted := false
+			for _, s := range r.Steps {
+				if s.Evicted != Empty && !evicted {
+					evicted = true
+					if tt.wantEvicted != 0 && s.Evicted != tt.wantEvicted {
+						t.Errorf("first evicted %d, want %d", s.Evicted, tt.wantEvicted)
+					}
+				}
+				if s.Fault {
+					faults++
+				}
+				if len(s.Frames) != r.Frames || !s.Fault && s.Evicted != Empty {
+					t.Errorf("inconsistent step %+v", s)
+				}
+			}
+			if faults != r.Faults {
+				t.Errorf("%d steps faulted, want %d", faults, r.Faults)
+			}
+		})
+	}
+}
+
+func TestOptimal_lowerBound(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		refs := Generate(rng, 40, 8, 0.3)
+		for frames := 1; frames <= 6; frames++ {
+			optimal := Optimal(refs, frames).Faults
+			for _, a := range Algorithms(DefaultWindow) {
+				if a.Name == "ws" {
+					continue // it holds as many frames as its window needs
+				}
+				if faults := a.Run(refs, frames).Faults; faults < optimal {
+					t.Fatalf("%s faults %d times with %d frames on %v, fewer than optimal's %d", a.Name, faults,
+						frames, FormatReferences(refs), optimal)
+				}
+			}
+		}
+	}
+}
+
+func TestStep_frames(t *testing.T) {
+	t.Parallel()
+	refs, _ := ParseReferences("1 2 3 1 4")
+	r := LRU(refs, 3)
+	want := []struct {
+		fault   bool
+		evicted int64
+		frames  []int64
+	}{
+		{fault: true, evicted: Empty, frames: []int64{1, Empty, Empty}},
+		{fault: true, evicted: Empty, frames: []int64{1, 2, Empty}},
+		{fault: true, evicted: Empty, frames: []int64{1, 2, 3}},
+		{evicted: Empty, frames: []int64{1, 2, 3}},
+		{fault: true, evicted: 2, frames: []int64{1, 4, 3}},
+	}
+	for i, s := range r.Steps {
+		if s.Fault != want[i].fault || s.Evicted != want[i].evicted || !reflect.DeepEqual(s.Frames, want[i].frames) ||
+			s.Hand != -1 {
+			t.Errorf("LRU() step %d = %+v, want %+v", i, s, want[i])
+		}
+	}
+}
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		run            Func
+		refs           string
+		wantEvicted    []int64
+		wantWriteBacks int
+		wantHands      []int
+	}{
+		// every page is referenced again before the first fault, so the hand clears them all and evicts as FIFO
+		{name: "clock full sweep", run: Clock, refs: "1 2 3 2 4 1 5", wantEvicted: []int64{1, 2, 3},
+			wantHands: []int{0, 0, 0, 0, 1, 2, 0}},
+		{name: "clock dirty", run: Clock, refs: "1w 2 3 4 5", wantEvicted: []int64{1, 2}, wantWriteBacks: 1,
+			wantHands: []int{0, 0, 0, 1, 2}},
+		// enhanced second chance passes over the dirty page 1 for the clean 2 and 3
+		{name: "esc dirty", run: EnhancedSecondChance, refs: "1w 2 3 4 5", wantEvicted: []int64{2, 3},
+			wantHands: []int{0, 0, 0, 2, 0}},
+		// the second chance: 2 was referenced since the hand last passed, so 3 goes instead
+		{name: "esc referenced", run: EnhancedSecondChance, refs: "1 2w 3 4 2 5", wantEvicted: []int64{1, 3},
+			wantHands: []int{0, 0, 0, 1, 1, 0}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			refs, err := ParseReferences(tt.refs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := tt.run(refs, 3)
+			var evicted []int64
+			var hands []int
+			for _, s := range r.Steps {
+				if s.Evicted != Empty {
+					evicted = append(evicted, s.Evicted)
+				}
+				hands = append(hands, s.Hand)
+			}
+			if !reflect.DeepEqual(evicted, tt.wantEvicted) || r.WriteBacks != tt.wantWriteBacks {
+				t.Errorf("evicted %v with %d write-backs, want %v with %d", evicted, r.WriteBacks, tt.wantEvicted,
+					tt.wantWriteBacks)
+			}
+			if !reflect.DeepEqual(hands, tt.wantHands) {
+				t.Errorf("hand at %v, want %v", hands, tt.wantHands)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package paging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBadTLB marks a TLB whose entries don't divide into sets of its associativity, or with an unknown replacement
+// policy.
+var ErrBadTLB = errors.New("bad TLB")
+
+// TLB describes a translation lookaside buffer: Entries page translations in sets of Ways entries, a page mapping to
+// the set numbered page modulo the number of sets. Ways of 0 makes it fully associative, one set of every entry.
+// Policy picks the entry of a full set to replace, "lru" or "fifo".
+type TLB struct {
+	Entries int
+	Ways    int
+	Policy  string
+}
+
+// Validate reports whether t describes a TLB that can be simulated.
+func (t TLB) Validate() error {
+	switch {
+	case t.Entries < 1:
+		return fmt.Errorf("%w: a TLB needs at least 1 entry", ErrBadTLB)
+	case t.Ways < 0 || t.Ways > 0 && t.Entries%t.Ways != 0:
+		return fmt.Errorf("%w: %d entries do not divide into sets of %d", ErrBadTLB, t.Entries, t.Ways)
+	case t.Policy != "lru" && t.Policy != "fifo":
+		return fmt.Errorf("%w: unknown replacement policy %q (want lru or fifo)", ErrBadTLB, t.Policy)
+	}
+
+	return nil
+}
+
+// ways returns the entries per set.
+func (t TLB) ways() int {
+	if t.Ways == 0 {
+		return t.Entries
+	}
+
+	return t.Ways
+}
+
+// TLBResult is a TLB's view of a run: whether each reference's translation was in the TLB.
+type TLBResult struct {
+	Hit  []bool
+	Hits int
+}
+
+// HitRatio returns the fraction of lookups that hit, or 0 for none.
+func (r TLBResult) HitRatio() float64 {
+	if len(r.Hit) == 0 {
+		return 0
+	}
+
+	return float64(r.Hits) / float64(len(r.Hit))
+}
+
+// Latencies are the times, in any one unit, of a TLB lookup, a memory access, and servicing a page fault.
+type Latencies struct {
+	TLB    float64
+	Memory float64
+	Fault  float64
+}
+
+// EffectiveAccessTime returns the average time of a memory access in run with the TLB lookups of r: every access
+// looks in the TLB and then reads memory, a TLB miss first reads the page table in memory as well, and a page fault
+// adds the time to service it.
+func (r TLBResult) EffectiveAccessTime(run Result, l Latencies) float64 {
+	return l.TLB + l.Memory + (1-r.HitRatio())*l.Memory + run.FaultRate()*l.Fault
+}
+
+// tlbEntry is one translation held by a TLB, with when it was loaded and last used.
+type tlbEntry struct {
+	page   int64
+	loaded int
+	used   int
+}
+
+// SimulateTLB runs t in front of the page table of run, looking up every reference of run in turn. A miss loads the
+// translation, replacing an entry of its set by t's policy if the set is full, and a page evicted from memory has its
+// translation dropped from the TLB, as it no longer maps to a frame. t must be valid.
+func SimulateTLB(run Result, t TLB) TLBResult {
+	ways := t.ways()
+	sets := make([][]tlbEntry, t.Entries/ways)
+	r := TLBResult{Hit: make([]bool, len(run.Steps))}
+	for i, s := range run.Steps {
+		if s.Evicted != Empty {
+			set := &sets[s.Evicted%int64(len(sets))]
+			for j := range *set {
+				if (*set)[j].page == s.Evicted {
+					*set = append((*set)[:j], (*set)[j+1:]...)
+					break
+				}
+			}
+		}
+		set := &sets[s.Ref.Page%int64(len(sets))]
+		hit := -1
+		for j := range *set {
+			if (*set)[j].page == s.Ref.Page {
+				hit = j
+				break
+			}
+		}
+		switch {
+		case hit >= 0:
+			r.Hit[i] = true
+			r.Hits++
+			(*set)[hit].used = i
+		case len(*set) < ways:
+			*set = append(*set, tlbEntry{page: s.Ref.Page, loaded: i, used: i})
+		default:
+			victim := 0
+			for j, e := range *set {
+				if t.Policy == "lru" && e.used < (*set)[victim].used ||
+					t.Policy == "fifo" && e.loaded < (*set)[victim].loaded {
+					victim = j
+				}
+			}
+			(*set)[victim] = tlbEntry{page: s.Ref.Page, loaded: i, used: i}
+		}
+	}
+
+	return r
+}
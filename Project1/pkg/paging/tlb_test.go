@@ -0,0 +1,84 @@
+package paging
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTLB_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		tlb     TLB
+		wantErr bool
+	}{
+		{tlb: TLB{Entries: 16, Policy: "lru"}},
+		{tlb: TLB{Entries: 16, Ways: 4, Policy: "fifo"}},
+		{tlb: TLB{Entries: 0, Policy: "lru"}, wantErr: true},
+		{tlb: TLB{Entries: 16, Ways: 3, Policy: "lru"}, wantErr: true},
+		{tlb: TLB{Entries: 16, Policy: "random"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := tt.tlb.Validate(); tt.wantErr != errors.Is(err, ErrBadTLB) {
+			t.Errorf("%+v.Validate() = %v", tt.tlb, err)
+		}
+	}
+}
+
+func TestSimulateTLB(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		refs    string
+		frames  int
+		tlb     TLB
+		wantHit []bool
+	}{
+		{
+			name: "fully associative lru", refs: "1 2 1 3 1 2", frames: 4, tlb: TLB{Entries: 2, Policy: "lru"},
+			// 3 replaces 2, the least recently used
+			wantHit: []bool{false, false, true, false, true, false},
+		},
+		{
+			name: "fully associative fifo", refs: "1 2 1 3 1 2", frames: 4, tlb: TLB{Entries: 2, Policy: "fifo"},
+			// 3 replaces 1, the first loaded
+			wantHit: []bool{false, false, true, false, false, false},
+		},
+		{
+			name: "direct mapped", refs: "1 3 1 2", frames: 4, tlb: TLB{Entries: 2, Ways: 1, Policy: "lru"},
+			// 1 and 3 share set 1, so they push each other out
+			wantHit: []bool{false, false, false, false},
+		},
+		{
+			name: "eviction invalidates", refs: "1 2 3 1", frames: 2, tlb: TLB{Entries: 4, Policy: "lru"},
+			// page 1 leaves memory for 3, so its translation goes too
+			wantHit: []bool{false, false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			refs, _ := ParseReferences(tt.refs)
+			r := SimulateTLB(LRU(refs, tt.frames), tt.tlb)
+			if !reflect.DeepEqual(r.Hit, tt.wantHit) {
+				t.Errorf("SimulateTLB() hits %v, want %v", r.Hit, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestTLBResult_EffectiveAccessTime(t *testing.T) {
+	t.Parallel()
+	refs, _ := ParseReferences("1 1 1 1 2")
+	run := LRU(refs, 2) // 2 faults of 5
+	r := SimulateTLB(run, TLB{Entries: 2, Policy: "lru"})
+	if r.HitRatio() != 0.6 {
+		t.Fatalf("HitRatio() = %v, want 0.6", r.HitRatio())
+	}
+	// 1 + 100 + 0.4*100 + 0.4*1000
+	if got := r.EffectiveAccessTime(run, Latencies{TLB: 1, Memory: 100, Fault: 1000}); math.Abs(got-541) > 1e-9 {
+		t.Errorf("EffectiveAccessTime() = %v, want 541", got)
+	}
+}
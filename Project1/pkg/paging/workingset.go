@@ -0,0 +1,134 @@
+package paging
+
+// WorkingSetSizes returns, for every reference of refs, the size of the working set there: the number of distinct
+// pages among the window references ending with it. A window below 1 is treated as 1.
+func WorkingSetSizes(refs []Reference, window int) []int {
+	window = max(window, 1)
+	sizes := make([]int, len(refs))
+	counts := make(map[int64]int) // references to each page in the window
+	for t, ref := range refs {
+		counts[ref.Page]++
+		if t >= window {
+			old := refs[t-window].Page
+			if counts[old]--; counts[old] == 0 {
+				delete(counts, old)
+			}
+		}
+		sizes[t] = len(counts)
+	}
+
+	return sizes
+}
+
+// WorkingSet keeps in memory exactly the working set, the pages used in the last window references, rather than a
+// fixed number of frames: a page is loaded when a reference faults on it and dropped as soon as it falls out of the
+// window, so the frames a process holds grow and shrink with its locality. Each step's Evicted is the page dropped, if
+// any; a page joins the first free frame, and Result.Frames is the most frames ever held. A window below 1 is treated
+// as 1.
+func WorkingSet(refs []Reference, window int) Result {
+	window = max(window, 1)
+	var (
+		r      = Result{Steps: make([]Step, 0, len(refs))}
+		frames []int64
+		dirty  []bool
+		last   = make(map[int64]int) // the latest reference to each resident page
+		sizes  = WorkingSetSizes(refs, window)
+	)
+	for t, ref := range refs {
+		step := Step{Ref: ref, Evicted: Empty, Hand: -1, WorkingSet: sizes[t]}
+		if t >= window {
+			// the reference leaving the window takes its page with it unless the page was used again since
+			if old := refs[t-window].Page; last[old] == t-window && old != ref.Page {
+				i := findPage(frames, old)
+				step.Evicted, step.WriteBack = old, dirty[i]
+				if dirty[i] {
+					r.WriteBacks++
+				}
+				frames[i], dirty[i] = Empty, false
+				delete(last, old)
+			}
+		}
+		i := findPage(frames, ref.Page)
+		if i < 0 {
+			step.Fault = true
+			r.Faults++
+			if i = findPage(frames, Empty); i < 0 {
+				frames, dirty = append(frames, Empty), append(dirty, false)
+				i = len(frames) - 1
+			}
+			frames[i] = ref.Page
+		}
+		last[ref.Page] = t
+		dirty[i] = dirty[i] || ref.Write
+		step.Frames = append([]int64(nil), frames...)
+		step.Dirty = append([]bool(nil), dirty...)
+		step.Referenced = make([]bool, len(frames))
+		r.Frames = max(r.Frames, len(frames))
+		r.Steps = append(r.Steps, step)
+	}
+	// pad every step to the most frames held, so the steps line up
+	for i := range r.Steps {
+		for len(r.Steps[i].Frames) < r.Frames {
+			r.Steps[i].Frames = append(r.Steps[i].Frames, Empty)
+			r.Steps[i].Referenced = append(r.Steps[i].Referenced, false)
+			r.Steps[i].Dirty = append(r.Steps[i].Dirty, false)
+		}
+	}
+
+	return r
+}
+
+// findPage returns the frame of frames holding page, or -1.
+func findPage(frames []int64, page int64) int {
+	for i, p := range frames {
+		if p == page {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// WSClock is the working-set clock: a fixed number of frames in a circle, as with Clock, but the hand evicts a page
+// only once it has gone unused for longer than window references. On a fault the hand sweeps forward; a referenced
+// page has its bit cleared and is stamped with the time, and an unreferenced one older than the window is evicted if
+// clean, or written back and passed over if dirty, so it is clean by the time the hand comes round again. If a whole
+// sweep finds nothing to evict, the clean page stamped longest ago goes, or failing that the dirty one. Each step's
+// WorkingSet is the true working-set size, for comparison with the frames held.
+func WSClock(refs []Reference, frames, window int) Result {
+	window = max(window, 1)
+	r := replace(refs, frames, true, func(f *frameTable, _ []Reference, t int) int {
+		n := len(f.pages)
+		evict := func(i int) int {
+			f.hand = (i + 1) % n
+			return i
+		}
+		for k := 0; k < n; k++ {
+			i := (f.hand + k) % n
+			switch {
+			case f.referenced[i]:
+				f.referenced[i], f.stamp[i] = false, t
+			case t-f.stamp[i] > window && !f.dirty[i]:
+				return evict(i)
+			case t-f.stamp[i] > window:
+				f.dirty[i] = false
+				f.writeBacks++
+			}
+		}
+		// nothing old enough, so fall back on the clean page stamped longest ago, or failing that any page
+		best := f.hand
+		for k := 1; k < n; k++ {
+			i := (f.hand + k) % n
+			if f.dirty[best] && !f.dirty[i] || f.dirty[best] == f.dirty[i] && f.stamp[i] < f.stamp[best] {
+				best = i
+			}
+		}
+
+		return evict(best)
+	})
+	for i, size := range WorkingSetSizes(refs, window) {
+		r.Steps[i].WorkingSet = size
+	}
+
+	return r
+}
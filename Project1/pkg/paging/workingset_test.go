@@ -0,0 +1,109 @@
+package paging
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWorkingSetSizes(t *testing.T) {
+	t.Parallel()
+	refs, _ := ParseReferences("1 2 1 3 4 4")
+	tests := []struct {
+		window int
+		want   []int
+	}{
+		{window: 0, want: []int{1, 1, 1, 1, 1, 1}},
+		{window: 3, want: []int{1, 2, 2, 3, 3, 2}},
+		{window: 10, want: []int{1, 2, 2, 3, 4, 4}},
+	}
+	for _, tt := range tests {
+		if got := WorkingSetSizes(refs, tt.window); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WorkingSetSizes(%d) = %v, want %v", tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestWorkingSet(t *testing.T) {
+	t.Parallel()
+	refs, _ := ParseReferences("1 2 1 3w 4 4")
+	r := WorkingSet(refs, 3)
+	var evicted []int64
+	for _, s := range r.Steps {
+		if s.Evicted != Empty {
+			evicted = append(evicted, s.Evicted)
+		}
+	}
+	if r.Faults != 4 || r.Frames != 3 || !reflect.DeepEqual(evicted, []int64{2, 1}) {
+		t.Errorf("WorkingSet() = %d faults in %d frames evicting %v, want 4 in 3 evicting [2 1]", r.Faults, r.Frames,
+			evicted)
+	}
+	if last := r.Steps[len(r.Steps)-1]; !reflect.DeepEqual(last.Frames, []int64{Empty, 4, 3}) {
+		t.Errorf("WorkingSet() ends with %v, want [-1 4 3]", last.Frames)
+	}
+
+	// the frames held are always exactly the working set
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		refs := Generate(rng, 60, 9, 0.2)
+		for _, window := range []int{1, 4, 12} {
+			for _, s := range WorkingSet(refs, window).Steps {
+				held := 0
+				for _, page := range s.Frames {
+					if page != Empty {
+						held++
+					}
+				}
+				if held != s.WorkingSet {
+					t.Fatalf("window %d: %d frames held at %v, want the working set of %d", window, held, s,
+						s.WorkingSet)
+				}
+			}
+		}
+	}
+}
+
+func TestWSClock(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		refs           string
+		window         int
+		wantEvicted    []int64
+		wantWriteBacks int
+		wantHands      []int
+	}{
+		// 3 has gone unreferenced for longer than the window when 5 faults, while 2 was just used
+		{name: "old page", refs: "1 2 3 4 2 5", window: 1, wantEvicted: []int64{1, 3},
+			wantHands: []int{0, 0, 0, 1, 1, 0}},
+		// with nothing old enough, the clean pages go first, and the dirty old 1 is written back ahead of its eviction
+		{name: "dirty page", refs: "1w 2 3 4 5 6", window: 1, wantEvicted: []int64{2, 3, 1}, wantWriteBacks: 1,
+			wantHands: []int{0, 0, 0, 2, 0, 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			refs, _ := ParseReferences(tt.refs)
+			r := WSClock(refs, 3, tt.window)
+			var evicted []int64
+			var hands []int
+			for _, s := range r.Steps {
+				if s.Evicted != Empty {
+					evicted = append(evicted, s.Evicted)
+				}
+				hands = append(hands, s.Hand)
+			}
+			if !reflect.DeepEqual(evicted, tt.wantEvicted) || r.WriteBacks != tt.wantWriteBacks {
+				t.Errorf("evicted %v with %d write-backs, want %v with %d", evicted, r.WriteBacks, tt.wantEvicted,
+					tt.wantWriteBacks)
+			}
+			if !reflect.DeepEqual(hands, tt.wantHands) {
+				t.Errorf("hand at %v, want %v", hands, tt.wantHands)
+			}
+			if r.Steps[2].WorkingSet != min(3, tt.window) {
+				t.Errorf("working set %d at the third reference", r.Steps[2].WorkingSet)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package queue holds the generic ready queues the schedulers share: a first-in, first-out Queue and a
+// PriorityQueue ordered by a less function.
+package queue
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Queue is a first-in, first-out queue. The zero Queue is empty and ready to use.
+type Queue[T any] struct {
+	items []T
+	head  int // index of the front item; items before it have been popped
+}
+
+// Len returns the number of items in q.
+func (q *Queue[T]) Len() int {
+	return len(q.items) - q.head
+}
+
+// Push adds v to the back of q.
+func (q *Queue[T]) Push(v T) {
+	if q.head > 0 && q.head == len(q.items) {
+		// empty, so start over at the front of the backing array rather than growing it
+		q.items, q.head = q.items[:0], 0
+	}
+	q.items = append(q.items, v)
+}
+
+// Pop removes and returns the front of q, or reports false if q is empty.
+func (q *Queue[T]) Pop() (T, bool) {
+	var zero T
+	if q.Len() == 0 {
+		return zero, false
+	}
+	v := q.items[q.head]
+	q.items[q.head] = zero // let it be garbage collected
+	q.head++
+	if q.head > len(q.items)/2 {
+		// more than half the backing array is popped, so move the rest down to reuse it
+		n := copy(q.items, q.items[q.head:])
+		clear(q.items[n:])
+		q.items, q.head = q.items[:n], 0
+	}
+
+	return v, true
+}
+
+// Peek returns the front of q without removing it, or reports false if q is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	if q.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.items[q.head], true
+}
+
+// Items returns the items of q from front to back. The slice shares q's storage, so it is only valid until the next
+// Push or Pop and must not be modified.
+func (q *Queue[T]) Items() []T {
+	return q.items[q.head:]
+}
+
+// Each calls f with a pointer to every item of q from front to back, so f can update them in place.
+func (q *Queue[T]) Each(f func(*T)) {
+	for i := q.head; i < len(q.items); i++ {
+		f(&q.items[i])
+	}
+}
+
+// PriorityQueue is a queue whose Pop returns the least item by its less function. Items that are equal by less
+// come out in the order they were pushed.
+type PriorityQueue[T any] struct {
+	h entries[T]
+}
+
+// NewPriority returns an empty PriorityQueue ordered by less.
+func NewPriority[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{h: entries[T]{less: less}}
+}
+
+// Len returns the number of items in q.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.h.items)
+}
+
+// Push adds v to q.
+func (q *PriorityQueue[T]) Push(v T) {
+	heap.Push(&q.h, entry[T]{v: v, seq: q.h.seq})
+	q.h.seq++
+}
+
+// Pop removes and returns the least item of q, or reports false if q is empty.
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	if q.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return heap.Pop(&q.h).(entry[T]).v, true
+}
+
+// Peek returns the least item of q without removing it, or reports false if q is empty.
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if q.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.h.items[0].v, true
+}
+
+// Items returns a copy of the items of q in the order Pop would return them.
+func (q *PriorityQueue[T]) Items() []T {
+	sorted := append([]entry[T](nil), q.h.items...)
+	sort.Slice(sorted, func(i, j int) bool { return q.h.before(sorted[i], sorted[j]) })
+	items := make([]T, len(sorted))
+	for i := range sorted {
+		items[i] = sorted[i].v
+	}
+
+	return items
+}
+
+// Each calls f with a pointer to every item of q, in no particular order, so f can update them in place. f must not
+// change how they compare.
+func (q *PriorityQueue[T]) Each(f func(*T)) {
+	for i := range q.h.items {
+		f(&q.h.items[i].v)
+	}
+}
+
+// entry is an item of a PriorityQueue along with when it was pushed, which breaks ties.
+type entry[T any] struct {
+	v   T
+	seq uint64
+}
+
+// entries is the heap.Interface behind PriorityQueue.
+type entries[T any] struct {
+	items []entry[T]
+	less  func(a, b T) bool
+	seq   uint64 // seq of the next push
+}
+
+func (h *entries[T]) before(a, b entry[T]) bool {
+	if h.less(a.v, b.v) {
+		return true
+	}
+	if h.less(b.v, a.v) {
+		return false
+	}
+
+	return a.seq < b.seq
+}
+
+func (h *entries[T]) Len() int           { return len(h.items) }
+func (h *entries[T]) Less(i, j int) bool { return h.before(h.items[i], h.items[j]) }
+func (h *entries[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *entries[T]) Push(x any)         { h.items = append(h.items, x.(entry[T])) }
+
+func (h *entries[T]) Pop() any {
+	n := len(h.items) - 1
+	last := h.items[n]
+	h.items[n] = entry[T]{}
+	h.items = h.items[:n]
+
+	return last
+}
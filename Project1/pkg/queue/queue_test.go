@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+	var q Queue[int]
+	if _, ok := q.Pop(); ok {
+		t.Fatal("Pop() of an empty queue reported an item")
+	}
+	var popped []int
+	for i := 1; i <= 10; i++ {
+		q.Push(i)
+		if i%3 == 0 {
+			// interleave pops so the backing array gets compacted and reused
+			v, _ := q.Pop()
+			popped = append(popped, v)
+		}
+	}
+	if front, _ := q.Peek(); front != 4 || q.Len() != 7 {
+		t.Errorf("Peek() = %d with Len() = %d, want 4 with 7", front, q.Len())
+	}
+	q.Each(func(v *int) { *v *= 10 })
+	if got, want := q.Items(), []int{40, 50, 60, 70, 80, 90, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		popped = append(popped, v)
+	}
+	if want := []int{1, 2, 3, 40, 50, 60, 70, 80, 90, 100}; !reflect.DeepEqual(popped, want) {
+		t.Errorf("popped %v, want %v", popped, want)
+	}
+	q.Push(11)
+	if v, ok := q.Pop(); !ok || v != 11 {
+		t.Errorf("Pop() after draining = %d, %v, want 11", v, ok)
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+	type job struct {
+		name string
+		key  int
+	}
+	q := NewPriority(func(a, b job) bool { return a.key < b.key })
+	if _, ok := q.Peek(); ok {
+		t.Fatal("Peek() of an empty queue reported an item")
+	}
+	for _, j := range []job{{"a", 3}, {"b", 1}, {"c", 3}, {"d", 2}, {"e", 1}, {"f", 3}} {
+		q.Push(j)
+	}
+	want := []job{{"b", 1}, {"e", 1}, {"d", 2}, {"a", 3}, {"c", 3}, {"f", 3}}
+	if got := q.Items(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if front, _ := q.Peek(); front != want[0] {
+		t.Errorf("Peek() = %v, want %v", front, want[0])
+	}
+	q.Each(func(j *job) { j.name += "!" })
+	var got []job
+	for q.Len() > 0 {
+		j, _ := q.Pop()
+		got = append(got, j)
+	}
+	for i := range want {
+		want[i].name += "!"
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("popped %v, want %v (ties in push order)", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package report
+
+// AccountingRecord is how one finished process of a report spent its time, the way process accounting records it:
+// Start when it first ran and End when it completed, Real between its arrival and End, of which User running its own
+// code, Sys in the kernel on its behalf, Wait ready in the queue, and Blocked neither, waiting for a page or an I/O.
+// CPUPercent is its CPU time, user and system, as a percent of its real time, the way ps reports %CPU.
+type AccountingRecord struct {
+	SchemaVersion int     `json:"schema_version" csv:"schema_version"`
+	Algorithm     string  `json:"algorithm" csv:"algorithm"`
+	PID           int64   `json:"pid" csv:"pid"`
+	Start         int64   `json:"start" csv:"start"`
+	End           int64   `json:"end" csv:"end"`
+	Real          int64   `json:"real" csv:"real"`
+	User          int64   `json:"user" csv:"user"`
+	Sys           int64   `json:"sys" csv:"sys"`
+	Wait          int64   `json:"wait" csv:"wait"`
+	Blocked       int64   `json:"blocked" csv:"blocked"`
+	CPUPercent    float64 `json:"cpu_percent" csv:"cpu_percent"`
+}
+
+// Accounting returns the accounting record of every finished process of r, in the order of r.Processes. A process
+// that never ran starts when it arrived.
+func (r Report) Accounting() []AccountingRecord {
+	start := make(map[int64]int64)
+	for _, s := range r.Gantt {
+		if _, ok := start[s.PID]; !ok && !s.Switch {
+			start[s.PID] = s.Start
+		}
+	}
+	records := make([]AccountingRecord, 0, len(r.Processes))
+	for i, t := range r.CPUTimes() {
+		p := r.Processes[i]
+		first, ok := start[p.ProcessID]
+		if !ok {
+			first = p.ArrivalTime
+		}
+		var cpu float64
+		if t.Real > 0 {
+			cpu = 100 * float64(t.User+t.Sys) / float64(t.Real)
+		}
+		records = append(records, AccountingRecord{
+			SchemaVersion: SchemaVersion,
+			Algorithm:     r.Title,
+			PID:           p.ProcessID,
+			Start:         first,
+			End:           p.Completion,
+			Real:          t.Real,
+			User:          t.User,
+			Sys:           t.Sys,
+			Wait:          p.Wait,
+			Blocked:       max(t.Real-t.User-t.Sys-p.Wait, 0),
+			CPUPercent:    cpu,
+		})
+	}
+
+	return records
+}
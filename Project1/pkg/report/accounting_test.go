@@ -0,0 +1,54 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestReport_Accounting(t *testing.T) {
+	t.Parallel()
+	// P1 runs, enters the kernel, and then is blocked from 4 to 6; P2 waits for it and the switch to it
+	gantt := []sched.TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 1, Start: 3, Stop: 4, Kernel: true},
+		{PID: 2, Start: 4, Stop: 5, Switch: true},
+		{PID: 2, Start: 5, Stop: 7},
+		{PID: 1, Start: 7, Stop: 8},
+	}
+	done := []workload.Process{
+		{ProcessID: 1, Burst: 4, Wait: 1, Turnaround: 8, Completion: 8},
+		{ProcessID: 2, Burst: 2, ArrivalTime: 1, Wait: 4, Turnaround: 6, Completion: 7},
+	}
+	r := New("RR", sched.NewResult(gantt, done))
+	want := []AccountingRecord{
+		{SchemaVersion: SchemaVersion, Algorithm: "RR", PID: 1, Start: 0, End: 8, Real: 8, User: 4, Sys: 1, Wait: 1,
+			Blocked: 2, CPUPercent: 62.5},
+		{SchemaVersion: SchemaVersion, Algorithm: "RR", PID: 2, Start: 5, End: 7, Real: 6, User: 2, Wait: 4,
+			CPUPercent: 100 * 2.0 / 6},
+	}
+	got := r.Accounting()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Accounting() = %+v, want %+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, got[:1]); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRows := [][]string{
+		{"schema_version", "algorithm", "pid", "start", "end", "real", "user", "sys", "wait", "blocked", "cpu_percent"},
+		{"1", "RR", "1", "0", "8", "8", "4", "1", "1", "2", "62.5"},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("WriteCSV() = %v, want %v", rows, wantRows)
+	}
+}
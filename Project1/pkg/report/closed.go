@@ -0,0 +1,60 @@
+package report
+
+import "GolandProjects/Project1/pkg/sched"
+
+// SteadyState is how a closed workload behaved once it settled: of the jobs that finished from Warmup up to
+// Horizon, how many there were, the throughput they make, and their average response time, from submission to
+// completion, and wait, along with the share of the window the CPU was busy. Users is the population and Think the
+// mean think time between a job's completion and its user's next submission.
+type SteadyState struct {
+	Users       int
+	Think       float64
+	Warmup      int64
+	Horizon     int64
+	Jobs        int
+	Throughput  float64
+	Response    float64
+	Wait        float64
+	Utilization float64
+}
+
+// NewSteadyState measures the steady state of the closed run r of users users thinking for think on average, from
+// warmup up to horizon.
+func NewSteadyState(r sched.ClosedResult, users int, think float64, warmup, horizon int64) SteadyState {
+	s := SteadyState{Users: users, Think: think, Warmup: warmup, Horizon: horizon}
+	if horizon <= warmup {
+		return s
+	}
+	for _, p := range r.PerProcess {
+		if p.Completion >= warmup && p.Completion < horizon {
+			s.Jobs++
+			s.Response += float64(p.Turnaround)
+			s.Wait += float64(p.Wait)
+		}
+	}
+	if s.Jobs > 0 {
+		s.Response /= float64(s.Jobs)
+		s.Wait /= float64(s.Jobs)
+	}
+	var busy int64
+	for _, slice := range r.Slices {
+		if !slice.Switch {
+			busy += max(0, min(slice.Stop, horizon)-max(slice.Start, warmup))
+		}
+	}
+	window := float64(horizon - warmup)
+	s.Throughput = float64(s.Jobs) / window
+	s.Utilization = float64(busy) / window
+
+	return s
+}
+
+// LawResponse returns the average response time the interactive response time law, R = N/X − Z, predicts from the
+// throughput, or 0 without any. The closer it is to Response, the closer the window is to a steady state.
+func (s SteadyState) LawResponse() float64 {
+	if s.Throughput == 0 {
+		return 0
+	}
+
+	return float64(s.Users)/s.Throughput - s.Think
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+func TestNewSteadyState(t *testing.T) {
+	t.Parallel()
+	// one user thinking 2 between jobs of 3 finishes a job every 5 time units
+	loop := sched.ClosedLoop{Users: 1, Horizon: 40, Think: func(int) int64 { return 2 },
+		Job: func(int) (int64, int64) { return 3, 1 }}
+	r, err := sched.RunClosed(context.Background(), loop, sched.FCFSPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		warmup int64
+		want   SteadyState
+	}{
+		{name: "whole run", want: SteadyState{Users: 1, Think: 2, Horizon: 40, Jobs: 7, Throughput: 7.0 / 40,
+			Response: 3, Utilization: 24.0 / 40}},
+		// from 10 to 40 takes in whole cycles, so the law holds exactly
+		{name: "after warmup", warmup: 10, want: SteadyState{Users: 1, Think: 2, Warmup: 10, Horizon: 40, Jobs: 6,
+			Throughput: 0.2, Response: 3, Utilization: 18.0 / 30}},
+		{name: "empty window", warmup: 40, want: SteadyState{Users: 1, Think: 2, Warmup: 40, Horizon: 40}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NewSteadyState(r, 1, 2, tt.warmup, 40)
+			if got != tt.want {
+				t.Errorf("NewSteadyState() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if law := NewSteadyState(r, 1, 2, 10, 40).LawResponse(); math.Abs(law-3) > 1e-9 {
+		t.Errorf("LawResponse() = %g, want 3", law)
+	}
+}
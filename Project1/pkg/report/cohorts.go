@@ -0,0 +1,46 @@
+package report
+
+import (
+	"sort"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ArrivalCohort aggregates the finished processes that arrived in the same window of time, Start up to but not
+// including Start plus the window width.
+type ArrivalCohort struct {
+	Start     int64
+	Processes []workload.Process
+}
+
+// AvgWait is the mean waiting time of the cohort.
+func (c ArrivalCohort) AvgWait() float64 {
+	return average(c.Processes, func(p workload.Process) float64 { return float64(p.Wait) })
+}
+
+// AvgTurnaround is the mean turnaround of the cohort.
+func (c ArrivalCohort) AvgTurnaround() float64 {
+	return average(c.Processes, func(p workload.Process) float64 { return float64(p.Turnaround) })
+}
+
+// GroupByArrival buckets done into arrival windows of width time units, earliest first. Windows no process arrived in
+// are left out.
+func GroupByArrival(done []workload.Process, width int64) []ArrivalCohort {
+	index := make(map[int64]int)
+	cohorts := make([]ArrivalCohort, 0)
+	for _, p := range done {
+		start := p.ArrivalTime / width * width
+		j, ok := index[start]
+		if !ok {
+			j = len(cohorts)
+			index[start] = j
+			cohorts = append(cohorts, ArrivalCohort{Start: start})
+		}
+		cohorts[j].Processes = append(cohorts[j].Processes, p)
+	}
+	sort.Slice(cohorts, func(i, j int) bool {
+		return cohorts[i].Start < cohorts[j].Start
+	})
+
+	return cohorts
+}
@@ -0,0 +1,34 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestGroupByArrival(t *testing.T) {
+	t.Parallel()
+	done := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 12, Wait: 4, Turnaround: 6},
+		{ProcessID: 2, ArrivalTime: 0, Wait: 0, Turnaround: 3},
+		{ProcessID: 3, ArrivalTime: 9, Wait: 2, Turnaround: 5},
+		{ProcessID: 4, ArrivalTime: 31, Wait: 1, Turnaround: 2},
+		{ProcessID: 5, ArrivalTime: 10, Wait: 8, Turnaround: 10},
+	}
+	got := GroupByArrival(done, 10)
+	want := []ArrivalCohort{
+		{Start: 0, Processes: []workload.Process{done[1], done[2]}},
+		{Start: 10, Processes: []workload.Process{done[0], done[4]}},
+		{Start: 30, Processes: []workload.Process{done[3]}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GroupByArrival() = %v, want %v", got, want)
+	}
+	if got[1].AvgWait() != 6 || got[1].AvgTurnaround() != 8 {
+		t.Errorf("cohort 10 averages wait %v and turnaround %v, want 6 and 8", got[1].AvgWait(), got[1].AvgTurnaround())
+	}
+	if got := GroupByArrival(nil, 5); len(got) != 0 {
+		t.Errorf("GroupByArrival(nil) = %v, want none", got)
+	}
+}
@@ -0,0 +1,112 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ProcessColumns holds the metrics of finished processes column by column, one flat array per metric, for runs too
+// large to hold as rows of records or strings. Writing them out formats one row at a time into a single buffer.
+type ProcessColumns struct {
+	PID        []int64
+	Arrival    []int64
+	Burst      []int64
+	Priority   []int64
+	Wait       []int64
+	Turnaround []int64
+	Completion []int64
+	Deadline   []int64
+}
+
+// NewProcessColumns returns empty columns with room for n processes.
+func NewProcessColumns(n int) *ProcessColumns {
+	return &ProcessColumns{
+		PID:        make([]int64, 0, n),
+		Arrival:    make([]int64, 0, n),
+		Burst:      make([]int64, 0, n),
+		Priority:   make([]int64, 0, n),
+		Wait:       make([]int64, 0, n),
+		Turnaround: make([]int64, 0, n),
+		Completion: make([]int64, 0, n),
+		Deadline:   make([]int64, 0, n),
+	}
+}
+
+// Append adds the finished process p.
+func (c *ProcessColumns) Append(p workload.Process) {
+	c.PID = append(c.PID, p.ProcessID)
+	c.Arrival = append(c.Arrival, p.ArrivalTime)
+	c.Burst = append(c.Burst, p.Burst)
+	c.Priority = append(c.Priority, p.Priority)
+	c.Wait = append(c.Wait, p.Wait)
+	c.Turnaround = append(c.Turnaround, p.Turnaround)
+	c.Completion = append(c.Completion, p.Completion)
+	c.Deadline = append(c.Deadline, p.Deadline)
+}
+
+// Len returns the number of processes held.
+func (c *ProcessColumns) Len() int {
+	return len(c.PID)
+}
+
+// Process returns the i-th process held, as it was appended.
+func (c *ProcessColumns) Process(i int) workload.Process {
+	return workload.Process{
+		ProcessID:   c.PID[i],
+		ArrivalTime: c.Arrival[i],
+		Priority:    c.Priority[i],
+		Deadline:    c.Deadline[i],
+		Burst:       c.Burst[i],
+		Wait:        c.Wait[i],
+		Turnaround:  c.Turnaround[i],
+		Completion:  c.Completion[i],
+	}
+}
+
+// WriteCSV writes the processes held as ProcessRecord rows of the algorithm titled algorithm, under a header row if
+// header is set, exactly as WriteCSV would write their records.
+func (c *ProcessColumns) WriteCSV(w io.Writer, algorithm string, header bool) error {
+	bw := bufio.NewWriter(w)
+	if header {
+		_, _ = bw.WriteString(strings.Join(csvHeader(reflect.TypeOf(ProcessRecord{})), ",") + "\n")
+	}
+	// let encoding/csv quote the title if it needs it
+	var title bytes.Buffer
+	cw := csv.NewWriter(&title)
+	_ = cw.Write([]string{algorithm})
+	cw.Flush()
+	title.Truncate(title.Len() - 1)
+	row := make([]byte, 0, 128)
+	for i := range c.PID {
+		row = strconv.AppendInt(row[:0], SchemaVersion, 10)
+		row = append(row, ',')
+		row = append(row, title.Bytes()...)
+		for _, v := range [...]int64{c.PID[i], c.Arrival[i], c.Burst[i], c.Priority[i], c.Wait[i], c.Turnaround[i],
+			c.Completion[i]} {
+			row = append(row, ',')
+			row = strconv.AppendInt(row, v, 10)
+		}
+		row = append(row, ',')
+		row = strconv.AppendFloat(row, sched.NormalizedTurnaround(c.Process(i)), 'f', -1, 64)
+		row = append(row, ',')
+		row = strconv.AppendInt(row, c.Deadline[i], 10)
+		row = append(row, ',')
+		row = strconv.AppendInt(row, Tardiness(c.Process(i)), 10)
+		row = append(row, '\n')
+		_, _ = bw.Write(row)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("%w: writing CSV records", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestProcessColumns(t *testing.T) {
+	t.Parallel()
+	processes := workload.Generate(rand.New(rand.NewSource(1)),
+		workload.GenerateOptions{Count: 20, MaxBurst: 9, MaxArrival: 30, MaxPriority: 4})
+	result, err := sched.SJF(context.Background(), processes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		title  string
+		header bool
+	}{
+		{name: "with header", title: "SJF", header: true},
+		{name: "rows only", title: "SJF", header: false},
+		{name: "title needing quotes", title: `RR, "q=2"`, header: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := New(tt.title, result)
+			c := NewProcessColumns(len(r.Processes))
+			for _, p := range r.Processes {
+				c.Append(p)
+			}
+			if c.Len() != len(r.Processes) {
+				t.Fatalf("Len() = %d, want %d", c.Len(), len(r.Processes))
+			}
+			for i, p := range r.Processes {
+				if got := c.Process(i); got != p {
+					t.Errorf("Process(%d) = %+v, want %+v", i, got, p)
+				}
+			}
+
+			var got, want bytes.Buffer
+			if err := c.WriteCSV(&got, tt.title, tt.header); err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteCSV(&want, r.ProcessRecords()); err != nil {
+				t.Fatal(err)
+			}
+			wantCSV := want.String()
+			if !tt.header {
+				_, wantCSV, _ = strings.Cut(wantCSV, "\n")
+			}
+			if got.String() != wantCSV {
+				t.Errorf("WriteCSV() =\n%s\nwant\n%s", got.String(), wantCSV)
+			}
+		})
+	}
+}
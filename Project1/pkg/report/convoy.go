@@ -0,0 +1,55 @@
+package report
+
+import (
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// MinConvoyFollowers is how many short processes must queue behind a long one before FindConvoys calls it a convoy.
+const MinConvoyFollowers = 2
+
+// Convoy is a long process whose run held up shorter processes queued behind it: the convoy effect that makes FCFS
+// waits balloon when a long job arrives just ahead of many short ones.
+type Convoy struct {
+	Leader workload.Process
+	// Start and Stop bound the leader's run that the followers waited through.
+	Start int64
+	Stop  int64
+	// Followers are the processes that arrived before the leader's run ended, were still unfinished when it began,
+	// and need at most half as long as it ran.
+	Followers []workload.Process
+	// ExtraWait is the followers' combined wait during the leader's run, which they would not have spent behind a
+	// scheduler that let short processes go first.
+	ExtraWait int64
+}
+
+// FindConvoys returns the convoys in a finished run, in the order they ran: every slice of the Gantt chart during
+// which at least MinConvoyFollowers processes needing at most half the slice's length were kept waiting.
+func FindConvoys(gantt []sched.TimeSlice, done []workload.Process) []Convoy {
+	byPID := make(map[int64]workload.Process, len(done))
+	for _, p := range done {
+		byPID[p.ProcessID] = p
+	}
+
+	var convoys []Convoy
+	for _, s := range gantt {
+		leader, ok := byPID[s.PID]
+		if s.Switch || !ok {
+			continue
+		}
+		c := Convoy{Leader: leader, Start: s.Start, Stop: s.Stop}
+		for _, p := range done {
+			if p.ProcessID == leader.ProcessID || p.ArrivalTime >= s.Stop || p.Completion <= s.Start ||
+				2*p.Burst > s.Stop-s.Start {
+				continue
+			}
+			c.Followers = append(c.Followers, p)
+			c.ExtraWait += s.Stop - max(s.Start, p.ArrivalTime)
+		}
+		if len(c.Followers) >= MinConvoyFollowers {
+			convoys = append(convoys, c)
+		}
+	}
+
+	return convoys
+}
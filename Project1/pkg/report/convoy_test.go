@@ -0,0 +1,62 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestFindConvoys(t *testing.T) {
+	t.Parallel()
+	// the textbook convoy: a burst of 24 arrives just ahead of two bursts of 3
+	long := workload.Process{ProcessID: 1, Burst: 24, Completion: 24}
+	short := workload.Process{ProcessID: 2, ArrivalTime: 1, Burst: 3, Completion: 27}
+	shorter := workload.Process{ProcessID: 3, ArrivalTime: 2, Burst: 3, Completion: 30}
+	tests := []struct {
+		name  string
+		gantt []sched.TimeSlice
+		done  []workload.Process
+		want  []Convoy
+	}{
+		{
+			name:  "fcfs",
+			gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 24}, {PID: 2, Start: 24, Stop: 27}, {PID: 3, Start: 27, Stop: 30}},
+			done:  []workload.Process{long, short, shorter},
+			want:  []Convoy{{Leader: long, Start: 0, Stop: 24, Followers: []workload.Process{short, shorter}, ExtraWait: 23 + 22}},
+		},
+		{
+			name: "with a context switch",
+			gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 1, Switch: true}, {PID: 1, Start: 1, Stop: 25},
+				{PID: 2, Start: 25, Stop: 28}, {PID: 3, Start: 28, Stop: 31}},
+			done: []workload.Process{long, short, shorter},
+			want: []Convoy{{Leader: long, Start: 1, Stop: 25, Followers: []workload.Process{short, shorter}, ExtraWait: 24 + 23}},
+		},
+		{
+			name:  "one follower is no convoy",
+			gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 24}, {PID: 2, Start: 24, Stop: 27}},
+			done:  []workload.Process{long, short},
+		},
+		{
+			name:  "followers arrive too late",
+			gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 7}, {PID: 3, Start: 7, Stop: 10}},
+			done: []workload.Process{{ProcessID: 1, Burst: 4, Completion: 4},
+				{ProcessID: 2, ArrivalTime: 4, Burst: 3, Completion: 7}, {ProcessID: 3, ArrivalTime: 5, Burst: 3, Completion: 10}},
+		},
+		{
+			name:  "round-robin slices are too short",
+			gantt: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 6}},
+			done:  []workload.Process{long, short, shorter},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FindConvoys(tt.gantt, tt.done); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindConvoys() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package report
+
+import "GolandProjects/Project1/pkg/sched"
+
+// CPUTime is how a process spent its time the way time(1) reports it: Real from arrival to completion, User running
+// its own code, and Sys in the kernel on its behalf.
+type CPUTime struct {
+	PID  int64
+	Real int64
+	User int64
+	Sys  int64
+}
+
+// SysShare is the fraction of the CPU time of t spent in the kernel, or 0 if it had none.
+func (t CPUTime) SysShare() float64 {
+	if t.User+t.Sys == 0 {
+		return 0
+	}
+
+	return float64(t.Sys) / float64(t.User+t.Sys)
+}
+
+// CPUTimes returns the real, user, and system time of every process of r, in the order of r.Processes.
+func (r Report) CPUTimes() []CPUTime {
+	sys := sched.SystemTime(r.Gantt)
+	times := make([]CPUTime, 0, len(r.Processes))
+	for _, p := range r.Processes {
+		times = append(times, CPUTime{PID: p.ProcessID, Real: p.Turnaround, User: p.Burst, Sys: sys[p.ProcessID]})
+	}
+
+	return times
+}
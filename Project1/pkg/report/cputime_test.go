@@ -0,0 +1,34 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestReport_CPUTimes(t *testing.T) {
+	t.Parallel()
+	gantt := []sched.TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 1, Start: 3, Stop: 4, Kernel: true},
+		{PID: 2, Start: 4, Stop: 5, Switch: true},
+		{PID: 2, Start: 5, Stop: 7},
+	}
+	done := []workload.Process{
+		{ProcessID: 1, Burst: 3, Turnaround: 4, Completion: 4},
+		{ProcessID: 2, Burst: 2, ArrivalTime: 1, Wait: 4, Turnaround: 6, Completion: 7},
+	}
+	r := New("test", sched.NewResult(gantt, done))
+	want := []CPUTime{{PID: 1, Real: 4, User: 3, Sys: 1}, {PID: 2, Real: 6, User: 2}}
+	if got := r.CPUTimes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CPUTimes() = %v, want %v", got, want)
+	}
+	if r.System != 1 || r.Busy != 6 || r.Overhead != 1 || r.Idle != 0 {
+		t.Errorf("system %d, busy %d, overhead %d, idle %d, want 1, 6, 1, and 0", r.System, r.Busy, r.Overhead, r.Idle)
+	}
+	if got := want[0].SysShare(); got != 0.25 {
+		t.Errorf("SysShare() = %v, want 0.25", got)
+	}
+}
@@ -0,0 +1,55 @@
+package report
+
+import "GolandProjects/Project1/pkg/workload"
+
+// Lateness is how long after its deadline p completed, negative if it finished early. It is 0 for a process without
+// a deadline.
+func Lateness(p workload.Process) int64 {
+	if p.Deadline == 0 {
+		return 0
+	}
+
+	return p.Completion - p.Deadline
+}
+
+// Tardiness is p's lateness if it finished after its deadline and 0 otherwise.
+func Tardiness(p workload.Process) int64 {
+	return max(Lateness(p), 0)
+}
+
+// DeadlineSummary is how well a run met the deadlines of its processes. Only processes with a deadline count.
+type DeadlineSummary struct {
+	Processes      int
+	Misses         int
+	MaxTardiness   int64
+	TotalTardiness int64
+	AvgLateness    float64
+}
+
+// Deadlines sums up the lateness and tardiness of the finished processes done, or returns nil if none of them has a
+// deadline.
+func Deadlines(done []workload.Process) *DeadlineSummary {
+	var (
+		s     DeadlineSummary
+		total int64
+	)
+	for _, p := range done {
+		if p.Deadline == 0 {
+			continue
+		}
+		s.Processes++
+		total += Lateness(p)
+		tardiness := Tardiness(p)
+		if tardiness > 0 {
+			s.Misses++
+		}
+		s.TotalTardiness += tardiness
+		s.MaxTardiness = max(s.MaxTardiness, tardiness)
+	}
+	if s.Processes == 0 {
+		return nil
+	}
+	s.AvgLateness = float64(total) / float64(s.Processes)
+
+	return &s
+}
@@ -0,0 +1,49 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestDeadlines(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		done []workload.Process
+		want *DeadlineSummary
+	}{
+		{name: "no deadlines", done: []workload.Process{{ProcessID: 1, Completion: 9}}},
+		{
+			name: "all met",
+			done: []workload.Process{{ProcessID: 1, Completion: 4, Deadline: 6}, {ProcessID: 2, Completion: 8, Deadline: 8}},
+			want: &DeadlineSummary{Processes: 2, AvgLateness: -1},
+		},
+		{
+			name: "some missed",
+			done: []workload.Process{
+				{ProcessID: 1, Completion: 4, Deadline: 10},
+				{ProcessID: 2, Completion: 9, Deadline: 7},
+				{ProcessID: 3, Completion: 12},
+				{ProcessID: 4, Completion: 15, Deadline: 10},
+			},
+			want: &DeadlineSummary{Processes: 3, Misses: 2, MaxTardiness: 5, TotalTardiness: 7, AvgLateness: 1.0 / 3},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Deadlines(tt.done); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Deadlines() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if got := Lateness(workload.Process{Completion: 5}); got != 0 {
+		t.Errorf("Lateness() without a deadline = %d, want 0", got)
+	}
+	if got := Tardiness(workload.Process{Completion: 5, Deadline: 8}); got != 0 {
+		t.Errorf("Tardiness() of an early process = %d, want 0", got)
+	}
+}
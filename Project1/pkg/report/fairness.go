@@ -0,0 +1,101 @@
+package report
+
+import (
+	"sort"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// PriorityClass aggregates the finished processes sharing one priority value.
+type PriorityClass struct {
+	Priority  int64
+	Processes []workload.Process
+}
+
+// AvgWait is the mean waiting time of the class.
+func (c PriorityClass) AvgWait() float64 {
+	return c.average(func(p workload.Process) float64 { return float64(p.Wait) })
+}
+
+// AvgTurnaround is the mean turnaround of the class.
+func (c PriorityClass) AvgTurnaround() float64 {
+	return c.average(func(p workload.Process) float64 { return float64(p.Turnaround) })
+}
+
+// AvgCPUShare is the mean CPU share of the class.
+func (c PriorityClass) AvgCPUShare() float64 {
+	return c.average(CPUShare)
+}
+
+func (c PriorityClass) average(metric func(workload.Process) float64) float64 {
+	return average(c.Processes, metric)
+}
+
+// average is the mean of metric over processes, or 0 when there are none.
+func average(processes []workload.Process, metric func(workload.Process) float64) float64 {
+	if len(processes) == 0 {
+		return 0
+	}
+	var total float64
+	for _, p := range processes {
+		total += metric(p)
+	}
+
+	return total / float64(len(processes))
+}
+
+// JainIndex computes Jain's fairness index (Σx)² / (n·Σx²) over xs. It ranges from 1/n, when a single member gets
+// everything, to 1, when every member gets the same. An empty or all-zero input is treated as perfectly fair.
+func JainIndex(xs []float64) float64 {
+	var sum, sumSquares float64
+	for _, x := range xs {
+		sum += x
+		sumSquares += x * x
+	}
+	if sumSquares == 0 {
+		return 1
+	}
+
+	return sum * sum / (float64(len(xs)) * sumSquares)
+}
+
+// CPUShare is the fraction of its time in the system that p spent running, the inverse of its normalized turnaround.
+func CPUShare(p workload.Process) float64 {
+	if p.Turnaround == 0 {
+		return 0
+	}
+
+	return float64(p.Burst) / float64(p.Turnaround)
+}
+
+// GroupByPriority buckets done by priority, highest priority (lowest value) first.
+func GroupByPriority(done []workload.Process) []PriorityClass {
+	index := make(map[int64]int)
+	classes := make([]PriorityClass, 0)
+	for i := range done {
+		j, ok := index[done[i].Priority]
+		if !ok {
+			j = len(classes)
+			index[done[i].Priority] = j
+			classes = append(classes, PriorityClass{Priority: done[i].Priority})
+		}
+		classes[j].Processes = append(classes[j].Processes, done[i])
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Priority < classes[j].Priority
+	})
+
+	return classes
+}
+
+// Fairness returns Jain's index over the CPU share and the waiting time of each process.
+func Fairness(done []workload.Process) (share, wait float64) {
+	shares := make([]float64, len(done))
+	waits := make([]float64, len(done))
+	for i := range done {
+		shares[i] = CPUShare(done[i])
+		waits[i] = float64(done[i].Wait)
+	}
+
+	return JainIndex(shares), JainIndex(waits)
+}
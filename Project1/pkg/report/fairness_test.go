@@ -0,0 +1,48 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestJainIndex(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{name: "equal", xs: []float64{3, 3, 3}, want: 1},
+		{name: "one gets everything", xs: []float64{4, 0, 0, 0}, want: 0.25},
+		{name: "all zero", xs: []float64{0, 0}, want: 1},
+		{name: "empty", want: 1},
+		{name: "mixed", xs: []float64{1, 2, 3}, want: 36.0 / 42.0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := JainIndex(tt.xs); got != tt.want {
+				t.Errorf("jainIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupByPriority(t *testing.T) {
+	t.Parallel()
+	done := []workload.Process{
+		{ProcessID: 1, Priority: 3},
+		{ProcessID: 2, Priority: 1},
+		{ProcessID: 3, Priority: 3},
+	}
+	want := []PriorityClass{
+		{Priority: 1, Processes: []workload.Process{{ProcessID: 2, Priority: 1}}},
+		{Priority: 3, Processes: []workload.Process{{ProcessID: 1, Priority: 3}, {ProcessID: 3, Priority: 3}}},
+	}
+	if got := GroupByPriority(done); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupByPriority() = %v, want %v", got, want)
+	}
+}
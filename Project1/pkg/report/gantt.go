@@ -0,0 +1,205 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+const (
+	// ganttUnitWidth is the number of columns one time unit occupies.
+	ganttUnitWidth = 2
+	// ganttLineWidth is the widest a row of the chart may grow before wrapping.
+	ganttLineWidth = 80
+)
+
+// ganttCell is one box of the rendered chart: a slice of a process, a stretch of idle time, context-switch
+// overhead, or kernel time spent on behalf of a process.
+type ganttCell struct {
+	PID    int64
+	Label  string
+	Start  int64
+	Stop   int64
+	Width  int
+	Idle   bool
+	Switch bool
+	Kernel bool
+}
+
+// ganttCells lays gantt out as cells whose width is proportional to their duration, inserting idle cells wherever
+// the CPU had nothing to run, including before the first arrival, so the chart always starts at time 0.
+func ganttCells(gantt []sched.TimeSlice) []ganttCell {
+	cells := make([]ganttCell, 0, len(gantt))
+	var last int64
+	for i := range gantt {
+		if gantt[i].Start > last {
+			cells = append(cells, newGanttCell("", last, gantt[i].Start, true))
+		}
+		if gantt[i].Switch || gantt[i].Kernel {
+			// overhead is drawn unlabeled so even a one-unit switch stays a narrow mini-slice
+			cell := newGanttCell("", gantt[i].Start, gantt[i].Stop, false)
+			cell.PID, cell.Switch, cell.Kernel = gantt[i].PID, gantt[i].Switch, gantt[i].Kernel
+			cells = append(cells, cell)
+			last = gantt[i].Stop
+			continue
+		}
+		cell := newGanttCell(fmt.Sprint(gantt[i].PID), gantt[i].Start, gantt[i].Stop, false)
+		cell.PID = gantt[i].PID
+		cells = append(cells, cell)
+		last = gantt[i].Stop
+	}
+
+	return cells
+}
+
+func newGanttCell(label string, start, stop int64, idle bool) ganttCell {
+	width := int(stop-start) * ganttUnitWidth
+	if width < len(label)+2 {
+		width = len(label) + 2
+	}
+	if width > ganttLineWidth-2 {
+		// a single slice longer than a whole row is drawn at full row width rather than to scale
+		width = ganttLineWidth - 2
+	}
+
+	return ganttCell{Label: label, Start: start, Stop: stop, Width: width, Idle: idle}
+}
+
+// ganttLines splits cells into rows that fit within ganttLineWidth, always keeping at least one cell per row.
+func ganttLines(cells []ganttCell) [][]ganttCell {
+	lines := make([][]ganttCell, 0)
+	var (
+		line  []ganttCell
+		width = 1
+	)
+	for _, c := range cells {
+		if len(line) > 0 && width+c.Width+1 > ganttLineWidth {
+			lines = append(lines, line)
+			line, width = nil, 1
+		}
+		line = append(line, c)
+		width += c.Width + 1
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// WriteGantt draws gantt as boxes proportional to each slice's duration, wrapping onto as many rows as needed with
+// a time ruler under each row. With color, each process's boxes are drawn in a color of their own. If the kernel
+// worked on behalf of any process, every row gets a second track under it where that kernel time is shaded, leaving
+// the first track to the time processes ran their own code.
+func WriteGantt(w io.Writer, gantt []sched.TimeSlice, color bool) {
+	cells := ganttCells(gantt)
+	kernel := false
+	for _, c := range cells {
+		kernel = kernel || c.Kernel
+	}
+	if kernel {
+		_, _ = fmt.Fprintln(w, "Gantt schedule (top track user time, bottom track ▒ kernel time)")
+	} else {
+		_, _ = fmt.Fprintln(w, "Gantt schedule")
+	}
+	for _, line := range ganttLines(cells) {
+		var top, user, between, sys, bottom strings.Builder
+		top.WriteString("┌")
+		user.WriteString("│")
+		between.WriteString("├")
+		sys.WriteString("│")
+		bottom.WriteString("└")
+		for i, c := range line {
+			if i > 0 {
+				top.WriteString("┬")
+				between.WriteString("┼")
+				bottom.WriteString("┴")
+			}
+			top.WriteString(strings.Repeat("─", c.Width))
+			between.WriteString(strings.Repeat("─", c.Width))
+			bottom.WriteString(strings.Repeat("─", c.Width))
+			switch {
+			case c.Idle:
+				user.WriteString(strings.Repeat("░", c.Width))
+				sys.WriteString(strings.Repeat("░", c.Width))
+			case c.Switch:
+				user.WriteString(strings.Repeat("▓", c.Width))
+				sys.WriteString(strings.Repeat("▓", c.Width))
+			case c.Kernel:
+				user.WriteString(strings.Repeat(" ", c.Width))
+				text := strings.Repeat("▒", c.Width)
+				if color {
+					text = colorize(c.PID, text)
+				}
+				sys.WriteString(text)
+			default:
+				left := (c.Width - len(c.Label)) / 2
+				text := strings.Repeat(" ", left) + c.Label + strings.Repeat(" ", c.Width-left-len(c.Label))
+				if color {
+					text = colorize(c.PID, text)
+				}
+				user.WriteString(text)
+				sys.WriteString(strings.Repeat(" ", c.Width))
+			}
+			user.WriteString("│")
+			sys.WriteString("│")
+		}
+		top.WriteString("┐")
+		between.WriteString("┤")
+		bottom.WriteString("┘")
+
+		_, _ = fmt.Fprintln(w, top.String())
+		_, _ = fmt.Fprintln(w, user.String())
+		if kernel {
+			_, _ = fmt.Fprintln(w, between.String())
+			_, _ = fmt.Fprintln(w, sys.String())
+		}
+		_, _ = fmt.Fprintln(w, bottom.String())
+		_, _ = fmt.Fprintln(w, ganttRuler(line))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// ganttRuler labels each cell boundary of line with its time, dropping labels that would collide with the one
+// before them.
+func ganttRuler(line []ganttCell) string {
+	var (
+		ruler []byte
+		col   int
+	)
+	mark := func(col int, t int64) {
+		label := fmt.Sprint(t)
+		if len(ruler) > 0 && col <= len(ruler) {
+			return
+		}
+		ruler = append(ruler, strings.Repeat(" ", col-len(ruler))...)
+		ruler = append(ruler, label...)
+	}
+	for _, c := range line {
+		mark(col, c.Start)
+		col += c.Width + 1
+	}
+	mark(col, line[len(line)-1].Stop)
+
+	return string(ruler)
+}
+
+// pidColors are the ANSI SGR parameters cycled through by PID: black text on a colored background, so labels stay
+// readable whatever the terminal's own colors are.
+var pidColors = []string{
+	"30;41", "30;42", "30;43", "30;44", "30;45", "30;46",
+	"30;101", "30;102", "30;103", "30;104", "30;105", "30;106",
+}
+
+// colorize wraps s in the color assigned to pid. The color depends only on the PID so a process looks the same in
+// every chart of a run.
+func colorize(pid int64, s string) string {
+	i := pid % int64(len(pidColors))
+	if i < 0 {
+		i += int64(len(pidColors))
+	}
+
+	return "\x1b[" + pidColors[i] + "m" + s + "\x1b[0m"
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+func Test_ganttCells(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []sched.TimeSlice
+		want  []ganttCell
+	}{
+		{
+			name: "idle between slices",
+			gantt: []sched.TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 12, Start: 5, Stop: 6},
+				{PID: 3, Start: 6, Stop: 100},
+			},
+			want: []ganttCell{
+				{PID: 1, Label: "1", Start: 0, Stop: 3, Width: 6},
+				{Start: 3, Stop: 5, Width: 4, Idle: true},
+				{PID: 12, Label: "12", Start: 5, Stop: 6, Width: 4},
+				{PID: 3, Label: "3", Start: 6, Stop: 100, Width: ganttLineWidth - 2},
+			},
+		},
+		{
+			name:  "first arrival after 0",
+			gantt: []sched.TimeSlice{{PID: 1, Start: 2, Stop: 5}},
+			want: []ganttCell{
+				{Start: 0, Stop: 2, Width: 4, Idle: true},
+				{PID: 1, Label: "1", Start: 2, Stop: 5, Width: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ganttCells(tt.gantt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ganttCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteGantt(t *testing.T) {
+	t.Parallel()
+	gantt := make([]sched.TimeSlice, 0)
+	for i := int64(0); i < 30; i++ {
+		gantt = append(gantt, sched.TimeSlice{PID: i%3 + 1, Start: i * 2, Stop: i*2 + 2})
+	}
+	var w bytes.Buffer
+	WriteGantt(&w, gantt, false)
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	// title plus two wrapped rows of box top, labels, box bottom, and ruler
+	if len(lines) != 9 {
+		t.Fatalf("outputGantt() wrote %d lines, want 9:\n%s", len(lines), w.String())
+	}
+	for _, l := range lines {
+		if n := len([]rune(l)); n > ganttLineWidth {
+			t.Errorf("line is %d columns, want at most %d: %q", n, ganttLineWidth, l)
+		}
+	}
+	if !strings.HasPrefix(lines[8], "30") || !strings.HasSuffix(lines[8], "60") {
+		t.Errorf("second ruler = %q, want it to run from 30 to 60", lines[8])
+	}
+}
+
+func TestWriteGantt_kernel(t *testing.T) {
+	t.Parallel()
+	gantt := []sched.TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 1, Start: 2, Stop: 3, Kernel: true},
+		{PID: 2, Start: 4, Stop: 6},
+	}
+	var w bytes.Buffer
+	WriteGantt(&w, gantt, false)
+	want := `Gantt schedule (top track user time, bottom track ▒ kernel time)
+┌────┬──┬──┬────┐
+│ 1  │  │░░│ 2  │
+├────┼──┼──┼────┤
+│    │▒▒│░░│    │
+└────┴──┴──┴────┘
+0    2  3  4    6
+`
+	if got := strings.TrimSuffix(w.String(), "\n"); got != want {
+		t.Errorf("WriteGantt() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_colorize(t *testing.T) {
+	t.Parallel()
+	if got, want := colorize(1, " 1 "), "\x1b[30;42m 1 \x1b[0m"; got != want {
+		t.Errorf("colorize() = %q, want %q", got, want)
+	}
+	if colorize(2, "x") != colorize(2+int64(len(pidColors)), "x") {
+		t.Error("colorize() should cycle through the palette by PID")
+	}
+}
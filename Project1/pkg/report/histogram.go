@@ -0,0 +1,169 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// HistogramBin counts the processes whose waiting time falls in [Low, High].
+type HistogramBin struct {
+	Low   int64
+	High  int64
+	Count int
+}
+
+const (
+	MaxHistogramBins  = 10
+	histogramBarWidth = 40
+)
+
+// WaitHistogram buckets the waiting times of done into at most maxBins equal-width bins starting at 0.
+func WaitHistogram(done []workload.Process, maxBins int) []HistogramBin {
+	if len(done) == 0 || maxBins < 1 {
+		return nil
+	}
+	var maxWait int64
+	for i := range done {
+		if done[i].Wait > maxWait {
+			maxWait = done[i].Wait
+		}
+	}
+	// ceil((maxWait+1)/maxBins) so every wait in [0, maxWait] lands in a bin
+	width := (maxWait + int64(maxBins)) / int64(maxBins)
+	bins := make([]HistogramBin, maxWait/width+1)
+	for i := range bins {
+		bins[i].Low = int64(i) * width
+		bins[i].High = bins[i].Low + width - 1
+	}
+	for i := range done {
+		if done[i].Wait < 0 {
+			continue
+		}
+		bins[done[i].Wait/width].Count++
+	}
+
+	return bins
+}
+
+func histogramLabel(b HistogramBin) string {
+	if b.Low == b.High {
+		return fmt.Sprint(b.Low)
+	}
+
+	return fmt.Sprintf("%d-%d", b.Low, b.High)
+}
+
+func histogramMaxCount(bins []HistogramBin) int {
+	var most int
+	for i := range bins {
+		if bins[i].Count > most {
+			most = bins[i].Count
+		}
+	}
+
+	return most
+}
+
+// WriteHistogram writes bins as horizontal bars scaled to histogramBarWidth.
+func WriteHistogram(w io.Writer, bins []HistogramBin) {
+	_, _ = fmt.Fprintln(w, "Waiting-time histogram")
+	var labelWidth int
+	for i := range bins {
+		if l := len(histogramLabel(bins[i])); l > labelWidth {
+			labelWidth = l
+		}
+	}
+	most := histogramMaxCount(bins)
+	for i := range bins {
+		bar := 0
+		if most > 0 {
+			bar = bins[i].Count * histogramBarWidth / most
+		}
+		if bar == 0 && bins[i].Count > 0 {
+			bar = 1
+		}
+		bars := strings.Repeat("#", bar)
+		if bars != "" {
+			bars += " "
+		}
+		_, _ = fmt.Fprintf(w, "%*s | %s%d\n", labelWidth, histogramLabel(bins[i]), bars, bins[i].Count)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// WriteHistogramSVG renders bins as a vertical bar chart.
+func WriteHistogramSVG(w io.Writer, title string, bins []HistogramBin) error {
+	const (
+		barWidth = 40
+		gap      = 10
+		height   = 200
+		margin   = 40
+	)
+	width := margin*2 + len(bins)*(barWidth+gap)
+	most := histogramMaxCount(bins)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height+margin*2)
+	fmt.Fprintf(&b, `<text x="%d" y="20" text-anchor="middle">%s waiting times</text>`+"\n", width/2, svgEscape(title))
+	for i := range bins {
+		barHeight := 0
+		if most > 0 {
+			barHeight = bins[i].Count * height / most
+		}
+		x := margin + i*(barWidth+gap)
+		y := margin + height - barHeight
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="steelblue"/>`+"\n", x, y, barWidth, barHeight)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%d</text>`+"\n", x+barWidth/2, y-4, bins[i].Count)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%s</text>`+"\n",
+			x+barWidth/2, margin+height+16, histogramLabel(bins[i]))
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// SaveHistogramSVG writes the histogram for title into dir, named after the title.
+func SaveHistogramSVG(dir, title string, bins []HistogramBin) error {
+	f, err := os.Create(filepath.Join(dir, Slug(title)+"-wait-histogram.svg"))
+	if err != nil {
+		return fmt.Errorf("%w: creating histogram SVG", err)
+	}
+	if err := WriteHistogramSVG(f, title, bins); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing histogram SVG", err)
+	}
+
+	return f.Close()
+}
+
+var svgReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func svgEscape(s string) string {
+	return svgReplacer.Replace(s)
+}
+
+// Slug lowercases s and collapses every run of non-alphanumeric characters into a single dash.
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
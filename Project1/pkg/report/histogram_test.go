@@ -0,0 +1,65 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestWaitHistogram(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		waits []int64
+		want  []HistogramBin
+	}{
+		{
+			name:  "unit bins",
+			waits: []int64{0, 2, 2},
+			want: []HistogramBin{
+				{Low: 0, High: 0, Count: 1},
+				{Low: 1, High: 1},
+				{Low: 2, High: 2, Count: 2},
+			},
+		},
+		{
+			name:  "wide bins",
+			waits: []int64{0, 9, 14, 25},
+			want: []HistogramBin{
+				{Low: 0, High: 2, Count: 1},
+				{Low: 3, High: 5},
+				{Low: 6, High: 8},
+				{Low: 9, High: 11, Count: 1},
+				{Low: 12, High: 14, Count: 1},
+				{Low: 15, High: 17},
+				{Low: 18, High: 20},
+				{Low: 21, High: 23},
+				{Low: 24, High: 26, Count: 1},
+			},
+		},
+		{
+			name: "no processes",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			done := make([]workload.Process, len(tt.waits))
+			for i := range tt.waits {
+				done[i].Wait = tt.waits[i]
+			}
+			if got := WaitHistogram(done, MaxHistogramBins); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("waitHistogram() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	t.Parallel()
+	if got, want := Slug("First-come, first-serve"), "first-come-first-serve"; got != want {
+		t.Errorf("slug() = %q, want %q", got, want)
+	}
+}
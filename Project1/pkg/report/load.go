@@ -0,0 +1,125 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// LoadPeriods are the time units the load averages decay over, after the 1, 5, and 15 minutes of Unix's.
+var LoadPeriods = [3]int64{1, 5, 15}
+
+// LoadAverage is the load averages at Time, at the end of a time unit, over each of LoadPeriods.
+type LoadAverage struct {
+	Time     int64
+	Averages [3]float64
+}
+
+// Load is how congested a run was: the length of the ready queue in every time unit from 0 on, and the load
+// averages after each. A process is in the ready queue from its arrival to its completion whenever it isn't running,
+// whether in its own code or in the kernel, so a process blocked outside the scheduler counts as ready too. The load
+// averages are Unix's: exponentially decaying averages of the runnable processes, those ready and the one running,
+// sampled every time unit.
+type Load struct {
+	Queue    []int
+	Averages []LoadAverage
+}
+
+// NewLoad samples the ready queue and load averages of the run of done scheduled as gantt.
+func NewLoad(gantt []sched.TimeSlice, done []workload.Process) Load {
+	var end int64
+	for _, s := range gantt {
+		end = max(end, s.Stop)
+	}
+	for _, p := range done {
+		end = max(end, p.Completion)
+	}
+	present := make([]int, end+1) // the change in processes present at each time
+	for _, p := range done {
+		if p.ArrivalTime < p.Completion {
+			present[p.ArrivalTime]++
+			present[p.Completion]--
+		}
+	}
+	running := make([]bool, end)
+	for _, s := range gantt {
+		if s.Switch {
+			continue
+		}
+		for t := s.Start; t < s.Stop; t++ {
+			running[t] = true
+		}
+	}
+
+	var decay [3]float64
+	for i, n := range LoadPeriods {
+		decay[i] = math.Exp(-1 / float64(n))
+	}
+	l := Load{Queue: make([]int, end), Averages: make([]LoadAverage, end)}
+	var (
+		count    int
+		averages [3]float64
+	)
+	for t := int64(0); t < end; t++ {
+		count += present[t]
+		l.Queue[t] = count
+		if running[t] {
+			l.Queue[t] = max(count-1, 0)
+		}
+		for i := range averages {
+			averages[i] = averages[i]*decay[i] + float64(count)*(1-decay[i])
+		}
+		l.Averages[t] = LoadAverage{Time: t + 1, Averages: averages}
+	}
+
+	return l
+}
+
+// AverageQueue returns the mean length of the ready queue over the run, or 0 for an empty run.
+func (l Load) AverageQueue() float64 {
+	if len(l.Queue) == 0 {
+		return 0
+	}
+	total := 0
+	for _, n := range l.Queue {
+		total += n
+	}
+
+	return float64(total) / float64(len(l.Queue))
+}
+
+// LongestQueue returns the most processes ever in the ready queue and the first time unit it held them.
+func (l Load) LongestQueue() (int, int64) {
+	longest, at := 0, int64(0)
+	for t, n := range l.Queue {
+		if n > longest {
+			longest, at = n, int64(t)
+		}
+	}
+
+	return longest, at
+}
+
+// loadRows is about how many rows WriteLoad samples the load averages at.
+const loadRows = 20
+
+// WriteLoad writes the average and longest ready queue of l and its load averages as a table of data points, at
+// most about loadRows of them evenly spaced, and always the last.
+func WriteLoad(w io.Writer, l Load) {
+	longest, at := l.LongestQueue()
+	_, _ = fmt.Fprintf(w, "Ready queue: %.2f processes on average, at most %d (first at %d)\n", l.AverageQueue(),
+		longest, at)
+	_, _ = fmt.Fprintf(w, "Load average over time (runnable processes over the last %d, %d, and %d time units)\n",
+		LoadPeriods[0], LoadPeriods[1], LoadPeriods[2])
+	_, _ = fmt.Fprintf(w, "%6s %7s %7s %7s\n", "time", "1", "5", "15")
+	stride := max((len(l.Averages)+loadRows-1)/loadRows, 1)
+	for i, a := range l.Averages {
+		if (i+1)%stride == 0 || i == len(l.Averages)-1 {
+			_, _ = fmt.Fprintf(w, "%6d %7.2f %7.2f %7.2f\n", a.Time, a.Averages[0], a.Averages[1], a.Averages[2])
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestNewLoad(t *testing.T) {
+	t.Parallel()
+	// P2 waits through the switch to it, and the CPU idles from 4 until P3 arrives at 5
+	gantt := []sched.TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 3, Switch: true},
+		{PID: 2, Start: 3, Stop: 4},
+		{PID: 3, Start: 5, Stop: 6},
+	}
+	done := []workload.Process{
+		{ProcessID: 1, Completion: 2},
+		{ProcessID: 2, ArrivalTime: 1, Completion: 4},
+		{ProcessID: 3, ArrivalTime: 5, Completion: 6},
+	}
+	l := NewLoad(gantt, done)
+	if want := []int{0, 1, 1, 0, 0, 0}; !reflect.DeepEqual(l.Queue, want) {
+		t.Errorf("Queue = %v, want %v", l.Queue, want)
+	}
+	if got := l.AverageQueue(); got != 2.0/6 {
+		t.Errorf("AverageQueue() = %v, want %v", got, 2.0/6)
+	}
+	if longest, at := l.LongestQueue(); longest != 1 || at != 1 {
+		t.Errorf("LongestQueue() = %d at %d, want 1 at 1", longest, at)
+	}
+	// one runnable process in the first time unit moves the 1-unit average 1-1/e of the way from 0 to 1
+	if got, want := l.Averages[0].Averages[0], 1-math.Exp(-1); math.Abs(got-want) > 1e-9 || l.Averages[0].Time != 1 {
+		t.Errorf("Averages[0] = %+v, want a 1-unit load of %v at 1", l.Averages[0], want)
+	}
+	// nothing runnable from 4 to 5 pulls every average down
+	if a, b := l.Averages[3].Averages, l.Averages[4].Averages; b[0] >= a[0] || b[1] >= a[1] || b[2] >= a[2] {
+		t.Errorf("load averages rose from %v to %v while idle", a, b)
+	}
+
+	var w bytes.Buffer
+	WriteLoad(&w, l)
+	if want := "Ready queue: 0.33 processes on average, at most 1 (first at 1)"; !strings.Contains(w.String(), want) {
+		t.Errorf("WriteLoad() missing %q:\n%s", want, w.String())
+	}
+}
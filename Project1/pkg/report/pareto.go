@@ -0,0 +1,47 @@
+package report
+
+// Dominates reports whether a is at least as low as b in every dimension and lower in at least one, so no one who
+// wants all of them low would pick b over a.
+func Dominates(a, b []float64) bool {
+	lower := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			lower = true
+		}
+	}
+
+	return lower
+}
+
+// ParetoFront returns, for each of points, the index of a point that dominates it, or -1 for the points on the
+// Pareto front: those no other point beats in one dimension without losing in another. Lower is better in every
+// dimension. The dominating point named is the first in order that is itself on the front.
+func ParetoFront(points [][]float64) []int {
+	dominated := make([]int, len(points))
+	for i := range points {
+		dominated[i] = -1
+		for j := range points {
+			if Dominates(points[j], points[i]) {
+				dominated[i] = j
+				break
+			}
+		}
+	}
+	// domination is transitive, so every dominated point is dominated by one on the front
+	for i, j := range dominated {
+		if j < 0 {
+			continue
+		}
+		for k := range points {
+			if dominated[k] < 0 && Dominates(points[k], points[i]) {
+				dominated[i] = k
+				break
+			}
+		}
+	}
+
+	return dominated
+}
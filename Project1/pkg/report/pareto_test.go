@@ -0,0 +1,39 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParetoFront(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		points [][]float64
+		want   []int
+	}{
+		{name: "none", want: []int{}},
+		{name: "one", points: [][]float64{{3, 4}}, want: []int{-1}},
+		{
+			name:   "trade-off",
+			points: [][]float64{{1, 9}, {5, 5}, {9, 1}},
+			want:   []int{-1, -1, -1},
+		},
+		{
+			name: "dominated",
+			// {6,6} is dominated by {5,5} and {4,5}; the one named must be on the front
+			points: [][]float64{{6, 6}, {5, 5}, {1, 9}, {4, 5}, {4, 5}},
+			want:   []int{3, 3, -1, -1, -1},
+		},
+		{name: "equal points are both on the front", points: [][]float64{{2, 2}, {2, 2}}, want: []int{-1, -1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ParetoFront(tt.points); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParetoFront() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
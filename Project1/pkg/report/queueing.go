@@ -0,0 +1,183 @@
+package report
+
+import (
+	"math"
+	"sort"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// queueModel tells the queueing-theory models apart.
+type queueModel int
+
+const (
+	mm1 queueModel = iota
+	mg1
+	processorSharing
+	srpt
+)
+
+// QueueModel is a queueing-theory model of a single CPU along with the name it is selected by, the algorithm whose
+// runs it predicts, and a one-line description for listings.
+type QueueModel struct {
+	Name        string
+	Algorithm   string
+	Description string
+	kind        queueModel
+}
+
+// QueueModels lists every queueing-theory model, those of the same algorithm together.
+var QueueModels = []QueueModel{
+	{Name: "M/M/1", Algorithm: "fcfs",
+		Description: "Poisson arrivals and exponential service, served in arrival order", kind: mm1},
+	{Name: "M/G/1", Algorithm: "fcfs",
+		Description: "Poisson arrivals and any service distribution, served in arrival order (Pollaczek–Khinchine)",
+		kind:        mg1},
+	{Name: "M/G/1-PS", Algorithm: "rr",
+		Description: "processor sharing, round-robin as its quantum shrinks to nothing", kind: processorSharing},
+	{Name: "M/G/1-SRPT", Algorithm: "sjf",
+		Description: "shortest remaining processing time first, preempting on every arrival", kind: srpt},
+}
+
+// Queue is a workload seen as a single-server queue: its arrival rate, the mean and second moment of its bursts,
+// the service times, and the coefficients of variation of the times between arrivals and of the bursts, both near 1
+// for the Poisson arrivals and exponential service of M/M/1.
+type Queue struct {
+	Jobs           int
+	ArrivalRate    float64
+	MeanService    float64
+	SecondMoment   float64
+	InterarrivalCV float64
+	ServiceCV      float64
+	// services holds the distinct bursts in increasing order, and shares the fraction of jobs with each.
+	services []int64
+	shares   []float64
+}
+
+// NewQueue measures processes, in arrival order, as a queue. The arrival rate is the arrivals after the first over
+// the time they span, or 0 if they all arrive at once.
+func NewQueue(processes []workload.Process) Queue {
+	q := Queue{Jobs: len(processes)}
+	if q.Jobs == 0 {
+		return q
+	}
+	counts := make(map[int64]int)
+	var bursts []float64
+	for _, p := range processes {
+		counts[p.BurstDuration]++
+		bursts = append(bursts, float64(p.BurstDuration))
+	}
+	for s := range counts {
+		q.services = append(q.services, s)
+	}
+	sort.Slice(q.services, func(i, j int) bool { return q.services[i] < q.services[j] })
+	for _, s := range q.services {
+		share := float64(counts[s]) / float64(q.Jobs)
+		q.shares = append(q.shares, share)
+		q.MeanService += share * float64(s)
+		q.SecondMoment += share * float64(s*s)
+	}
+	q.ServiceCV = coefficientOfVariation(bursts)
+
+	if span := processes[q.Jobs-1].ArrivalTime - processes[0].ArrivalTime; span > 0 {
+		q.ArrivalRate = float64(q.Jobs-1) / float64(span)
+		gaps := make([]float64, q.Jobs-1)
+		for i := range gaps {
+			gaps[i] = float64(processes[i+1].ArrivalTime - processes[i].ArrivalTime)
+		}
+		q.InterarrivalCV = coefficientOfVariation(gaps)
+	}
+
+	return q
+}
+
+// coefficientOfVariation returns the standard deviation of values over their mean, or 0 for a mean of 0.
+func coefficientOfVariation(values []float64) float64 {
+	var sum, squares float64
+	for _, v := range values {
+		sum += v
+		squares += v * v
+	}
+	n := float64(len(values))
+	if sum == 0 {
+		return 0
+	}
+	mean := sum / n
+
+	return math.Sqrt(max(0, squares/n-mean*mean)) / mean
+}
+
+// Load returns the utilization ρ = λE[S] the arrivals offer the CPU.
+func (q Queue) Load() float64 {
+	return q.ArrivalRate * q.MeanService
+}
+
+// Stable reports whether the queue settles, needing a load below 1; otherwise it grows without bound and no model
+// predicts a finite response time.
+func (q Queue) Stable() bool {
+	return q.ArrivalRate > 0 && q.Load() < 1
+}
+
+// Response returns the mean response time, arrival to completion, model predicts for q, or +Inf if q is not stable.
+func (q Queue) Response(model QueueModel) float64 {
+	if !q.Stable() {
+		return math.Inf(1)
+	}
+	rho := q.Load()
+	switch model.kind {
+	case mm1:
+		return q.MeanService / (1 - rho)
+	case mg1:
+		return q.MeanService + q.ArrivalRate*q.SecondMoment/(2*(1-rho))
+	case processorSharing:
+		return q.MeanService / (1 - rho)
+	}
+
+	var mean float64
+	for i, x := range q.services {
+		mean += q.shares[i] * q.srptResponse(x)
+	}
+	return mean
+}
+
+// Wait returns the mean time model predicts a job spends in the queue rather than running: its response less its
+// service.
+func (q Queue) Wait(model QueueModel) float64 {
+	return q.Response(model) - q.MeanService
+}
+
+// srptResponse returns the mean response time under SRPT of a job of service x (Schrage and Miller): first the work
+// it finds of the jobs of service up to x, counting those longer only up to x, cleared while the jobs shorter than x
+// that arrive meanwhile go ahead of it, and then its own service, each unit of which, from k-1 left to go up to k, is
+// slowed by the jobs shorter than k that arrive meanwhile. Jobs of the same service are served in arrival order.
+func (q Queue) srptResponse(x int64) float64 {
+	var (
+		rho, below float64 // load of the jobs of service up to x, and under x
+		moment     float64 // second moment of service of the jobs up to x, counting longer ones as x
+	)
+	for i, s := range q.services {
+		load := q.ArrivalRate * q.shares[i] * float64(s)
+		switch {
+		case s < x:
+			below += load
+			fallthrough
+		case s == x:
+			rho += load
+			moment += q.shares[i] * float64(s*s)
+		default:
+			moment += q.shares[i] * float64(x*x)
+		}
+	}
+	response := q.ArrivalRate * moment / (2 * (1 - rho) * (1 - below))
+
+	var shorter float64 // load of the jobs shorter than k
+	j := 0
+	for k := int64(1); k <= x; k++ {
+		for ; j < len(q.services) && q.services[j] < k; j++ {
+			shorter += q.ArrivalRate * q.shares[j] * float64(q.services[j])
+		}
+		response += 1 / (1 - shorter)
+	}
+
+	return response
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"math"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestNewQueue(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 4, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 8, BurstDuration: 5},
+	}
+	q := NewQueue(processes)
+	// 2 arrivals over 8 units, bursts averaging 3 with a second moment of 11 and a standard deviation of √2
+	if q.Jobs != 3 || q.ArrivalRate != 0.25 || q.MeanService != 3 || math.Abs(q.SecondMoment-11) > 1e-9 ||
+		q.InterarrivalCV != 0 || math.Abs(q.ServiceCV-math.Sqrt2/3) > 1e-9 {
+		t.Errorf("NewQueue() = %+v", q)
+	}
+	if q.Load() != 0.75 || !q.Stable() {
+		t.Errorf("Load() = %g, Stable() = %v, want 0.75 and true", q.Load(), q.Stable())
+	}
+}
+
+func TestQueue_Response(t *testing.T) {
+	t.Parallel()
+	// arrivals every 5 units of jobs of 2 each, a load of 0.4
+	var processes []workload.Process
+	for i := 0; i < 11; i++ {
+		processes = append(processes, workload.Process{ProcessID: int64(i + 1), ArrivalTime: int64(5 * i),
+			BurstDuration: 2})
+	}
+	q := NewQueue(processes)
+	tests := []struct {
+		model QueueModel
+		want  float64
+	}{
+		{model: QueueModels[0], want: 2 / 0.6},
+		// M/D/1: 2 + 0.2*4/(2*0.6)
+		{model: QueueModels[1], want: 2 + 0.8/1.2},
+		{model: QueueModels[2], want: 2 / 0.6},
+		// with every job alike, SRPT serves them in arrival order as M/D/1 does
+		{model: QueueModels[3], want: 2 + 0.8/1.2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.model.Name, func(t *testing.T) {
+			t.Parallel()
+			if got := q.Response(tt.model); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Response() = %g, want %g", got, tt.want)
+			}
+			if got := q.Wait(tt.model); math.Abs(got-(tt.want-2)) > 1e-9 {
+				t.Errorf("Wait() = %g, want %g", got, tt.want-2)
+			}
+		})
+	}
+
+	// SRPT lets a short job past a long one: jobs of 1 and 3 alike, at 0.2 per unit
+	mixed := Queue{ArrivalRate: 0.2, MeanService: 2, SecondMoment: 5, services: []int64{1, 3}, shares: []float64{0.5, 0.5}}
+	// the job of 1 waits out 0.2*(0.5+0.5)/(2*0.9*1) and runs 1; the job of 3 waits out 0.2*5/(2*0.6*0.9) and runs
+	// 1 + 1/0.9 + 1/0.9
+	short := 1 + 0.2/1.8
+	long := 1/1.08 + 1 + 2/0.9
+	if got := mixed.Response(QueueModels[3]); math.Abs(got-(short+long)/2) > 1e-9 {
+		t.Errorf("Response() under SRPT = %g, want %g", got, (short+long)/2)
+	}
+	if mixed.Response(QueueModels[3]) >= mixed.Response(QueueModels[1]) {
+		t.Errorf("Response() under SRPT is no shorter than in arrival order")
+	}
+
+	if got := (Queue{ArrivalRate: 0.5, MeanService: 2}).Response(QueueModels[0]); !math.IsInf(got, 1) {
+		t.Errorf("Response() at load 1 = %g, want +Inf", got)
+	}
+}
@@ -0,0 +1,148 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+// SchemaVersion is the version of AlgorithmRecord and ProcessRecord. It goes up only when a field is renamed,
+// removed, or changes meaning; new fields are added without a new version, so consumers should ignore fields and
+// columns they don't know.
+const SchemaVersion = 1
+
+// AlgorithmRecord is the run-wide metrics of one report as a flat record for machine-readable output.
+type AlgorithmRecord struct {
+	SchemaVersion int     `json:"schema_version" csv:"schema_version"`
+	Name          string  `json:"name" csv:"name"`
+	AvgWait       float64 `json:"avg_wait" csv:"avg_wait"`
+	AvgTurnaround float64 `json:"avg_turnaround" csv:"avg_turnaround"`
+	AvgNormalized float64 `json:"avg_normalized_turnaround" csv:"avg_normalized_turnaround"`
+	AvgResponse   float64 `json:"avg_response" csv:"avg_response"`
+	Throughput    float64 `json:"throughput" csv:"throughput"`
+	Utilization   float64 `json:"utilization" csv:"utilization"`
+	FairnessShare float64 `json:"fairness_cpu_share" csv:"fairness_cpu_share"`
+	FairnessWait  float64 `json:"fairness_wait" csv:"fairness_wait"`
+	Busy          int64   `json:"busy" csv:"busy"`
+	Idle          int64   `json:"idle" csv:"idle"`
+	Overhead      int64   `json:"overhead" csv:"overhead"`
+	Switches      int     `json:"switches" csv:"switches"`
+	Energy        float64 `json:"energy" csv:"energy"`
+	Finished      int     `json:"finished" csv:"finished"`
+	Stopped       string  `json:"stopped,omitempty" csv:"stopped"`
+	// DeadlineMisses and MaxTardiness count only processes with deadlines, and are 0 when none has one.
+	DeadlineMisses int   `json:"deadline_misses,omitempty" csv:"deadline_misses"`
+	MaxTardiness   int64 `json:"max_tardiness,omitempty" csv:"max_tardiness"`
+}
+
+// ProcessRecord is the metrics of one finished process of a report as a flat record for machine-readable output.
+type ProcessRecord struct {
+	SchemaVersion int     `json:"schema_version" csv:"schema_version"`
+	Algorithm     string  `json:"algorithm" csv:"algorithm"`
+	PID           int64   `json:"pid" csv:"pid"`
+	Arrival       int64   `json:"arrival" csv:"arrival"`
+	Burst         int64   `json:"burst" csv:"burst"`
+	Priority      int64   `json:"priority" csv:"priority"`
+	Wait          int64   `json:"wait" csv:"wait"`
+	Turnaround    int64   `json:"turnaround" csv:"turnaround"`
+	Completion    int64   `json:"completion" csv:"completion"`
+	Normalized    float64 `json:"normalized_turnaround" csv:"normalized_turnaround"`
+	// Deadline is 0 for a process without one, and Tardiness how long after it the process completed.
+	Deadline  int64 `json:"deadline,omitempty" csv:"deadline"`
+	Tardiness int64 `json:"tardiness,omitempty" csv:"tardiness"`
+}
+
+// Record returns the run-wide metrics of r.
+func (r Report) Record() AlgorithmRecord {
+	record := AlgorithmRecord{
+		SchemaVersion: SchemaVersion,
+		Name:          r.Title,
+		AvgWait:       r.Summary.Wait,
+		AvgTurnaround: r.Summary.Turnaround,
+		AvgNormalized: r.Summary.Normalized,
+		AvgResponse:   r.AvgResponse,
+		Throughput:    r.Summary.Throughput,
+		Utilization:   r.Utilization(),
+		FairnessShare: r.Fairness.Share,
+		FairnessWait:  r.Fairness.Wait,
+		Busy:          r.Busy,
+		Idle:          r.Idle,
+		Overhead:      r.Overhead,
+		Switches:      r.Switches,
+		Energy:        r.Energy(),
+		Finished:      len(r.Processes),
+		Stopped:       r.Stopped,
+	}
+	if r.Deadlines != nil {
+		record.DeadlineMisses = r.Deadlines.Misses
+		record.MaxTardiness = r.Deadlines.MaxTardiness
+	}
+
+	return record
+}
+
+// ProcessRecords returns the metrics of every finished process of r, in the order of r.Processes.
+func (r Report) ProcessRecords() []ProcessRecord {
+	records := make([]ProcessRecord, len(r.Processes))
+	for i, p := range r.Processes {
+		records[i] = ProcessRecord{
+			SchemaVersion: SchemaVersion,
+			Algorithm:     r.Title,
+			PID:           p.ProcessID,
+			Arrival:       p.ArrivalTime,
+			Burst:         p.Burst,
+			Priority:      p.Priority,
+			Wait:          p.Wait,
+			Turnaround:    p.Turnaround,
+			Completion:    p.Completion,
+			Normalized:    sched.NormalizedTurnaround(p),
+			Deadline:      p.Deadline,
+			Tardiness:     Tardiness(p),
+		}
+	}
+
+	return records
+}
+
+// WriteCSV writes records as CSV under a header row of their csv tags, in field order.
+func WriteCSV[T AlgorithmRecord | ProcessRecord | AccountingRecord | BurstTurnaround](w io.Writer, records []T) error {
+	var zero T
+	row := csvHeader(reflect.TypeOf(zero))
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(row)
+	for _, record := range records {
+		v := reflect.ValueOf(record)
+		for i := range row {
+			switch f := v.Field(i); f.Kind() {
+			case reflect.String:
+				row[i] = f.String()
+			case reflect.Float64:
+				row[i] = strconv.FormatFloat(f.Float(), 'f', -1, 64)
+			default:
+				row[i] = strconv.FormatInt(f.Int(), 10)
+			}
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("%w: writing CSV records", err)
+	}
+
+	return nil
+}
+
+// csvHeader returns the csv tags of the fields of the record type typ, in field order.
+func csvHeader(typ reflect.Type) []string {
+	header := make([]string, typ.NumField())
+	for i := range header {
+		header[i] = typ.Field(i).Tag.Get("csv")
+	}
+
+	return header
+}
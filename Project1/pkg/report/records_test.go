@@ -0,0 +1,54 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestReport_ProcessRecords(t *testing.T) {
+	t.Parallel()
+	r := New("FCFS", sched.NewResult([]sched.TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 6}}, []workload.Process{
+		{ProcessID: 1, Burst: 2, Priority: 1, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, ArrivalTime: 1, Burst: 4, Wait: 1, Turnaround: 5, Completion: 6},
+	}))
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, r.ProcessRecords()); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"schema_version", "algorithm", "pid", "arrival", "burst", "priority", "wait", "turnaround", "completion",
+			"normalized_turnaround", "deadline", "tardiness"},
+		{"1", "FCFS", "1", "0", "2", "1", "0", "2", "2", "1", "0", "0"},
+		{"1", "FCFS", "2", "1", "4", "0", "1", "5", "6", "1.25", "0", "0"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("WriteCSV() = %v, want %v", rows, want)
+	}
+
+	b, err := json.Marshal(r.Record())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["schema_version"] != float64(SchemaVersion) || decoded["name"] != "FCFS" || decoded["busy"] != float64(6) ||
+		decoded["finished"] != float64(2) {
+		t.Errorf("Record() encodes as %s", b)
+	}
+	if _, ok := decoded["stopped"]; ok {
+		t.Errorf("Record() of a complete run has a stopped field: %s", b)
+	}
+}
@@ -0,0 +1,139 @@
+// Package report turns a finished scheduling run into metrics (averages, utilization, fairness, waiting-time
+// histogram, throughput curve, timeline) and renders them as text and SVG charts.
+package report
+
+import (
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// Report is everything known about one finished scheduling run. It is the data handed to -template files, so its
+// exported fields and methods are part of the template interface.
+type Report struct {
+	Title     string
+	Gantt     []sched.TimeSlice
+	Processes []workload.Process
+	Summary   sched.Metrics
+	// AvgResponse is the mean time from arrival to first running, over the processes in the Gantt chart.
+	AvgResponse float64
+	Busy        int64
+	Idle        int64
+	Overhead    int64
+	Switches    int
+	Fairness    FairnessIndex
+	Classes     []PriorityClass
+	Histogram   []HistogramBin
+	Throughput  []ThroughputPoint
+	// Stopped says why the run ended before every process finished, such as a -timeout; it is empty for complete
+	// runs, whose Processes are the whole workload.
+	Stopped string `json:",omitempty"`
+	// Seed is the -seed the run drew its random numbers from, so a run with lottery draws or perturbed bursts can be
+	// reproduced. It is 0 for a run that drew none.
+	Seed int64 `json:",omitempty"`
+	// Deadlines sums up the deadlines met and missed; it is nil when no process has a deadline.
+	Deadlines *DeadlineSummary `json:",omitempty"`
+	// System is the part of Busy the kernel spent working on behalf of processes rather than running their code.
+	System int64 `json:",omitempty"`
+}
+
+// FairnessIndex holds Jain's index over the per-process CPU shares and waiting times.
+type FairnessIndex struct {
+	Share float64
+	Wait  float64
+}
+
+// Utilization is the fraction of the run the CPU spent executing processes.
+func (r Report) Utilization() float64 {
+	return CPUUtilization(r.Busy, r.Idle, r.Overhead)
+}
+
+// IdlePower is the power an idle CPU draws, as a fraction of the power it draws running a process or switching
+// between processes.
+const IdlePower = 0.1
+
+// Energy is the energy the CPU spent on the run, in time units at full power: busy and overhead time count fully
+// and idle time at IdlePower. It goes down with fewer context switches when they cost time.
+func (r Report) Energy() float64 {
+	return float64(r.Busy+r.Overhead) + IdlePower*float64(r.Idle)
+}
+
+// New gathers the metrics for the finished run r. Each process in r.PerProcess must have its Burst, Wait,
+// Turnaround, and Completion set.
+func New(title string, r sched.Result) Report {
+	var (
+		lastCompletion int64
+		busy           int64
+		overhead       int64
+		system         int64
+	)
+	for _, s := range r.Slices {
+		switch {
+		case s.Switch:
+			overhead += s.Stop - s.Start
+		case s.Kernel:
+			system += s.Stop - s.Start
+		}
+	}
+	busy = system
+	for _, p := range r.PerProcess {
+		busy += p.Burst
+		if p.Completion > lastCompletion {
+			lastCompletion = p.Completion
+		}
+	}
+	share, wait := Fairness(r.PerProcess)
+
+	return Report{
+		Title:       title,
+		Gantt:       r.Slices,
+		Processes:   r.PerProcess,
+		Summary:     r.Summary,
+		AvgResponse: AvgResponse(r.Slices, r.PerProcess),
+		Busy:        busy,
+		Idle:        lastCompletion - busy - overhead,
+		Overhead:    overhead,
+		Switches:    sched.CountContextSwitches(r.Slices),
+		Fairness:    FairnessIndex{Share: share, Wait: wait},
+		Classes:     GroupByPriority(r.PerProcess),
+		Histogram:   WaitHistogram(r.PerProcess, MaxHistogramBins),
+		Throughput:  ThroughputCurve(r.PerProcess),
+		Deadlines:   Deadlines(r.PerProcess),
+		System:      system,
+	}
+}
+
+// CPUUtilization is busy/(busy+idle+overhead), or 0 for an empty run.
+func CPUUtilization(busy, idle, overhead int64) float64 {
+	total := busy + idle + overhead
+	if total == 0 {
+		return 0
+	}
+
+	return float64(busy) / float64(total)
+}
+
+// AvgResponse is the mean response time of the processes of done that appear in gantt: how long each waited from
+// its arrival until it first ran.
+func AvgResponse(gantt []sched.TimeSlice, done []workload.Process) float64 {
+	first := make(map[int64]int64, len(done))
+	for _, s := range gantt {
+		if _, ok := first[s.PID]; !ok && !s.Switch {
+			first[s.PID] = s.Start
+		}
+	}
+	var (
+		total int64
+		count int
+	)
+	for _, p := range done {
+		if start, ok := first[p.ProcessID]; ok {
+			total += start - p.ArrivalTime
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+
+	return float64(total) / float64(count)
+}
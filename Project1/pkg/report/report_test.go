@@ -0,0 +1,71 @@
+package report
+
+import (
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestCPUUtilization(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                 string
+		busy, idle, overhead int64
+		want                 float64
+	}{
+		{name: "fully busy", busy: 20, want: 1},
+		{name: "idle and overhead", busy: 6, idle: 3, overhead: 1, want: 0.6},
+		{name: "empty", want: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := CPUUtilization(tt.busy, tt.idle, tt.overhead); got != tt.want {
+				t.Errorf("CPUUtilization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_Energy(t *testing.T) {
+	t.Parallel()
+	r := Report{Busy: 6, Idle: 3, Overhead: 1}
+	if got, want := r.Energy(), 7+3*IdlePower; got != want {
+		t.Errorf("Energy() = %v, want %v", got, want)
+	}
+}
+
+func TestAvgResponse(t *testing.T) {
+	t.Parallel()
+	done := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0},
+		{ProcessID: 2, ArrivalTime: 1},
+		{ProcessID: 3, ArrivalTime: 2},
+	}
+	tests := []struct {
+		name  string
+		gantt []sched.TimeSlice
+		want  float64
+	}{
+		{
+			name: "round-robin",
+			// 2 first runs at 3, after a context switch, and 3 at 6; later slices don't count
+			gantt: []sched.TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 3, Switch: true}, {PID: 2, Start: 3, Stop: 5},
+				{PID: 1, Start: 5, Stop: 6}, {PID: 3, Start: 6, Stop: 7}, {PID: 2, Start: 7, Stop: 8}},
+			want: (0 + 2 + 4) / 3.0,
+		},
+		{name: "streamed run without a chart", want: 0},
+		{name: "only some charted", gantt: []sched.TimeSlice{{PID: 3, Start: 5, Stop: 6}}, want: 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := AvgResponse(tt.gantt, done); got != tt.want {
+				t.Errorf("AvgResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BurstTurnaround is the burst and turnaround of one finished process of a report as a flat record, a point of the
+// scatter of turnaround against burst length that shows which processes an algorithm favors.
+type BurstTurnaround struct {
+	Algorithm  string  `json:"algorithm" csv:"algorithm"`
+	PID        int64   `json:"pid" csv:"pid"`
+	Burst      int64   `json:"burst" csv:"burst"`
+	Turnaround int64   `json:"turnaround" csv:"turnaround"`
+	Normalized float64 `json:"normalized_turnaround" csv:"normalized_turnaround"`
+}
+
+// BurstTurnarounds returns the burst and turnaround of every finished process of r, in the order of r.Processes.
+func (r Report) BurstTurnarounds() []BurstTurnaround {
+	points := make([]BurstTurnaround, len(r.Processes))
+	for i, p := range r.ProcessRecords() {
+		points[i] = BurstTurnaround{Algorithm: p.Algorithm, PID: p.PID, Burst: p.Burst, Turnaround: p.Turnaround,
+			Normalized: p.Normalized}
+	}
+
+	return points
+}
+
+// scatterColors are the colors the series of a scatter chart cycle through, in order.
+var scatterColors = []string{"steelblue", "darkorange", "seagreen", "crimson", "rebeccapurple", "goldenrod"}
+
+// WriteScatterSVG renders points as a scatter chart of turnaround against burst, a series of its own color for every
+// algorithm in the order they first appear, over the dashed line where turnaround equals burst, that of a process that
+// never waited.
+func WriteScatterSVG(w io.Writer, points []BurstTurnaround) error {
+	const (
+		width  = 480
+		height = 320
+		margin = 40
+		legend = 180
+	)
+	var (
+		maxBurst, maxTurnaround int64 = 1, 1
+		series                  []string
+		color                   = map[string]string{}
+	)
+	for _, p := range points {
+		maxBurst, maxTurnaround = max(maxBurst, p.Burst), max(maxTurnaround, p.Turnaround)
+		if _, ok := color[p.Algorithm]; !ok {
+			color[p.Algorithm] = scatterColors[len(series)%len(scatterColors)]
+			series = append(series, p.Algorithm)
+		}
+	}
+	x := func(burst int64) int { return margin + int(burst*width/maxBurst) }
+	y := func(turnaround int64) int { return margin + height - int(turnaround*height/maxTurnaround) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n",
+		width+margin*2+legend, height+margin*2)
+	fmt.Fprintf(&b, `<text x="%d" y="20" text-anchor="middle">Turnaround by burst length</text>`+"\n", width/2+margin)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", x(0), y(0), x(maxBurst), y(0))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", x(0), y(0), x(0), y(maxTurnaround))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">burst %d</text>`+"\n", x(maxBurst), y(0)+16, maxBurst)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%d</text>`+"\n", x(0)-4, y(maxTurnaround)+4,
+		maxTurnaround)
+	diagonal := min(maxBurst, maxTurnaround)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="gray" stroke-dasharray="4 4"/>`+"\n",
+		x(0), y(0), x(diagonal), y(diagonal))
+	for _, p := range points {
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="4" fill="%s" fill-opacity="0.7"><title>%s: P%d</title></circle>`+"\n",
+			x(p.Burst), y(p.Turnaround), color[p.Algorithm], svgEscape(p.Algorithm), p.PID)
+	}
+	for i, s := range series {
+		ly := margin + i*20
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="4" fill="%s"/>`+"\n", x(maxBurst)+20, ly, color[s])
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", x(maxBurst)+30, ly+4, svgEscape(s))
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// SaveScatterSVG writes the scatter chart of points into dir as turnaround-vs-burst.svg.
+func SaveScatterSVG(dir string, points []BurstTurnaround) error {
+	f, err := os.Create(filepath.Join(dir, "turnaround-vs-burst.svg"))
+	if err != nil {
+		return fmt.Errorf("%w: creating scatter SVG", err)
+	}
+	if err := WriteScatterSVG(f, points); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing scatter SVG", err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestReport_BurstTurnarounds(t *testing.T) {
+	t.Parallel()
+	fcfs := New("FCFS", sched.NewResult([]sched.TimeSlice{{PID: 1, Stop: 4}, {PID: 2, Start: 4, Stop: 5}},
+		[]workload.Process{
+			{ProcessID: 1, Burst: 4, Turnaround: 4, Completion: 4},
+			{ProcessID: 2, Burst: 1, Wait: 4, Turnaround: 5, Completion: 5},
+		}))
+	sjf := New("SJF", sched.NewResult([]sched.TimeSlice{{PID: 2, Stop: 1}, {PID: 1, Start: 1, Stop: 5}},
+		[]workload.Process{
+			{ProcessID: 2, Burst: 1, Turnaround: 1, Completion: 1},
+			{ProcessID: 1, Burst: 4, Wait: 1, Turnaround: 5, Completion: 5},
+		}))
+	want := []BurstTurnaround{
+		{Algorithm: "FCFS", PID: 1, Burst: 4, Turnaround: 4, Normalized: 1},
+		{Algorithm: "FCFS", PID: 2, Burst: 1, Turnaround: 5, Normalized: 5},
+	}
+	if got := fcfs.BurstTurnarounds(); !reflect.DeepEqual(got, want) {
+		t.Errorf("BurstTurnarounds() = %+v, want %+v", got, want)
+	}
+
+	var w bytes.Buffer
+	if err := WriteScatterSVG(&w, append(fcfs.BurstTurnarounds(), sjf.BurstTurnarounds()...)); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		// the short process is at the left, high for FCFS and low for SJF
+		`<circle cx="160" cy="40" r="4" fill="steelblue" fill-opacity="0.7"><title>FCFS: P2</title></circle>`,
+		`<circle cx="160" cy="296" r="4" fill="darkorange" fill-opacity="0.7"><title>SJF: P2</title></circle>`,
+		`<text x="550" y="64">SJF</text>`,
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("WriteScatterSVG() missing %q:\n%s", want, w.String())
+		}
+	}
+}
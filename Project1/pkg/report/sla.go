@@ -0,0 +1,119 @@
+package report
+
+import "GolandProjects/Project1/pkg/workload"
+
+// latency tells the latencies a service-level target can be set on apart.
+type latency int
+
+const (
+	turnaround latency = iota
+	response
+	wait
+)
+
+// LatencyMetric is a latency a service-level target can be set on, along with the name it is selected by, the title
+// its reports carry, and a one-line description for listings.
+type LatencyMetric struct {
+	Name        string
+	Title       string
+	Description string
+	kind        latency
+}
+
+// LatencyMetrics lists every latency a target can be set on, the default first.
+var LatencyMetrics = []LatencyMetric{
+	{Name: "turnaround", Title: "Turnaround", Description: "from arrival to completion", kind: turnaround},
+	{Name: "response", Title: "Response", Description: "from arrival to first running", kind: response},
+	{Name: "wait", Title: "Wait", Description: "time spent ready in the queue", kind: wait},
+}
+
+// SLA is a service-level objective: a target no process's latency, as Metric measures it, should exceed. Target
+// applies to every process not in Targets, which holds targets of their own; a target of 0 or less sets none.
+type SLA struct {
+	Metric  LatencyMetric
+	Target  int64
+	Targets map[int64]int64
+}
+
+// TargetOf returns the target the process pid is held to, or 0 if it has none.
+func (s SLA) TargetOf(pid int64) int64 {
+	if t, ok := s.Targets[pid]; ok {
+		return max(t, 0)
+	}
+
+	return max(s.Target, 0)
+}
+
+// SLAProcess is a process held to a target and the latency it had.
+type SLAProcess struct {
+	PID     int64
+	Latency int64
+	Target  int64
+}
+
+// Violation is how far p's latency went over its target, or 0 if it met it.
+func (p SLAProcess) Violation() int64 {
+	return max(p.Latency-p.Target, 0)
+}
+
+// SLAResult is how well a run met an SLA: every process held to a target, in the order of the report's processes,
+// with how many met it and how far the rest went over theirs, in total and at most.
+type SLAResult struct {
+	SLA            SLA
+	Processes      []SLAProcess
+	Met            int
+	TotalViolation int64
+	MaxViolation   int64
+}
+
+// MetShare returns the fraction of the processes held to a target that met it, or 1 if none was.
+func (r SLAResult) MetShare() float64 {
+	if len(r.Processes) == 0 {
+		return 1
+	}
+
+	return float64(r.Met) / float64(len(r.Processes))
+}
+
+// Evaluate measures the latency of every finished process of r held to a target of s against it. A process's
+// response is taken from its first slice of r's Gantt chart, or is its wait if it has none.
+func (s SLA) Evaluate(r Report) SLAResult {
+	first := make(map[int64]int64)
+	for _, sl := range r.Gantt {
+		if _, ok := first[sl.PID]; !ok && !sl.Switch {
+			first[sl.PID] = sl.Start
+		}
+	}
+	result := SLAResult{SLA: s}
+	for _, p := range r.Processes {
+		target := s.TargetOf(p.ProcessID)
+		if target == 0 {
+			continue
+		}
+		sp := SLAProcess{PID: p.ProcessID, Latency: s.latency(p, first), Target: target}
+		if v := sp.Violation(); v > 0 {
+			result.TotalViolation += v
+			result.MaxViolation = max(result.MaxViolation, v)
+		} else {
+			result.Met++
+		}
+		result.Processes = append(result.Processes, sp)
+	}
+
+	return result
+}
+
+// latency returns p's latency as s measures it, given the time every process first ran.
+func (s SLA) latency(p workload.Process, first map[int64]int64) int64 {
+	switch s.Metric.kind {
+	case response:
+		if t, ok := first[p.ProcessID]; ok {
+			return t - p.ArrivalTime
+		}
+		return p.Wait
+	case wait:
+		return p.Wait
+	}
+
+	return p.Turnaround
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestSLA_Evaluate(t *testing.T) {
+	t.Parallel()
+	// P2 arrives at 1 and first runs at 3, after P1 and a switch
+	r := New("FCFS", sched.NewResult([]sched.TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 3, Switch: true},
+		{PID: 2, Start: 3, Stop: 7},
+	}, []workload.Process{
+		{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, ArrivalTime: 1, Burst: 4, Wait: 2, Turnaround: 6, Completion: 7},
+	}))
+	tests := []struct {
+		name string
+		sla  SLA
+		want SLAResult
+	}{
+		{
+			name: "turnaround",
+			sla:  SLA{Metric: LatencyMetrics[0], Target: 3},
+			want: SLAResult{Processes: []SLAProcess{{PID: 1, Latency: 2, Target: 3}, {PID: 2, Latency: 6, Target: 3}},
+				Met: 1, TotalViolation: 3, MaxViolation: 3},
+		},
+		{
+			name: "response with a target of its own",
+			sla:  SLA{Metric: LatencyMetrics[1], Target: 1, Targets: map[int64]int64{1: 0}},
+			want: SLAResult{Processes: []SLAProcess{{PID: 2, Latency: 2, Target: 1}}, TotalViolation: 1,
+				MaxViolation: 1},
+		},
+		{
+			name: "wait",
+			sla:  SLA{Metric: LatencyMetrics[2], Targets: map[int64]int64{2: 2}},
+			want: SLAResult{Processes: []SLAProcess{{PID: 2, Latency: 2, Target: 2}}, Met: 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.want.SLA = tt.sla
+			if got := tt.sla.Evaluate(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if got := (SLAResult{}).MetShare(); got != 1 {
+		t.Errorf("MetShare() of no targets = %v, want 1", got)
+	}
+}
@@ -0,0 +1,247 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Estimate is the mean of a sample with its 95% confidence interval, Mean ± HalfWidth.
+type Estimate struct {
+	Mean      float64
+	HalfWidth float64
+	N         int
+}
+
+// Estimate95 returns the mean of values with the 95% confidence interval of Student's t distribution, which stays
+// honest for small samples. A sample of fewer than two values has an infinite interval.
+func Estimate95(values []float64) Estimate {
+	e := Estimate{N: len(values), HalfWidth: math.Inf(1)}
+	if len(values) == 0 {
+		e.Mean = math.NaN()
+		return e
+	}
+	for _, v := range values {
+		e.Mean += v
+	}
+	e.Mean /= float64(len(values))
+	if len(values) < 2 {
+		return e
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (v - e.Mean) * (v - e.Mean)
+	}
+	stddev := math.Sqrt(squares / float64(len(values)-1))
+	e.HalfWidth = tCritical95(len(values)-1) * stddev / math.Sqrt(float64(len(values)))
+
+	return e
+}
+
+// Low is the lower end of the interval.
+func (e Estimate) Low() float64 {
+	return e.Mean - e.HalfWidth
+}
+
+// High is the upper end of the interval.
+func (e Estimate) High() float64 {
+	return e.Mean + e.HalfWidth
+}
+
+// String formats e as "mean ± half-width" to two decimals.
+func (e Estimate) String() string {
+	return fmt.Sprintf("%.2f ± %.2f", e.Mean, e.HalfWidth)
+}
+
+// t95 holds the two-sided 95% critical values of Student's t distribution for 1 to 30 degrees of freedom.
+var t95 = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tCritical95 returns the two-sided 95% critical value of Student's t distribution with df degrees of freedom. Past
+// the table it uses the next lower tabulated value, which errs on the wide side, and the normal 1.96 from 1000 on.
+func tCritical95(df int) float64 {
+	switch {
+	case df <= len(t95):
+		return t95[df-1]
+	case df < 40:
+		return 2.042
+	case df < 60:
+		return 2.021
+	case df < 120:
+		return 2.000
+	case df < 1000:
+		return 1.980
+	default:
+		return 1.960
+	}
+}
+
+// PairedTTest returns the two-sided p-value of Student's paired t-test that the differences diffs between paired
+// observations have mean 0.
+func PairedTTest(diffs []float64) float64 {
+	e := Estimate95(diffs)
+	if len(diffs) < 2 {
+		return 1
+	}
+	stderr := e.HalfWidth / tCritical95(len(diffs)-1)
+	if stderr == 0 {
+		if e.Mean == 0 {
+			return 1
+		}
+		return 0
+	}
+	t := e.Mean / stderr
+	df := float64(len(diffs) - 1)
+
+	return regularizedBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// WilcoxonSignedRank returns the two-sided p-value of the Wilcoxon signed-rank test that the differences diffs
+// between paired observations are symmetric about 0. Unlike the t-test it assumes nothing about their distribution.
+// Zero differences are dropped. Up to 25 differences without ties get the exact p-value, and others the normal
+// approximation with a correction for ties.
+func WilcoxonSignedRank(diffs []float64) float64 {
+	var nonzero []float64
+	for _, d := range diffs {
+		if d != 0 {
+			nonzero = append(nonzero, d)
+		}
+	}
+	n := len(nonzero)
+	if n == 0 {
+		return 1
+	}
+	sort.Slice(nonzero, func(i, j int) bool { return math.Abs(nonzero[i]) < math.Abs(nonzero[j]) })
+
+	// rank by magnitude, giving tied magnitudes their mean rank
+	var (
+		positive float64 // sum of the ranks of positive differences
+		ties     float64 // sum of t³-t over groups of t ties
+	)
+	for i := 0; i < n; {
+		j := i
+		for j < n && math.Abs(nonzero[j]) == math.Abs(nonzero[i]) {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if nonzero[k] > 0 {
+				positive += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	if ties == 0 && n <= 25 {
+		return exactSignedRank(n, int(positive))
+	}
+	mean := float64(n*(n+1)) / 4
+	variance := float64(n*(n+1)*(2*n+1))/24 - ties/48
+	z := (math.Abs(positive-mean) - 0.5) / math.Sqrt(variance) // with continuity correction
+	if z < 0 {
+		z = 0
+	}
+
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactSignedRank returns the two-sided p-value of a signed-rank sum of w over n differences, counting the subsets
+// of the ranks 1 to n with each sum.
+func exactSignedRank(n, w int) float64 {
+	maxSum := n * (n + 1) / 2
+	counts := make([]float64, maxSum+1)
+	counts[0] = 1
+	for rank := 1; rank <= n; rank++ {
+		for s := maxSum; s >= rank; s-- {
+			counts[s] += counts[s-rank]
+		}
+	}
+	var below, above float64 // subsets summing to at most and at least w
+	for s, c := range counts {
+		if s <= w {
+			below += c
+		}
+		if s >= w {
+			above += c
+		}
+	}
+	total := math.Ldexp(1, n)
+
+	return math.Min(1, 2*math.Min(below, above)/total)
+}
+
+// regularizedBeta returns the regularized incomplete beta function I_x(a, b), by its continued fraction.
+func regularizedBeta(x, a, b float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	case x > (a+1)/(a+b+2):
+		// the continued fraction converges quickly only below this point
+		return 1 - regularizedBeta(1-x, b, a)
+	}
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab-lga-lgb+a*math.Log(x)+b*math.Log(1-x)) / a
+
+	// Lentz's method
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	f := d
+	for m := 1; m <= 300; m++ {
+		fm := float64(m)
+		for _, numerator := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + numerator*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + numerator/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			f *= c * d
+		}
+		if math.Abs(c*d-1) < 1e-12 {
+			break
+		}
+	}
+
+	return front * f
+}
+
+// Slope returns the least-squares slope of ys against xs: how much y changes per unit of x. It is 0 when the xs
+// don't vary.
+func Slope(xs, ys []float64) float64 {
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(len(xs))
+	meanY /= float64(len(ys))
+	var covariance, variance float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if variance == 0 {
+		return 0
+	}
+
+	return covariance / variance
+}
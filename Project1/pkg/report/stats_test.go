@@ -0,0 +1,131 @@
+package report
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimate95(t *testing.T) {
+	t.Parallel()
+	large := make([]float64, 2000)
+	for i := range large {
+		large[i] = float64(i % 2) // mean 0.5, sample standard deviation just over 0.5
+	}
+	tests := []struct {
+		name          string
+		values        []float64
+		wantMean      float64
+		wantHalfWidth float64
+	}{
+		{name: "one value", values: []float64{3}, wantMean: 3, wantHalfWidth: math.Inf(1)},
+		{name: "two values", values: []float64{1, 3}, wantMean: 2, wantHalfWidth: 12.706},
+		{name: "constant", values: []float64{4, 4, 4, 4}, wantMean: 4},
+		{name: "five values", values: []float64{2, 4, 4, 5, 5}, wantMean: 4, wantHalfWidth: 2.776 * math.Sqrt(1.5) / math.Sqrt(5)},
+		{name: "large sample", values: large, wantMean: 0.5, wantHalfWidth: 1.96 * math.Sqrt(500.0/1999) / math.Sqrt(2000)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Estimate95(tt.values)
+			if got.N != len(tt.values) || math.Abs(got.Mean-tt.wantMean) > 1e-9 ||
+				!(got.HalfWidth == tt.wantHalfWidth || math.Abs(got.HalfWidth-tt.wantHalfWidth) < 1e-9) {
+				t.Errorf("Estimate95() = %+v, want mean %v ± %v", got, tt.wantMean, tt.wantHalfWidth)
+			}
+			if got.Low() > got.Mean || got.High() < got.Mean {
+				t.Errorf("interval [%v, %v] misses the mean %v", got.Low(), got.High(), got.Mean)
+			}
+		})
+	}
+	if got := Estimate95(nil); !math.IsNaN(got.Mean) {
+		t.Errorf("Estimate95(nil).Mean = %v, want NaN", got.Mean)
+	}
+	if got, want := Estimate95([]float64{1, 3}).String(), "2.00 ± 12.71"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestPairedTTest(t *testing.T) {
+	t.Parallel()
+	// standard deviation sqrt(11) over 11 values, so standard error 1 and t the 95% critical value with 10 df
+	critical := make([]float64, 11)
+	for i := range critical {
+		critical[i] = 2.228 + float64(i-5)
+	}
+	tests := []struct {
+		name  string
+		diffs []float64
+		want  float64
+	}{
+		{name: "one difference", diffs: []float64{3}, want: 1},
+		{name: "all zero", diffs: []float64{0, 0, 0}, want: 1},
+		{name: "constant", diffs: []float64{2, 2, 2}, want: 0},
+		{name: "t of sqrt 18 with 4 df", diffs: []float64{1, 2, 3, 4, 5}, want: 0.013236},
+		{name: "critical value", diffs: critical, want: 0.05},
+		{name: "symmetric", diffs: []float64{-2, -1, 1, 2}, want: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := PairedTTest(tt.diffs); math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("PairedTTest(%v) = %v, want %v", tt.diffs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWilcoxonSignedRank(t *testing.T) {
+	t.Parallel()
+	alternating := make([]float64, 40)
+	for i := range alternating {
+		alternating[i] = float64(i%2*2 - 1) // -1, 1, ...
+	}
+	tests := []struct {
+		name  string
+		diffs []float64
+		want  float64
+	}{
+		{name: "all zero", diffs: []float64{0, 0}, want: 1},
+		{name: "all positive", diffs: []float64{1, 2, 3, 4, 5}, want: 2.0 / 32},
+		{name: "smallest negative", diffs: []float64{-1, 2, 3, 4, 5}, want: 4.0 / 32},
+		{name: "zeros dropped", diffs: []float64{0, 5, 4, 0, 3, 2, 1}, want: 2.0 / 32},
+		{name: "all negative", diffs: []float64{-1, -2, -3, -4, -5}, want: 2.0 / 32},
+		{name: "one difference", diffs: []float64{7}, want: 1},
+		// ranks 1.5, 1.5, 3.5, 3.5, 5: W+ 15 against mean 7.5 and variance 13.75 - 12/48
+		{name: "ties", diffs: []float64{1, 1, 2, 2, 3}, want: math.Erfc(7 / math.Sqrt(13.5) / math.Sqrt2)},
+		{name: "balanced ties", diffs: alternating, want: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := WilcoxonSignedRank(tt.diffs); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("WilcoxonSignedRank(%v) = %v, want %v", tt.diffs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlope(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		xs, ys []float64
+		want   float64
+	}{
+		{name: "line", xs: []float64{1, 2, 3}, ys: []float64{5, 3, 1}, want: -2},
+		{name: "noisy", xs: []float64{0, 1, 2, 3}, ys: []float64{0, 2, 1, 3}, want: 0.8},
+		{name: "one point", xs: []float64{4}, ys: []float64{7}},
+		{name: "flat", xs: []float64{1, 2}, ys: []float64{3, 3}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Slope(tt.xs, tt.ys); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Slope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
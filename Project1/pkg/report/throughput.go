@@ -0,0 +1,115 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ThroughputPoint is the cumulative number of completions by Time.
+type ThroughputPoint struct {
+	Time      int64
+	Completed int
+}
+
+// Throughput is the average completion rate from the start of the run up to p.Time.
+func (p ThroughputPoint) Throughput() float64 {
+	if p.Time == 0 {
+		return 0
+	}
+
+	return float64(p.Completed) / float64(p.Time)
+}
+
+// ThroughputCurve returns one point per distinct completion time in done, in time order.
+func ThroughputCurve(done []workload.Process) []ThroughputPoint {
+	completions := make([]int64, len(done))
+	for i := range done {
+		completions[i] = done[i].Completion
+	}
+	sort.Slice(completions, func(i, j int) bool {
+		return completions[i] < completions[j]
+	})
+
+	curve := make([]ThroughputPoint, 0, len(completions))
+	for i, c := range completions {
+		if len(curve) > 0 && curve[len(curve)-1].Time == c {
+			curve[len(curve)-1].Completed = i + 1
+			continue
+		}
+		curve = append(curve, ThroughputPoint{Time: c, Completed: i + 1})
+	}
+
+	return curve
+}
+
+// WriteThroughputCurve writes the curve as a table of data points.
+func WriteThroughputCurve(w io.Writer, curve []ThroughputPoint) {
+	_, _ = fmt.Fprintln(w, "Throughput over time")
+	_, _ = fmt.Fprintf(w, "%6s %10s %11s\n", "time", "completed", "throughput")
+	for _, p := range curve {
+		_, _ = fmt.Fprintf(w, "%6d %10d %9.2f/t\n", p.Time, p.Completed, p.Throughput())
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// WriteThroughputSVG renders the cumulative completions as a step chart.
+func WriteThroughputSVG(w io.Writer, title string, curve []ThroughputPoint) error {
+	const (
+		width  = 480
+		height = 200
+		margin = 40
+	)
+	var maxTime int64 = 1
+	maxCompleted := 1
+	if len(curve) > 0 {
+		maxTime = curve[len(curve)-1].Time
+		maxCompleted = curve[len(curve)-1].Completed
+	}
+	x := func(t int64) int { return margin + int(t*width/maxTime) }
+	y := func(c int) int { return margin + height - c*height/maxCompleted }
+
+	points := []string{fmt.Sprintf("%d,%d", x(0), y(0))}
+	last := 0
+	for _, p := range curve {
+		points = append(points,
+			fmt.Sprintf("%d,%d", x(p.Time), y(last)),
+			fmt.Sprintf("%d,%d", x(p.Time), y(p.Completed)))
+		last = p.Completed
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n",
+		width+margin*2, height+margin*2)
+	fmt.Fprintf(&b, `<text x="%d" y="20" text-anchor="middle">%s completions over time</text>`+"\n",
+		width/2+margin, svgEscape(title))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", x(0), y(0), x(maxTime), y(0))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", x(0), y(0), x(0), y(maxCompleted))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%d</text>`+"\n", x(maxTime), y(0)+16, maxTime)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%d</text>`+"\n", x(0)-4, y(maxCompleted)+4, maxCompleted)
+	fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="steelblue" stroke-width="2"/>`+"\n",
+		strings.Join(points, " "))
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// SaveThroughputSVG writes the throughput curve for title into dir, named after the title.
+func SaveThroughputSVG(dir, title string, curve []ThroughputPoint) error {
+	f, err := os.Create(filepath.Join(dir, Slug(title)+"-throughput.svg"))
+	if err != nil {
+		return fmt.Errorf("%w: creating throughput SVG", err)
+	}
+	if err := WriteThroughputSVG(f, title, curve); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing throughput SVG", err)
+	}
+
+	return f.Close()
+}
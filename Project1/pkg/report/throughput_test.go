@@ -0,0 +1,30 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestThroughputCurve(t *testing.T) {
+	t.Parallel()
+	done := []workload.Process{
+		{ProcessID: 1, Completion: 14},
+		{ProcessID: 2, Completion: 5},
+		{ProcessID: 3, Completion: 14},
+		{ProcessID: 4, Completion: 20},
+	}
+	want := []ThroughputPoint{
+		{Time: 5, Completed: 1},
+		{Time: 14, Completed: 3},
+		{Time: 20, Completed: 4},
+	}
+	got := ThroughputCurve(done)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("throughputCurve() = %v, want %v", got, want)
+	}
+	if tp := got[2].Throughput(); tp != 0.2 {
+		t.Errorf("Throughput() = %v, want 0.2", tp)
+	}
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Process states drawn in the timeline, one character per time unit.
+const (
+	StateRunning = '#'
+	StateReady   = '.'
+	StateAbsent  = ' '
+	StateIdle    = '-'
+)
+
+// TimelineRow is the state strip of one process, or of the CPU itself when Label is "idle".
+type TimelineRow struct {
+	Label  string
+	States string
+}
+
+// Timeline returns one row per process showing, for each time unit, whether it was running, waiting in the ready
+// queue, or not in the system, followed by a row marking the units the CPU sat idle.
+func (r Report) Timeline() []TimelineRow {
+	var end int64
+	for i := range r.Processes {
+		if r.Processes[i].Completion > end {
+			end = r.Processes[i].Completion
+		}
+	}
+
+	busy := make([]bool, end)
+	rows := make([]TimelineRow, 0, len(r.Processes)+1)
+	for _, p := range r.Processes {
+		states := []rune(strings.Repeat(string(StateAbsent), int(end)))
+		for t := p.ArrivalTime; t < p.Completion && t < end; t++ {
+			states[t] = StateReady
+		}
+		for _, s := range r.Gantt {
+			if s.PID != p.ProcessID || s.Switch {
+				continue
+			}
+			for t := s.Start; t < s.Stop && t < end; t++ {
+				states[t] = StateRunning
+				busy[t] = true
+			}
+		}
+		rows = append(rows, TimelineRow{Label: fmt.Sprint(p.ProcessID), States: string(states)})
+	}
+
+	idle := []rune(strings.Repeat(" ", int(end)))
+	for t := range busy {
+		if !busy[t] {
+			idle[t] = StateIdle
+		}
+	}
+
+	return append(rows, TimelineRow{Label: "idle", States: string(idle)})
+}
+
+// timelineChunk is how many time units are drawn per line before the timeline wraps.
+const timelineChunk = 60
+
+// WriteTimeline writes r's timeline with a ruler marking every tenth time unit.
+func WriteTimeline(w io.Writer, r Report) {
+	rows := r.Timeline()
+	_, _ = fmt.Fprintf(w, "Process timeline (%c running, %c ready, %c CPU idle)\n", StateRunning, StateReady, StateIdle)
+	labelWidth := len("idle")
+	for _, row := range rows {
+		if len(row.Label) > labelWidth {
+			labelWidth = len(row.Label)
+		}
+	}
+
+	end := len(rows[len(rows)-1].States)
+	for from := 0; from < end; from += timelineChunk {
+		to := from + timelineChunk
+		if to > end {
+			to = end
+		}
+		_, _ = fmt.Fprintf(w, "%*s  %s\n", labelWidth, "", timelineRuler(from, to, to == end))
+		for _, row := range rows {
+			_, _ = fmt.Fprintf(w, "%*s |%s|\n", labelWidth, row.Label, row.States[from:to])
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// timelineRuler labels the time units in [from, to) at every multiple of ten, and to as well if last, so a tick at
+// the boundary between two chunks is labeled once, at the start of the second.
+func timelineRuler(from, to int, last bool) string {
+	if last {
+		to++
+	}
+	ruler := []byte(strings.Repeat(" ", to-from))
+	for t := from; t < to; t++ {
+		if t%10 != 0 {
+			continue
+		}
+		label := fmt.Sprint(t)
+		col := t - from
+		if col+len(label) > len(ruler) {
+			ruler = append(ruler, strings.Repeat(" ", col+len(label)-len(ruler))...)
+		}
+		copy(ruler[col:], label)
+	}
+
+	return strings.TrimRight(string(ruler), " ")
+}
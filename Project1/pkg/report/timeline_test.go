@@ -0,0 +1,60 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestReport_Timeline(t *testing.T) {
+	t.Parallel()
+	r := Report{
+		Gantt: []sched.TimeSlice{
+			{PID: 1, Start: 0, Stop: 2},
+			{PID: 2, Start: 2, Stop: 3},
+			{PID: 1, Start: 3, Stop: 4},
+			{PID: 3, Start: 6, Stop: 8},
+		},
+		Processes: []workload.Process{
+			{ProcessID: 1, ArrivalTime: 0, Completion: 4},
+			{ProcessID: 2, ArrivalTime: 1, Completion: 3},
+			{ProcessID: 3, ArrivalTime: 6, Completion: 8},
+		},
+	}
+	want := []TimelineRow{
+		{Label: "1", States: "##.#    "},
+		{Label: "2", States: " .#     "},
+		{Label: "3", States: "      ##"},
+		{Label: "idle", States: "    --  "},
+	}
+	if got := r.Timeline(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Timeline() = %q, want %q", got, want)
+	}
+}
+
+func Test_timelineRuler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		from, to int
+		last     bool
+		want     string
+	}{
+		{name: "whole timeline", from: 0, to: 12, last: true, want: "0         10"},
+		{name: "ends on a tick", from: 50, to: 60, last: true, want: "50        60"},
+		// the next chunk's ruler starts with 60, so this one leaves it out
+		{name: "chunk before another", from: 0, to: 60, want: "0         10        20        30        40        50"},
+		{name: "last chunk", from: 60, to: 65, last: true, want: "60"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := timelineRuler(tt.from, tt.to, tt.last); got != tt.want {
+				t.Errorf("timelineRuler(%d, %d, %v) = %q, want %q", tt.from, tt.to, tt.last, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// Package rw simulates a readers–writers lock: any number of readers may hold it together, but a writer only alone.
+// Which waiting accesses the lock lets in when decides who waits and who starves, so it runs the same trace of
+// accesses under readers-preference, writers-preference, and fair policies and measures the latency of each class.
+package rw
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Kind is whether an access reads or writes.
+type Kind int
+
+const (
+	Read Kind = iota
+	Write
+)
+
+// String returns "read" or "write".
+func (k Kind) String() string {
+	if k == Write {
+		return "write"
+	}
+
+	return "read"
+}
+
+// Access is one reader or writer asking for the lock at Arrival and holding it for Duration time units once in.
+type Access struct {
+	ID       int
+	Kind     Kind
+	Arrival  int64
+	Duration int64
+}
+
+// Config is how Generate draws a trace: Accesses accesses, each a write with probability WriteFraction, arriving a
+// time drawn uniformly from 0 to twice MeanGap after the one before, and holding the lock for a time drawn uniformly
+// from the range of its kind.
+type Config struct {
+	Accesses      int
+	WriteFraction float64
+	MeanGap       int64
+	ReadMin       int64
+	ReadMax       int64
+	WriteMin      int64
+	WriteMax      int64
+}
+
+// Generate draws a trace of accesses in arrival order from rng.
+func Generate(rng *rand.Rand, c Config) []Access {
+	draw := func(lo, hi int64) int64 { return max(lo+rng.Int63n(max(hi-lo, 0)+1), 1) }
+	accesses := make([]Access, c.Accesses)
+	var time int64
+	for i := range accesses {
+		if i > 0 {
+			time += rng.Int63n(2*max(c.MeanGap, 0) + 1)
+		}
+		a := Access{ID: i + 1, Kind: Read, Arrival: time}
+		if rng.Float64() < c.WriteFraction {
+			a.Kind, a.Duration = Write, draw(c.WriteMin, c.WriteMax)
+		} else {
+			a.Duration = draw(c.ReadMin, c.ReadMax)
+		}
+		accesses[i] = a
+	}
+
+	return accesses
+}
+
+// admit returns the indexes of the accesses of waiting, in arrival order, to let in now, with readers holding the
+// lock and, if writing, a writer holding it.
+type admit func(waiting []Access, readers int, writing bool) []int
+
+// Policy is a readers–writers lock policy along with the name it is selected by, the title its reports carry, and
+// a one-line description for listings.
+type Policy struct {
+	Name        string
+	Title       string
+	Description string
+	admit       admit
+}
+
+// Policies lists every policy in the order they run by default.
+var Policies = []Policy{
+	{Name: "readers", Title: "Readers-preference",
+		Description: "readers join whenever no writer holds the lock; writers can starve", admit: readersFirst},
+	{Name: "writers", Title: "Writers-preference",
+		Description: "no reader starts while a writer waits; readers can starve", admit: writersFirst},
+	{Name: "fair", Title: "Fair",
+		Description: "accesses go in in arrival order, readers together up to the next writer", admit: fair},
+}
+
+// readersFirst lets every waiting reader in unless a writer holds the lock, and a writer only when no reader holds
+// or wants it.
+func readersFirst(waiting []Access, readers int, writing bool) []int {
+	if writing {
+		return nil
+	}
+	var in []int
+	for i, a := range waiting {
+		if a.Kind == Read {
+			in = append(in, i)
+		}
+	}
+	if len(in) == 0 && readers == 0 {
+		return firstWriter(waiting)
+	}
+
+	return in
+}
+
+// writersFirst lets the first waiting writer in once the lock is free, and readers only when no writer waits.
+func writersFirst(waiting []Access, readers int, writing bool) []int {
+	if writing {
+		return nil
+	}
+	if w := firstWriter(waiting); w != nil {
+		if readers == 0 {
+			return w
+		}
+		return nil
+	}
+
+	return allWaiting(waiting)
+}
+
+// fair lets accesses in in arrival order: the readers at the head of the queue together, up to the first writer, or
+// that writer alone once the lock is free.
+func fair(waiting []Access, readers int, writing bool) []int {
+	if writing || len(waiting) == 0 {
+		return nil
+	}
+	if waiting[0].Kind == Write {
+		if readers == 0 {
+			return []int{0}
+		}
+		return nil
+	}
+	var in []int
+	for i := 0; i < len(waiting) && waiting[i].Kind == Read; i++ {
+		in = append(in, i)
+	}
+
+	return in
+}
+
+func firstWriter(waiting []Access) []int {
+	for i, a := range waiting {
+		if a.Kind == Write {
+			return []int{i}
+		}
+	}
+
+	return nil
+}
+
+func allWaiting(waiting []Access) []int {
+	in := make([]int, len(waiting))
+	for i := range in {
+		in[i] = i
+	}
+
+	return in
+}
+
+// Served is an access with when the lock let it in and when it let the lock go.
+type Served struct {
+	Access
+	Start  int64
+	Finish int64
+}
+
+// Latency returns how long s waited for the lock.
+func (s Served) Latency() int64 {
+	return s.Start - s.Arrival
+}
+
+// Result is a run of one policy over a trace: every access as served, in the order of the trace.
+type Result struct {
+	Policy Policy
+	Served []Served
+}
+
+// Class is the latency of one kind of access over a run.
+type Class struct {
+	Kind    Kind
+	Count   int
+	Average float64
+	Longest int64
+}
+
+// Class returns the latency of the accesses of kind.
+func (r Result) Class(kind Kind) Class {
+	c := Class{Kind: kind}
+	var total int64
+	for _, s := range r.Served {
+		if s.Kind == kind {
+			c.Count++
+			total += s.Latency()
+			c.Longest = max(c.Longest, s.Latency())
+		}
+	}
+	if c.Count > 0 {
+		c.Average = float64(total) / float64(c.Count)
+	}
+
+	return c
+}
+
+// Starved returns how many accesses of kind waited longer than limit time units.
+func (r Result) Starved(kind Kind, limit int64) int {
+	n := 0
+	for _, s := range r.Served {
+		if s.Kind == kind && s.Latency() > limit {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Finished returns when the last access let the lock go.
+func (r Result) Finished() int64 {
+	var last int64
+	for _, s := range r.Served {
+		last = max(last, s.Finish)
+	}
+
+	return last
+}
+
+// Simulate runs accesses, in arrival order, through a lock under p a time unit at a time. At every unit, accesses
+// whose time is up let the lock go, those arriving join the back of the queue, and the policy lets in whichever of
+// the queue it will.
+func Simulate(accesses []Access, p Policy) Result {
+	served := make([]Served, len(accesses))
+	var (
+		waiting []Access
+		holding []int // indexes into served of the accesses holding the lock
+		next    int   // the next access to arrive
+	)
+	index := make(map[int]int, len(accesses))
+	for i, a := range accesses {
+		index[a.ID] = i
+	}
+	for time := int64(0); next < len(accesses) || len(waiting) > 0 || len(holding) > 0; time++ {
+		still := holding[:0]
+		for _, i := range holding {
+			if served[i].Finish > time {
+				still = append(still, i)
+			}
+		}
+		holding = still
+		for next < len(accesses) && accesses[next].Arrival <= time {
+			waiting = append(waiting, accesses[next])
+			next++
+		}
+
+		readers, writing := 0, false
+		for _, i := range holding {
+			if served[i].Kind == Write {
+				writing = true
+			} else {
+				readers++
+			}
+		}
+		in := p.admit(waiting, readers, writing)
+		sort.Ints(in)
+		for k := len(in) - 1; k >= 0; k-- {
+			a := waiting[in[k]]
+			i := index[a.ID]
+			served[i] = Served{Access: a, Start: time, Finish: time + max(a.Duration, 1)}
+			holding = append(holding, i)
+			waiting = append(waiting[:in[k]], waiting[in[k]+1:]...)
+		}
+	}
+
+	return Result{Policy: p, Served: served}
+}
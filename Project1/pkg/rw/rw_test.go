@@ -0,0 +1,86 @@
+package rw
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+	// a writer arriving among a stream of readers
+	stream := []Access{
+		{ID: 1, Kind: Read, Arrival: 0, Duration: 4},
+		{ID: 2, Kind: Write, Arrival: 1, Duration: 2},
+		{ID: 3, Kind: Read, Arrival: 2, Duration: 2},
+		{ID: 4, Kind: Read, Arrival: 3, Duration: 3},
+	}
+	// a reader arriving between two writers
+	between := []Access{
+		{ID: 1, Kind: Write, Arrival: 0, Duration: 3},
+		{ID: 2, Kind: Read, Arrival: 1, Duration: 1},
+		{ID: 3, Kind: Write, Arrival: 2, Duration: 1},
+	}
+	tests := []struct {
+		name     string
+		accesses []Access
+		policy   Policy
+		starts   []int64
+	}{
+		{name: "readers stream", accesses: stream, policy: Policies[0], starts: []int64{0, 6, 2, 3}},
+		{name: "writers stream", accesses: stream, policy: Policies[1], starts: []int64{0, 4, 6, 6}},
+		{name: "fair stream", accesses: stream, policy: Policies[2], starts: []int64{0, 4, 6, 6}},
+		{name: "readers between", accesses: between, policy: Policies[0], starts: []int64{0, 3, 4}},
+		{name: "writers between", accesses: between, policy: Policies[1], starts: []int64{0, 4, 3}},
+		{name: "fair between", accesses: between, policy: Policies[2], starts: []int64{0, 3, 4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := Simulate(tt.accesses, tt.policy)
+			var starts []int64
+			for _, s := range r.Served {
+				starts = append(starts, s.Start)
+			}
+			if !reflect.DeepEqual(starts, tt.starts) {
+				t.Errorf("accesses start at %v, want %v", starts, tt.starts)
+			}
+		})
+	}
+
+	r := Simulate(stream, Policies[0])
+	if c := r.Class(Write); c.Count != 1 || c.Average != 5 || c.Longest != 5 {
+		t.Errorf("Class(Write) = %+v, want 1 write waiting 5", c)
+	}
+	if c := r.Class(Read); c.Count != 3 || c.Average != 0 {
+		t.Errorf("Class(Read) = %+v, want 3 reads waiting 0", c)
+	}
+	if r.Starved(Write, 4) != 1 || r.Starved(Write, 5) != 0 || r.Finished() != 8 {
+		t.Errorf("Starved() = %d and %d, Finished() = %d, want 1, 0, and 8", r.Starved(Write, 4), r.Starved(Write, 5),
+			r.Finished())
+	}
+}
+
+func TestSimulate_exclusion(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 20; trial++ {
+		accesses := Generate(rng, Config{Accesses: 40, WriteFraction: 0.3, MeanGap: 2, ReadMin: 1, ReadMax: 5,
+			WriteMin: 1, WriteMax: 3})
+		for _, p := range Policies {
+			r := Simulate(accesses, p)
+			for i, a := range r.Served {
+				if a.Start < a.Arrival || a.Finish != a.Start+a.Duration {
+					t.Fatalf("%s: access %+v served out of time", p.Name, a)
+				}
+				for _, b := range r.Served[i+1:] {
+					overlap := a.Start < b.Finish && b.Start < a.Finish
+					if overlap && (a.Kind == Write || b.Kind == Write) {
+						t.Fatalf("%s: %+v and %+v hold the lock at once", p.Name, a, b)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package sched
+
+import (
+	"context"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ClosedLoop describes a closed workload: a fixed population of Users, each of whom thinks, submits a job, waits for
+// it to finish, and thinks again before submitting the next, until Horizon. Think returns how long the user numbered
+// user, from 0, thinks next, and Job the burst and priority of its next job.
+type ClosedLoop struct {
+	Users   int
+	Horizon int64
+	Think   func(user int) int64
+	Job     func(user int) (burst, priority int64)
+}
+
+// ClosedResult is a closed run to its horizon: the jobs finished by then, numbered from 1 in the order they were
+// submitted, and the user that submitted every job, finished or not, by PID.
+type ClosedResult struct {
+	Result
+	Users map[int64]int
+}
+
+// RunClosed simulates the closed workload c under choose, every user submitting its first job once it has first
+// thought. A job that would arrive at the horizon or later is never submitted, and one that has not finished by then
+// is left out of the result.
+func RunClosed(ctx context.Context, c ClosedLoop, choose Policy) (ClosedResult, error) {
+	sim := NewSimulation(nil)
+	users := make(map[int64]int)
+	submit := func(user int, at int64) error {
+		at += max(c.Think(user), 0)
+		if at >= c.Horizon {
+			return nil
+		}
+		burst, priority := c.Job(user)
+		pid := int64(len(users) + 1)
+		users[pid] = user
+
+		return sim.Submit(workload.Process{ProcessID: pid, ArrivalTime: at, BurstDuration: burst, Priority: priority})
+	}
+	for u := 0; u < c.Users; u++ {
+		if err := submit(u, 0); err != nil {
+			return ClosedResult{}, err
+		}
+	}
+	for sim.Time < c.Horizon && !sim.Finished() {
+		done := len(sim.Done)
+		if err := sim.Run(ctx, choose, sim.Time+1); err != nil {
+			return ClosedResult{}, err
+		}
+		for _, p := range sim.Done[done:] {
+			if err := submit(users[p.ProcessID], p.Completion); err != nil {
+				return ClosedResult{}, err
+			}
+		}
+	}
+
+	return ClosedResult{Result: sim.Result(), Users: users}, nil
+}
@@ -0,0 +1,55 @@
+package sched
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRunClosed(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		loop      ClosedLoop
+		want      []TimeSlice
+		wantUsers map[int64]int
+		wantDone  int
+	}{
+		{
+			name: "one user",
+			// a job of 3 after every think of 2, the last finishing right at the horizon
+			loop: ClosedLoop{Users: 1, Horizon: 20, Think: func(int) int64 { return 2 },
+				Job: func(int) (int64, int64) { return 3, 1 }},
+			want: []TimeSlice{{PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 7, Stop: 10}, {PID: 3, Start: 12, Stop: 15},
+				{PID: 4, Start: 17, Stop: 20}},
+			wantUsers: map[int64]int{1: 0, 2: 0, 3: 0, 4: 0},
+			wantDone:  4,
+		},
+		{
+			name: "users queue",
+			// two users resubmit at once, so each job waits out the other's; P5 is still running at the horizon
+			loop: ClosedLoop{Users: 2, Horizon: 9, Think: func(int) int64 { return 0 },
+				Job: func(int) (int64, int64) { return 2, 1 }},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 6},
+				{PID: 4, Start: 6, Stop: 8}, {PID: 5, Start: 8, Stop: 9}},
+			wantUsers: map[int64]int{1: 0, 2: 1, 3: 0, 4: 1, 5: 0, 6: 1},
+			wantDone:  4,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RunClosed(context.Background(), tt.loop, FCFSPolicy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Slices, tt.want) || !reflect.DeepEqual(got.Users, tt.wantUsers) {
+				t.Errorf("RunClosed() = %v %v, want %v %v", got.Slices, got.Users, tt.want, tt.wantUsers)
+			}
+			if len(got.PerProcess) != tt.wantDone {
+				t.Errorf("RunClosed() finished %d jobs, want %d", len(got.PerProcess), tt.wantDone)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package sched
+
+// EstimatedSJFPolicy is non-preemptive shortest-job-first as a real scheduler runs it, knowing only estimates of the
+// bursts, by PID: whenever the CPU is free, it runs the ready process with the least estimated burst to completion,
+// preferring the earliest arrival on a tie. A process without an estimate is taken at its true burst.
+func EstimatedSJFPolicy(estimates map[int64]int64) Policy {
+	return func(d Decision) (int64, error) {
+		for _, p := range d.Ready {
+			if p.ProcessID == d.Running {
+				return p.ProcessID, nil
+			}
+		}
+		return leastBy(d, func(p ProcessMetrics) int64 { return estimated(estimates, p) }), nil
+	}
+}
+
+// EstimatedSRTFPolicy is shortest-remaining-time-first knowing only estimates of the bursts, by PID: it runs the
+// ready process with the least estimated time left, its estimate less the time it has run, keeping the running
+// process on a tie and otherwise preferring the earliest arrival. A process that has outrun its estimate is expected
+// to finish at once, and one without an estimate is taken at its true burst.
+func EstimatedSRTFPolicy(estimates map[int64]int64) Policy {
+	return func(d Decision) (int64, error) {
+		return leastBy(d, func(p ProcessMetrics) int64 {
+			return max(estimated(estimates, p)-(p.Burst-p.BurstDuration), 0)
+		}), nil
+	}
+}
+
+// estimated returns the estimated whole burst of p, or its true one without an estimate.
+func estimated(estimates map[int64]int64, p ProcessMetrics) int64 {
+	if e, ok := estimates[p.ProcessID]; ok {
+		return e
+	}
+
+	return p.Burst
+}
@@ -0,0 +1,75 @@
+package sched
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestEstimatedPolicies(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4},
+	}
+	tests := []struct {
+		name      string
+		policy    Policy
+		estimates map[int64]int64
+		want      []TimeSlice
+	}{
+		{
+			name:   "sjf runs to completion",
+			policy: EstimatedSJFPolicy(map[int64]int64{1: 6, 2: 5, 3: 1}),
+			// P3's estimate puts it ahead of the truly shorter P2
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 6}, {PID: 3, Start: 6, Stop: 10}, {PID: 2, Start: 10, Stop: 12}},
+		},
+		{
+			name:   "srtf preempts",
+			policy: EstimatedSRTFPolicy(map[int64]int64{1: 6, 3: 1}),
+			// P3, expected to take 1, outruns its estimate and keeps the CPU until it finishes, and P2 is taken at
+			// its true burst
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 5}, {PID: 2, Start: 5, Stop: 7},
+				{PID: 1, Start: 7, Stop: 12}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RunPolicy(context.Background(), processes, tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Slices, tt.want) {
+				t.Errorf("RunPolicy() = %v, want %v", got.Slices, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimatedSRTFPolicy_exact(t *testing.T) {
+	t.Parallel()
+	processes := workload.Generate(rand.New(rand.NewSource(5)),
+		workload.GenerateOptions{Count: 15, MaxBurst: 9, MaxArrival: 25, MaxPriority: 3})
+	estimates := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		estimates[p.ProcessID] = p.BurstDuration
+	}
+	// exact estimates schedule exactly as SRTF does
+	want, err := RunPolicy(context.Background(), processes, SRTFPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := RunPolicy(context.Background(), processes, EstimatedSRTFPolicy(estimates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EstimatedSRTFPolicy() = %v, want %v", got.Slices, want.Slices)
+	}
+}
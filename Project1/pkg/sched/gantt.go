@@ -0,0 +1,192 @@
+package sched
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ErrInvalidGantt marks a Gantt chart that cannot be the schedule of its workload.
+var ErrInvalidGantt = errors.New("invalid Gantt chart")
+
+// MergeSlices returns gantt with every run of back-to-back slices of the same process, and of the same kind, joined
+// into one slice. Empty slices are dropped.
+func MergeSlices(gantt []TimeSlice) []TimeSlice {
+	merged := make([]TimeSlice, 0, len(gantt))
+	for _, s := range gantt {
+		if s.Stop <= s.Start {
+			continue
+		}
+		if n := len(merged); n > 0 && merged[n-1].PID == s.PID && merged[n-1].Switch == s.Switch &&
+			merged[n-1].Stop == s.Start {
+			merged[n-1].Stop = s.Stop
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+// ValidateGantt checks that gantt is a schedule of the finished processes done: its slices are non-empty, in time
+// order, and never overlap; each process runs only after it arrives and for exactly its burst; and it finishes at its
+// Completion. It returns every problem found, joined, each an ErrInvalidGantt.
+func ValidateGantt(gantt []TimeSlice, done []workload.Process) error {
+	var (
+		problems []error
+		ran      = make(map[int64]int64) // time each PID has run
+		last     = make(map[int64]int64) // when each PID last stopped running
+		known    = make(map[int64]workload.Process, len(done))
+	)
+	for _, p := range done {
+		known[p.ProcessID] = p
+	}
+	for i, s := range gantt {
+		if s.Stop <= s.Start {
+			problems = append(problems, fmt.Errorf("%w: slice %d of P%d is empty or reversed (%d-%d)",
+				ErrInvalidGantt, i, s.PID, s.Start, s.Stop))
+		}
+		if i > 0 && s.Start < gantt[i-1].Stop {
+			problems = append(problems, fmt.Errorf("%w: slice %d of P%d at %d overlaps P%d until %d",
+				ErrInvalidGantt, i, s.PID, s.Start, gantt[i-1].PID, gantt[i-1].Stop))
+		}
+		if s.Switch {
+			continue
+		}
+		p, ok := known[s.PID]
+		if !ok {
+			problems = append(problems, fmt.Errorf("%w: slice %d runs P%d, which is not in the workload",
+				ErrInvalidGantt, i, s.PID))
+			continue
+		}
+		if s.Start < p.ArrivalTime {
+			problems = append(problems, fmt.Errorf("%w: P%d runs at %d before it arrives at %d",
+				ErrInvalidGantt, s.PID, s.Start, p.ArrivalTime))
+		}
+		ran[s.PID] += s.Stop - s.Start
+		last[s.PID] = s.Stop
+	}
+	for _, p := range done {
+		if ran[p.ProcessID] != p.Burst {
+			problems = append(problems, fmt.Errorf("%w: P%d runs for %d, want its burst of %d",
+				ErrInvalidGantt, p.ProcessID, ran[p.ProcessID], p.Burst))
+		} else if last[p.ProcessID] != p.Completion {
+			problems = append(problems, fmt.Errorf("%w: P%d last runs until %d, but completes at %d",
+				ErrInvalidGantt, p.ProcessID, last[p.ProcessID], p.Completion))
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// GanttDiff is a stretch of time two Gantt charts spend differently. A and B are the PIDs each runs then, or 0 when
+// its CPU is idle or switching.
+type GanttDiff struct {
+	Start int64
+	Stop  int64
+	A     int64
+	B     int64
+}
+
+// DiffGantt returns the stretches of time, in order, in which a and b run different processes. Charts that run the
+// same processes at the same times have no differences, however their slices are split.
+func DiffGantt(a, b []TimeSlice) []GanttDiff {
+	var (
+		diffs  []GanttDiff
+		ai, bi int
+		time   int64
+	)
+	// running returns the PID gantt runs at time, from slice i on, and when that stops being true
+	running := func(gantt []TimeSlice, i *int, time int64) (int64, int64) {
+		for *i < len(gantt) && gantt[*i].Stop <= time {
+			*i++
+		}
+		if *i == len(gantt) {
+			return 0, -1
+		}
+		s := gantt[*i]
+		if s.Start > time {
+			return 0, s.Start
+		}
+		if s.Switch {
+			return 0, s.Stop
+		}
+		return s.PID, s.Stop
+	}
+	for {
+		pa, untilA := running(a, &ai, time)
+		pb, untilB := running(b, &bi, time)
+		if untilA < 0 && untilB < 0 {
+			return diffs
+		}
+		next := untilA
+		if untilA < 0 || untilB >= 0 && untilB < untilA {
+			next = untilB
+		}
+		if pa != pb {
+			if n := len(diffs); n > 0 && diffs[n-1].Stop == time && diffs[n-1].A == pa && diffs[n-1].B == pb {
+				diffs[n-1].Stop = next
+			} else {
+				diffs = append(diffs, GanttDiff{Start: time, Stop: next, A: pa, B: pb})
+			}
+		}
+		time = next
+	}
+}
+
+// chart records the Gantt chart of a scheduler as it runs. With a SliceSink in the scheduler's context, it writes
+// every slice to the sink once the slice can no longer grow and keeps only the latest, so memory stays the same
+// however long the run.
+type chart struct {
+	slices []TimeSlice
+	sink   SliceSink
+	err    error // the first error from sink
+}
+
+func newChart(ctx context.Context) *chart {
+	sink, _ := ctx.Value(sliceSinkKey{}).(SliceSink)
+	return &chart{slices: make([]TimeSlice, 0), sink: sink}
+}
+
+// add records s, joining it to the slice before it when it carries straight on from it. Empty slices are dropped.
+func (c *chart) add(s TimeSlice) {
+	if s.Stop <= s.Start {
+		return
+	}
+	if n := len(c.slices); n > 0 && c.slices[n-1].PID == s.PID && c.slices[n-1].Switch == s.Switch &&
+		c.slices[n-1].Stop == s.Start {
+		c.slices[n-1].Stop = s.Stop
+		return
+	}
+	if c.sink != nil {
+		c.flush()
+	}
+	c.slices = append(c.slices, s)
+}
+
+// extend records pid running for the time unit starting at time.
+func (c *chart) extend(pid, time int64) {
+	c.add(TimeSlice{PID: pid, Start: time, Stop: time + 1})
+}
+
+// flush writes the slices kept so far to the sink and forgets them.
+func (c *chart) flush() {
+	for _, s := range c.slices {
+		if c.err == nil {
+			c.err = c.sink.WriteSlice(s)
+		}
+	}
+	c.slices = c.slices[:0]
+}
+
+// close returns the finished chart, or, when it was streamed, nil and the first error the sink returned.
+func (c *chart) close() ([]TimeSlice, error) {
+	if c.sink == nil {
+		return MergeSlices(c.slices), nil
+	}
+	c.flush()
+
+	return nil, c.err
+}
@@ -0,0 +1,116 @@
+package sched
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestMergeSlices(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 3},
+		{PID: 1, Start: 3, Stop: 4}, {PID: 2, Start: 4, Stop: 5, Switch: true}, {PID: 2, Start: 5, Stop: 6},
+		{PID: 2, Start: 7, Stop: 8},
+	}
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 5, Switch: true}, {PID: 2, Start: 5, Stop: 6},
+		{PID: 2, Start: 7, Stop: 8},
+	}
+	if got := MergeSlices(gantt); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeSlices() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateGantt(t *testing.T) {
+	t.Parallel()
+	done := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, Burst: 2, Completion: 2},
+		{ProcessID: 2, ArrivalTime: 1, Burst: 2, Completion: 5},
+	}
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		valid bool
+	}{
+		{name: "valid", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 3, Stop: 5}}, valid: true},
+		{
+			name:  "valid with switch overhead",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3, Switch: true}, {PID: 2, Start: 3, Stop: 5}},
+			valid: true,
+		},
+		{name: "overlap", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 1, Stop: 3}}},
+		{name: "before arrival", gantt: []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 4}}},
+		{name: "short burst", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 5}}},
+		{name: "wrong completion", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}}},
+		{name: "unknown process", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 5}}},
+		{name: "empty slice", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 3, Stop: 3}, {PID: 2, Start: 3, Stop: 5}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateGantt(tt.gantt, done)
+			if tt.valid && err != nil || !tt.valid && !errors.Is(err, ErrInvalidGantt) {
+				t.Errorf("ValidateGantt() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+// TestValidateGantt_schedulers checks every scheduler's Gantt chart against its own results on random workloads.
+func TestValidateGantt_schedulers(t *testing.T) {
+	t.Parallel()
+	rr := func(q int64) Func {
+		return func(ctx context.Context, processes []workload.Process) (Result, error) { return RR(ctx, processes, q) }
+	}
+	schedulers := map[string]Func{"fcfs": FCFS, "sjf": SJF, "priority": Priority, "rr1": rr(1), "rr3": rr(3)}
+	for seed := int64(0); seed < 100; seed++ {
+		processes := workload.Generate(rand.New(rand.NewSource(seed)),
+			workload.GenerateOptions{Count: 8, MaxBurst: 6, MaxArrival: 15, MaxPriority: 3})
+		for name, schedule := range schedulers {
+			r, _ := schedule(context.Background(), processes)
+			if err := ValidateGantt(r.Slices, r.PerProcess); err != nil {
+				t.Fatalf("%s on seed %d: %v\n%v", name, seed, err, r.Slices)
+			}
+			if merged := MergeSlices(r.Slices); !reflect.DeepEqual(merged, r.Slices) {
+				t.Fatalf("%s on seed %d: fragmented Gantt chart %v", name, seed, r.Slices)
+			}
+		}
+	}
+}
+
+func TestDiffGantt(t *testing.T) {
+	t.Parallel()
+	a := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 6}}
+	tests := []struct {
+		name string
+		b    []TimeSlice
+		want []GanttDiff
+	}{
+		{name: "same but split differently", b: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 6}}},
+		{
+			name: "swapped",
+			b:    []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 6}},
+			want: []GanttDiff{{Start: 0, Stop: 2, A: 1, B: 2}, {Start: 4, Stop: 6, A: 2, B: 1}},
+		},
+		{
+			name: "idle and longer",
+			b:    []TimeSlice{{PID: 1, Start: 1, Stop: 5}, {PID: 2, Start: 5, Stop: 7}},
+			want: []GanttDiff{{Start: 0, Stop: 1, A: 1, B: 0}, {Start: 4, Stop: 5, A: 2, B: 1}, {Start: 6, Stop: 7, A: 0, B: 2}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := DiffGantt(a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffGantt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package sched
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// Lottery gives every ready process tickets by priority and draws a winner from rng to run for each time unit, so
+// each process gets the CPU in proportion to its tickets on average. processes must be in arrival order.
+func Lottery(ctx context.Context, processes []workload.Process, rng *rand.Rand) (Result, error) {
+	return RunPolicy(ctx, processes, LotteryPolicy(rng))
+}
+
+// LotteryPolicy draws the next process from rng. The lowest priority value ready gets the most tickets: a process
+// holds one ticket plus one for every priority level it is above the least important ready process.
+func LotteryPolicy(rng *rand.Rand) Policy {
+	return func(d Decision) (int64, error) {
+		var least int64
+		for _, p := range d.Ready {
+			least = max(least, p.Priority)
+		}
+		var total int64
+		for _, p := range d.Ready {
+			total += least - p.Priority + 1
+		}
+		draw := rng.Int63n(total)
+		for _, p := range d.Ready {
+			if draw -= least - p.Priority + 1; draw < 0 {
+				return p.ProcessID, nil
+			}
+		}
+
+		return d.Ready[len(d.Ready)-1].ProcessID, nil
+	}
+}
+
+// NewRand returns the random source for one randomized component, such as the lottery draws. Each component draws
+// from its own stream, named by stream and derived from seed, so the same seed reproduces every component however
+// many of them a run uses and in whatever order concurrently running algorithms reach them. The empty stream uses
+// seed as it is.
+func NewRand(seed int64, stream string) *rand.Rand {
+	if stream != "" {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(stream))
+		seed ^= int64(h.Sum64())
+	}
+
+	return rand.New(rand.NewSource(seed))
+}
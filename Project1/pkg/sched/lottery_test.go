@@ -0,0 +1,49 @@
+package sched
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestLottery(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 30, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 30, Priority: 3},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 4, Priority: 2},
+	}
+	r, err := Lottery(context.Background(), processes, rand.New(rand.NewSource(1)))
+	done := r.PerProcess
+	if len(done) != len(processes) || err != nil {
+		t.Fatalf("lottery finished %d of %d processes (stopped: %v)", len(done), len(processes), err)
+	}
+	again, _ := Lottery(context.Background(), processes, rand.New(rand.NewSource(1)))
+	for i := range done {
+		if done[i] != again.PerProcess[i] {
+			t.Errorf("the same seed scheduled P%d differently: %+v and %+v", done[i].ProcessID, done[i], again.PerProcess[i])
+		}
+	}
+}
+
+func TestLotteryPolicy(t *testing.T) {
+	t.Parallel()
+	d := Decision{Ready: []workload.Process{
+		{ProcessID: 1, Priority: 1}, // 3 tickets
+		{ProcessID: 2, Priority: 3}, // 1 ticket
+	}}
+	choose := LotteryPolicy(rand.New(rand.NewSource(1)))
+	wins := map[int64]int{}
+	for i := 0; i < 4000; i++ {
+		pid, err := choose(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wins[pid]++
+	}
+	if wins[1] < 2800 || wins[1] > 3200 {
+		t.Errorf("P1 with 3 of 4 tickets won %d of 4000 draws, want about 3000", wins[1])
+	}
+}
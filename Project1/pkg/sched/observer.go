@@ -0,0 +1,123 @@
+package sched
+
+import (
+	"context"
+	"log/slog"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// Observer is told about every event of a simulation as it happens, to trace, animate, stream, or grade a run
+// without changing the schedulers. Events are delivered in time order; at the same time, a completion comes first,
+// then arrivals, then any preemption and the dispatch. Each is passed a copy of the process as it stands, with
+// BurstDuration the burst remaining.
+type Observer interface {
+	// OnArrival is called when p joins the ready queue.
+	OnArrival(time int64, p workload.Process)
+	// OnDispatch is called when p is given the CPU, having not had it the time unit before.
+	OnDispatch(time int64, p workload.Process)
+	// OnPreempt is called when p loses the CPU to another process before finishing.
+	OnPreempt(time int64, p workload.Process)
+	// OnComplete is called when p finishes, with its Wait, Turnaround, and Completion filled in.
+	OnComplete(time int64, p workload.Process)
+}
+
+// NopObserver ignores every event. Embed it to implement only the Observer methods of interest.
+type NopObserver struct{}
+
+func (NopObserver) OnArrival(int64, workload.Process)  {}
+func (NopObserver) OnDispatch(int64, workload.Process) {}
+func (NopObserver) OnPreempt(int64, workload.Process)  {}
+func (NopObserver) OnComplete(int64, workload.Process) {}
+
+type observersKey struct{}
+
+// WithObserver returns a context carrying o, after any observers ctx already carries, for the schedulers to notify.
+func WithObserver(ctx context.Context, o Observer) context.Context {
+	observers := observersFrom(ctx)
+	return context.WithValue(ctx, observersKey{}, append(observers[:len(observers):len(observers)], o))
+}
+
+func observersFrom(ctx context.Context) []Observer {
+	observers, _ := ctx.Value(observersKey{}).([]Observer)
+	return observers
+}
+
+// emitter delivers a scheduler's events to the observers in its context, and to the logger at debug level. It keeps
+// track of the running process to turn a change of process into a preemption and a dispatch. Its methods cost one
+// check when nothing is listening.
+type emitter struct {
+	observers []Observer
+	running   workload.Process
+	since     int64 // when running was dispatched
+}
+
+func newEmitter(ctx context.Context) *emitter {
+	observers := observersFrom(ctx)
+	if l := LoggerFrom(ctx); l.Enabled(ctx, slog.LevelDebug) {
+		observers = append(observers[:len(observers):len(observers)], logObserver{ctx: ctx, l: l})
+	}
+
+	return &emitter{observers: observers}
+}
+
+// arrive reports p joining the ready queue.
+func (e *emitter) arrive(p workload.Process) {
+	for _, o := range e.observers {
+		o.OnArrival(p.ArrivalTime, p)
+	}
+}
+
+// dispatch reports p running from time, preempting the process running before unless it is p itself or finished.
+func (e *emitter) dispatch(time int64, p workload.Process) {
+	if len(e.observers) == 0 || p.ProcessID == e.running.ProcessID {
+		return
+	}
+	if e.running.ProcessID != 0 {
+		preempted := e.running
+		preempted.BurstDuration -= time - e.since
+		for _, o := range e.observers {
+			o.OnPreempt(time, preempted)
+		}
+	}
+	e.running, e.since = p, time
+	for _, o := range e.observers {
+		o.OnDispatch(time, p)
+	}
+}
+
+// resume picks up where a paused simulation left off, with p as it was when dispatched at since and still running.
+func (e *emitter) resume(since int64, p workload.Process) {
+	e.running, e.since = p, since
+}
+
+// complete reports p finishing at its Completion time.
+func (e *emitter) complete(p workload.Process) {
+	if len(e.observers) == 0 {
+		return
+	}
+	if p.ProcessID == e.running.ProcessID {
+		e.running = workload.Process{}
+	}
+	p.BurstDuration = 0 // FCFS never counts it down
+	for _, o := range e.observers {
+		o.OnComplete(p.Completion, p)
+	}
+}
+
+// logObserver records dispatches and completions at debug level.
+type logObserver struct {
+	NopObserver
+	ctx context.Context
+	l   *slog.Logger
+}
+
+func (o logObserver) OnDispatch(time int64, p workload.Process) {
+	o.l.LogAttrs(o.ctx, slog.LevelDebug, "dispatch", slog.Int64("time", time), slog.Int64("pid", p.ProcessID))
+}
+
+func (o logObserver) OnComplete(time int64, p workload.Process) {
+	o.l.LogAttrs(o.ctx, slog.LevelDebug, "finish",
+		slog.Int64("time", time), slog.Int64("pid", p.ProcessID),
+		slog.Int64("wait", p.Wait), slog.Int64("turnaround", p.Turnaround))
+}
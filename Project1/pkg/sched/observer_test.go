@@ -0,0 +1,83 @@
+package sched
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// recorder is an Observer writing down every event as "event time P<pid> remaining".
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) record(event string, time int64, p workload.Process) {
+	r.events = append(r.events, fmt.Sprintf("%s %d P%d %d", event, time, p.ProcessID, p.BurstDuration))
+}
+
+func (r *recorder) OnArrival(time int64, p workload.Process)  { r.record("arrive", time, p) }
+func (r *recorder) OnDispatch(time int64, p workload.Process) { r.record("dispatch", time, p) }
+func (r *recorder) OnPreempt(time int64, p workload.Process)  { r.record("preempt", time, p) }
+func (r *recorder) OnComplete(time int64, p workload.Process) { r.record("complete", time, p) }
+
+func TestObserver(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}
+	tests := []struct {
+		name     string
+		schedule Func
+		want     []string
+	}{
+		{
+			name:     "FCFS",
+			schedule: FCFS,
+			want: []string{
+				"arrive 0 P1 4", "dispatch 0 P1 4", "arrive 1 P2 1", "complete 4 P1 0",
+				"dispatch 4 P2 1", "complete 5 P2 0",
+			},
+		},
+		{
+			name:     "SJF",
+			schedule: SJF,
+			want: []string{
+				"arrive 0 P1 4", "dispatch 0 P1 4", "arrive 1 P2 1", "preempt 1 P1 3", "dispatch 1 P2 1",
+				"complete 2 P2 0", "dispatch 2 P1 3", "complete 5 P1 0",
+			},
+		},
+		{
+			name: "policy",
+			schedule: func(ctx context.Context, processes []workload.Process) (Result, error) {
+				return RunPolicy(ctx, processes, func(d Decision) (int64, error) {
+					return d.Ready[len(d.Ready)-1].ProcessID, nil
+				})
+			},
+			want: []string{
+				"arrive 0 P1 4", "dispatch 0 P1 4", "arrive 1 P2 1", "preempt 1 P1 3", "dispatch 1 P2 1",
+				"complete 2 P2 0", "dispatch 2 P1 3", "complete 5 P1 0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var first, second recorder
+			ctx := WithObserver(WithObserver(context.Background(), &first), &second)
+			if _, err := tt.schedule(ctx, processes); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(first.events, tt.want) {
+				t.Errorf("events = %q, want %q", first.events, tt.want)
+			}
+			if !reflect.DeepEqual(second.events, first.events) {
+				t.Errorf("second observer saw %q, first saw %q", second.events, first.events)
+			}
+		})
+	}
+}
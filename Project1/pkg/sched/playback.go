@@ -0,0 +1,208 @@
+package sched
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// EventKind is what happened in an Event.
+type EventKind int
+
+const (
+	EventComplete EventKind = iota
+	EventArrival
+	EventPreempt
+	EventDispatch
+)
+
+var eventKindNames = [...]string{EventComplete: "complete", EventArrival: "arrival", EventPreempt: "preempt", EventDispatch: "dispatch"}
+
+// String returns the lowercase name of k, such as "dispatch".
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventKindNames) {
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+
+	return eventKindNames[k]
+}
+
+// Event is one event of a finished run, as an Observer would have been told about it.
+type Event struct {
+	Time    int64
+	Kind    EventKind
+	Process workload.Process
+}
+
+// Deliver calls the method of o for e.
+func (e Event) Deliver(o Observer) {
+	switch e.Kind {
+	case EventArrival:
+		o.OnArrival(e.Time, e.Process)
+	case EventDispatch:
+		o.OnDispatch(e.Time, e.Process)
+	case EventPreempt:
+		o.OnPreempt(e.Time, e.Process)
+	case EventComplete:
+		o.OnComplete(e.Time, e.Process)
+	}
+}
+
+// Events reconstructs the events of the finished run r from its Gantt chart and processes, in the order a scheduler
+// delivers them to its observers. Arrived processes carry their input fields; dispatched and preempted ones also
+// their Burst, the BurstDuration remaining, and the Wait so far; completed ones are as in r.PerProcess.
+func Events(r Result) []Event {
+	var (
+		events    []Event
+		remaining = make(map[int64]int64, len(r.PerProcess))
+		done      = make(map[int64]workload.Process, len(r.PerProcess))
+	)
+	for _, p := range r.PerProcess {
+		remaining[p.ProcessID] = p.Burst
+		done[p.ProcessID] = p
+		arrived := workload.Process{
+			ProcessID: p.ProcessID, ArrivalTime: p.ArrivalTime, BurstDuration: p.Burst, Priority: p.Priority,
+			Deadline: p.Deadline,
+		}
+		events = append(events, Event{Time: p.ArrivalTime, Kind: EventArrival, Process: arrived})
+		completed := p
+		completed.BurstDuration = 0
+		events = append(events, Event{Time: p.Completion, Kind: EventComplete, Process: completed})
+	}
+
+	// state returns p as it stands at time, before it runs again
+	state := func(pid, time int64) workload.Process {
+		p := done[pid]
+		ran := p.Burst - remaining[pid]
+		return workload.Process{
+			ProcessID: pid, ArrivalTime: p.ArrivalTime, BurstDuration: remaining[pid], Priority: p.Priority,
+			Deadline: p.Deadline, Burst: p.Burst, Wait: time - p.ArrivalTime - ran,
+		}
+	}
+	var running int64 // PID with the CPU, or 0
+	for _, s := range MergeSlices(r.Slices) {
+		if _, ok := done[s.PID]; s.Switch || !ok {
+			continue
+		}
+		if s.PID != running {
+			if running != 0 && remaining[running] > 0 {
+				events = append(events, Event{Time: s.Start, Kind: EventPreempt, Process: state(running, s.Start)})
+			}
+			events = append(events, Event{Time: s.Start, Kind: EventDispatch, Process: state(s.PID, s.Start)})
+			running = s.PID
+		}
+		remaining[s.PID] -= s.Stop - s.Start
+	}
+
+	// arrivals at the same time come in arrival order, and every other event is already in time order
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Kind == EventArrival && a.Process.ProcessID < b.Process.ProcessID
+	})
+
+	return events
+}
+
+// Player replays the events of a finished run against the wall clock, delivering each to observers once its time
+// comes, for animations and live demos. Its speed can be changed and it can be paused while it plays.
+type Player struct {
+	events []Event
+	mu     sync.Mutex
+	speed  float64 // time units per second
+	paused bool
+	wake   chan struct{} // signals Play that the speed or pause changed
+}
+
+// NewPlayer returns a Player for r that plays speed time units per second.
+func NewPlayer(r Result, speed float64) *Player {
+	return &Player{events: Events(r), speed: speed, wake: make(chan struct{}, 1)}
+}
+
+// SetSpeed changes how many time units play per second; a speed of 0 or less pauses.
+func (p *Player) SetSpeed(speed float64) {
+	p.mu.Lock()
+	p.speed = speed
+	p.mu.Unlock()
+	p.notify()
+}
+
+// Speed returns how many time units play per second.
+func (p *Player) Speed() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.speed
+}
+
+// SetPaused pauses or resumes playback.
+func (p *Player) SetPaused(paused bool) {
+	p.mu.Lock()
+	p.paused = paused
+	p.mu.Unlock()
+	p.notify()
+}
+
+func (p *Player) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Play delivers every event to observers at its time, from time 0, and returns once the last has been delivered, or
+// with the cause if ctx ends first. Events due at the same time are delivered together.
+func (p *Player) Play(ctx context.Context, observers ...Observer) error {
+	var (
+		position float64 // simulated time played so far
+		last     = time.Now()
+	)
+	for i := 0; i < len(p.events); {
+		if float64(p.events[i].Time) <= position {
+			for _, o := range observers {
+				p.events[i].Deliver(o)
+			}
+			i++
+			continue
+		}
+
+		p.mu.Lock()
+		speed, paused := p.speed, p.paused || p.speed <= 0
+		p.mu.Unlock()
+		var (
+			timer *time.Timer
+			fired <-chan time.Time // stays nil, never firing, while paused
+		)
+		if !paused {
+			timer = time.NewTimer(time.Duration((float64(p.events[i].Time) - position) / speed * float64(time.Second)))
+			fired = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return context.Cause(ctx)
+		case <-fired:
+			position, last = float64(p.events[i].Time), time.Now()
+		case <-p.wake:
+			// play the time since the last update at the old speed, then go round again at the new one
+			now := time.Now()
+			if !paused {
+				position += now.Sub(last).Seconds() * speed
+				timer.Stop()
+			}
+			last = now
+		}
+	}
+
+	return nil
+}
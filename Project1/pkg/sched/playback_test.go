@@ -0,0 +1,107 @@
+package sched
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// TestEvents checks that replaying a result tells an observer exactly what the scheduler told it live.
+func TestEvents(t *testing.T) {
+	t.Parallel()
+	schedulers := map[string]Func{
+		"fcfs": FCFS, "sjf": SJF, "priority": Priority,
+		"rr": func(ctx context.Context, processes []workload.Process) (Result, error) { return RR(ctx, processes, 2) },
+		"srtf policy": func(ctx context.Context, processes []workload.Process) (Result, error) {
+			return RunPolicy(ctx, processes, SRTFPolicy)
+		},
+	}
+	for seed := int64(0); seed < 50; seed++ {
+		processes := workload.Generate(rand.New(rand.NewSource(seed)),
+			workload.GenerateOptions{Count: 8, MaxBurst: 6, MaxArrival: 20, MaxPriority: 3})
+		for name, schedule := range schedulers {
+			var live, replayed recorder
+			r, err := schedule(WithObserver(context.Background(), &live), processes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range Events(r) {
+				e.Deliver(&replayed)
+			}
+			if !reflect.DeepEqual(replayed.events, live.events) {
+				t.Fatalf("%s on seed %d replayed\n%v\nwant\n%v", name, seed, replayed.events, live.events)
+			}
+		}
+	}
+}
+
+// clock is an Observer noting when each event is delivered.
+type clock struct {
+	NopObserver
+	mu        sync.Mutex
+	start     time.Time
+	completed []time.Duration
+}
+
+func (c *clock) OnComplete(int64, workload.Process) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.completed = append(c.completed, time.Since(c.start))
+}
+
+func (c *clock) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.completed)
+}
+
+func TestPlayer(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 10},
+	}
+	r, err := FCFS(context.Background(), processes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// at 200 time units a second, P1 completes at 50ms and P2 at 100ms
+	p := NewPlayer(r, 200)
+	c := &clock{start: time.Now()}
+	if err := p.Play(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.completed) != 2 || c.completed[0] < 50*time.Millisecond || c.completed[1] < 100*time.Millisecond {
+		t.Errorf("completions played at %v, want after 50ms and 100ms", c.completed)
+	}
+
+	// paused, nothing plays until resumed, and then at the new speed
+	p = NewPlayer(r, 200)
+	p.SetPaused(true)
+	c = &clock{start: time.Now()}
+	done := make(chan error)
+	go func() { done <- p.Play(context.Background(), c) }()
+	time.Sleep(150 * time.Millisecond)
+	if n := c.count(); n != 0 {
+		t.Fatalf("%d completions played while paused", n)
+	}
+	p.SetSpeed(1e6)
+	p.SetPaused(false)
+	if err := <-done; err != nil || c.count() != 2 {
+		t.Errorf("Play() = %v with %d completions after resuming", err, c.count())
+	}
+
+	// an ended context stops playback
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := NewPlayer(r, 1).Play(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Play() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
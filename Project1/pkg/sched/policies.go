@@ -0,0 +1,107 @@
+package sched
+
+// FCFSPolicy runs each process to completion in arrival order, the policy engine's first-come, first-serve.
+func FCFSPolicy(d Decision) (int64, error) {
+	for _, p := range d.Ready {
+		if p.ProcessID == d.Running {
+			return p.ProcessID, nil
+		}
+	}
+
+	return d.Ready[0].ProcessID, nil
+}
+
+// SRTFPolicy runs the ready process with the least remaining burst, shortest-remaining-time-first, keeping the
+// running process on a tie and otherwise preferring the earliest arrival.
+func SRTFPolicy(d Decision) (int64, error) {
+	return leastBy(d, func(p ProcessMetrics) int64 { return p.BurstDuration }), nil
+}
+
+// PriorityPolicy runs the ready process with the lowest priority value, keeping the running process on a tie and
+// otherwise preferring the earliest arrival.
+func PriorityPolicy(d Decision) (int64, error) {
+	return leastBy(d, func(p ProcessMetrics) int64 { return p.Priority }), nil
+}
+
+// leastBy returns the PID of the ready process with the smallest key, the running process if it is one of them, or
+// else the first in arrival order.
+func leastBy(d Decision, key func(ProcessMetrics) int64) int64 {
+	best := d.Ready[0]
+	for _, p := range d.Ready[1:] {
+		if k := key(p); k < key(best) || k == key(best) && p.ProcessID == d.Running {
+			best = p
+		}
+	}
+
+	return best.ProcessID
+}
+
+// RoundRobinPolicy runs each ready process for up to quantum(time) time units in turn, then sends it to the back of
+// the queue behind any process that arrived meanwhile, as RR does. The quantum is asked for at every decision, so it
+// can change partway through a run; a running process that has already had the new quantum is preempted at once. A
+// quantum below 1 is treated as 1. A process that leaves the ready queue to block loses its turn and rejoins at the
+// back. The policy keeps its own queue, so a new one is needed for every run.
+func RoundRobinPolicy(quantum func(time int64) int64) Policy {
+	return roundRobin(quantum, &RoundRobinState{})
+}
+
+// RoundRobinState is where a round-robin policy is in its rotation, kept in a Simulation so that a paused run resumes
+// with the same turns it would have taken had it never paused.
+type RoundRobinState struct {
+	Quantum int64
+	// Queue holds the ready PIDs waiting their turn, in order.
+	Queue []int64
+	// Current is the PID whose turn it is, or 0 before the first, and Used the time units of its turn it has had.
+	Current int64
+	Used    int64
+}
+
+// ResumableRoundRobinPolicy is RoundRobinPolicy with the constant quantum state.Quantum, keeping its rotation in
+// state as it goes. Continuing from a saved state needs a copy of it for every run.
+func ResumableRoundRobinPolicy(state *RoundRobinState) Policy {
+	return roundRobin(func(int64) int64 { return state.Quantum }, state)
+}
+
+func roundRobin(quantum func(time int64) int64, state *RoundRobinState) Policy {
+	queued := make(map[int64]bool, len(state.Queue)+1)
+	for _, pid := range state.Queue {
+		queued[pid] = true
+	}
+	if state.Current != 0 {
+		queued[state.Current] = true
+	}
+	return func(d Decision) (int64, error) {
+		ready := make(map[int64]bool, len(d.Ready))
+		for _, p := range d.Ready {
+			ready[p.ProcessID] = true
+		}
+		kept := state.Queue[:0]
+		for _, pid := range state.Queue {
+			if ready[pid] {
+				kept = append(kept, pid)
+			} else {
+				delete(queued, pid)
+			}
+		}
+		state.Queue = kept
+		if !ready[state.Current] {
+			delete(queued, state.Current)
+		}
+		for _, p := range d.Ready {
+			if !queued[p.ProcessID] {
+				queued[p.ProcessID] = true
+				state.Queue = append(state.Queue, p.ProcessID)
+			}
+		}
+		if ready[state.Current] {
+			if state.Used < max(quantum(d.Time), 1) {
+				state.Used++
+				return state.Current, nil
+			}
+			state.Queue = append(state.Queue, state.Current)
+		}
+		state.Current, state.Queue, state.Used = state.Queue[0], state.Queue[1:], 1
+
+		return state.Current, nil
+	}
+}
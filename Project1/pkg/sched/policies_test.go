@@ -0,0 +1,58 @@
+package sched
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestRoundRobinPolicy(t *testing.T) {
+	t.Parallel()
+	processes := workload.Generate(rand.New(rand.NewSource(3)),
+		workload.GenerateOptions{Count: 15, MaxBurst: 9, MaxArrival: 25, MaxPriority: 3})
+	for _, quantum := range []int64{0, 1, 2, 3, 5} {
+		quantum := quantum
+		// a constant quantum runs exactly as RR does
+		want, err := RR(context.Background(), processes, quantum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := RunPolicy(context.Background(), processes, RoundRobinPolicy(func(int64) int64 { return quantum }))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("quantum %d: %v, want %v", quantum, got.Slices, want.Slices)
+		}
+	}
+}
+
+func TestRoundRobinPolicy_changingQuantum(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}
+	// a quantum of 3 until time 2, then 1: P1 has had 2 units by then, so it is preempted at once
+	quantum := func(time int64) int64 {
+		if time < 2 {
+			return 3
+		}
+		return 1
+	}
+	got, err := RunPolicy(context.Background(), processes, RoundRobinPolicy(quantum))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4},
+		{PID: 2, Start: 4, Stop: 5}, {PID: 1, Start: 5, Stop: 6}, {PID: 2, Start: 6, Stop: 7},
+		{PID: 1, Start: 7, Stop: 8},
+	}
+	if !reflect.DeepEqual(got.Slices, want) {
+		t.Errorf("RunPolicy() = %v, want %v", got.Slices, want)
+	}
+}
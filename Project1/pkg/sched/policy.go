@@ -0,0 +1,48 @@
+package sched
+
+import (
+	"context"
+	"errors"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ErrNotReady is the reason a policy run stops when the policy chooses a process that is not ready.
+var ErrNotReady = errors.New("process not ready")
+
+// ErrBadSimulation marks a saved simulation that is not consistent enough to resume.
+var ErrBadSimulation = errors.New("bad simulation")
+
+// ErrUnchangeable marks a change to a paused simulation that cannot be made, such as to a process that has finished.
+var ErrUnchangeable = errors.New("cannot change process")
+
+// ErrNotBlocked marks a wake of a process that is not blocked.
+var ErrNotBlocked = errors.New("process not blocked")
+
+// ErrBadSubmission marks a process submitted to a simulation that cannot join it, such as one arriving in the past.
+var ErrBadSubmission = errors.New("cannot submit process")
+
+// Decision is what a policy sees when it picks the process to run for the next time unit.
+type Decision struct {
+	Time int64
+	// Running is the PID that ran the previous time unit, or 0 if the CPU was idle or just started.
+	Running int64
+	// Ready holds every arrived, unfinished process in arrival order. BurstDuration is the time it still needs,
+	// Burst its whole burst, and Wait how long it has waited so far. It is the engine's own queue, so a policy must
+	// not modify it.
+	Ready []workload.Process
+}
+
+// Policy chooses which ready process runs for the next time unit by returning its PID.
+type Policy func(d Decision) (int64, error)
+
+// RunPolicy simulates processes one time unit at a time, letting choose pick the running process at every unit, so
+// a policy only has to make decisions while the engine keeps the Gantt chart and metrics. processes must be in
+// arrival order. A policy error, or a choice of a process that is not ready, stops the run and is returned as the
+// reason it stopped.
+func RunPolicy(ctx context.Context, processes []workload.Process, choose Policy) (Result, error) {
+	sim := NewSimulation(processes)
+	err := sim.Run(ctx, choose, -1)
+
+	return sim.Result(), err
+}
@@ -0,0 +1,89 @@
+package sched
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestRunPolicy(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 9, BurstDuration: 1, Priority: 1},
+	}
+	first := func(d Decision) (int64, error) { return d.Ready[0].ProcessID, nil }
+	shortest := func(d Decision) (int64, error) {
+		best := d.Ready[0]
+		for _, p := range d.Ready[1:] {
+			if p.BurstDuration < best.BurstDuration {
+				best = p
+			}
+		}
+		return best.ProcessID, nil
+	}
+
+	tests := []struct {
+		name      string
+		choose    Policy
+		wantGantt []TimeSlice
+		wantWaits []int64
+	}{
+		{
+			name:      "first come",
+			choose:    first,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}, {PID: 3, Start: 9, Stop: 10}},
+			wantWaits: []int64{0, 3, 0},
+		},
+		{
+			name:   "shortest remaining",
+			choose: shortest,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 6}, {PID: 3, Start: 9, Stop: 10},
+			},
+			wantWaits: []int64{2, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := RunPolicy(context.Background(), processes, tt.choose)
+			gantt, done := r.Slices, r.PerProcess
+			if err != nil {
+				t.Fatalf("RunPolicy() stopped early: %v", err)
+			}
+			if !reflect.DeepEqual(gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", gantt, tt.wantGantt)
+			}
+			for i, p := range done {
+				if p.ProcessID != processes[i].ProcessID || p.Wait != tt.wantWaits[i] ||
+					p.Turnaround != p.Wait+p.Burst || p.Completion != p.ArrivalTime+p.Turnaround {
+					t.Errorf("process %d = %+v, want wait %d", i, p, tt.wantWaits[i])
+				}
+			}
+		})
+	}
+
+	// a policy that breaks the rules stops the run with its reason
+	bad := []struct {
+		name   string
+		choose Policy
+		want   string
+	}{
+		{name: "not ready", choose: func(Decision) (int64, error) { return 3, nil }, want: "chose P3, which is not ready"},
+		{name: "error", choose: func(Decision) (int64, error) { return 0, errors.New("plugin crashed") }, want: "plugin crashed"},
+	}
+	for _, tt := range bad {
+		r, err := RunPolicy(context.Background(), processes, tt.choose)
+		done := r.PerProcess
+		if err == nil || !strings.Contains(err.Error(), tt.want) || len(done) != 0 {
+			t.Errorf("%s: stopped with %v and %d processes, want %q and none", tt.name, err, len(done), tt.want)
+		}
+	}
+}
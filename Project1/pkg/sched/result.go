@@ -0,0 +1,83 @@
+package sched
+
+import "GolandProjects/Project1/pkg/workload"
+
+// Result is what a scheduler produces: the data of a run, with no rendering. Turning it into text, charts, or
+// encoded results is left to the caller, such as package report.
+type Result struct {
+	// Slices is the Gantt chart of what ran when, in time order.
+	Slices []TimeSlice
+	// PerProcess holds the finished processes in the order they finished.
+	PerProcess []ProcessMetrics
+	// Summary averages PerProcess.
+	Summary Metrics
+}
+
+// ProcessMetrics is a finished process: its input fields along with the Burst it ran for and the Wait, Turnaround,
+// and Completion the scheduler filled in.
+type ProcessMetrics = workload.Process
+
+// Metrics holds the run-wide averages shown in the schedule table footer.
+type Metrics struct {
+	Wait       float64
+	Turnaround float64
+	Normalized float64
+	Throughput float64
+}
+
+// NewResult assembles the Result of a run with the Gantt chart slices and the finished processes done, averaging
+// their metrics.
+func NewResult(slices []TimeSlice, done []ProcessMetrics) Result {
+	return Result{Slices: slices, PerProcess: done, Summary: Summarize(done)}
+}
+
+// Summarize averages the metrics of the finished processes done. Throughput counts them over the time until the
+// last one completed.
+func Summarize(done []ProcessMetrics) Metrics {
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		totalNormalized float64
+		lastCompletion  int64
+	)
+	for i := range done {
+		totalWait += float64(done[i].Wait)
+		totalTurnaround += float64(done[i].Turnaround)
+		totalNormalized += NormalizedTurnaround(done[i])
+		if done[i].Completion > lastCompletion {
+			lastCompletion = done[i].Completion
+		}
+	}
+
+	count := float64(len(done))
+	if count == 0 {
+		// nothing finished, so there is nothing to average
+		count = 1
+	}
+
+	return Metrics{
+		Wait:       totalWait / count,
+		Turnaround: totalTurnaround / count,
+		Normalized: totalNormalized / count,
+		Throughput: throughput(len(done), lastCompletion),
+	}
+}
+
+// throughput is how many processes finished per time unit over a run that ended at end.
+func throughput(finished int, end int64) float64 {
+	if end == 0 {
+		return 0
+	}
+
+	return float64(finished) / float64(end)
+}
+
+// NormalizedTurnaround is the turnaround of p relative to its burst, i.e. how many times longer than its own service
+// time the process spent in the system. A process that never waits scores 1.
+func NormalizedTurnaround(p ProcessMetrics) float64 {
+	if p.Burst == 0 {
+		return 0
+	}
+
+	return float64(p.Turnaround) / float64(p.Burst)
+}
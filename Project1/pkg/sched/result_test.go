@@ -0,0 +1,56 @@
+package sched
+
+import (
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+	done := []workload.Process{
+		{ProcessID: 1, Burst: 2, Wait: 0, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, Burst: 2, Wait: 2, Turnaround: 4, Completion: 4},
+	}
+	want := Metrics{Wait: 1, Turnaround: 3, Normalized: 1.5, Throughput: 0.5}
+	if got := Summarize(done); got != want {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+	if got := Summarize(nil); got != (Metrics{}) {
+		t.Errorf("Summarize(nil) = %+v, want zero", got)
+	}
+}
+
+func TestNormalizedTurnaround(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		p    workload.Process
+		want float64
+	}{
+		{
+			name: "no wait",
+			p:    workload.Process{Burst: 5, Turnaround: 5},
+			want: 1,
+		},
+		{
+			name: "waited twice its burst",
+			p:    workload.Process{Burst: 4, Wait: 8, Turnaround: 12},
+			want: 3,
+		},
+		{
+			name: "zero burst",
+			p:    workload.Process{Turnaround: 3},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NormalizedTurnaround(tt.p); got != tt.want {
+				t.Errorf("NormalizedTurnaround() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package sched simulates CPU scheduling algorithms over a workload, producing the Gantt chart of what ran when and
+// the finished processes with their waiting, turnaround, and completion times.
+//
+// Every scheduler takes a context: when it ends, the scheduler stops and returns what finished so far along with the
+// context's cause. The context can also carry a logger (WithLogger), Hooks (WithHooks), and Observers
+// (WithObserver) for watching a simulation as it runs, and a SliceSink (WithSliceSink) to stream the Gantt chart of a
+// huge run to disk rather than keep it in memory.
+package sched
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// TimeSlice is a stretch of time the CPU spent on one process.
+type TimeSlice struct {
+	PID   int64
+	Start int64
+	Stop  int64
+	// Switch marks context-switch overhead spent dispatching PID rather than time PID ran.
+	Switch bool
+	// Kernel marks time spent in the kernel on behalf of PID, on its system calls and the interrupts they raise,
+	// rather than running its own code.
+	Kernel bool `json:",omitempty"`
+}
+
+// Hooks are called by the schedulers as a simulation runs. Any of them may be nil.
+type Hooks struct {
+	// Decide is called before every scheduling decision takes effect, with the process about to run, the others
+	// ready, and the Gantt chart so far, which is only its latest slice while the chart is streamed to a SliceSink.
+	// It may block, to step through a simulation.
+	Decide func(time int64, running workload.Process, ready []workload.Process, gantt []TimeSlice)
+	// Advance is called with every stretch of simulated time as it passes, to track progress.
+	Advance func(units int64)
+}
+
+type (
+	hooksKey  struct{}
+	loggerKey struct{}
+)
+
+// WithHooks returns a context carrying h for the schedulers to call.
+func WithHooks(ctx context.Context, h Hooks) context.Context {
+	return context.WithValue(ctx, hooksKey{}, h)
+}
+
+func hooksFrom(ctx context.Context) Hooks {
+	h, _ := ctx.Value(hooksKey{}).(Hooks)
+	return h
+}
+
+func (h Hooks) decide(time int64, running workload.Process, ready []workload.Process, gantt []TimeSlice) {
+	if h.Decide != nil {
+		h.Decide(time, running, ready, gantt)
+	}
+}
+
+func (h Hooks) advance(units int64) {
+	if h.Advance != nil {
+		h.Advance(units)
+	}
+}
+
+// WithLogger returns a context carrying l for the schedulers to log their decisions through at debug level.
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// LoggerFrom returns the logger carried by ctx, or slog.Default.
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+
+	return slog.Default()
+}
+
+// result is what a scheduler returns: its Gantt chart with fragments merged, and if ctx ended the run early, the
+// Result of only the processes in done that finished, and the reason it ended. A chart streamed to a SliceSink is
+// left out, and the first error writing it is returned once the run is over.
+func result(ctx context.Context, gantt *chart, done []workload.Process) (Result, error) {
+	slices, err := gantt.close()
+	if err != nil {
+		err = fmt.Errorf("%w: streaming the Gantt chart", err)
+	}
+	if ctx.Err() == nil {
+		return NewResult(slices, done), err
+	}
+	finished := make([]workload.Process, 0, len(done))
+	for _, p := range done {
+		if p.ProcessID != 0 {
+			finished = append(finished, p)
+		}
+	}
+
+	return NewResult(slices, finished), context.Cause(ctx)
+}
+
+// arrivedBy returns the processes after i that have arrived by time, the ready queue of a run-to-completion
+// scheduler working through processes in order.
+func arrivedBy(processes []workload.Process, i int, time int64) []workload.Process {
+	j := i + 1
+	for j < len(processes) && processes[j].ArrivalTime <= time {
+		j++
+	}
+
+	return processes[i+1 : j]
+}
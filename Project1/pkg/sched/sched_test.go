@@ -0,0 +1,24 @@
+package sched
+
+import (
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_arrivedBy(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1}, {ProcessID: 2, ArrivalTime: 1}, {ProcessID: 3, ArrivalTime: 4}, {ProcessID: 4, ArrivalTime: 9},
+	}
+	got := arrivedBy(processes, 0, 4)
+	want := []int64{2, 3}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want PIDs %v", got, want)
+	}
+	for i := range want {
+		if got[i].ProcessID != want[i] {
+			t.Errorf("position %d is P%d, want P%d", i, got[i].ProcessID, want[i])
+		}
+	}
+}
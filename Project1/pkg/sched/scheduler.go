@@ -0,0 +1,146 @@
+package sched
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// Scheduler is a scheduling algorithm. Schedule simulates it on processes, which it must treat as read-only so the
+// same workload can be scheduled by several algorithms at once, and returns the Result of the run, or of what
+// finished before ctx ended along with its cause.
+type Scheduler interface {
+	Name() string
+	Schedule(ctx context.Context, processes []workload.Process) (Result, error)
+}
+
+// Func is the signature of the schedulers in this package once any settings, such as a time quantum, are bound.
+type Func func(ctx context.Context, processes []workload.Process) (Result, error)
+
+type funcScheduler struct {
+	name string
+	f    Func
+}
+
+// New returns a Scheduler named name that runs f. f is given its own copy of the processes, so it may use them as
+// scratch space without disturbing other runs of the same workload.
+func New(name string, f Func) Scheduler {
+	return funcScheduler{name: name, f: f}
+}
+
+func (s funcScheduler) Name() string {
+	return s.name
+}
+
+func (s funcScheduler) Schedule(ctx context.Context, processes []workload.Process) (Result, error) {
+	return s.f(ctx, append([]workload.Process(nil), processes...))
+}
+
+// RunAll runs every scheduler on processes concurrently, each on its own copy, and returns their results and errors
+// in the order of schedulers.
+func RunAll(ctx context.Context, schedulers []Scheduler, processes []workload.Process) ([]Result, []error) {
+	var (
+		results = make([]Result, len(schedulers))
+		errs    = make([]error, len(schedulers))
+		wg      sync.WaitGroup
+	)
+	for i := range schedulers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = schedulers[i].Schedule(ctx, append([]workload.Process(nil), processes...))
+		}(i)
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
+// Algorithm is a Scheduler along with the title its reports carry and a one-line description for listings.
+type Algorithm struct {
+	Scheduler
+	Title       string
+	Description string
+}
+
+var (
+	ErrUnknownAlgorithm   = errors.New("unknown algorithm")
+	ErrDuplicateScheduler = errors.New("duplicate scheduler")
+)
+
+// Registry is a set of algorithms looked up by their schedulers' names, kept in the order they were registered.
+// The zero Registry is empty and ready to use. A Registry is not safe for concurrent registration.
+type Registry struct {
+	algorithms []Algorithm
+}
+
+// NewRegistry returns a registry of algorithms. It panics if two share a name, as a mistake in the program.
+func NewRegistry(algorithms ...Algorithm) *Registry {
+	r := new(Registry)
+	for _, a := range algorithms {
+		if err := r.Register(a); err != nil {
+			panic(err)
+		}
+	}
+
+	return r
+}
+
+// Register adds a, which must not share its name with a registered algorithm, after the others.
+func (r *Registry) Register(a Algorithm) error {
+	if _, ok := r.Lookup(a.Name()); ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateScheduler, a.Name())
+	}
+	r.algorithms = append(r.algorithms, a)
+
+	return nil
+}
+
+// Lookup returns the algorithm named name.
+func (r *Registry) Lookup(name string) (Algorithm, bool) {
+	for _, a := range r.algorithms {
+		if a.Name() == name {
+			return a, true
+		}
+	}
+
+	return Algorithm{}, false
+}
+
+// Algorithms returns every registered algorithm in the order registered.
+func (r *Registry) Algorithms() []Algorithm {
+	return append([]Algorithm(nil), r.algorithms...)
+}
+
+// Names returns the names of the registered algorithms in the order registered.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.algorithms))
+	for i := range r.algorithms {
+		names[i] = r.algorithms[i].Name()
+	}
+
+	return names
+}
+
+// Select resolves a comma-separated list of names, case-insensitively and in the order given. An empty list
+// selects every algorithm.
+func (r *Registry) Select(list string) ([]Algorithm, error) {
+	if strings.TrimSpace(list) == "" {
+		return r.Algorithms(), nil
+	}
+	selected := make([]Algorithm, 0)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		a, ok := r.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("%w %q (want one of %s)", ErrUnknownAlgorithm, name, strings.Join(r.Names(), ","))
+		}
+		selected = append(selected, a)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,108 @@
+package sched
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry(
+		Algorithm{Scheduler: New("fcfs", FCFS), Title: "First-come, first-serve"},
+		Algorithm{Scheduler: New("sjf", SJF), Title: "Shortest-job-first"},
+	)
+	if err := r.Register(Algorithm{Scheduler: New("sjf", SJF)}); !errors.Is(err, ErrDuplicateScheduler) {
+		t.Errorf("registering sjf twice: error = %v, want %v", err, ErrDuplicateScheduler)
+	}
+	if err := r.Register(Algorithm{Scheduler: New("rr", func(ctx context.Context, processes []workload.Process) (Result, error) {
+		return RR(ctx, processes, 2)
+	})}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.Names(), []string{"fcfs", "sjf", "rr"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		list    string
+		want    []string
+		wantErr error
+	}{
+		{name: "default", want: []string{"fcfs", "sjf", "rr"}},
+		{name: "subset in given order", list: "rr, FCFS", want: []string{"rr", "fcfs"}},
+		{name: "unknown", list: "fcfs,mlfq", wantErr: ErrUnknownAlgorithm},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := r.Select(tt.list)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, a := range got {
+				names = append(names, a.Name())
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Select(%q) = %v, want %v", tt.list, names, tt.want)
+			}
+		})
+	}
+
+	a, ok := r.Lookup("fcfs")
+	if !ok || a.Title != "First-come, first-serve" {
+		t.Fatalf("Lookup(fcfs) = %+v, %v", a, ok)
+	}
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1}}
+	got, err := a.Schedule(context.Background(), processes)
+	want, _ := FCFS(context.Background(), processes)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("registered fcfs = %+v, %v, want %+v", got, err, want)
+	}
+}
+
+// TestRunAll runs every scheduler on one shared workload at once; run it with -race to check they never write to
+// their input or to each other's state.
+func TestRunAll(t *testing.T) {
+	t.Parallel()
+	processes := workload.Generate(rand.New(rand.NewSource(7)),
+		workload.GenerateOptions{Count: 60, MaxBurst: 8, MaxArrival: 120, MaxPriority: 4})
+	original := append([]workload.Process(nil), processes...)
+	schedulers := []Scheduler{
+		New("fcfs", FCFS),
+		New("sjf", SJF),
+		New("priority", Priority),
+		New("rr", func(ctx context.Context, processes []workload.Process) (Result, error) {
+			return RR(ctx, processes, 3)
+		}),
+		New("srtf", func(ctx context.Context, processes []workload.Process) (Result, error) {
+			return RunPolicy(ctx, processes, SRTFPolicy)
+		}),
+	}
+	// several copies of each, so the same algorithm also runs concurrently with itself
+	var all []Scheduler
+	for i := 0; i < 4; i++ {
+		all = append(all, schedulers...)
+	}
+
+	results, errs := RunAll(context.Background(), all, processes)
+	for i, s := range all {
+		if errs[i] != nil {
+			t.Fatalf("%s: %v", s.Name(), errs[i])
+		}
+		want, _ := s.Schedule(context.Background(), processes)
+		if !reflect.DeepEqual(results[i], want) {
+			t.Errorf("%s: concurrent result differs from running it alone", s.Name())
+		}
+	}
+	if !reflect.DeepEqual(processes, original) {
+		t.Errorf("running the schedulers modified the workload")
+	}
+}
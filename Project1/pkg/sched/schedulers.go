@@ -0,0 +1,202 @@
+package sched
+
+import (
+	"context"
+
+	"GolandProjects/Project1/pkg/queue"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// FCFS runs processes to completion one after another in the order given, which must be by arrival time.
+func FCFS(ctx context.Context, processes []workload.Process) (Result, error) {
+	var (
+		serviceTime int64
+		waitingTime int64
+		arrived     int // processes announced as arrived
+		done        = make([]workload.Process, len(processes))
+		gantt       = newChart(ctx)
+		events      = newEmitter(ctx)
+		hooks       = hooksFrom(ctx)
+	)
+	for i := range processes {
+		if ctx.Err() != nil {
+			break // out of time, so report the processes that finished
+		}
+		if processes[i].ArrivalTime > serviceTime {
+			// CPU sits idle until the next process arrives
+			serviceTime = processes[i].ArrivalTime
+		}
+		waitingTime = serviceTime - processes[i].ArrivalTime
+
+		start := waitingTime + processes[i].ArrivalTime
+		for ; arrived < len(processes) && processes[arrived].ArrivalTime <= start; arrived++ {
+			events.arrive(processes[arrived])
+		}
+		events.dispatch(start, processes[i])
+		if hooks.Decide != nil {
+			hooks.decide(start, processes[i], arrivedBy(processes, i, start), gantt.slices)
+		}
+
+		done[i] = processes[i]
+		done[i].Burst = processes[i].BurstDuration
+		done[i].Wait = waitingTime
+		done[i].Turnaround = processes[i].BurstDuration + waitingTime
+		done[i].Completion = processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		for ; arrived < len(processes) && processes[arrived].ArrivalTime < done[i].Completion; arrived++ {
+			events.arrive(processes[arrived])
+		}
+		events.complete(done[i])
+
+		serviceTime += processes[i].BurstDuration
+		hooks.advance(processes[i].BurstDuration)
+
+		gantt.add(TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+	}
+
+	return result(ctx, gantt, done)
+}
+
+// SJF runs the ready process with the least remaining burst for every time unit, keeping the running process on a
+// tie. processes must be in arrival order and numbered from 1.
+func SJF(ctx context.Context, processes []workload.Process) (Result, error) {
+	return preemptive(ctx, processes, func(p workload.Process) int64 { return p.BurstDuration })
+}
+
+// Priority runs the ready process with the lowest priority value for every time unit, keeping the running process
+// on a tie. processes must be in arrival order and numbered from 1.
+func Priority(ctx context.Context, processes []workload.Process) (Result, error) {
+	return preemptive(ctx, processes, func(p workload.Process) int64 { return p.Priority })
+}
+
+// preemptive runs the ready process with the lowest key for every time unit. The running process keeps the CPU
+// unless another has a strictly lower key, and ties among the rest go to the one that arrived first.
+func preemptive(ctx context.Context, processes []workload.Process, key func(workload.Process) int64) (Result, error) {
+	var (
+		done     = make([]workload.Process, len(processes))
+		gantt    = newChart(ctx)
+		time     int64
+		arrived  int // processes added to the ready queue
+		finished int
+		running  workload.Process
+		busy     bool // whether running holds a process
+		ready    = queue.NewPriority(func(a, b workload.Process) bool {
+			return key(a) < key(b) || key(a) == key(b) && a.ProcessID < b.ProcessID
+		})
+		events = newEmitter(ctx)
+		hooks  = hooksFrom(ctx)
+	)
+	for finished < len(processes) {
+		if ctx.Err() != nil {
+			break // out of time, so report the processes that finished
+		}
+
+		for ; arrived < len(processes) && processes[arrived].ArrivalTime <= time; arrived++ {
+			p := processes[arrived]
+			p.Burst = p.BurstDuration
+			ready.Push(p)
+			events.arrive(processes[arrived])
+		}
+		if next, ok := ready.Peek(); ok && (!busy || key(next) < key(running)) {
+			if busy {
+				ready.Push(running)
+			}
+			running, busy = ready.Pop()
+		}
+		if !busy {
+			//nothing has arrived yet so the CPU sits idle for this tick
+			time++
+			continue
+		}
+		if hooks.Decide != nil {
+			hooks.decide(time, running, ready.Items(), gantt.slices)
+		}
+		events.dispatch(time, running)
+		gantt.extend(running.ProcessID, time)
+		time++
+		hooks.advance(1)
+
+		running.BurstDuration--
+		ready.Each(func(p *workload.Process) { p.Wait++ })
+		if running.BurstDuration < 1 {
+			running.Turnaround = running.Wait + running.Burst
+			running.Completion = time
+			done[running.ProcessID-1] = running
+			events.complete(running)
+			busy = false
+			finished++
+		}
+	}
+
+	return result(ctx, gantt, done)
+}
+
+// RR runs round-robin: each process runs for up to timeQuantum time units, then goes to the back of the ready queue
+// behind any process that arrived meanwhile. A quantum below 1 is treated as 1. processes must be in arrival order
+// and numbered from 1.
+func RR(ctx context.Context, processes []workload.Process, timeQuantum int64) (Result, error) {
+	var (
+		done     = make([]workload.Process, len(processes))
+		gantt    = newChart(ctx)
+		time     int64
+		arrived  int // processes added to the ready queue
+		finished int
+		running  workload.Process
+		busy     bool  // whether running holds a process
+		used     int64 // time units of its quantum running has had
+		ready    queue.Queue[workload.Process]
+		events   = newEmitter(ctx)
+		hooks    = hooksFrom(ctx)
+	)
+	if timeQuantum < 1 {
+		timeQuantum = 1
+	}
+	for finished < len(processes) {
+		if ctx.Err() != nil {
+			break // out of time, so report the processes that finished
+		}
+
+		for ; arrived < len(processes) && processes[arrived].ArrivalTime <= time; arrived++ {
+			p := processes[arrived]
+			p.Burst = p.BurstDuration
+			ready.Push(p)
+			events.arrive(processes[arrived])
+		}
+		if busy && used == timeQuantum {
+			ready.Push(running)
+			busy = false
+		}
+		if !busy {
+			if running, busy = ready.Pop(); !busy {
+				//nothing has arrived yet so the CPU sits idle for this tick
+				time++
+				continue
+			}
+			used = 0
+		}
+		if hooks.Decide != nil {
+			hooks.decide(time, running, ready.Items(), gantt.slices)
+		}
+		events.dispatch(time, running)
+		gantt.extend(running.ProcessID, time)
+		time++
+		hooks.advance(1)
+
+		running.BurstDuration--
+		used++
+		ready.Each(func(p *workload.Process) { p.Wait++ })
+		if running.BurstDuration < 1 {
+			running.Turnaround = running.Wait + running.Burst
+			running.Completion = time
+			done[running.ProcessID-1] = running
+			events.complete(running)
+			busy = false
+			finished++
+		}
+	}
+
+	return result(ctx, gantt, done)
+}
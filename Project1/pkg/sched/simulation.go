@@ -0,0 +1,377 @@
+package sched
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// Simulation is the state of the policy engine between two time units: the clock, the processes still to arrive,
+// the ready queue and any blocked processes, and the Gantt chart and finished processes so far. It is all a run needs
+// to continue, so a simulation can be paused, saved, and resumed later, under the same policy or a different one.
+type Simulation struct {
+	Time int64
+	// Running is the PID that ran the previous time unit, or 0 if the CPU was idle or just started.
+	Running int64
+	// Workload holds the processes as given, in arrival order; the first Arrived of them have arrived.
+	Workload []workload.Process
+	Arrived  int
+	// Ready holds every arrived, unfinished process that is not blocked, in the order it arrived or stopped blocking,
+	// as in a Decision.
+	Ready []workload.Process
+	// Blocked holds the processes a Blocker took off the ready queue, in the order they stop blocking.
+	Blocked []Blocked `json:",omitempty"`
+	// Done holds the finished processes in the order they finished.
+	Done  []workload.Process
+	Gantt []TimeSlice
+	// RoundRobin is the rotation of the ResumableRoundRobinPolicy the simulation ran under, if it ran under one.
+	RoundRobin *RoundRobinState `json:",omitempty"`
+}
+
+// Blocked is a process off the ready queue until time Until, such as while a page fault it took is serviced, or
+// with Until -1 until it is woken, such as when an I/O request it made will take as long as its device gets to it.
+type Blocked struct {
+	Process workload.Process
+	Until   int64
+}
+
+// Blocker is asked before a ready process is given the CPU for a time unit whether it must block first, and for how
+// many time units. Returning 0 lets it run, and a negative number blocks it until Wake. A blocked process neither
+// runs nor waits, and rejoins the back of the ready queue when its time is up, to be asked again the next time it is
+// chosen.
+type Blocker func(time int64, p workload.Process) int64
+
+// NewSimulation returns a simulation of processes, which must be in arrival order, at time 0.
+func NewSimulation(processes []workload.Process) *Simulation {
+	return &Simulation{Workload: append([]workload.Process(nil), processes...), Gantt: make([]TimeSlice, 0)}
+}
+
+// LoadSimulation reads a simulation written by Save.
+func LoadSimulation(r io.Reader) (*Simulation, error) {
+	var s Simulation
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("%w: reading simulation", err)
+	}
+	if s.Arrived < 0 || s.Arrived > len(s.Workload) || len(s.Ready)+len(s.Blocked)+len(s.Done) > s.Arrived {
+		return nil, fmt.Errorf("%w: simulation has %d processes arrived of %d, %d ready, %d blocked, and %d done",
+			ErrBadSimulation, s.Arrived, len(s.Workload), len(s.Ready), len(s.Blocked), len(s.Done))
+	}
+	if s.RoundRobin != nil && s.RoundRobin.Quantum < 1 {
+		return nil, fmt.Errorf("%w: simulation has a round-robin quantum of %d", ErrBadSimulation, s.RoundRobin.Quantum)
+	}
+	if s.Gantt == nil {
+		s.Gantt = make([]TimeSlice, 0)
+	}
+
+	return &s, nil
+}
+
+// Save writes s as JSON for LoadSimulation.
+func (s *Simulation) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("%w: writing simulation", err)
+	}
+
+	return nil
+}
+
+// Clone returns a copy of s sharing nothing with it, to continue the same state more than once.
+func (s *Simulation) Clone() *Simulation {
+	c := *s
+	c.Workload = append([]workload.Process(nil), s.Workload...)
+	c.Ready = append([]workload.Process(nil), s.Ready...)
+	c.Blocked = append([]Blocked(nil), s.Blocked...)
+	c.Done = append([]workload.Process(nil), s.Done...)
+	c.Gantt = append(make([]TimeSlice, 0, len(s.Gantt)), s.Gantt...)
+	if s.RoundRobin != nil {
+		rr := *s.RoundRobin
+		rr.Queue = append([]int64(nil), s.RoundRobin.Queue...)
+		c.RoundRobin = &rr
+	}
+
+	return &c
+}
+
+// SetBurst changes the whole burst of the process pid to burst from now on, as if it had been given that burst from
+// the start. A process that has arrived keeps the time it has already run, so burst must be more than that; a
+// process that has finished can no longer be changed.
+func (s *Simulation) SetBurst(pid, burst int64) error {
+	i, err := s.changeable(pid)
+	if err != nil {
+		return err
+	}
+	if burst < 1 {
+		return fmt.Errorf("%w: P%d cannot have a burst of %d", ErrUnchangeable, pid, burst)
+	}
+	for _, p := range s.queued() {
+		if p.ProcessID == pid {
+			ran := p.Burst - p.BurstDuration
+			if burst <= ran {
+				return fmt.Errorf("%w: P%d has already run %d time units by time %d, so its burst cannot be %d",
+					ErrUnchangeable, pid, ran, s.Time, burst)
+			}
+			p.Burst, p.BurstDuration = burst, burst-ran
+		}
+	}
+	s.Workload[i].BurstDuration = burst
+
+	return nil
+}
+
+// SetPriority changes the priority of the process pid to priority from now on. A process that has finished can no
+// longer be changed.
+func (s *Simulation) SetPriority(pid, priority int64) error {
+	i, err := s.changeable(pid)
+	if err != nil {
+		return err
+	}
+	for _, p := range s.queued() {
+		if p.ProcessID == pid {
+			p.Priority = priority
+		}
+	}
+	s.Workload[i].Priority = priority
+
+	return nil
+}
+
+// queued returns the arrived, unfinished processes, ready or blocked, for changing in place.
+func (s *Simulation) queued() []*workload.Process {
+	queued := make([]*workload.Process, 0, len(s.Ready)+len(s.Blocked))
+	for i := range s.Ready {
+		queued = append(queued, &s.Ready[i])
+	}
+	for i := range s.Blocked {
+		queued = append(queued, &s.Blocked[i].Process)
+	}
+
+	return queued
+}
+
+// changeable returns the index in the workload of the process pid, or an error if there is no such process or it has
+// finished.
+func (s *Simulation) changeable(pid int64) (int, error) {
+	for _, p := range s.Done {
+		if p.ProcessID == pid {
+			return 0, fmt.Errorf("%w: P%d finished at time %d, before time %d", ErrUnchangeable, pid, p.Completion,
+				s.Time)
+		}
+	}
+	for i := range s.Workload {
+		if s.Workload[i].ProcessID == pid {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: there is no P%d", ErrUnchangeable, pid)
+}
+
+// Submit adds p to the processes still to arrive, keeping them in arrival order, as the users of a closed workload
+// submit jobs once their earlier ones finish. p must have a burst and a PID of its own and arrive no earlier than now.
+func (s *Simulation) Submit(p workload.Process) error {
+	if p.ArrivalTime < s.Time || p.BurstDuration < 1 {
+		return fmt.Errorf("%w: P%d arriving at %d with a burst of %d at time %d", ErrBadSubmission, p.ProcessID,
+			p.ArrivalTime, p.BurstDuration, s.Time)
+	}
+	for i := range s.Workload {
+		if s.Workload[i].ProcessID == p.ProcessID {
+			return fmt.Errorf("%w: there is already a P%d", ErrBadSubmission, p.ProcessID)
+		}
+	}
+	pending := s.Workload[s.Arrived:]
+	at := s.Arrived + sort.Search(len(pending), func(j int) bool { return pending[j].ArrivalTime > p.ArrivalTime })
+	s.Workload = append(s.Workload[:at], append([]workload.Process{p}, s.Workload[at:]...)...)
+
+	return nil
+}
+
+// Finished reports whether every process has finished.
+func (s *Simulation) Finished() bool {
+	return len(s.Done) == len(s.Workload)
+}
+
+// Run advances s one time unit at a time, letting choose pick the running process at every unit, until every
+// process has finished, the clock reaches until (unless until is negative), or ctx ends. It returns ctx's cause if
+// ctx ended the run, and the policy's error, or ErrNotReady for a choice of a process that is not ready, if a
+// decision failed; either way s is left as it was before the unit it stopped at, ready to resume.
+func (s *Simulation) Run(ctx context.Context, choose Policy, until int64) error {
+	return s.RunBlocking(ctx, choose, nil, until)
+}
+
+// RunBlocking is Run with block asked about every process choose picks before it runs, so processes can block, as
+// for page faults or I/O. A process that blocks gives up the time unit before it starts, and choose picks again from
+// the rest. A nil block never blocks.
+func (s *Simulation) RunBlocking(ctx context.Context, choose Policy, block Blocker, until int64) error {
+	var (
+		events = newEmitter(ctx)
+		hooks  = hooksFrom(ctx)
+	)
+	for i := range s.Ready {
+		if s.Ready[i].ProcessID == s.Running {
+			since := s.Time
+			if n := len(s.Gantt); n > 0 && s.Gantt[n-1].PID == s.Running && s.Gantt[n-1].Stop == s.Time {
+				since = s.Gantt[n-1].Start
+			}
+			dispatched := s.Ready[i]
+			dispatched.BurstDuration += s.Time - since
+			events.resume(since, dispatched)
+		}
+	}
+	for !s.Finished() && (until < 0 || s.Time < until) {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		s.admit(events)
+		if len(s.Ready) == 0 {
+			// nothing has arrived yet, or everything is blocked, so the CPU sits idle for this time unit
+			s.Time, s.Running = s.Time+1, 0
+			continue
+		}
+
+		pid, err := choose(Decision{Time: s.Time, Running: s.Running, Ready: s.Ready})
+		if err != nil {
+			return fmt.Errorf("%w: at time %d", err, s.Time)
+		}
+		chosen := -1
+		for i := range s.Ready {
+			if s.Ready[i].ProcessID == pid {
+				chosen = i
+				break
+			}
+		}
+		if chosen < 0 {
+			return fmt.Errorf("%w: at time %d the policy chose P%d, which is not ready", ErrNotReady, s.Time, pid)
+		}
+		if block != nil {
+			if units := block(s.Time, s.Ready[chosen]); units != 0 {
+				until := int64(-1)
+				if units > 0 {
+					until = s.Time + units
+				}
+				s.block(s.Ready[chosen], until)
+				s.Ready = append(s.Ready[:chosen], s.Ready[chosen+1:]...)
+				continue
+			}
+		}
+		if hooks.Decide != nil {
+			others := append(append([]workload.Process(nil), s.Ready[:chosen]...), s.Ready[chosen+1:]...)
+			hooks.decide(s.Time, s.Ready[chosen], others, s.Gantt)
+		}
+
+		if n := len(s.Gantt); n > 0 && s.Gantt[n-1].PID == pid && !s.Gantt[n-1].Switch && s.Gantt[n-1].Stop == s.Time {
+			s.Gantt[n-1].Stop++
+		} else {
+			s.Gantt = append(s.Gantt, TimeSlice{PID: pid, Start: s.Time, Stop: s.Time + 1})
+		}
+		for i := range s.Ready {
+			if i != chosen {
+				s.Ready[i].Wait++
+			}
+		}
+		events.dispatch(s.Time, s.Ready[chosen])
+		s.Time++
+		hooks.advance(1)
+		s.Running = pid
+		s.Ready[chosen].BurstDuration--
+		if s.Ready[chosen].BurstDuration < 1 {
+			p := s.Ready[chosen]
+			p.Completion = s.Time
+			p.Turnaround = p.Completion - p.ArrivalTime
+			s.Done = append(s.Done, p)
+			events.complete(p)
+			s.Ready = append(s.Ready[:chosen], s.Ready[chosen+1:]...)
+		}
+	}
+
+	return nil
+}
+
+// admit moves the processes that stop blocking or arrive by now to the back of the ready queue.
+func (s *Simulation) admit(events *emitter) {
+	for len(s.Blocked) > 0 && s.Blocked[0].Until >= 0 && s.Blocked[0].Until <= s.Time {
+		s.Ready = append(s.Ready, s.Blocked[0].Process)
+		s.Blocked = s.Blocked[1:]
+	}
+	for s.Arrived < len(s.Workload) && s.Workload[s.Arrived].ArrivalTime <= s.Time {
+		s.Ready = append(s.Ready, s.Workload[s.Arrived])
+		s.Ready[len(s.Ready)-1].Burst = s.Workload[s.Arrived].BurstDuration
+		events.arrive(s.Workload[s.Arrived])
+		s.Arrived++
+	}
+}
+
+// Stall spends units time units of the CPU on overhead on behalf of the process pid, such as servicing an interrupt
+// its I/O raised, or of no process with pid 0. No process runs meanwhile: the Gantt chart gets an overhead slice,
+// every ready process waits, and the process running before is preempted, so the policy decides again after it.
+func (s *Simulation) Stall(ctx context.Context, pid, units int64) {
+	if units <= 0 {
+		return
+	}
+	events, hooks := newEmitter(ctx), hooksFrom(ctx)
+	for ; units > 0; units-- {
+		s.admit(events)
+		if n := len(s.Gantt); n > 0 && s.Gantt[n-1].PID == pid && s.Gantt[n-1].Switch && s.Gantt[n-1].Stop == s.Time {
+			s.Gantt[n-1].Stop++
+		} else {
+			s.Gantt = append(s.Gantt, TimeSlice{PID: pid, Start: s.Time, Stop: s.Time + 1, Switch: true})
+		}
+		for i := range s.Ready {
+			s.Ready[i].Wait++
+		}
+		s.Time++
+		hooks.advance(1)
+	}
+	s.Running = 0
+}
+
+// block adds p to the blocked processes until the time until, or until woken if until is -1, keeping them in the
+// order they will stop blocking with those waiting to be woken last.
+func (s *Simulation) block(p workload.Process, until int64) {
+	at := len(s.Blocked)
+	if until >= 0 {
+		at = sort.Search(len(s.Blocked), func(j int) bool {
+			return s.Blocked[j].Until > until || s.Blocked[j].Until < 0
+		})
+	}
+	s.Blocked = append(s.Blocked[:at], append([]Blocked{{Process: p, Until: until}}, s.Blocked[at:]...)...)
+}
+
+// Wake ends the block of the process pid, which rejoins the back of the ready queue at the start of the next time unit
+// run. It returns ErrNotBlocked if pid is not blocked.
+func (s *Simulation) Wake(pid int64) error {
+	for i, b := range s.Blocked {
+		if b.Process.ProcessID == pid {
+			s.Blocked = append(s.Blocked[:i], s.Blocked[i+1:]...)
+			s.block(b.Process, s.Time)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: P%d at time %d", ErrNotBlocked, pid, s.Time)
+}
+
+// Result returns the result of the processes finished so far, in workload order.
+func (s *Simulation) Result() Result {
+	position := make(map[int64]int, len(s.Workload)) // index of each PID in the workload
+	for i := range s.Workload {
+		position[s.Workload[i].ProcessID] = i
+	}
+	done := make([]workload.Process, len(s.Workload))
+	for _, p := range s.Done {
+		done[position[p.ProcessID]] = p
+	}
+	finished := make([]workload.Process, 0, len(s.Done))
+	for _, p := range done {
+		if p.ProcessID != 0 {
+			finished = append(finished, p)
+		}
+	}
+
+	return NewResult(s.Gantt, finished)
+}
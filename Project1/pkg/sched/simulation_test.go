@@ -0,0 +1,318 @@
+package sched
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestSimulation(t *testing.T) {
+	t.Parallel()
+	processes := workload.Generate(rand.New(rand.NewSource(5)),
+		workload.GenerateOptions{Count: 12, MaxBurst: 6, MaxArrival: 20, MaxPriority: 3})
+	tests := []struct {
+		name   string
+		choose Policy
+	}{
+		{name: "fcfs", choose: FCFSPolicy},
+		{name: "srtf", choose: SRTFPolicy},
+		{name: "priority", choose: PriorityPolicy},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want, err := RunPolicy(context.Background(), processes, tt.choose)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// pausing at any time, saving, and resuming ends the same as running straight through
+			for _, at := range []int64{0, 1, 7, 15, 1000} {
+				paused := NewSimulation(processes)
+				if err := paused.Run(context.Background(), tt.choose, at); err != nil {
+					t.Fatal(err)
+				}
+				if paused.Time != min(at, want.Slices[len(want.Slices)-1].Stop) {
+					t.Errorf("paused at %d, want %d", paused.Time, at)
+				}
+				var saved bytes.Buffer
+				if err := paused.Save(&saved); err != nil {
+					t.Fatal(err)
+				}
+				resumed, err := LoadSimulation(&saved)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := resumed.Run(context.Background(), tt.choose, -1); err != nil {
+					t.Fatal(err)
+				}
+				if got := resumed.Result(); !reflect.DeepEqual(got, want) {
+					t.Errorf("resumed from %d: %+v, want %+v", at, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulation_roundRobin(t *testing.T) {
+	t.Parallel()
+	processes := workload.Generate(rand.New(rand.NewSource(5)),
+		workload.GenerateOptions{Count: 12, MaxBurst: 6, MaxArrival: 20, MaxPriority: 3})
+	for _, quantum := range []int64{1, 2, 3} {
+		want, err := RR(context.Background(), processes, quantum)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the rotation is saved with the simulation, so resuming mid-turn takes the same turns
+		for _, at := range []int64{1, 7, 15} {
+			paused := NewSimulation(processes)
+			paused.RoundRobin = &RoundRobinState{Quantum: quantum}
+			if err := paused.Run(context.Background(), ResumableRoundRobinPolicy(paused.RoundRobin), at); err != nil {
+				t.Fatal(err)
+			}
+			var saved bytes.Buffer
+			if err := paused.Save(&saved); err != nil {
+				t.Fatal(err)
+			}
+			resumed, err := LoadSimulation(&saved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := resumed.Run(context.Background(), ResumableRoundRobinPolicy(resumed.RoundRobin), -1); err != nil {
+				t.Fatal(err)
+			}
+			if got := resumed.Result(); !reflect.DeepEqual(got, want) {
+				t.Errorf("quantum %d resumed from %d: %v, want %v", quantum, at, got.Slices, want.Slices)
+			}
+		}
+	}
+}
+
+func TestSimulation_resumeEvents(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1},
+	}
+	sim := NewSimulation(processes)
+	if err := sim.Run(context.Background(), SRTFPolicy, 2); err != nil {
+		t.Fatal(err)
+	}
+	var events recorder
+	if err := sim.Clone().Run(WithObserver(context.Background(), &events), SRTFPolicy, -1); err != nil {
+		t.Fatal(err)
+	}
+	// P1 ran from 0, so at 2 it is preempted with 2 of its 4 units left
+	want := []string{"arrive 2 P2 1", "preempt 2 P1 2", "dispatch 2 P2 1", "complete 3 P2 0", "dispatch 3 P1 2",
+		"complete 5 P1 0"}
+	if !reflect.DeepEqual(events.events, want) {
+		t.Errorf("events = %q, want %q", events.events, want)
+	}
+}
+
+func TestLoadSimulation(t *testing.T) {
+	t.Parallel()
+	for _, in := range []string{`{"Arrived": 3, "Workload": [{"ProcessID": 1}]}`, `not json`,
+		`{"RoundRobin": {"Quantum": 0}}`} {
+		if _, err := LoadSimulation(strings.NewReader(in)); err == nil {
+			t.Errorf("LoadSimulation(%q) succeeded", in)
+		}
+	}
+	if _, err := LoadSimulation(strings.NewReader(`{"Arrived": -1}`)); !errors.Is(err, ErrBadSimulation) {
+		t.Errorf("error = %v, want %v", err, ErrBadSimulation)
+	}
+}
+
+func TestSimulation_SetBurst(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 3, ArrivalTime: 9, BurstDuration: 4},
+	}
+	tests := []struct {
+		name     string
+		pid      int64
+		burst    int64
+		wantErr  bool
+		wantDone int64
+	}{
+		{name: "ready", pid: 2, burst: 3, wantDone: 5},
+		{name: "not arrived", pid: 3, burst: 1, wantDone: 10},
+		{name: "already ran more", pid: 2, burst: 1, wantErr: true},
+		{name: "finished", pid: 1, burst: 5, wantErr: true},
+		{name: "no such process", pid: 4, burst: 5, wantErr: true},
+		{name: "empty burst", pid: 3, burst: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// P1 has finished and P2 has run 2 of its 6 units by time 4
+			sim := NewSimulation(processes)
+			if err := sim.Run(context.Background(), FCFSPolicy, 4); err != nil {
+				t.Fatal(err)
+			}
+			err := sim.SetBurst(tt.pid, tt.burst)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnchangeable) {
+					t.Errorf("SetBurst() error = %v, want %v", err, ErrUnchangeable)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := sim.Run(context.Background(), FCFSPolicy, -1); err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range sim.Result().PerProcess {
+				if p.ProcessID == tt.pid && (p.Burst != tt.burst || p.Completion != tt.wantDone) {
+					t.Errorf("P%d has burst %d and completes at %d, want %d and %d", p.ProcessID, p.Burst,
+						p.Completion, tt.burst, tt.wantDone)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulation_RunBlocking(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2}}
+	tests := []struct {
+		name   string
+		choose Policy
+	}{
+		{name: "fcfs", choose: FCFSPolicy},
+		{name: "rr", choose: RoundRobinPolicy(func(int64) int64 { return 2 })},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// P1 blocks for 4 time units before its second unit, and only the first time it is asked
+			blocked := false
+			block := func(_ int64, p workload.Process) int64 {
+				if p.ProcessID != 1 || p.Burst-p.BurstDuration != 1 || blocked {
+					return 0
+				}
+				blocked = true
+				return 4
+			}
+			sim := NewSimulation(processes)
+			if err := sim.RunBlocking(context.Background(), tt.choose, block, -1); err != nil {
+				t.Fatal(err)
+			}
+			got := sim.Result()
+			want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 5, Stop: 7}}
+			if !reflect.DeepEqual(got.Slices, want) {
+				t.Errorf("Gantt chart = %v, want %v", got.Slices, want)
+			}
+			// blocked time is neither running nor waiting
+			if p := got.PerProcess[0]; p.Wait != 0 || p.Turnaround != 7 {
+				t.Errorf("P1 waits %d with turnaround %d, want 0 and 7", p.Wait, p.Turnaround)
+			}
+			if p := got.PerProcess[1]; p.Wait != 1 || p.Completion != 3 {
+				t.Errorf("P2 waits %d and completes at %d, want 1 and 3", p.Wait, p.Completion)
+			}
+		})
+	}
+}
+
+func TestSimulation_Wake(t *testing.T) {
+	t.Parallel()
+	// P1 blocks until woken before its second unit, and is woken at time 4
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}}
+	blocked := false
+	block := func(_ int64, p workload.Process) int64 {
+		if p.ProcessID != 1 || p.Burst-p.BurstDuration != 1 || blocked {
+			return 0
+		}
+		blocked = true
+		return -1
+	}
+	sim := NewSimulation(processes)
+	for !sim.Finished() {
+		if sim.Time == 4 {
+			if err := sim.Wake(1); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := sim.RunBlocking(context.Background(), FCFSPolicy, block, sim.Time+1); err != nil {
+			t.Fatal(err)
+		}
+		if sim.Time > 10 {
+			t.Fatal("P1 was never woken")
+		}
+	}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 4, Stop: 5}}
+	if got := sim.Result().Slices; !reflect.DeepEqual(got, want) {
+		t.Errorf("Gantt chart = %v, want %v", got, want)
+	}
+	if err := sim.Wake(1); !errors.Is(err, ErrNotBlocked) {
+		t.Errorf("Wake() of a finished process = %v, want %v", err, ErrNotBlocked)
+	}
+}
+
+func TestSimulation_Submit(t *testing.T) {
+	t.Parallel()
+	sim := NewSimulation([]workload.Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 6, BurstDuration: 1},
+	})
+	if err := sim.Run(context.Background(), FCFSPolicy, 1); err != nil {
+		t.Fatal(err)
+	}
+	// P3 joins between P1, already arrived, and P2, still to arrive
+	if err := sim.Submit(workload.Process{ProcessID: 3, ArrivalTime: 3, BurstDuration: 2}); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []workload.Process{
+		{ProcessID: 4, BurstDuration: 1},
+		{ProcessID: 4, ArrivalTime: 5},
+		{ProcessID: 2, ArrivalTime: 5, BurstDuration: 1},
+	} {
+		if err := sim.Submit(p); !errors.Is(err, ErrBadSubmission) {
+			t.Errorf("Submit(%+v) error = %v, want %v", p, err, ErrBadSubmission)
+		}
+	}
+	if err := sim.Run(context.Background(), FCFSPolicy, -1); err != nil {
+		t.Fatal(err)
+	}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 3, Stop: 5}, {PID: 2, Start: 6, Stop: 7}}
+	if got := sim.Result().Slices; !reflect.DeepEqual(got, want) {
+		t.Errorf("Gantt chart = %v, want %v", got, want)
+	}
+}
+
+func TestSimulation_Stall(t *testing.T) {
+	t.Parallel()
+	// P2 arrives during the 2 units of overhead at time 1, and both wait through what is left of it
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 1}}
+	sim := NewSimulation(processes)
+	if err := sim.Run(context.Background(), FCFSPolicy, 1); err != nil {
+		t.Fatal(err)
+	}
+	sim.Stall(context.Background(), 1, 2)
+	sim.Stall(context.Background(), 1, 0)
+	if err := sim.Run(context.Background(), FCFSPolicy, -1); err != nil {
+		t.Fatal(err)
+	}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 3, Switch: true},
+		{PID: 1, Start: 3, Stop: 4}, {PID: 2, Start: 4, Stop: 5}}
+	r := sim.Result()
+	if !reflect.DeepEqual(r.Slices, want) {
+		t.Errorf("Gantt chart = %v, want %v", r.Slices, want)
+	}
+	if r.PerProcess[0].Wait != 2 || r.PerProcess[1].Wait != 2 {
+		t.Errorf("waits = %d and %d, want 2 and 2", r.PerProcess[0].Wait, r.PerProcess[1].Wait)
+	}
+}
@@ -0,0 +1,155 @@
+package sched
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SliceSink receives the slices of a Gantt chart in time order as a scheduler finishes them.
+type SliceSink interface {
+	WriteSlice(s TimeSlice) error
+}
+
+type sliceSinkKey struct{}
+
+// WithSliceSink returns a context whose schedulers write their Gantt chart to sink as they run instead of keeping
+// it, returning a Result without Slices. Every slice written is complete, and back-to-back slices of the same process
+// are already joined. It keeps the memory of a run with millions of slices to that of its processes.
+//
+// FCFS, SJF, Priority, and RR stream their charts. The policy engine keeps its chart whatever the context carries,
+// since a paused Simulation needs it to resume.
+func WithSliceSink(ctx context.Context, sink SliceSink) context.Context {
+	return context.WithValue(ctx, sliceSinkKey{}, sink)
+}
+
+// ErrBadSliceFile marks a slice file that is cut short or wasn't written by a SliceWriter.
+var ErrBadSliceFile = errors.New("bad slice file")
+
+// sliceFileMagic starts every slice file, with the version of its format.
+const sliceFileMagic = "SLICES1\n"
+
+// SliceWriter is a SliceSink that writes slices to a file in a compact binary format for ReadSlices to read back.
+// Each slice takes a few bytes: its PID, its start as the gap since the slice before it stopped, and its length.
+type SliceWriter struct {
+	w        *bufio.Writer
+	buf      []byte
+	header   bool  // whether sliceFileMagic is written
+	last     int64 // when the slice before stopped
+	count    int
+	switches int
+	running  int64 // PID of the process slice before, for counting switches
+	started  bool
+}
+
+// NewSliceWriter returns a SliceWriter writing to w. Call Flush once the run is over.
+func NewSliceWriter(w io.Writer) *SliceWriter {
+	return &SliceWriter{w: bufio.NewWriter(w), buf: make([]byte, 0, 3*binary.MaxVarintLen64+1)}
+}
+
+// WriteSlice writes s, which must not start before the slice written before it stopped.
+func (sw *SliceWriter) WriteSlice(s TimeSlice) error {
+	if s.Start < sw.last || s.Stop < s.Start {
+		return fmt.Errorf("%w: slice of P%d at %d-%d is out of order", ErrInvalidGantt, s.PID, s.Start, s.Stop)
+	}
+	if err := sw.writeHeader(); err != nil {
+		return err
+	}
+	var kind byte
+	if s.Switch {
+		kind = 1
+	}
+	sw.buf = binary.AppendVarint(sw.buf[:0], s.PID)
+	sw.buf = binary.AppendUvarint(sw.buf, uint64(s.Start-sw.last))
+	sw.buf = binary.AppendUvarint(sw.buf, uint64(s.Stop-s.Start))
+	sw.buf = append(sw.buf, kind)
+	if _, err := sw.w.Write(sw.buf); err != nil {
+		return fmt.Errorf("%w: writing slice file", err)
+	}
+
+	sw.last = s.Stop
+	sw.count++
+	if !s.Switch {
+		if sw.started && s.PID != sw.running {
+			sw.switches++
+		}
+		sw.running, sw.started = s.PID, true
+	}
+
+	return nil
+}
+
+// Count returns the number of slices written.
+func (sw *SliceWriter) Count() int {
+	return sw.count
+}
+
+// ContextSwitches returns the context switches among the slices written, as CountContextSwitches counts them.
+func (sw *SliceWriter) ContextSwitches() int {
+	return sw.switches
+}
+
+// Flush writes any buffered slices to the underlying writer.
+func (sw *SliceWriter) Flush() error {
+	if err := sw.writeHeader(); err != nil {
+		return err
+	}
+	if err := sw.w.Flush(); err != nil {
+		return fmt.Errorf("%w: writing slice file", err)
+	}
+
+	return nil
+}
+
+func (sw *SliceWriter) writeHeader() error {
+	if sw.header {
+		return nil
+	}
+	if _, err := sw.w.WriteString(sliceFileMagic); err != nil {
+		return fmt.Errorf("%w: writing slice file", err)
+	}
+	sw.header = true
+
+	return nil
+}
+
+// ReadSlices calls f with every slice a SliceWriter wrote to r, in order, stopping at the first error f returns.
+func ReadSlices(r io.Reader, f func(TimeSlice) error) error {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(sliceFileMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != sliceFileMagic {
+		return fmt.Errorf("%w: missing header", ErrBadSliceFile)
+	}
+	var last int64
+	for i := 0; ; i++ {
+		pid, err := binary.ReadVarint(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: slice %d: %v", ErrBadSliceFile, i, err)
+		}
+		gap, err := binary.ReadUvarint(br)
+		if err != nil {
+			return fmt.Errorf("%w: slice %d is cut short", ErrBadSliceFile, i)
+		}
+		length, err := binary.ReadUvarint(br)
+		if err != nil {
+			return fmt.Errorf("%w: slice %d is cut short", ErrBadSliceFile, i)
+		}
+		kind, err := br.ReadByte()
+		if err != nil || kind > 1 {
+			return fmt.Errorf("%w: slice %d is cut short or has an unknown kind", ErrBadSliceFile, i)
+		}
+
+		s := TimeSlice{PID: pid, Start: last + int64(gap), Switch: kind == 1}
+		s.Stop = s.Start + int64(length)
+		last = s.Stop
+		if err := f(s); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package sched
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// sliceRecorder is a SliceSink that keeps what it is given, failing once it holds failAfter slices if that is set.
+type sliceRecorder struct {
+	slices    []TimeSlice
+	failAfter int
+}
+
+var errSinkFull = errors.New("sink full")
+
+func (r *sliceRecorder) WriteSlice(s TimeSlice) error {
+	if r.failAfter > 0 && len(r.slices) == r.failAfter {
+		return errSinkFull
+	}
+	r.slices = append(r.slices, s)
+	return nil
+}
+
+func TestWithSliceSink(t *testing.T) {
+	t.Parallel()
+	rr := func(q int64) Func {
+		return func(ctx context.Context, processes []workload.Process) (Result, error) { return RR(ctx, processes, q) }
+	}
+	schedulers := map[string]Func{"fcfs": FCFS, "sjf": SJF, "priority": Priority, "rr1": rr(1), "rr3": rr(3)}
+	for seed := int64(0); seed < 50; seed++ {
+		processes := workload.Generate(rand.New(rand.NewSource(seed)),
+			workload.GenerateOptions{Count: 8, MaxBurst: 6, MaxArrival: 15, MaxPriority: 3})
+		for name, schedule := range schedulers {
+			want, _ := schedule(context.Background(), processes)
+			var sink sliceRecorder
+			got, err := schedule(WithSliceSink(context.Background(), &sink), processes)
+			if err != nil {
+				t.Fatalf("%s on seed %d: %v", name, seed, err)
+			}
+			if got.Slices != nil {
+				t.Errorf("%s on seed %d: streamed run kept its chart %v", name, seed, got.Slices)
+			}
+			if !reflect.DeepEqual(sink.slices, want.Slices) {
+				t.Errorf("%s on seed %d: streamed %v, want %v", name, seed, sink.slices, want.Slices)
+			}
+			if !reflect.DeepEqual(got.PerProcess, want.PerProcess) {
+				t.Errorf("%s on seed %d: streaming changed the metrics", name, seed)
+			}
+		}
+	}
+}
+
+func TestWithSliceSink_error(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2},
+	}
+	sink := sliceRecorder{failAfter: 1}
+	r, err := FCFS(WithSliceSink(context.Background(), &sink), processes)
+	if !errors.Is(err, errSinkFull) {
+		t.Fatalf("FCFS() error = %v, want %v", err, errSinkFull)
+	}
+	if len(r.PerProcess) != len(processes) {
+		t.Errorf("FCFS() finished %d processes, want %d", len(r.PerProcess), len(processes))
+	}
+}
+
+func TestSliceWriter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		slices   []TimeSlice
+		switches int
+	}{
+		{name: "empty"},
+		{
+			name:     "idle gaps and overhead",
+			slices:   []TimeSlice{{PID: 1, Start: 3, Stop: 5}, {PID: 2, Start: 5, Stop: 6, Switch: true}, {PID: 2, Start: 6, Stop: 9}, {PID: 1, Start: 20, Stop: 21}},
+			switches: 2,
+		},
+		{
+			name:     "large values",
+			slices:   []TimeSlice{{PID: 1 << 40, Start: 1 << 50, Stop: 1<<50 + 1<<33}, {PID: 1 << 40, Start: 1<<50 + 1<<34, Stop: 1<<50 + 1<<35}},
+			switches: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			sw := NewSliceWriter(&buf)
+			for _, s := range tt.slices {
+				if err := sw.WriteSlice(s); err != nil {
+					t.Fatalf("WriteSlice(%v) error = %v", s, err)
+				}
+			}
+			if err := sw.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if sw.Count() != len(tt.slices) || sw.ContextSwitches() != tt.switches {
+				t.Errorf("Count(), ContextSwitches() = %d, %d, want %d, %d",
+					sw.Count(), sw.ContextSwitches(), len(tt.slices), tt.switches)
+			}
+			if want := CountContextSwitches(tt.slices); sw.ContextSwitches() != want {
+				t.Errorf("ContextSwitches() = %d, CountContextSwitches() = %d", sw.ContextSwitches(), want)
+			}
+
+			var got []TimeSlice
+			if err := ReadSlices(&buf, func(s TimeSlice) error {
+				got = append(got, s)
+				return nil
+			}); err != nil {
+				t.Fatalf("ReadSlices() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.slices) {
+				t.Errorf("ReadSlices() = %v, want %v", got, tt.slices)
+			}
+		})
+	}
+}
+
+func TestSliceWriter_outOfOrder(t *testing.T) {
+	t.Parallel()
+	sw := NewSliceWriter(&bytes.Buffer{})
+	if err := sw.WriteSlice(TimeSlice{PID: 1, Start: 2, Stop: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.WriteSlice(TimeSlice{PID: 2, Start: 3, Stop: 5}); !errors.Is(err, ErrInvalidGantt) {
+		t.Errorf("WriteSlice() of an overlapping slice error = %v, want %v", err, ErrInvalidGantt)
+	}
+}
+
+func TestReadSlices_bad(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	sw := NewSliceWriter(&buf)
+	_ = sw.WriteSlice(TimeSlice{PID: 300, Start: 0, Stop: 400})
+	_ = sw.Flush()
+	whole := buf.Bytes()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty"},
+		{name: "not a slice file", data: []byte("pid,arrival\n1,0\n")},
+		{name: "cut short", data: whole[:len(whole)-2]},
+		{name: "unknown kind", data: append(append([]byte(nil), whole[:len(whole)-1]...), 7)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ReadSlices(bytes.NewReader(tt.data), func(TimeSlice) error { return nil })
+			if !errors.Is(err, ErrBadSliceFile) {
+				t.Errorf("ReadSlices() error = %v, want %v", err, ErrBadSliceFile)
+			}
+		})
+	}
+}
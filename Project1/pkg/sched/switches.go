@@ -0,0 +1,73 @@
+package sched
+
+import "GolandProjects/Project1/pkg/workload"
+
+// ChargeContextSwitches models a fixed dispatch cost for every context switch. The schedulers decide as if switches
+// were free; each switch between two back-to-back slices of different processes is then charged by inserting an
+// overhead slice of cost time units and delaying everything after it, until idle CPU time absorbs the delay. Each
+// finished process completes when its last slice now stops, and the summary is recomputed. With cost <= 0 r is
+// returned unchanged.
+func ChargeContextSwitches(r Result, cost int64) Result {
+	if cost <= 0 {
+		return r
+	}
+	gantt, done := r.Slices, r.PerProcess
+
+	var (
+		charged = make([]TimeSlice, 0, len(gantt)*2)
+		stops   = make(map[int64]int64, len(done)) // PID to where its last slice stops once charged
+		shift   int64
+	)
+	for i := range gantt {
+		if i > 0 && gantt[i].PID != gantt[i-1].PID && gantt[i].Start <= gantt[i-1].Stop {
+			charged = append(charged, TimeSlice{
+				PID:    gantt[i].PID,
+				Start:  gantt[i].Start + shift,
+				Stop:   gantt[i].Start + shift + cost,
+				Switch: true,
+			})
+			shift += cost
+		} else if i > 0 {
+			// the CPU was idle before this slice, so it starts as soon as the charged slices before it let it
+			shift = max(charged[len(charged)-1].Stop-gantt[i].Start, 0)
+		}
+		s := gantt[i]
+		s.Start, s.Stop = s.Start+shift, s.Stop+shift
+		charged = append(charged, s)
+		stops[s.PID] = s.Stop
+	}
+
+	delayed := make([]workload.Process, len(done))
+	for i := range done {
+		delayed[i] = done[i]
+		if stop, ok := stops[done[i].ProcessID]; ok && stop > done[i].Completion {
+			delay := stop - done[i].Completion
+			delayed[i].Completion += delay
+			delayed[i].Turnaround += delay
+			delayed[i].Wait += delay
+		}
+	}
+
+	return NewResult(charged, delayed)
+}
+
+// CountContextSwitches counts the dispatches of a different process than the one before it, ignoring overhead
+// slices themselves and any idle time in between.
+func CountContextSwitches(gantt []TimeSlice) int {
+	var (
+		count int
+		last  int64
+		seen  bool
+	)
+	for _, s := range gantt {
+		if s.Switch {
+			continue
+		}
+		if seen && s.PID != last {
+			count++
+		}
+		last, seen = s.PID, true
+	}
+
+	return count
+}
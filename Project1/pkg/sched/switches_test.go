@@ -0,0 +1,67 @@
+package sched
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestChargeContextSwitches(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 2, Start: 4, Stop: 5},
+		{PID: 3, Start: 8, Stop: 9},
+	}
+	done := []workload.Process{
+		{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, Burst: 3, ArrivalTime: 1, Wait: 1, Turnaround: 4, Completion: 5},
+		{ProcessID: 3, Burst: 1, ArrivalTime: 8, Turnaround: 1, Completion: 9},
+	}
+
+	got := ChargeContextSwitches(NewResult(gantt, done), 1)
+	gotGantt, gotDone := got.Slices, got.PerProcess
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 3, Switch: true},
+		{PID: 2, Start: 3, Stop: 5},
+		{PID: 2, Start: 5, Stop: 6},
+		{PID: 3, Start: 8, Stop: 9},
+	}
+	if !reflect.DeepEqual(gotGantt, wantGantt) {
+		t.Errorf("gantt = %v, want %v", gotGantt, wantGantt)
+	}
+	wantDone := []workload.Process{
+		{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, Burst: 3, ArrivalTime: 1, Wait: 2, Turnaround: 5, Completion: 6},
+		{ProcessID: 3, Burst: 1, ArrivalTime: 8, Turnaround: 1, Completion: 9},
+	}
+	if !reflect.DeepEqual(gotDone, wantDone) {
+		t.Errorf("done = %v, want %v", gotDone, wantDone)
+	}
+	if got := CountContextSwitches(gotGantt); got != 2 {
+		t.Errorf("CountContextSwitches() = %d, want 2", got)
+	}
+
+	// an idle gap shorter than the overhead charged before it absorbs only part of the delay
+	partial := ChargeContextSwitches(NewResult([]TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 3},
+		{PID: 3, Start: 3, Stop: 4},
+		{PID: 4, Start: 5, Stop: 6},
+	}, []workload.Process{
+		{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, Burst: 1, ArrivalTime: 2, Turnaround: 1, Completion: 3},
+		{ProcessID: 3, Burst: 1, ArrivalTime: 3, Turnaround: 1, Completion: 4},
+		{ProcessID: 4, Burst: 1, ArrivalTime: 5, Turnaround: 1, Completion: 6},
+	}), 1)
+	if got := partial.PerProcess[3]; got.Completion != 7 || got.Wait != 1 {
+		t.Errorf("P4 completes at %d after waiting %d, want 7 and 1", got.Completion, got.Wait)
+	}
+
+	if r := NewResult(gantt, done); !reflect.DeepEqual(ChargeContextSwitches(r, 0), r) {
+		t.Error("ChargeContextSwitches() with no cost should leave the run unchanged")
+	}
+}
@@ -0,0 +1,81 @@
+package sched
+
+// ChargeSystemTime models the time processes spend in the kernel on their system calls and the interrupts those
+// raise. The schedulers decide as if processes only ever ran their own code; then, for every time unit a process runs
+// it, it owes percent(pid)/100 units of kernel time, and whenever it owes a whole unit a kernel slice of it is
+// inserted right after, delaying everything later. A process finishes after its last kernel slice; its Burst stays
+// the user time it ran, and it is not counted as waiting while the kernel works for it. With a nil percent r is
+// returned unchanged.
+func ChargeSystemTime(r Result, percent func(pid int64) int64) Result {
+	if percent == nil {
+		return r
+	}
+
+	type insertion struct {
+		at    int64 // original time the kernel slice was inserted at
+		pid   int64
+		units int64
+	}
+	var (
+		charged  = make([]TimeSlice, 0, len(r.Slices)*2)
+		inserted []insertion
+		owed     = make(map[int64]int64) // hundredths of a unit each process owes the kernel
+		shift    int64
+	)
+	for _, s := range r.Slices {
+		if s.Switch || s.Kernel {
+			s.Start, s.Stop = s.Start+shift, s.Stop+shift
+			charged = append(charged, s)
+			continue
+		}
+		start := s.Start
+		for t := s.Start; t < s.Stop; t++ {
+			owed[s.PID] += max(percent(s.PID), 0)
+			units := owed[s.PID] / 100
+			if units == 0 {
+				continue
+			}
+			owed[s.PID] %= 100
+			charged = append(charged,
+				TimeSlice{PID: s.PID, Start: start + shift, Stop: t + 1 + shift},
+				TimeSlice{PID: s.PID, Start: t + 1 + shift, Stop: t + 1 + shift + units, Kernel: true})
+			inserted = append(inserted, insertion{at: t + 1, pid: s.PID, units: units})
+			shift += units
+			start = t + 1
+		}
+		if start < s.Stop {
+			charged = append(charged, TimeSlice{PID: s.PID, Start: start + shift, Stop: s.Stop + shift})
+		}
+	}
+
+	delayed := make([]ProcessMetrics, len(r.PerProcess))
+	for i, p := range r.PerProcess {
+		var delay, own int64
+		for _, in := range inserted {
+			switch {
+			case in.pid == p.ProcessID && in.at <= p.Completion:
+				own += in.units
+			case in.at < p.Completion:
+				delay += in.units
+			}
+		}
+		p.Completion += delay + own
+		p.Turnaround += delay + own
+		p.Wait += delay
+		delayed[i] = p
+	}
+
+	return NewResult(charged, delayed)
+}
+
+// SystemTime returns how long the kernel worked on behalf of each process in gantt, by PID.
+func SystemTime(gantt []TimeSlice) map[int64]int64 {
+	sys := make(map[int64]int64)
+	for _, s := range gantt {
+		if s.Kernel {
+			sys[s.PID] += s.Stop - s.Start
+		}
+	}
+
+	return sys
+}
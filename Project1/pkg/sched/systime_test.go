@@ -0,0 +1,55 @@
+package sched
+
+import (
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestChargeSystemTime(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4},
+		{PID: 2, Start: 4, Stop: 6},
+	}
+	done := []workload.Process{
+		{ProcessID: 1, Burst: 4, Turnaround: 4, Completion: 4},
+		{ProcessID: 2, Burst: 2, Wait: 4, Turnaround: 6, Completion: 6},
+	}
+	percent := func(pid int64) int64 { return 50 * pid }
+
+	got := ChargeSystemTime(NewResult(gantt, done), percent)
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 1, Start: 2, Stop: 3, Kernel: true},
+		{PID: 1, Start: 3, Stop: 5},
+		{PID: 1, Start: 5, Stop: 6, Kernel: true},
+		{PID: 2, Start: 6, Stop: 7},
+		{PID: 2, Start: 7, Stop: 8, Kernel: true},
+		{PID: 2, Start: 8, Stop: 9},
+		{PID: 2, Start: 9, Stop: 10, Kernel: true},
+	}
+	if !reflect.DeepEqual(got.Slices, wantGantt) {
+		t.Errorf("gantt = %v, want %v", got.Slices, wantGantt)
+	}
+	wantDone := []workload.Process{
+		{ProcessID: 1, Burst: 4, Turnaround: 6, Completion: 6},
+		{ProcessID: 2, Burst: 2, Wait: 6, Turnaround: 10, Completion: 10},
+	}
+	if !reflect.DeepEqual(got.PerProcess, wantDone) {
+		t.Errorf("done = %v, want %v", got.PerProcess, wantDone)
+	}
+	if sys := SystemTime(got.Slices); sys[1] != 2 || sys[2] != 2 {
+		t.Errorf("SystemTime() = %v, want 2 for both", sys)
+	}
+
+	// switch overhead charged afterwards keeps the kernel slices
+	switched := ChargeContextSwitches(got, 1)
+	if s := switched.Slices[4]; !s.Switch || s.PID != 2 || !switched.Slices[6].Kernel {
+		t.Errorf("switch-charged gantt = %v, want a switch to 2 at 6 and kernel slices kept", switched.Slices)
+	}
+	if unchanged := ChargeSystemTime(NewResult(gantt, done), nil); !reflect.DeepEqual(unchanged.Slices, gantt) {
+		t.Errorf("ChargeSystemTime(nil) gantt = %v, want it unchanged", unchanged.Slices)
+	}
+}
@@ -0,0 +1,72 @@
+package segment
+
+import "fmt"
+
+// Hole is a run of physical memory no segment occupies.
+type Hole struct {
+	Base int64
+	Size int64
+}
+
+// Layout is how the segments of a set of tables lie in physical memory: the units they occupy, a shared segment
+// once, and the holes between them.
+type Layout struct {
+	Memory int64
+	Used   int64
+	Holes  []Hole
+}
+
+// Free returns the units of memory in holes.
+func (l Layout) Free() int64 {
+	return l.Memory - l.Used
+}
+
+// Largest returns the size of the largest hole, or 0 when memory is full.
+func (l Layout) Largest() int64 {
+	var largest int64
+	for _, h := range l.Holes {
+		largest = max(largest, h.Size)
+	}
+
+	return largest
+}
+
+// External returns the external fragmentation, the fraction of free memory outside the largest hole: 0 when the
+// free memory is one hole, so a segment as large as all of it still fits, and nearing 1 as it splinters. It is 0 when
+// memory is full.
+func (l Layout) External() float64 {
+	if l.Free() == 0 {
+		return 0
+	}
+
+	return 1 - float64(l.Largest())/float64(l.Free())
+}
+
+// LayOut places the segments of tables, which must be valid, in memory units of physical memory, or in memory
+// reaching just to the end of the last segment when memory is 0. A segment that ends past memory is an error.
+func LayOut(tables []Table, memory int64) (Layout, error) {
+	regions := placed(tables)
+	if memory == 0 {
+		for _, s := range regions {
+			memory = max(memory, s.End())
+		}
+	}
+	l := Layout{Memory: memory}
+	var next int64
+	for _, s := range regions {
+		if s.End() > memory {
+			return Layout{}, fmt.Errorf("%w: segment at %d-%d ends past the %d units of memory", ErrBadTable, s.Base,
+				s.End()-1, memory)
+		}
+		if s.Base > next {
+			l.Holes = append(l.Holes, Hole{Base: next, Size: s.Base - next})
+		}
+		l.Used += s.Limit
+		next = s.End()
+	}
+	if memory > next {
+		l.Holes = append(l.Holes, Hole{Base: next, Size: memory - next})
+	}
+
+	return l, nil
+}
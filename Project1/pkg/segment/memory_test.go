@@ -0,0 +1,69 @@
+package segment
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLayOut(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		tables   string
+		memory   int64
+		used     int64
+		holes    []Hole
+		external float64
+		wantErr  bool
+	}{
+		{
+			name:     "textbook",
+			tables:   textbook,
+			memory:   8000,
+			used:     3900,
+			holes:    []Hole{{Base: 0, Size: 1400}, {Base: 2400, Size: 800}, {Base: 5700, Size: 600}, {Base: 6700, Size: 1300}},
+			external: 1 - 1400.0/4100,
+		},
+		{
+			name:   "to the last segment",
+			tables: "0 10 10",
+			used:   10,
+			holes:  []Hole{{Base: 0, Size: 10}},
+		},
+		{
+			name:   "shared counted once",
+			tables: "table P1; 0 0 10\ntable P2; 0 0 10; 1 20 10",
+			memory: 30,
+			used:   20,
+			holes:  []Hole{{Base: 10, Size: 10}},
+		},
+		{name: "full", tables: "0 0 10; 1 10 10", memory: 20, used: 20},
+		{name: "too small", tables: "0 0 10; 1 10 10", memory: 15, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tables, err := ParseTables(strings.NewReader(tt.tables))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := LayOut(tables, tt.memory)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadTable) {
+					t.Errorf("LayOut() error = %v, want %v", err, ErrBadTable)
+				}
+				return
+			}
+			if err != nil || got.Used != tt.used || !reflect.DeepEqual(got.Holes, tt.holes) {
+				t.Errorf("LayOut() = %+v, %v, want %d used in holes %v", got, err, tt.used, tt.holes)
+			}
+			if math.Abs(got.External()-tt.external) > 1e-9 {
+				t.Errorf("External() = %v, want %v", got.External(), tt.external)
+			}
+		})
+	}
+}
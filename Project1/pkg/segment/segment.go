@@ -0,0 +1,358 @@
+// Package segment simulates segmentation: a logical address names a segment and an offset into it, and the segment
+// table gives every segment a base, a limit, and the accesses it allows. It translates a stream of logical addresses
+// through one or more segment tables, trapping offsets beyond a limit and accesses a segment forbids, and measures the
+// external fragmentation the segments leave in physical memory. It is the companion of paging, which splits memory
+// into equal frames instead.
+package segment
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Perm is a set of the accesses a segment allows.
+type Perm uint8
+
+const (
+	Read Perm = 1 << iota
+	Write
+	Execute
+)
+
+// ParsePerm parses a set of accesses written as letters from "rwx", in any order, or "-" for none.
+func ParsePerm(s string) (Perm, error) {
+	var p Perm
+	if s == "-" {
+		return p, nil
+	}
+	for _, c := range strings.ToLower(s) {
+		switch c {
+		case 'r':
+			p |= Read
+		case 'w':
+			p |= Write
+		case 'x':
+			p |= Execute
+		default:
+			return 0, fmt.Errorf("%w: %q is not a set of r, w, and x", ErrBadTable, s)
+		}
+	}
+
+	return p, nil
+}
+
+// String writes p as ls does, as in "r-x".
+func (p Perm) String() string {
+	b := []byte("---")
+	for i, c := range "rwx" {
+		if p&(1<<i) != 0 {
+			b[i] = byte(c)
+		}
+	}
+
+	return string(b)
+}
+
+// Segment is one entry of a segment table: Limit units of physical memory from Base, allowing the accesses Perm.
+type Segment struct {
+	Number int
+	Base   int64
+	Limit  int64
+	Perm   Perm
+}
+
+// End returns the first address past s.
+func (s Segment) End() int64 {
+	return s.Base + s.Limit
+}
+
+// Table is the segment table of one process, named so addresses can pick it out.
+type Table struct {
+	Name     string
+	Segments []Segment
+}
+
+// find returns the segment numbered n, and whether t has one.
+func (t Table) find(n int) (Segment, bool) {
+	for _, s := range t.Segments {
+		if s.Number == n {
+			return s, true
+		}
+	}
+
+	return Segment{}, false
+}
+
+var (
+	// ErrBadTable marks a segment table that cannot be read, or whose segments overlap.
+	ErrBadTable = errors.New("bad segment table")
+	// ErrBadAddress marks a logical address that is not a segment and an offset.
+	ErrBadAddress = errors.New("bad logical address")
+)
+
+// ParseTables reads segment tables of one segment per line or per semicolon-separated entry, "NUMBER BASE LIMIT"
+// followed by the accesses it allows as in "rw" (all of them if left out). "table NAME" starts the table of another
+// process; segments before the first are in a table named "". Blank lines and anything after a # are ignored.
+// Segments are checked with Validate.
+func ParseTables(r io.Reader) ([]Table, error) {
+	var tables []Table
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, entry := range strings.Split(text, ";") {
+			fields := strings.Fields(entry)
+			if len(fields) == 0 {
+				continue
+			}
+			if fields[0] == "table" {
+				if len(fields) != 2 {
+					return nil, fmt.Errorf("%w: line %d: want \"table NAME\", got %q", ErrBadTable, line,
+						strings.TrimSpace(entry))
+				}
+				tables = append(tables, Table{Name: fields[1]})
+				continue
+			}
+			s, err := parseSegment(fields)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if len(tables) == 0 {
+				tables = append(tables, Table{})
+			}
+			t := &tables[len(tables)-1]
+			t.Segments = append(t.Segments, s)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading segment tables", err)
+	}
+	if err := Validate(tables); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// parseSegment parses the fields of a "NUMBER BASE LIMIT [PERMS]" entry.
+func parseSegment(fields []string) (Segment, error) {
+	if len(fields) != 3 && len(fields) != 4 {
+		return Segment{}, fmt.Errorf("%w: want \"NUMBER BASE LIMIT [rwx]\", got %q", ErrBadTable,
+			strings.Join(fields, " "))
+	}
+	s := Segment{Perm: Read | Write | Execute}
+	var err error
+	if s.Number, err = strconv.Atoi(fields[0]); err != nil || s.Number < 0 {
+		return Segment{}, fmt.Errorf("%w: %q is not a segment number", ErrBadTable, fields[0])
+	}
+	if s.Base, err = strconv.ParseInt(fields[1], 10, 64); err != nil || s.Base < 0 {
+		return Segment{}, fmt.Errorf("%w: %q is not a base address", ErrBadTable, fields[1])
+	}
+	if s.Limit, err = strconv.ParseInt(fields[2], 10, 64); err != nil || s.Limit < 1 {
+		return Segment{}, fmt.Errorf("%w: %q is not a positive limit", ErrBadTable, fields[2])
+	}
+	if len(fields) == 4 {
+		if s.Perm, err = ParsePerm(fields[3]); err != nil {
+			return Segment{}, err
+		}
+	}
+
+	return s, nil
+}
+
+// Validate reports whether tables can be translated through: table names and the segment numbers within a table are
+// unique, and no two segments overlap unless they are the same memory, a segment the processes share.
+func Validate(tables []Table) error {
+	names := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		if names[t.Name] {
+			return fmt.Errorf("%w: two tables named %q", ErrBadTable, t.Name)
+		}
+		names[t.Name] = true
+		numbers := make(map[int]bool, len(t.Segments))
+		for _, s := range t.Segments {
+			if numbers[s.Number] {
+				return fmt.Errorf("%w: table %q has two segments numbered %d", ErrBadTable, t.Name, s.Number)
+			}
+			numbers[s.Number] = true
+		}
+	}
+	regions := placed(tables)
+	for i := 1; i < len(regions); i++ {
+		if a, b := regions[i-1], regions[i]; b.Base < a.End() {
+			return fmt.Errorf("%w: segments at %d-%d and %d-%d overlap", ErrBadTable, a.Base, a.End()-1, b.Base,
+				b.End()-1)
+		}
+	}
+
+	return nil
+}
+
+// placed returns the memory the segments of tables occupy in address order, a shared segment once.
+func placed(tables []Table) []Segment {
+	var regions []Segment
+	seen := make(map[[2]int64]bool)
+	for _, t := range tables {
+		for _, s := range t.Segments {
+			if key := [2]int64{s.Base, s.Limit}; !seen[key] {
+				seen[key] = true
+				regions = append(regions, s)
+			}
+		}
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Base < regions[j].Base })
+
+	return regions
+}
+
+// Address is a logical address: Offset into the segment numbered Segment of the table named Table, accessed as
+// Access.
+type Address struct {
+	Table   string
+	Segment int
+	Offset  int64
+	Access  Perm
+}
+
+// String formats a as ParseAddresses reads it.
+func (a Address) String() string {
+	s := fmt.Sprintf("%d:%d", a.Segment, a.Offset)
+	if a.Table != "" {
+		s = a.Table + "/" + s
+	}
+	switch a.Access {
+	case Write:
+		s += "w"
+	case Execute:
+		s += "x"
+	}
+
+	return s
+}
+
+// ParseAddresses parses logical addresses separated by spaces, commas, or newlines, each written SEGMENT:OFFSET and
+// read from unless followed by w for a write or x for an instruction fetch, as in 2:53w. TABLE/ before one, as in
+// P2/0:10, translates it through that process's table; without it, through the first table.
+func ParseAddresses(s string) ([]Address, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	addrs := make([]Address, 0, len(fields))
+	for i, f := range fields {
+		a := Address{Access: Read}
+		rest := strings.ToLower(f)
+		if table, after, ok := strings.Cut(f, "/"); ok {
+			a.Table, rest = table, strings.ToLower(after)
+		}
+		if r, ok := strings.CutSuffix(rest, "w"); ok {
+			a.Access, rest = Write, r
+		} else if r, ok := strings.CutSuffix(rest, "x"); ok {
+			a.Access, rest = Execute, r
+		}
+		segment, offset, ok := strings.Cut(rest, ":")
+		var err1, err2 error
+		a.Segment, err1 = strconv.Atoi(segment)
+		a.Offset, err2 = strconv.ParseInt(offset, 10, 64)
+		if !ok || err1 != nil || err2 != nil || a.Segment < 0 || a.Offset < 0 {
+			return nil, fmt.Errorf("%w: address %d: %q is not SEGMENT:OFFSET", ErrBadAddress, i+1, f)
+		}
+		addrs = append(addrs, a)
+	}
+
+	return addrs, nil
+}
+
+// Fault is why a logical address could not be translated, or None.
+type Fault int
+
+const (
+	None Fault = iota
+	NoTable
+	NoSegment
+	Limit
+	Protection
+)
+
+// Faults lists every fault in the order reports count them.
+var Faults = []Fault{NoTable, NoSegment, Limit, Protection}
+
+// String describes f.
+func (f Fault) String() string {
+	switch f {
+	case NoTable:
+		return "no such table"
+	case NoSegment:
+		return "no such segment"
+	case Limit:
+		return "offset beyond limit"
+	case Protection:
+		return "protection fault"
+	}
+
+	return ""
+}
+
+// Translation is what became of one logical address: the physical address it translated to, or the fault it raised,
+// and the segment it named, if there was one.
+type Translation struct {
+	Address  Address
+	Segment  Segment
+	Physical int64
+	Fault    Fault
+}
+
+// Translate translates every one of addrs through tables, as the hardware would: the segment number picks an entry
+// of the table, an offset past the limit traps, as does an access the segment does not allow, and otherwise the
+// physical address is the base plus the offset.
+func Translate(tables []Table, addrs []Address) []Translation {
+	out := make([]Translation, len(addrs))
+	for i, a := range addrs {
+		out[i] = translate(tables, a)
+	}
+
+	return out
+}
+
+// translate translates one address.
+func translate(tables []Table, a Address) Translation {
+	tr := Translation{Address: a, Physical: -1}
+	var t Table
+	found := false
+	for _, candidate := range tables {
+		if a.Table == candidate.Name || a.Table == "" {
+			t, found = candidate, true
+			break
+		}
+	}
+	if !found {
+		tr.Fault = NoTable
+		return tr
+	}
+	s, ok := t.find(a.Segment)
+	switch {
+	case !ok:
+		tr.Fault = NoSegment
+	case a.Offset >= s.Limit:
+		tr.Segment, tr.Fault = s, Limit
+	case s.Perm&a.Access == 0:
+		tr.Segment, tr.Fault = s, Protection
+	default:
+		tr.Segment, tr.Physical = s, s.Base+a.Offset
+	}
+
+	return tr
+}
+
+// Count returns how many of translations raised f.
+func Count(translations []Translation, f Fault) int {
+	n := 0
+	for _, t := range translations {
+		if t.Fault == f {
+			n++
+		}
+	}
+
+	return n
+}
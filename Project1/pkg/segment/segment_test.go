@@ -0,0 +1,182 @@
+package segment
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// textbook is the segment table of the segmentation example in Silberschatz, Galvin, and Gagne, with the code
+// segments made read-and-execute.
+const textbook = `0 1400 1000 rx
+1 6300 400 rw
+2 4300 400 rw
+3 3200 1100
+4 4700 1000 r`
+
+func TestParsePerm(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "rwx", want: "rwx"},
+		{in: "xr", want: "r-x"},
+		{in: "-", want: "---"},
+		{in: "rq", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParsePerm(tt.in)
+			if tt.wantErr != errors.Is(err, ErrBadTable) {
+				t.Fatalf("ParsePerm(%q) error = %v", tt.in, err)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParsePerm(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTables(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      string
+		want    []Table
+		wantErr bool
+	}{
+		{
+			name: "unnamed",
+			in:   "0 100 50 r # code\n1 200 10",
+			want: []Table{{Segments: []Segment{{Number: 0, Base: 100, Limit: 50, Perm: Read},
+				{Number: 1, Base: 200, Limit: 10, Perm: Read | Write | Execute}}}},
+		},
+		{
+			name: "shared",
+			in:   "table P1; 0 0 10 rx; 1 10 5 rw\ntable P2; 0 0 10 rx; 1 20 5 rw",
+			want: []Table{
+				{Name: "P1", Segments: []Segment{{Number: 0, Limit: 10, Perm: Read | Execute},
+					{Number: 1, Base: 10, Limit: 5, Perm: Read | Write}}},
+				{Name: "P2", Segments: []Segment{{Number: 0, Limit: 10, Perm: Read | Execute},
+					{Number: 1, Base: 20, Limit: 5, Perm: Read | Write}}},
+			},
+		},
+		{name: "overlap", in: "0 0 10; 1 5 10", wantErr: true},
+		{name: "partly shared", in: "table P1; 0 0 10\ntable P2; 0 0 11", wantErr: true},
+		{name: "duplicate segment", in: "0 0 10; 0 20 10", wantErr: true},
+		{name: "duplicate table", in: "table P1; table P1", wantErr: true},
+		{name: "zero limit", in: "0 0 0", wantErr: true},
+		{name: "short", in: "0 100", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTables(strings.NewReader(tt.in))
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadTable) {
+					t.Errorf("ParseTables(%q) error = %v, want %v", tt.in, err, ErrBadTable)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTables(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAddresses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    []Address
+		wantErr bool
+	}{
+		{in: "2:53, 3:852w\n0:10x", want: []Address{{Segment: 2, Offset: 53, Access: Read},
+			{Segment: 3, Offset: 852, Access: Write}, {Segment: 0, Offset: 10, Access: Execute}}},
+		{in: "P2/1:4W", want: []Address{{Table: "P2", Segment: 1, Offset: 4, Access: Write}}},
+		{in: "2", wantErr: true},
+		{in: "2:-1", wantErr: true},
+		{in: "a:1", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseAddresses(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadAddress) {
+					t.Errorf("ParseAddresses(%q) error = %v, want %v", tt.in, err, ErrBadAddress)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAddresses(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+			}
+			var formatted []string
+			for _, a := range got {
+				formatted = append(formatted, a.String())
+			}
+			if again, _ := ParseAddresses(strings.Join(formatted, " ")); !reflect.DeepEqual(again, got) {
+				t.Errorf("ParseAddresses(%q) does not read back %v", formatted, got)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+	tables, err := ParseTables(strings.NewReader(textbook + "\ntable P2; 0 1400 1000 rx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		addr     string
+		physical int64
+		fault    Fault
+	}{
+		{addr: "2:53", physical: 4353},
+		{addr: "3:852w", physical: 4052},
+		{addr: "0:1222", physical: -1, fault: Limit},
+		{addr: "0:10w", physical: -1, fault: Protection},
+		{addr: "0:999x", physical: 2399},
+		{addr: "4:0x", physical: -1, fault: Protection},
+		{addr: "5:0", physical: -1, fault: NoSegment},
+		{addr: "P2/0:5", physical: 1405},
+		{addr: "P2/1:5", physical: -1, fault: NoSegment},
+		{addr: "P3/0:5", physical: -1, fault: NoTable},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.addr, func(t *testing.T) {
+			t.Parallel()
+			addrs, err := ParseAddresses(tt.addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Translate(tables, addrs)[0]
+			if got.Physical != tt.physical || got.Fault != tt.fault {
+				t.Errorf("Translate(%s) = %d, %q, want %d, %q", tt.addr, got.Physical, got.Fault, tt.physical,
+					tt.fault)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+	tables, _ := ParseTables(strings.NewReader(textbook))
+	addrs, _ := ParseAddresses("2:53 0:1222 1:400 0:0w 4:5")
+	got := Translate(tables, addrs)
+	for f, want := range map[Fault]int{None: 2, Limit: 2, Protection: 1, NoSegment: 0} {
+		if n := Count(got, f); n != want {
+			t.Errorf("Count(%q) = %d, want %d", f, n, want)
+		}
+	}
+}
@@ -0,0 +1,487 @@
+// Package synctrace runs a small declarative trace of threads, each a list of semaphore P and V, mutex lock and
+// unlock, and critical-region enter and exit operations, one operation a time unit under a scheduler. It records the
+// interleaving as it goes, flags misuse of the primitives, such as unlocking a mutex another thread holds or two
+// threads inside one region, and reports the threads left deadlocked if every unfinished thread ends up blocked.
+package synctrace
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrBadTrace marks a trace that cannot be read, such as one naming an undeclared semaphore.
+var ErrBadTrace = errors.New("bad trace")
+
+// OpKind is what an operation does.
+type OpKind int
+
+const (
+	Work   OpKind = iota // a step outside any primitive
+	Wait                 // P on a semaphore
+	Signal               // V on a semaphore
+	Lock
+	Unlock
+	Enter // entering a critical region
+	Exit  // leaving a critical region
+)
+
+var opNames = []string{"work", "P", "V", "lock", "unlock", "enter", "exit"}
+
+// Op is one operation of a thread on the semaphore, mutex, or region named Object.
+type Op struct {
+	Kind   OpKind
+	Object string
+}
+
+// String returns o as written in a trace, such as "P(S)".
+func (o Op) String() string {
+	if o.Kind == Work {
+		return opNames[Work]
+	}
+
+	return fmt.Sprintf("%s(%s)", opNames[o.Kind], o.Object)
+}
+
+// Thread is a named list of operations run in order.
+type Thread struct {
+	Name string
+	Ops  []Op
+}
+
+// Semaphore is a counting semaphore and the count it starts at.
+type Semaphore struct {
+	Name    string
+	Initial int
+}
+
+// Program is a trace: its semaphores, mutexes, and threads, in the order declared, and Order, the threads to run
+// first, one per time unit, before the scheduler takes over.
+type Program struct {
+	Semaphores []Semaphore
+	Mutexes    []string
+	Threads    []Thread
+	Order      []string
+}
+
+// Scheduler picks which of runnable, indexes into the program's threads in ascending order, runs next, given the
+// thread that ran last, or -1 at first.
+type Scheduler func(runnable []int, last int) int
+
+// RoundRobin runs the threads in turn: the first runnable thread after the one that ran last.
+func RoundRobin(runnable []int, last int) int {
+	for _, t := range runnable {
+		if t > last {
+			return t
+		}
+	}
+
+	return runnable[0]
+}
+
+// Random returns a Scheduler picking any runnable thread at random from rng.
+func Random(rng *rand.Rand) Scheduler {
+	return func(runnable []int, _ int) int {
+		return runnable[rng.Intn(len(runnable))]
+	}
+}
+
+// Event is one time unit of a run: the operation a thread ran and what came of it. Blocked is whether the thread
+// blocked on it.
+type Event struct {
+	Time    int
+	Thread  string
+	Op      Op
+	Outcome string
+	Blocked bool
+}
+
+// Violation is a misuse of the primitives by a thread at a time.
+type Violation struct {
+	Time    int
+	Thread  string
+	Problem string
+}
+
+// Waiting is a thread blocked on a semaphore or mutex at the end of a run, with the thread holding the mutex, if any.
+type Waiting struct {
+	Thread string
+	Object string
+	Holder string
+}
+
+// String describes w, such as "T1 waits for M held by T2".
+func (w Waiting) String() string {
+	if w.Holder == "" {
+		return fmt.Sprintf("%s waits on %s", w.Thread, w.Object)
+	}
+
+	return fmt.Sprintf("%s waits for %s held by %s", w.Thread, w.Object, w.Holder)
+}
+
+// Timeline marks, one per time unit of a thread's timeline; a finished thread is blank.
+const (
+	MarkRan     = '#'
+	MarkReady   = '.'
+	MarkBlocked = '-'
+)
+
+// Result is a run of a program: every event, the timeline of every thread as marks in the order declared, every
+// violation, and the threads left deadlocked, if any, when the run ended at Time.
+type Result struct {
+	Program    *Program
+	Events     []Event
+	Timelines  [][]byte
+	Violations []Violation
+	Deadlock   []Waiting
+	Time       int
+}
+
+// machine is the state of a run.
+type machine struct {
+	p       *Program
+	count   map[string]int
+	owner   map[string]int   // the thread holding each mutex, or -1
+	queue   map[string][]int // the threads blocked on each semaphore and mutex, in the order they blocked
+	inside  map[string][]int // the threads inside each critical region
+	pc      []int
+	blocked []string // the object each thread is blocked on, or ""
+	r       *Result
+}
+
+// Run runs every thread of p, one operation a time unit, the threads of p.Order first and then those s picks, until
+// every thread finishes or every unfinished thread is blocked. A thread in p.Order that cannot run then is passed
+// over. P on a semaphore at 0 blocks until a V hands it the semaphore, and lock on a held mutex until its holder
+// hands it over on unlock, waiters going first come first served.
+func Run(p *Program, s Scheduler) Result {
+	m := &machine{p: p, count: make(map[string]int), owner: make(map[string]int), queue: make(map[string][]int),
+		inside: make(map[string][]int), pc: make([]int, len(p.Threads)), blocked: make([]string, len(p.Threads)),
+		r: &Result{Program: p, Timelines: make([][]byte, len(p.Threads))}}
+	for _, sem := range p.Semaphores {
+		m.count[sem.Name] = sem.Initial
+	}
+	for _, mutex := range p.Mutexes {
+		m.owner[mutex] = -1
+	}
+	index := make(map[string]int, len(p.Threads))
+	for t, thread := range p.Threads {
+		index[thread.Name] = t
+	}
+
+	order, last := p.Order, -1
+	for time := 0; ; time++ {
+		var runnable []int
+		for t := range p.Threads {
+			if m.pc[t] < len(p.Threads[t].Ops) && m.blocked[t] == "" {
+				runnable = append(runnable, t)
+			}
+		}
+		if len(runnable) == 0 {
+			m.r.Time = time
+			break
+		}
+		next := -1
+		for next < 0 && len(order) > 0 {
+			if t := index[order[0]]; m.pc[t] < len(p.Threads[t].Ops) && m.blocked[t] == "" {
+				next = t
+			}
+			order = order[1:]
+		}
+		if next < 0 {
+			next = s(runnable, last)
+		}
+		m.step(time, next)
+		for t := range p.Threads {
+			mark := byte(MarkReady)
+			switch {
+			case t == next:
+				mark = MarkRan
+			case m.blocked[t] != "":
+				mark = MarkBlocked
+			case m.pc[t] == len(p.Threads[t].Ops):
+				mark = ' '
+			}
+			m.r.Timelines[t] = append(m.r.Timelines[t], mark)
+		}
+		last = next
+	}
+
+	for t, object := range m.blocked {
+		if object == "" {
+			continue
+		}
+		w := Waiting{Thread: p.Threads[t].Name, Object: object}
+		if holder, ok := m.owner[object]; ok && holder >= 0 {
+			w.Holder = p.Threads[holder].Name
+		}
+		m.r.Deadlock = append(m.r.Deadlock, w)
+	}
+
+	return *m.r
+}
+
+// step runs the next operation of thread t.
+func (m *machine) step(time, t int) {
+	name := m.p.Threads[t].Name
+	op := m.p.Threads[t].Ops[m.pc[t]]
+	e := Event{Time: time, Thread: name, Op: op}
+	violate := func(format string, args ...any) {
+		m.r.Violations = append(m.r.Violations, Violation{Time: time, Thread: name,
+			Problem: fmt.Sprintf(format, args...)})
+	}
+	block := func() {
+		m.queue[op.Object] = append(m.queue[op.Object], t)
+		m.blocked[t], e.Blocked = op.Object, true
+	}
+
+	switch op.Kind {
+	case Work:
+		e.Outcome = "works"
+	case Wait:
+		if n := m.count[op.Object]; n > 0 {
+			m.count[op.Object] = n - 1
+			e.Outcome = fmt.Sprintf("%s %d -> %d", op.Object, n, n-1)
+		} else {
+			block()
+			e.Outcome = fmt.Sprintf("blocks, %s is 0", op.Object)
+		}
+	case Signal:
+		if w, ok := m.wake(time, op.Object); ok {
+			e.Outcome = fmt.Sprintf("wakes %s", m.p.Threads[w].Name)
+		} else {
+			m.count[op.Object]++
+			e.Outcome = fmt.Sprintf("%s %d -> %d", op.Object, m.count[op.Object]-1, m.count[op.Object])
+		}
+	case Lock:
+		switch holder := m.owner[op.Object]; holder {
+		case -1:
+			m.owner[op.Object] = t
+			e.Outcome = "acquires " + op.Object
+		case t:
+			violate("locks %s, which it already holds", op.Object)
+			block()
+			e.Outcome = "blocks on itself"
+		default:
+			block()
+			e.Outcome = fmt.Sprintf("blocks, %s held by %s", op.Object, m.p.Threads[holder].Name)
+		}
+	case Unlock:
+		switch holder := m.owner[op.Object]; {
+		case holder == -1:
+			violate("unlocks %s, which is not locked", op.Object)
+			e.Outcome = "ignored"
+		case holder != t:
+			violate("unlocks %s, held by %s", op.Object, m.p.Threads[holder].Name)
+			e.Outcome = "ignored"
+		default:
+			if w, ok := m.wake(time, op.Object); ok {
+				m.owner[op.Object] = w
+				e.Outcome = fmt.Sprintf("hands %s to %s", op.Object, m.p.Threads[w].Name)
+			} else {
+				m.owner[op.Object] = -1
+				e.Outcome = "releases " + op.Object
+			}
+		}
+	case Enter:
+		if in := m.inside[op.Object]; len(in) > 0 {
+			violate("enters %s while %s is inside", op.Object, m.threadNames(in))
+		}
+		m.inside[op.Object] = append(m.inside[op.Object], t)
+		e.Outcome = "enters " + op.Object
+	case Exit:
+		if !m.leave(op.Object, t) {
+			violate("exits %s without entering it", op.Object)
+		}
+		e.Outcome = "exits " + op.Object
+	}
+	if !e.Blocked {
+		m.advance(time, t)
+	}
+	m.r.Events = append(m.r.Events, e)
+}
+
+// wake unblocks the first thread blocked on object at time, moving it past the operation it blocked on, and returns
+// it.
+func (m *machine) wake(time int, object string) (int, bool) {
+	q := m.queue[object]
+	if len(q) == 0 {
+		return 0, false
+	}
+	w := q[0]
+	m.queue[object] = q[1:]
+	m.blocked[w] = ""
+	m.advance(time, w)
+
+	return w, true
+}
+
+// advance moves thread t to its next operation, flagging at time, if t has finished, any mutex it still holds or
+// region it is still inside.
+func (m *machine) advance(time, t int) {
+	m.pc[t]++
+	if m.pc[t] < len(m.p.Threads[t].Ops) {
+		return
+	}
+	name := m.p.Threads[t].Name
+	for _, mutex := range m.p.Mutexes {
+		if m.owner[mutex] == t {
+			m.r.Violations = append(m.r.Violations, Violation{Time: time, Thread: name,
+				Problem: "finishes holding " + mutex})
+		}
+	}
+	regions := make([]string, 0, len(m.inside))
+	for region := range m.inside {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	for _, region := range regions {
+		for _, i := range m.inside[region] {
+			if i == t {
+				m.r.Violations = append(m.r.Violations, Violation{Time: time, Thread: name,
+					Problem: "finishes inside " + region})
+			}
+		}
+	}
+}
+
+// leave takes t out of region, reporting whether it was inside.
+func (m *machine) leave(region string, t int) bool {
+	in := m.inside[region]
+	for i, u := range in {
+		if u == t {
+			m.inside[region] = append(in[:i:i], in[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *machine) threadNames(threads []int) string {
+	names := make([]string, len(threads))
+	for i, t := range threads {
+		names[i] = m.p.Threads[t].Name
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Parse reads a trace of entries, one per line or separated by semicolons, with # starting a comment:
+// "semaphore NAME COUNT" and "mutex NAME" declare the primitives, "thread NAME OP..." a thread, where each OP is one
+// of work, P(S), V(S), wait(S), signal(S), lock(M), unlock(M), enter(R), or exit(R), and "order THREAD..." the
+// threads to run first.
+func Parse(r io.Reader) (*Program, error) {
+	p := &Program{}
+	kinds := make(map[string]string) // whether each name is a semaphore, mutex, or thread
+	declares := map[string]bool{"semaphore": true, "mutex": true, "thread": true}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, entry := range strings.Split(text, ";") {
+			fields := strings.Fields(entry)
+			if len(fields) == 0 {
+				continue
+			}
+			if declares[fields[0]] && len(fields) > 1 && kinds[fields[1]] != "" {
+				return nil, fmt.Errorf("%w: line %d: %s is already a %s", ErrBadTrace, line, fields[1],
+					kinds[fields[1]])
+			}
+			switch fields[0] {
+			case "semaphore":
+				n, err := 0, error(nil)
+				if len(fields) == 3 {
+					n, err = strconv.Atoi(fields[2])
+				}
+				if len(fields) != 3 || err != nil || n < 0 {
+					return nil, fmt.Errorf("%w: line %d: want \"semaphore NAME COUNT\", got %q", ErrBadTrace, line,
+						strings.TrimSpace(entry))
+				}
+				p.Semaphores = append(p.Semaphores, Semaphore{Name: fields[1], Initial: n})
+				kinds[fields[1]] = "semaphore"
+			case "mutex":
+				if len(fields) != 2 {
+					return nil, fmt.Errorf("%w: line %d: want \"mutex NAME\", got %q", ErrBadTrace, line,
+						strings.TrimSpace(entry))
+				}
+				p.Mutexes = append(p.Mutexes, fields[1])
+				kinds[fields[1]] = "mutex"
+			case "thread":
+				if len(fields) < 3 {
+					return nil, fmt.Errorf("%w: line %d: want \"thread NAME OP...\", got %q", ErrBadTrace, line,
+						strings.TrimSpace(entry))
+				}
+				thread := Thread{Name: fields[1]}
+				for _, field := range fields[2:] {
+					op, err := parseOp(field, kinds)
+					if err != nil {
+						return nil, fmt.Errorf("line %d: thread %s: %w", line, thread.Name, err)
+					}
+					thread.Ops = append(thread.Ops, op)
+				}
+				p.Threads = append(p.Threads, thread)
+				kinds[fields[1]] = "thread"
+			case "order":
+				p.Order = append(p.Order, fields[1:]...)
+			default:
+				return nil, fmt.Errorf("%w: line %d: %q is not semaphore, mutex, thread, or order", ErrBadTrace, line,
+					fields[0])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading trace", err)
+	}
+	if len(p.Threads) == 0 {
+		return nil, fmt.Errorf("%w: no threads", ErrBadTrace)
+	}
+	for _, name := range p.Order {
+		if kinds[name] != "thread" {
+			return nil, fmt.Errorf("%w: order names %s, which is not a thread", ErrBadTrace, name)
+		}
+	}
+
+	return p, nil
+}
+
+// parseOp parses one operation of a thread, checking that it names a primitive of the right kind.
+func parseOp(field string, kinds map[string]string) (Op, error) {
+	if field == opNames[Work] {
+		return Op{Kind: Work}, nil
+	}
+	name, object, ok := strings.Cut(strings.TrimSuffix(field, ")"), "(")
+	if !ok || object == "" || !strings.HasSuffix(field, ")") {
+		return Op{}, fmt.Errorf("%w: %q is not an operation such as P(S) or lock(M)", ErrBadTrace, field)
+	}
+	var op Op
+	switch name {
+	case "P", "wait":
+		op = Op{Kind: Wait, Object: object}
+	case "V", "signal":
+		op = Op{Kind: Signal, Object: object}
+	case "lock":
+		op = Op{Kind: Lock, Object: object}
+	case "unlock":
+		op = Op{Kind: Unlock, Object: object}
+	case "enter":
+		return Op{Kind: Enter, Object: object}, nil
+	case "exit":
+		return Op{Kind: Exit, Object: object}, nil
+	default:
+		return Op{}, fmt.Errorf("%w: %q is not work, P, V, wait, signal, lock, unlock, enter, or exit", ErrBadTrace,
+			name)
+	}
+	want := "semaphore"
+	if op.Kind == Lock || op.Kind == Unlock {
+		want = "mutex"
+	}
+	if kinds[object] != want {
+		return Op{}, fmt.Errorf("%w: %s needs a declared %s", ErrBadTrace, op, want)
+	}
+
+	return op, nil
+}
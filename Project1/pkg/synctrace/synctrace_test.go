@@ -0,0 +1,147 @@
+package synctrace
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, in string) *Program {
+	t.Helper()
+	p, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	p := mustParse(t, "semaphore S 1 # binary\nmutex M; thread T1 P(S) work V(S)\nthread T2 lock(M) enter(cs) exit(cs)"+
+		" unlock(M) wait(S) signal(S); order T2 T1")
+	want := &Program{
+		Semaphores: []Semaphore{{Name: "S", Initial: 1}},
+		Mutexes:    []string{"M"},
+		Threads: []Thread{
+			{Name: "T1", Ops: []Op{{Kind: Wait, Object: "S"}, {Kind: Work}, {Kind: Signal, Object: "S"}}},
+			{Name: "T2", Ops: []Op{{Kind: Lock, Object: "M"}, {Kind: Enter, Object: "cs"}, {Kind: Exit, Object: "cs"},
+				{Kind: Unlock, Object: "M"}, {Kind: Wait, Object: "S"}, {Kind: Signal, Object: "S"}}},
+		},
+		Order: []string{"T2", "T1"},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Parse() = %+v, want %+v", p, want)
+	}
+
+	for _, in := range []string{
+		"semaphore S",
+		"semaphore S -1; thread T work",
+		"mutex M; thread T P(M)",
+		"semaphore S 1; thread T lock(S)",
+		"thread T P(S)",
+		"thread T acquire(S)",
+		"thread T lock(M",
+		"thread T",
+		"mutex M; mutex M; thread T work",
+		"thread T work; order U",
+		"semaphore S 0",
+		"barrier B",
+	} {
+		if _, err := Parse(strings.NewReader(in)); !errors.Is(err, ErrBadTrace) {
+			t.Errorf("Parse(%q) error = %v, want %v", in, err, ErrBadTrace)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		in         string
+		outcomes   []string
+		violations []string
+		deadlock   []string
+	}{
+		{
+			name: "semaphore handoff",
+			in:   "semaphore S 1; thread A P(S) work V(S); thread B P(S) V(S)",
+			outcomes: []string{"A P(S) S 1 -> 0", "B P(S) blocks, S is 0", "A work works", "A V(S) wakes B",
+				"B V(S) S 0 -> 1"},
+		},
+		{
+			name: "deadlock",
+			in:   "mutex M; mutex N; thread A lock(M) lock(N); thread B lock(N) lock(M)",
+			outcomes: []string{"A lock(M) acquires M", "B lock(N) acquires N", "A lock(N) blocks, N held by B",
+				"B lock(M) blocks, M held by A"},
+			deadlock: []string{"A waits for N held by B", "B waits for M held by A"},
+		},
+		{
+			name: "unprotected region",
+			in:   "mutex M; thread A enter(cs) exit(cs) unlock(M); thread B enter(cs) exit(cs) lock(M)",
+			outcomes: []string{"A enter(cs) enters cs", "B enter(cs) enters cs", "A exit(cs) exits cs",
+				"B exit(cs) exits cs", "A unlock(M) ignored", "B lock(M) acquires M"},
+			violations: []string{"1 B enters cs while A is inside", "4 A unlocks M, which is not locked",
+				"5 B finishes holding M"},
+		},
+		{
+			name:       "relock",
+			in:         "mutex M; semaphore S 0; thread A lock(M) lock(M); thread B P(S)",
+			outcomes:   []string{"A lock(M) acquires M", "B P(S) blocks, S is 0", "A lock(M) blocks on itself"},
+			violations: []string{"2 A locks M, which it already holds"},
+			deadlock:   []string{"A waits for M held by A", "B waits on S"},
+		},
+		{
+			name:     "order",
+			in:       "semaphore S 0; thread A P(S); thread B V(S); order A A B",
+			outcomes: []string{"A P(S) blocks, S is 0", "B V(S) wakes A"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := Run(mustParse(t, tt.in), RoundRobin)
+			var outcomes, violations, deadlock []string
+			for _, e := range r.Events {
+				outcomes = append(outcomes, e.Thread+" "+e.Op.String()+" "+e.Outcome)
+			}
+			for _, v := range r.Violations {
+				violations = append(violations, fmt.Sprintf("%d %s %s", v.Time, v.Thread, v.Problem))
+			}
+			for _, w := range r.Deadlock {
+				deadlock = append(deadlock, w.String())
+			}
+			if !reflect.DeepEqual(outcomes, tt.outcomes) {
+				t.Errorf("events = %q, want %q", outcomes, tt.outcomes)
+			}
+			if !reflect.DeepEqual(violations, tt.violations) {
+				t.Errorf("violations = %q, want %q", violations, tt.violations)
+			}
+			if !reflect.DeepEqual(deadlock, tt.deadlock) {
+				t.Errorf("deadlock = %q, want %q", deadlock, tt.deadlock)
+			}
+			if r.Time != len(r.Events) {
+				t.Errorf("Time = %d, want %d", r.Time, len(r.Events))
+			}
+		})
+	}
+}
+
+func TestRun_random(t *testing.T) {
+	t.Parallel()
+	// with a mutex around the region, no interleaving puts two threads inside it at once
+	p := mustParse(t, "mutex M; thread A lock(M) enter(cs) work exit(cs) unlock(M) work; "+
+		"thread B work lock(M) enter(cs) exit(cs) unlock(M); thread C lock(M) enter(cs) exit(cs) unlock(M)")
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		r := Run(p, Random(rng))
+		if len(r.Violations) > 0 || len(r.Deadlock) > 0 || r.Time < 15 {
+			t.Fatalf("Run() = %+v and %+v after %d, want no violations or deadlock after 15 or more", r.Violations,
+				r.Deadlock, r.Time)
+		}
+	}
+}
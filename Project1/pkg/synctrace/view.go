@@ -0,0 +1,38 @@
+package synctrace
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTimeline writes the timeline of every thread of r, one row each under its name and one mark per time unit,
+// under a ruler marking every tenth, and an X after the last time unit for every thread left deadlocked.
+func WriteTimeline(w io.Writer, r Result) error {
+	var b strings.Builder
+	label := 0
+	for _, t := range r.Program.Threads {
+		label = max(label, len(t.Name))
+	}
+	ruler := []byte(strings.Repeat(" ", r.Time))
+	for t := 0; t < r.Time; t += 10 {
+		n := fmt.Sprint(t)
+		copy(ruler[t:], n[:min(len(n), r.Time-t)])
+	}
+	fmt.Fprintf(&b, "%*s %s\n", label, "", strings.TrimRight(string(ruler), " "))
+	deadlocked := make(map[string]bool, len(r.Deadlock))
+	for _, d := range r.Deadlock {
+		deadlocked[d.Thread] = true
+	}
+	for i, timeline := range r.Timelines {
+		name := r.Program.Threads[i].Name
+		row := string(timeline)
+		if deadlocked[name] {
+			row += "X"
+		}
+		fmt.Fprintf(&b, "%*s %s\n", label, name, strings.TrimRight(row, " "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
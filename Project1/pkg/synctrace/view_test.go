@@ -0,0 +1,41 @@
+package synctrace
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTimeline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "finished",
+			in:   "mutex M; thread A lock(M) work unlock(M); thread Bee lock(M) unlock(M)",
+			want: "    0\n" +
+				"  A #.##\n" +
+				"Bee .#-.#\n",
+		},
+		{
+			name: "deadlock",
+			in:   "mutex M; mutex N; thread A lock(M) lock(N); thread B lock(N) lock(M)",
+			want: "  0\nA #.#-X\nB .#.#X\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := WriteTimeline(&w, Run(mustParse(t, tt.in), RoundRobin)); err != nil {
+				t.Fatal(err)
+			}
+			if w.String() != tt.want {
+				t.Errorf("WriteTimeline() =\n%s\nwant\n%s", w.String(), tt.want)
+			}
+		})
+	}
+}
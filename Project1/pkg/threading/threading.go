@@ -0,0 +1,183 @@
+// Package threading compares threading models for processes made of several threads, each running bursts with
+// blocking calls, such as reads, between them. Under many-to-one (user-level) threading the kernel schedules each
+// process as one entity and a thread library inside it runs its threads in turn, so a thread that blocks blocks the
+// whole process, threads ready to run and all. Under one-to-one (kernel-level) threading the kernel schedules every
+// thread on its own, and a blocking thread holds up nobody else.
+package threading
+
+import (
+	"context"
+	"fmt"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// Model is a threading model along with the name it is selected by, the title its reports carry, and a one-line
+// description for listings.
+type Model struct {
+	Name        string
+	Title       string
+	Description string
+	// kernel is whether the kernel schedules every thread, rather than every process.
+	kernel bool
+}
+
+// Models lists every threading model in the order they run by default.
+var Models = []Model{
+	{Name: "many-to-one", Title: "Many-to-one (user-level)",
+		Description: "the kernel sees one entity per process, so a blocking thread blocks them all"},
+	{Name: "one-to-one", Title: "One-to-one (kernel-level)",
+		Description: "the kernel schedules every thread, so a blocking thread blocks only itself", kernel: true},
+}
+
+// Entity is what the kernel schedules: a whole process under many-to-one, with PID its own, or one of its threads
+// under one-to-one, with PIDs numbered from 1 across every thread in workload order. Thread is the index of the
+// thread among its process's, or -1 for a whole process.
+type Entity struct {
+	PID     int64
+	Process int64
+	Thread  int
+}
+
+// Label names e, such as "P2" or "P2.T1".
+func (e Entity) Label() string {
+	if e.Thread < 0 {
+		return fmt.Sprintf("P%d", e.Process)
+	}
+
+	return fmt.Sprintf("P%d.T%d", e.Process, e.Thread+1)
+}
+
+// Process is how a process fared under a model: Blocked is the time its threads spent in blocking calls, and Stalled
+// the part of that its other threads were held up for, ready to run but blocked along with it.
+type Process struct {
+	workload.Process
+	Threads int
+	Blocked int64
+	Stalled int64
+}
+
+// Result is a run of a workload under a model: what the kernel scheduled, the Gantt chart of it, and every process in
+// workload order.
+type Result struct {
+	Model     Model
+	Entities  []Entity
+	Gantt     []sched.TimeSlice
+	Processes []Process
+}
+
+// entity is the state of a scheduled entity: the threads it runs one after another, the one running, which of its
+// bursts, and how much of that burst is left.
+type entity struct {
+	process int // index into the workload
+	threads []workload.Thread
+	thread  int
+	burst   int
+	left    int64
+}
+
+// Threads returns the threads of p, or one thread running its whole burst if it has none.
+func Threads(p workload.Process) ([]workload.Thread, error) {
+	threads, err := workload.ParseThreads(p.Threads)
+	if err != nil {
+		return nil, fmt.Errorf("P%d: %w", p.ProcessID, err)
+	}
+	if len(threads) == 0 {
+		threads = []workload.Thread{{Bursts: []int64{p.BurstDuration}}}
+	}
+
+	return threads, nil
+}
+
+// Run runs processes, which must be in arrival order, under m with the kernel scheduling by policy. A thread blocks
+// for each of its blocking calls once it has run the burst before it and the kernel next picks what it belongs to.
+// Under many-to-one the thread library runs each thread of a process until it finishes before starting the next.
+func Run(ctx context.Context, processes []workload.Process, m Model, policy sched.Policy) (Result, error) {
+	r := Result{Model: m, Processes: make([]Process, len(processes))}
+	var (
+		kernel []workload.Process
+		states = make(map[int64]*entity)
+	)
+	for i, p := range processes {
+		threads, err := Threads(p)
+		if err != nil {
+			return Result{}, err
+		}
+		r.Processes[i] = Process{Process: p, Threads: len(threads)}
+		if !m.kernel {
+			r.Entities = append(r.Entities, Entity{PID: p.ProcessID, Process: p.ProcessID, Thread: -1})
+			kernel = append(kernel, p)
+			states[p.ProcessID] = &entity{process: i, threads: threads, left: threads[0].Bursts[0]}
+			continue
+		}
+		for t, thread := range threads {
+			pid := int64(len(kernel) + 1)
+			r.Entities = append(r.Entities, Entity{PID: pid, Process: p.ProcessID, Thread: t})
+			kernel = append(kernel, workload.Process{ProcessID: pid, ArrivalTime: p.ArrivalTime,
+				BurstDuration: thread.CPU(), Priority: p.Priority})
+			states[pid] = &entity{process: i, threads: []workload.Thread{thread}, left: thread.Bursts[0]}
+		}
+	}
+
+	block := func(_ int64, p workload.Process) int64 {
+		e := states[p.ProcessID]
+		if e.left == 0 {
+			thread := e.threads[e.thread]
+			if e.burst < len(thread.Blocks) {
+				units := thread.Blocks[e.burst]
+				e.burst++
+				e.left = thread.Bursts[e.burst]
+				r.Processes[e.process].Blocked += units
+				if e.thread < len(e.threads)-1 {
+					// the threads still to run are ready, but blocked along with this one
+					r.Processes[e.process].Stalled += units
+				}
+				return units
+			}
+			e.thread, e.burst = e.thread+1, 0
+			e.left = e.threads[e.thread].Bursts[0]
+		}
+		e.left--
+		return 0
+	}
+	sim := sched.NewSimulation(kernel)
+	if err := sim.RunBlocking(ctx, policy, block, -1); err != nil {
+		return Result{}, err
+	}
+
+	result := sim.Result()
+	r.Gantt = result.Slices
+	for _, done := range result.PerProcess {
+		p := &r.Processes[states[done.ProcessID].process]
+		if done.Completion > p.Completion {
+			p.Completion = done.Completion
+			p.Turnaround = p.Completion - p.ArrivalTime
+		}
+	}
+
+	return r, nil
+}
+
+// AverageTurnaround returns the mean turnaround of the processes of r.
+func (r Result) AverageTurnaround() float64 {
+	if len(r.Processes) == 0 {
+		return 0
+	}
+	var total int64
+	for _, p := range r.Processes {
+		total += p.Turnaround
+	}
+
+	return float64(total) / float64(len(r.Processes))
+}
+
+// Stalled returns the time every process of r spent with ready threads held up by a blocking one.
+func (r Result) Stalled() int64 {
+	var total int64
+	for _, p := range r.Processes {
+		total += p.Stalled
+	}
+
+	return total
+}
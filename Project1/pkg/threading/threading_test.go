@@ -0,0 +1,77 @@
+package threading
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	// P1's first thread blocks for 4 after its first unit, with its second thread ready to run
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 4, Threads: "1/4/1 2"},
+		{ProcessID: 2, BurstDuration: 3}}
+	tests := []struct {
+		model       Model
+		labels      []string
+		gantt       []sched.TimeSlice
+		completions []int64
+		stalled     []int64
+	}{
+		{
+			model:       Models[0],
+			labels:      []string{"P1", "P2"},
+			gantt:       []sched.TimeSlice{{PID: 1, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 5, Stop: 8}},
+			completions: []int64{8, 4},
+			stalled:     []int64{4, 0},
+		},
+		{
+			model:  Models[1],
+			labels: []string{"P1.T1", "P1.T2", "P2.T1"},
+			gantt: []sched.TimeSlice{{PID: 1, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 3, Start: 3, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7}},
+			completions: []int64{7, 6},
+			stalled:     []int64{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.model.Name, func(t *testing.T) {
+			t.Parallel()
+			r, err := Run(context.Background(), processes, tt.model, sched.FCFSPolicy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var labels []string
+			for _, e := range r.Entities {
+				labels = append(labels, e.Label())
+			}
+			if !reflect.DeepEqual(labels, tt.labels) || !reflect.DeepEqual(r.Gantt, tt.gantt) {
+				t.Errorf("Run() = %v with %v, want %v with %v", labels, r.Gantt, tt.labels, tt.gantt)
+			}
+			for i, p := range r.Processes {
+				if p.Completion != tt.completions[i] || p.Stalled != tt.stalled[i] || p.Blocked != 4*int64(1-i) {
+					t.Errorf("P%d = %+v, want completion %d, stalled %d", p.ProcessID, p, tt.completions[i],
+						tt.stalled[i])
+				}
+			}
+		})
+	}
+
+	if _, err := Run(context.Background(), []workload.Process{{ProcessID: 1, BurstDuration: 1, Threads: "1/"}},
+		Models[0], sched.FCFSPolicy); err == nil {
+		t.Error("Run() of malformed threads succeeded")
+	}
+}
+
+func TestResult(t *testing.T) {
+	t.Parallel()
+	r := Result{Processes: []Process{{Process: workload.Process{Turnaround: 4}, Stalled: 2},
+		{Process: workload.Process{Turnaround: 7}, Stalled: 1}}}
+	if r.AverageTurnaround() != 5.5 || r.Stalled() != 3 {
+		t.Errorf("AverageTurnaround() = %.2f, Stalled() = %d, want 5.50 and 3", r.AverageTurnaround(), r.Stalled())
+	}
+}
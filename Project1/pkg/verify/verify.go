@@ -0,0 +1,160 @@
+// Package verify checks scheduling results against invariants every correct schedule keeps, whatever the algorithm,
+// for property tests, fuzzing, and trying out new schedulers.
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// ErrViolation marks a result that breaks an invariant.
+var ErrViolation = errors.New("invariant violated")
+
+// Invariant is a property of a complete run of processes that produced r.
+type Invariant struct {
+	Name  string
+	Check func(processes []workload.Process, r sched.Result) error
+}
+
+// Invariants are the properties Check verifies, in the order it checks them.
+var Invariants = []Invariant{
+	{Name: "every process finishes once", Check: EveryProcessFinishes},
+	{Name: "valid Gantt chart", Check: ValidGantt},
+	{Name: "service equals total burst", Check: ServiceEqualsBurst},
+	{Name: "no process runs before it arrives", Check: NoEarlyStart},
+	{Name: "CPU never idles with work ready", Check: WorkConserving},
+	{Name: "metrics agree with completions", Check: ConsistentMetrics},
+	{Name: "summary averages the processes", Check: ConsistentSummary},
+}
+
+// Check verifies every one of Invariants for the complete run of processes that produced r. It returns every
+// violation found, joined, each an ErrViolation naming its invariant.
+func Check(processes []workload.Process, r sched.Result) error {
+	var violations []error
+	for _, inv := range Invariants {
+		if err := inv.Check(processes, r); err != nil {
+			violations = append(violations, fmt.Errorf("%w: %s: %w", ErrViolation, inv.Name, err))
+		}
+	}
+
+	return errors.Join(violations...)
+}
+
+// EveryProcessFinishes checks that r finishes every process exactly once, with the arrival and burst it was given.
+func EveryProcessFinishes(processes []workload.Process, r sched.Result) error {
+	finished := make(map[int64]sched.ProcessMetrics, len(r.PerProcess))
+	for _, p := range r.PerProcess {
+		if _, ok := finished[p.ProcessID]; ok {
+			return fmt.Errorf("P%d finishes twice", p.ProcessID)
+		}
+		finished[p.ProcessID] = p
+	}
+	for _, p := range processes {
+		done, ok := finished[p.ProcessID]
+		switch {
+		case !ok:
+			return fmt.Errorf("P%d never finishes", p.ProcessID)
+		case done.ArrivalTime != p.ArrivalTime || done.Burst != p.BurstDuration:
+			return fmt.Errorf("P%d finishes with arrival %d and burst %d, want %d and %d",
+				p.ProcessID, done.ArrivalTime, done.Burst, p.ArrivalTime, p.BurstDuration)
+		}
+	}
+	if len(finished) != len(processes) {
+		return fmt.Errorf("%d processes finish, want %d", len(finished), len(processes))
+	}
+
+	return nil
+}
+
+// ValidGantt checks r's Gantt chart with sched.ValidateGantt.
+func ValidGantt(_ []workload.Process, r sched.Result) error {
+	return sched.ValidateGantt(r.Slices, r.PerProcess)
+}
+
+// ServiceEqualsBurst checks that the slices of r, not counting context-switch overhead, add up to the total burst of
+// processes.
+func ServiceEqualsBurst(processes []workload.Process, r sched.Result) error {
+	var service, burst int64
+	for _, s := range r.Slices {
+		if !s.Switch {
+			service += s.Stop - s.Start
+		}
+	}
+	for _, p := range processes {
+		burst += p.BurstDuration
+	}
+	if service != burst {
+		return fmt.Errorf("slices add up to %d, want the total burst of %d", service, burst)
+	}
+
+	return nil
+}
+
+// NoEarlyStart checks that no slice of r runs a process before it arrives.
+func NoEarlyStart(processes []workload.Process, r sched.Result) error {
+	arrival := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		arrival[p.ProcessID] = p.ArrivalTime
+	}
+	for _, s := range r.Slices {
+		if at, ok := arrival[s.PID]; ok && !s.Switch && s.Start < at {
+			return fmt.Errorf("P%d runs at %d before it arrives at %d", s.PID, s.Start, at)
+		}
+	}
+
+	return nil
+}
+
+// WorkConserving checks that the CPU of r is never idle while a process has arrived and not yet finished.
+func WorkConserving(processes []workload.Process, r sched.Result) error {
+	finished := make(map[int64]int64, len(r.PerProcess))
+	for _, p := range r.PerProcess {
+		finished[p.ProcessID] = p.Completion
+	}
+	slices := append([]sched.TimeSlice(nil), r.Slices...)
+	sort.SliceStable(slices, func(i, j int) bool { return slices[i].Start < slices[j].Start })
+
+	var idleFrom int64 // when the CPU last became idle
+	for _, slice := range slices {
+		if slice.Start > idleFrom {
+			for _, p := range processes {
+				if done, ok := finished[p.ProcessID]; ok && p.ArrivalTime < slice.Start && done > idleFrom {
+					return fmt.Errorf("CPU idles from %d to %d while P%d is ready", max(idleFrom, p.ArrivalTime),
+						slice.Start, p.ProcessID)
+				}
+			}
+		}
+		idleFrom = max(idleFrom, slice.Stop)
+	}
+
+	return nil
+}
+
+// ConsistentMetrics checks that every finished process of r has a turnaround from its arrival to its completion and
+// a wait of that turnaround less its burst.
+func ConsistentMetrics(_ []workload.Process, r sched.Result) error {
+	for _, p := range r.PerProcess {
+		if p.Turnaround != p.Completion-p.ArrivalTime {
+			return fmt.Errorf("P%d has turnaround %d, want completion %d less arrival %d",
+				p.ProcessID, p.Turnaround, p.Completion, p.ArrivalTime)
+		}
+		if p.Wait != p.Turnaround-p.Burst {
+			return fmt.Errorf("P%d waits %d, want turnaround %d less burst %d", p.ProcessID, p.Wait, p.Turnaround, p.Burst)
+		}
+	}
+
+	return nil
+}
+
+// ConsistentSummary checks that the summary of r is the average of its finished processes.
+func ConsistentSummary(_ []workload.Process, r sched.Result) error {
+	if want := sched.Summarize(r.PerProcess); r.Summary != want {
+		return fmt.Errorf("summary is %+v, want %+v", r.Summary, want)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// schedulers are the built-in algorithms the property tests hold to the invariants.
+var schedulers = map[string]sched.Func{
+	"fcfs":     sched.FCFS,
+	"sjf":      sched.SJF,
+	"priority": sched.Priority,
+	"rr": func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+		return sched.RR(ctx, processes, 3)
+	},
+	"srtf policy": func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+		return sched.RunPolicy(ctx, processes, sched.SRTFPolicy)
+	},
+	"lottery": func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+		return sched.RunPolicy(ctx, processes, sched.LotteryPolicy(rand.New(rand.NewSource(1))))
+	},
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	valid, err := sched.FCFS(context.Background(), processes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(processes, valid); err != nil {
+		t.Fatalf("Check() of FCFS = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(r *sched.Result)
+		want    func([]workload.Process, sched.Result) error
+	}{
+		{
+			name:    "process missing",
+			corrupt: func(r *sched.Result) { r.PerProcess = r.PerProcess[:1] },
+			want:    EveryProcessFinishes,
+		},
+		{
+			name:    "slice too short",
+			corrupt: func(r *sched.Result) { r.Slices[1].Stop-- },
+			want:    ServiceEqualsBurst,
+		},
+		{
+			name:    "runs early",
+			corrupt: func(r *sched.Result) { r.Slices[1].Start, r.Slices[1].Stop = 0, 2 },
+			want:    NoEarlyStart,
+		},
+		{
+			name: "idles with work ready",
+			corrupt: func(r *sched.Result) {
+				r.Slices[1].Start, r.Slices[1].Stop = 4, 6
+				r.PerProcess[1].Completion, r.PerProcess[1].Turnaround, r.PerProcess[1].Wait = 6, 5, 3
+			},
+			want: WorkConserving,
+		},
+		{
+			name:    "wrong wait",
+			corrupt: func(r *sched.Result) { r.PerProcess[1].Wait++ },
+			want:    ConsistentMetrics,
+		},
+		{
+			name:    "stale summary",
+			corrupt: func(r *sched.Result) { r.Summary.Wait = 0 },
+			want:    ConsistentSummary,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := sched.Result{
+				Slices:     append([]sched.TimeSlice(nil), valid.Slices...),
+				PerProcess: append([]sched.ProcessMetrics(nil), valid.PerProcess...),
+				Summary:    valid.Summary,
+			}
+			tt.corrupt(&r)
+			if err := tt.want(processes, r); err == nil {
+				t.Errorf("invariant passed a broken result: %+v", r)
+			}
+			if err := Check(processes, r); !errors.Is(err, ErrViolation) {
+				t.Errorf("Check() = %v, want %v", err, ErrViolation)
+			}
+		})
+	}
+}
+
+func TestInvariants_random(t *testing.T) {
+	t.Parallel()
+	for seed := int64(0); seed < 200; seed++ {
+		processes := workload.Generate(rand.New(rand.NewSource(seed)),
+			workload.GenerateOptions{Count: 10, MaxBurst: 8, MaxArrival: 30, MaxPriority: 4})
+		for name, schedule := range schedulers {
+			r, err := schedule(context.Background(), processes)
+			if err != nil {
+				t.Fatalf("%s on seed %d: %v", name, seed, err)
+			}
+			if err := Check(processes, r); err != nil {
+				t.Errorf("%s on seed %d: %v", name, seed, err)
+			}
+			// context switches delay the rest of the schedule, idle time included, but it must still be one
+			charged := sched.ChargeContextSwitches(r, 2)
+			for _, check := range []func([]workload.Process, sched.Result) error{
+				EveryProcessFinishes, ValidGantt, ServiceEqualsBurst, NoEarlyStart, ConsistentMetrics, ConsistentSummary,
+			} {
+				if err := check(processes, charged); err != nil {
+					t.Errorf("%s on seed %d with switch cost: %v", name, seed, err)
+				}
+			}
+		}
+	}
+}
+
+// FuzzSchedulers holds every built-in scheduler to the invariants on workloads built from the fuzzer's bytes, three
+// to a process: burst, arrival, and priority.
+func FuzzSchedulers(f *testing.F) {
+	f.Add([]byte{5, 0, 2, 9, 3, 1, 6, 3, 3})
+	f.Add([]byte{1, 10, 0, 1, 10, 0})
+	f.Add([]byte{24, 0, 0, 3, 0, 0, 3, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var processes []workload.Process
+		for i := 0; i+2 < len(data) && len(processes) < 32; i += 3 {
+			processes = append(processes, workload.Process{
+				BurstDuration: int64(data[i]%16) + 1,
+				ArrivalTime:   int64(data[i+1] % 64),
+				Priority:      int64(data[i+2] % 8),
+			})
+		}
+		processes = workload.Normalize(processes)
+		for name, schedule := range schedulers {
+			r, err := schedule(context.Background(), processes)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if err := Check(processes, r); err != nil {
+				t.Errorf("%s on %v: %v", name, processes, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package workload
+
+import (
+	"math"
+	"math/rand"
+)
+
+// profile tells the arrival and burst patterns Generate can draw apart.
+type profile int
+
+const (
+	uniform profile = iota
+	bursty
+	diurnal
+	heavyTail
+	mixed
+)
+
+// Profile is a pattern of arrivals, bursts, and priorities Generate draws a workload from, along with the name it is
+// selected by, the title its reports carry, and a one-line description for listings. The zero Profile is uniform.
+type Profile struct {
+	Name        string
+	Title       string
+	Description string
+	kind        profile
+}
+
+// Profiles lists every generator profile, the default first.
+var Profiles = []Profile{
+	{Name: "uniform", Title: "Uniform",
+		Description: "arrivals, bursts, and priorities all uniform over their ranges", kind: uniform},
+	{Name: "bursty", Title: "Bursty arrivals",
+		Description: "processes arrive in a few tight clusters with quiet gaps between them", kind: bursty},
+	{Name: "diurnal", Title: "Diurnal wave",
+		Description: "arrivals follow a day: quiet at the start and end, peaking midway", kind: diurnal},
+	{Name: "heavy-tail", Title: "Heavy-tail bursts",
+		Description: "Pareto bursts: most processes are short and a few run for the longest burst", kind: heavyTail},
+	{Name: "mixed", Title: "Mixed interactive/batch",
+		Description: "four in five processes interactive, short and urgent, the rest batch, long and least urgent",
+		kind:        mixed},
+}
+
+const (
+	// clusters is how many clusters a bursty workload's arrivals fall into, at most one per process.
+	clusters = 4
+	// paretoShape is the shape of the heavy-tailed bursts: below 2 their variance is infinite before the cap.
+	paretoShape = 1.2
+	// interactiveShare is the share of a mixed workload's processes that are interactive.
+	interactiveShare = 0.8
+)
+
+// draw fills in processes by the profile p, every value within the bounds of opts.
+func (p Profile) draw(rng *rand.Rand, opts GenerateOptions, processes []Process) {
+	// a bursty workload's clusters start at random and each spans a twentieth of the arrival range
+	var starts []int64
+	if p.kind == bursty {
+		starts = make([]int64, min(clusters, len(processes)))
+		for i := range starts {
+			starts[i] = rng.Int63n(opts.MaxArrival + 1)
+		}
+	}
+	for i := range processes {
+		q := &processes[i]
+		q.BurstDuration = rng.Int63n(opts.MaxBurst) + 1
+		q.ArrivalTime = rng.Int63n(opts.MaxArrival + 1)
+		q.Priority = rng.Int63n(opts.MaxPriority) + 1
+		switch p.kind {
+		case bursty:
+			start := starts[rng.Intn(len(starts))]
+			q.ArrivalTime = min(opts.MaxArrival, start+rng.Int63n(opts.MaxArrival/20+1))
+		case diurnal:
+			// accept an arrival with the probability of the day's rate at it, 0 at midnight and 1 at noon
+			day := float64(opts.MaxArrival + 1)
+			for rng.Float64() > (1-math.Cos(2*math.Pi*(float64(q.ArrivalTime)+0.5)/day))/2 {
+				q.ArrivalTime = rng.Int63n(opts.MaxArrival + 1)
+			}
+		case heavyTail:
+			q.BurstDuration = min(opts.MaxBurst, int64(math.Pow(1-rng.Float64(), -1/paretoShape)))
+		case mixed:
+			if rng.Float64() < interactiveShare {
+				q.BurstDuration = rng.Int63n(max(1, opts.MaxBurst/5)) + 1
+				q.Priority = 1
+			} else {
+				q.BurstDuration = opts.MaxBurst/2 + rng.Int63n(opts.MaxBurst-opts.MaxBurst/2) + 1
+				q.Priority = opts.MaxPriority
+			}
+		}
+	}
+}
+
+// GeneratePoisson draws a workload of count processes for queueing-theory comparisons: Poisson arrivals at rate
+// arrivals per time unit, each arrival time the continuous one rounded down, and exponentially distributed bursts
+// averaging service, rounded and at least 1, all at priority 1.
+func GeneratePoisson(rng *rand.Rand, count int, rate, service float64) []Process {
+	processes := make([]Process, count)
+	var at float64
+	for i := range processes {
+		at += rng.ExpFloat64() / rate
+		processes[i] = Process{
+			ArrivalTime:   int64(at),
+			BurstDuration: max(1, int64(math.Round(rng.ExpFloat64()*service))),
+			Priority:      1,
+		}
+	}
+
+	return Normalize(processes)
+}
@@ -0,0 +1,111 @@
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestGenerate_profiles(t *testing.T) {
+	t.Parallel()
+	opts := GenerateOptions{Count: 400, MaxBurst: 20, MaxArrival: 99, MaxPriority: 5}
+	tests := []struct {
+		profile Profile
+		// check reports what is wrong with a workload drawn by profile, if anything
+		check func(processes []Process) string
+	}{
+		{profile: Profiles[1], check: func(processes []Process) string {
+			// 4 clusters each spanning 5 arrival times
+			times := make(map[int64]bool)
+			for _, p := range processes {
+				times[p.ArrivalTime] = true
+			}
+			if len(times) > 20 {
+				return fmt.Sprintf("arrivals at %d times", len(times))
+			}
+			return ""
+		}},
+		{profile: Profiles[2], check: func(processes []Process) string {
+			// the first and last tenths of the day see about 1 in 40 arrivals, the middle fifth about 2 in 5
+			night, noon := arrivals(processes, 0, 10)+arrivals(processes, 90, 100), arrivals(processes, 40, 60)
+			if 4*night >= noon {
+				return fmt.Sprintf("%d arrivals at night against %d at noon", night, noon)
+			}
+			return ""
+		}},
+		{profile: Profiles[3], check: func(processes []Process) string {
+			short, longest := 0, 0
+			for _, p := range processes {
+				if p.BurstDuration == 1 {
+					short++
+				}
+				if p.BurstDuration == 20 {
+					longest++
+				}
+			}
+			if short < len(processes)/2 || longest == 0 {
+				return "bursts not heavy-tailed"
+			}
+			return ""
+		}},
+		{profile: Profiles[4], check: func(processes []Process) string {
+			for _, p := range processes {
+				interactive := p.BurstDuration <= 4 && p.Priority == 1
+				batch := p.BurstDuration > 10 && p.Priority == 5
+				if !interactive && !batch {
+					return "a process neither interactive nor batch"
+				}
+			}
+			return ""
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.profile.Name, func(t *testing.T) {
+			t.Parallel()
+			opts := opts
+			opts.Profile = tt.profile
+			processes := Generate(rand.New(rand.NewSource(1)), opts)
+			for _, p := range processes {
+				if p.BurstDuration < 1 || p.BurstDuration > opts.MaxBurst ||
+					p.ArrivalTime < 0 || p.ArrivalTime > opts.MaxArrival ||
+					p.Priority < 1 || p.Priority > opts.MaxPriority {
+					t.Fatalf("process %+v out of bounds %+v", p, opts)
+				}
+			}
+			if problem := tt.check(processes); problem != "" {
+				t.Errorf("Generate() of %s: %s", tt.profile.Name, problem)
+			}
+		})
+	}
+}
+
+// arrivals counts the processes arriving from from up to to.
+func arrivals(processes []Process, from, to int64) int {
+	n := 0
+	for _, p := range processes {
+		if p.ArrivalTime >= from && p.ArrivalTime < to {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestGeneratePoisson(t *testing.T) {
+	t.Parallel()
+	processes := GeneratePoisson(rand.New(rand.NewSource(1)), 5000, 0.2, 4)
+	last := processes[len(processes)-1]
+	var bursts int64
+	for _, p := range processes {
+		bursts += p.BurstDuration
+	}
+	// 5000 arrivals at 0.2 per unit span about 25000 units, and bursts average a little over 4 for the rounding up
+	// of the shortest
+	if rate := float64(len(processes)) / float64(last.ArrivalTime); rate < 0.19 || rate > 0.21 {
+		t.Errorf("GeneratePoisson() arrives at rate %.3f, want 0.2", rate)
+	}
+	if mean := float64(bursts) / float64(len(processes)); mean < 3.9 || mean > 4.3 {
+		t.Errorf("GeneratePoisson() bursts average %.2f, want about 4", mean)
+	}
+}
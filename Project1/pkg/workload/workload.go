@@ -0,0 +1,328 @@
+// Package workload holds the processes a scheduler runs and reads, writes, and generates them in the
+// <ProcessID>,<Burst Duration>,<Arrival Time>,<Priority>,<Deadline>,<Threads> CSV format.
+package workload
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Process is one process of a workload. ProcessID, ArrivalTime, BurstDuration, Priority, and Deadline describe the
+// input; a scheduler fills in the remaining fields for the processes it finishes.
+type Process struct {
+	ProcessID     int64
+	ArrivalTime   int64
+	BurstDuration int64
+	Priority      int64
+	// Deadline is the time by which the process should complete, or 0 if it has none.
+	Deadline int64 `json:",omitempty"`
+	// Threads are the threads the process's burst is split among, written as ParseThreads reads them, or "" for a
+	// process of a single thread that never blocks. Kept as written, a Process stays a comparable value.
+	Threads    string `json:",omitempty"`
+	Wait       int64
+	Turnaround int64
+	Burst      int64
+	Completion int64
+}
+
+// ErrInvalidWorkload marks a workload CSV that parses but doesn't describe processes. The more specific errors below
+// all wrap it, so callers that don't care why a workload is invalid can check for it alone.
+var ErrInvalidWorkload = errors.New("invalid workload")
+
+var (
+	// ErrBadRecord marks a row with the wrong number of fields, a field that isn't an integer, or a value the
+	// schedulers can't run, such as a burst of 0.
+	ErrBadRecord = fmt.Errorf("%w: bad record", ErrInvalidWorkload)
+	// ErrDuplicatePID marks a process ID used by more than one row.
+	ErrDuplicatePID = fmt.Errorf("%w: duplicate pid", ErrInvalidWorkload)
+	// ErrInfeasibleDeadline marks a process that cannot meet its deadline even if it runs as soon as it arrives.
+	ErrInfeasibleDeadline = fmt.Errorf("%w: infeasible deadline", ErrInvalidWorkload)
+)
+
+// Thread is one thread of a process: Bursts are the time units it runs between blocking calls, such as reads, and
+// Blocks how long each call blocks it, one between every two bursts.
+type Thread struct {
+	Bursts []int64
+	Blocks []int64
+}
+
+// CPU returns the time units t runs in all.
+func (t Thread) CPU() int64 {
+	var total int64
+	for _, b := range t.Bursts {
+		total += b
+	}
+
+	return total
+}
+
+// String returns t in the workload format, its bursts and blocks alternating between slashes, such as "2/3/1".
+func (t Thread) String() string {
+	var b strings.Builder
+	for i, burst := range t.Bursts {
+		if i > 0 {
+			fmt.Fprintf(&b, "/%d/", t.Blocks[i-1])
+		}
+		b.WriteString(strconv.FormatInt(burst, 10))
+	}
+
+	return b.String()
+}
+
+// ParseThreads parses the threads of a process, separated by spaces, each written as its bursts and blocks
+// alternating between slashes, such as "2/3/1 4" for a thread that runs 2, blocks 3, and runs 1 more, and a second
+// thread that runs 4. Every burst and block must be at least 1.
+func ParseThreads(s string) ([]Thread, error) {
+	var threads []Thread
+	for _, field := range strings.Fields(s) {
+		parts := strings.Split(field, "/")
+		if len(parts)%2 == 0 {
+			return nil, fmt.Errorf("%w: thread %q must start and end with a burst", ErrBadRecord, field)
+		}
+		var t Thread
+		for i, part := range parts {
+			n, err := strconv.ParseInt(part, 10, 64)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: thread %q: %q is not a time of at least 1", ErrBadRecord, field, part)
+			}
+			if i%2 == 0 {
+				t.Bursts = append(t.Bursts, n)
+			} else {
+				t.Blocks = append(t.Blocks, n)
+			}
+		}
+		threads = append(threads, t)
+	}
+
+	return threads, nil
+}
+
+// FormatThreads writes threads as ParseThreads reads them.
+func FormatThreads(threads []Thread) string {
+	written := make([]string, len(threads))
+	for i, t := range threads {
+		written[i] = t.String()
+	}
+
+	return strings.Join(written, " ")
+}
+
+// fields names the CSV columns in order; the last three are optional.
+var fields = []string{"pid", "burst", "arrival", "priority", "deadline", "threads"}
+
+// Load parses a workload CSV with Parse and checks it with Check, so that the processes it returns are ones the
+// schedulers can run. A workload that fails Check is every problem found, joined, each naming its line.
+func Load(r io.Reader) ([]Process, error) {
+	processes, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	if problems := Check(processes); len(problems) > 0 {
+		errs := make([]error, len(problems))
+		for i, p := range problems {
+			if p.Index < 0 {
+				errs[i] = fmt.Errorf("%w: %s", p.Err, p.Message)
+			} else {
+				errs[i] = fmt.Errorf("%w: line %d: %s", p.Err, p.Index+1, p.Message)
+			}
+		}
+
+		return nil, errors.Join(errs...)
+	}
+
+	return processes, nil
+}
+
+// Parse parses a workload CSV without checking it. The priority, deadline, and threads columns are optional, a
+// deadline of 0 means none, and threads are written as ParseThreads reads them. A malformed row is an ErrBadRecord
+// naming its line and column.
+func Parse(r io.Reader) ([]Process, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // the field count check below reports a short or long row as an ErrBadRecord
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	processes := make([]Process, len(rows))
+	for i := range rows {
+		if len(rows[i]) < len(fields)-3 || len(rows[i]) > len(fields) {
+			return nil, fmt.Errorf("%w: line %d: want 3 to 6 fields, got %d", ErrBadRecord, i+1, len(rows[i]))
+		}
+		var written string
+		if len(rows[i]) == len(fields) {
+			threads, err := ParseThreads(rows[i][len(fields)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			written, rows[i] = FormatThreads(threads), rows[i][:len(fields)-1]
+		}
+		values := make([]int64, len(fields)-1)
+		for j, field := range rows[i] {
+			if values[j], err = strconv.ParseInt(field, 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s %q is not an integer", ErrBadRecord, i+1, fields[j], field)
+			}
+		}
+		processes[i] = Process{ProcessID: values[0], BurstDuration: values[1], ArrivalTime: values[2], Priority: values[3],
+			Deadline: values[4], Threads: written}
+	}
+
+	return processes, nil
+}
+
+// threadsCPU returns the time units threads run in all.
+func threadsCPU(threads []Thread) int64 {
+	var total int64
+	for _, t := range threads {
+		total += t.CPU()
+	}
+
+	return total
+}
+
+// Problem is one way a workload breaks what the schedulers rely on. Index is the position of the process at fault, or
+// -1 for the workload as a whole, and Err is the error the problem is, wrapping ErrInvalidWorkload.
+type Problem struct {
+	Index   int
+	Message string
+	Err     error
+}
+
+// Check returns every way processes break what the schedulers rely on: there must be at least one, PIDs must run from
+// 1 to the number of processes without duplicates, since schedulers index their results by PID, bursts must be at
+// least 1, arrivals must not be negative or before the process before, priorities must not be negative, threads must
+// run the process's whole burst among them, and a deadline must not be negative or, as an ErrInfeasibleDeadline,
+// before the process could finish even if it ran as soon as it arrived.
+func Check(processes []Process) []Problem {
+	if len(processes) == 0 {
+		return []Problem{{Index: -1, Message: "no processes", Err: ErrInvalidWorkload}}
+	}
+
+	var problems []Problem
+	seen := make(map[int64]bool, len(processes))
+	for i, p := range processes {
+		switch {
+		case p.ProcessID < 1 || p.ProcessID > int64(len(processes)):
+			problems = append(problems, Problem{i, fmt.Sprintf("pid %d must be from 1 to %d", p.ProcessID,
+				len(processes)), ErrBadRecord})
+		case seen[p.ProcessID]:
+			problems = append(problems, Problem{i, fmt.Sprintf("pid %d is used twice", p.ProcessID), ErrDuplicatePID})
+		}
+		seen[p.ProcessID] = true
+		if p.BurstDuration < 1 {
+			problems = append(problems, Problem{i, fmt.Sprintf("burst %d must be at least 1", p.BurstDuration),
+				ErrBadRecord})
+		}
+		switch {
+		case p.ArrivalTime < 0:
+			problems = append(problems, Problem{i, fmt.Sprintf("arrival %d must not be negative", p.ArrivalTime),
+				ErrBadRecord})
+		case i > 0 && p.ArrivalTime < processes[i-1].ArrivalTime:
+			problems = append(problems, Problem{i, fmt.Sprintf("arrival %d is before the previous process's %d",
+				p.ArrivalTime, processes[i-1].ArrivalTime), ErrBadRecord})
+		}
+		if p.Priority < 0 {
+			problems = append(problems, Problem{i, fmt.Sprintf("priority %d must not be negative", p.Priority),
+				ErrBadRecord})
+		}
+		if threads, err := ParseThreads(p.Threads); err != nil {
+			problems = append(problems, Problem{i, fmt.Sprintf("threads %q must be bursts and blocks of at least 1 "+
+				"between slashes, such as \"2/3/1 4\"", p.Threads), ErrBadRecord})
+		} else if cpu := threadsCPU(threads); len(threads) > 0 && cpu != p.BurstDuration {
+			problems = append(problems, Problem{i, fmt.Sprintf("threads run %d in all, not the burst %d", cpu,
+				p.BurstDuration), ErrBadRecord})
+		}
+		switch {
+		case p.Deadline < 0:
+			problems = append(problems, Problem{i, fmt.Sprintf("deadline %d must not be negative", p.Deadline),
+				ErrBadRecord})
+		case p.Deadline != 0 && p.Deadline < p.ArrivalTime+p.BurstDuration:
+			problems = append(problems, Problem{i, fmt.Sprintf("deadline %d is before %d, the earliest it can finish",
+				p.Deadline, p.ArrivalTime+p.BurstDuration), ErrInfeasibleDeadline})
+		}
+	}
+
+	return problems
+}
+
+// Write writes processes in the <ProcessID>,<Burst Duration>,<Arrival Time>,<Priority> input format, with a
+// <Deadline> column too if any process has a deadline, and <Threads> after it if any has threads.
+func Write(w io.Writer, processes []Process) error {
+	threads := false
+	for _, p := range processes {
+		threads = threads || p.Threads != ""
+	}
+	deadlines := threads || HasDeadlines(processes)
+	cw := csv.NewWriter(w)
+	for _, p := range processes {
+		record := []string{
+			strconv.FormatInt(p.ProcessID, 10),
+			strconv.FormatInt(p.BurstDuration, 10),
+			strconv.FormatInt(p.ArrivalTime, 10),
+			strconv.FormatInt(p.Priority, 10),
+		}
+		if deadlines {
+			record = append(record, strconv.FormatInt(p.Deadline, 10))
+		}
+		if threads {
+			record = append(record, p.Threads)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("%w: writing workload", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("%w: writing workload", err)
+	}
+
+	return nil
+}
+
+// HasDeadlines reports whether any of processes has a deadline.
+func HasDeadlines(processes []Process) bool {
+	for _, p := range processes {
+		if p.Deadline != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Normalize returns a copy of processes in the form the schedulers require: stably sorted by arrival time, with PIDs
+// renumbered from 1 in that order. It turns arbitrary processes, such as a fuzzer's, into a workload to schedule.
+func Normalize(processes []Process) []Process {
+	normalized := append([]Process(nil), processes...)
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return normalized[i].ArrivalTime < normalized[j].ArrivalTime
+	})
+	for i := range normalized {
+		normalized[i].ProcessID = int64(i + 1)
+	}
+
+	return normalized
+}
+
+// GenerateOptions bounds the random workload drawn by Generate, and Profile shapes it within those bounds.
+type GenerateOptions struct {
+	Count       int
+	MaxBurst    int64
+	MaxArrival  int64
+	MaxPriority int64
+	Profile     Profile
+}
+
+// Generate draws a random workload sorted by arrival time, with PIDs numbered from 1 in that order.
+func Generate(rng *rand.Rand, opts GenerateOptions) []Process {
+	processes := make([]Process, opts.Count)
+	opts.Profile.draw(rng, opts, processes)
+
+	return Normalize(processes)
+}
@@ -0,0 +1,356 @@
+package workload
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "bad CSV",
+			args: args{
+				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "not an integer",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,nine,3,1\n"),
+			},
+			wantErr: ErrBadRecord,
+		},
+		{
+			name: "too few fields",
+			args: args{
+				r: strings.NewReader("1,5\n"),
+			},
+			wantErr: ErrBadRecord,
+		},
+		{
+			name: "duplicate pid",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,9,3,1\n1,6,3,3\n"),
+			},
+			wantErr: ErrDuplicatePID,
+		},
+		{
+			name: "pid out of range",
+			args: args{
+				r: strings.NewReader("1,3,0,1\n7,2,1,2\n"),
+			},
+			wantErr: ErrBadRecord,
+		},
+		{
+			name: "empty burst",
+			args: args{
+				r: strings.NewReader("1,0,0,1\n"),
+			},
+			wantErr: ErrBadRecord,
+		},
+		{
+			name: "out of arrival order",
+			args: args{
+				r: strings.NewReader("1,5,4,1\n2,9,1,1\n"),
+			},
+			wantErr: ErrBadRecord,
+		},
+		{
+			name: "empty",
+			args: args{
+				r: strings.NewReader(""),
+			},
+			wantErr: ErrInvalidWorkload,
+		},
+		{
+			name: "mixed widths",
+			args: args{
+				r: strings.NewReader("1,5,0\n2,4,6,1,0\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 6, BurstDuration: 4, Priority: 1},
+			},
+		},
+		{
+			name: "negative deadline",
+			args: args{
+				r: strings.NewReader("1,5,0,2,-1\n"),
+			},
+			wantErr: ErrBadRecord,
+		},
+		{
+			name: "infeasible deadline",
+			args: args{
+				r: strings.NewReader("1,5,0,2,9\n2,4,6,1,9\n"),
+			},
+			wantErr: ErrInfeasibleDeadline,
+		},
+		{
+			name: "deadlines",
+			args: args{
+				r: strings.NewReader("1,5,0,2,5\n2,4,6,1,0\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, Priority: 2, Deadline: 5},
+				{ProcessID: 2, ArrivalTime: 6, BurstDuration: 4, Priority: 1},
+			},
+		},
+		{
+			name: "success",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+2,9,3,1
+3,6,3,3`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Load(tt.args.r)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(tt.wantErr, ErrInvalidWorkload) && !errors.Is(err, ErrInvalidWorkload) {
+				t.Errorf("error = %v, want it to be an %v", err, ErrInvalidWorkload)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []Problem
+	}{
+		{name: "valid", processes: []Process{{ProcessID: 2, BurstDuration: 1}, {ProcessID: 1, BurstDuration: 3}}},
+		{name: "empty", want: []Problem{{Index: -1, Message: "no processes", Err: ErrInvalidWorkload}}},
+		{
+			name: "every problem",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 0, ArrivalTime: 4, Priority: -1},
+				{ProcessID: 1, BurstDuration: 2, ArrivalTime: 1},
+				{ProcessID: 9, BurstDuration: 2, ArrivalTime: -1},
+			},
+			want: []Problem{
+				{0, "burst 0 must be at least 1", ErrBadRecord},
+				{0, "priority -1 must not be negative", ErrBadRecord},
+				{1, "pid 1 is used twice", ErrDuplicatePID},
+				{1, "arrival 1 is before the previous process's 4", ErrBadRecord},
+				{2, "pid 9 must be from 1 to 3", ErrBadRecord},
+				{2, "arrival -1 must not be negative", ErrBadRecord},
+			},
+		},
+		{
+			name: "threads and deadlines",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Deadline: 4},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 1, Deadline: -3, Threads: "4 4"},
+				{ProcessID: 3, BurstDuration: 2, ArrivalTime: 1, Deadline: 3, Threads: "2/x"},
+			},
+			want: []Problem{
+				{0, "deadline 4 is before 5, the earliest it can finish", ErrInfeasibleDeadline},
+				{1, "threads run 8 in all, not the burst 9", ErrBadRecord},
+				{1, "deadline -3 must not be negative", ErrBadRecord},
+				{2, `threads "2/x" must be bursts and blocks of at least 1 between slashes, such as "2/3/1 4"`, ErrBadRecord},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Check(tt.processes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	opts := GenerateOptions{Count: 50, MaxBurst: 4, MaxArrival: 10, MaxPriority: 3}
+	processes := Generate(rand.New(rand.NewSource(1)), opts)
+	if len(processes) != opts.Count {
+		t.Fatalf("generated %d processes, want %d", len(processes), opts.Count)
+	}
+	for i, p := range processes {
+		if p.ProcessID != int64(i+1) {
+			t.Errorf("process %d has PID %d", i, p.ProcessID)
+		}
+		if i > 0 && p.ArrivalTime < processes[i-1].ArrivalTime {
+			t.Errorf("process %d arrives before process %d", p.ProcessID, processes[i-1].ProcessID)
+		}
+		if p.BurstDuration < 1 || p.BurstDuration > opts.MaxBurst ||
+			p.ArrivalTime < 0 || p.ArrivalTime > opts.MaxArrival ||
+			p.Priority < 1 || p.Priority > opts.MaxPriority {
+			t.Errorf("process %+v out of bounds %+v", p, opts)
+		}
+	}
+
+	// the written workload loads back unchanged
+	var w bytes.Buffer
+	if err := Write(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range loaded {
+		if loaded[i] != processes[i] {
+			t.Errorf("loaded %+v, want %+v", loaded[i], processes[i])
+		}
+	}
+
+	// and so does one with deadlines
+	processes[0].Deadline = processes[0].ArrivalTime + processes[0].BurstDuration + 3
+	w.Reset()
+	if err := Write(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err = Load(&w); err != nil || !reflect.DeepEqual(loaded, processes) {
+		t.Errorf("loaded %+v, %v, want %+v", loaded, err, processes)
+	}
+
+	// and so does one with threads
+	processes[1].Threads = FormatThreads([]Thread{{Bursts: []int64{processes[1].BurstDuration}}})
+	w.Reset()
+	if err := Write(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err = Load(&w); err != nil || !reflect.DeepEqual(loaded, processes) {
+		t.Errorf("loaded %+v, %v, want %+v", loaded, err, processes)
+	}
+}
+
+func TestParseThreads(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    []Thread
+		wantErr bool
+	}{
+		{in: "2/3/1  4", want: []Thread{{Bursts: []int64{2, 1}, Blocks: []int64{3}}, {Bursts: []int64{4}}}},
+		{in: ""},
+		{in: "2/3", wantErr: true},
+		{in: "2/0/1", wantErr: true},
+		{in: "2/x/1", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseThreads(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadRecord) {
+					t.Errorf("ParseThreads(%q) error = %v, want %v", tt.in, err, ErrBadRecord)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseThreads(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+			if written := FormatThreads(got); written != strings.Join(strings.Fields(tt.in), " ") {
+				t.Errorf("FormatThreads() = %q, want %q", written, tt.in)
+			}
+		})
+	}
+
+	processes, err := Load(strings.NewReader("1,6,0,1,0,2/3/1 3\n"))
+	if err != nil || processes[0].Threads != "2/3/1 3" {
+		t.Errorf("Load() = %+v, %v, want threads 2/3/1 3", processes, err)
+	}
+	if _, err := Load(strings.NewReader("1,7,0,1,0,2/3/1 3\n")); !errors.Is(err, ErrBadRecord) {
+		t.Errorf("Load() of threads short of the burst error = %v, want %v", err, ErrBadRecord)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 7, ArrivalTime: 4, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 9, ArrivalTime: 4, BurstDuration: 3},
+	}
+	want := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 4, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 3},
+	}
+	if got := Normalize(processes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalize() = %v, want %v", got, want)
+	}
+	if processes[0].ProcessID != 7 {
+		t.Errorf("Normalize() modified its input")
+	}
+}
+
+// FuzzLoad checks that Load either rejects its input or returns processes that survive a Write and Load unchanged.
+func FuzzLoad(f *testing.F) {
+	f.Add("1,5,0,2\n2,9,3,1\n3,6,3,3\n")
+	f.Add("1,5,0\n")
+	f.Add("1,5,0,2,0,2/3/3\n")
+	f.Add("1,5,0,2\n1,5,0,2\n")
+	f.Add("\"1\",\"2\",\"3\"\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		processes, err := Load(strings.NewReader(input))
+		if err != nil {
+			return
+		}
+		var w bytes.Buffer
+		if err := Write(&w, processes); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := Load(&w)
+		if err != nil {
+			t.Fatalf("written workload does not load: %v\n%s", err, w.String())
+		}
+		if len(processes) != 0 && !reflect.DeepEqual(loaded, processes) {
+			t.Errorf("loaded %v, want %v", loaded, processes)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// playCommand replays the selected algorithms' runs event by event at a chosen speed, for live demos.
+func playCommand(args []string) {
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "play [flags] (workload.csv | -example name)")
+	selected := fs.String("algorithms", "",
+		"comma-separated algorithms to play, one after another (default all: "+algorithmNames()+")")
+	speed := fs.Float64("speed", 2, "time units played per second")
+	exampleName := fs.String("example", "", "play a bundled example workload instead of a file ("+exampleNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 1, "round-robin time quantum")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for the lottery draws (default: based on the current time)")
+	_ = fs.Parse(args)
+	if *speed <= 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -speed must be positive", ErrInvalidArgs))
+	}
+	if options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: quantum must be at least 1", ErrInvalidArgs))
+	}
+	run, err := parseAlgorithms(*selected)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	options.seed = resolveSeed(options.seed)
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	controls := readControls(os.Stdin)
+	for _, a := range run {
+		if err := playAlgorithm(interrupted, os.Stdout, a, processes, *speed, controls); err != nil {
+			fatal(exitStopped, fmt.Errorf("%s stopped: %w", a.Title, err))
+		}
+	}
+}
+
+// playAlgorithm runs a on processes and plays its events to w at speed time units per second. Lines from controls
+// steer it as in -tui: + and - double and halve the speed, and p pauses and resumes.
+func playAlgorithm(ctx context.Context, w io.Writer, a sched.Algorithm, processes []workload.Process, speed float64, controls <-chan string) error {
+	result, err := a.Schedule(ctx, processes)
+	if err != nil {
+		return err
+	}
+	outputTitle(w, a.Title)
+
+	player := sched.NewPlayer(result, speed)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		paused := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case cmd, ok := <-controls:
+				if !ok {
+					return
+				}
+				switch cmd {
+				case "+":
+					player.SetSpeed(player.Speed() * 2)
+				case "-":
+					player.SetSpeed(player.Speed() / 2)
+				case "p":
+					paused = !paused
+					player.SetPaused(paused)
+				}
+			}
+		}
+	}()
+	if err := player.Play(ctx, eventPrinter{w: w}); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w)
+
+	return nil
+}
+
+// eventPrinter writes a line for every event it observes.
+type eventPrinter struct {
+	w io.Writer
+}
+
+func (e eventPrinter) OnArrival(time int64, p workload.Process) {
+	_, _ = fmt.Fprintf(e.w, "%6d  P%d arrives needing %d\n", time, p.ProcessID, p.BurstDuration)
+}
+
+func (e eventPrinter) OnDispatch(time int64, p workload.Process) {
+	_, _ = fmt.Fprintf(e.w, "%6d  P%d runs, %d to go\n", time, p.ProcessID, p.BurstDuration)
+}
+
+func (e eventPrinter) OnPreempt(time int64, p workload.Process) {
+	_, _ = fmt.Fprintf(e.w, "%6d  P%d is preempted, %d to go\n", time, p.ProcessID, p.BurstDuration)
+}
+
+func (e eventPrinter) OnComplete(time int64, p workload.Process) {
+	_, _ = fmt.Fprintf(e.w, "%6d  P%d finishes after waiting %d\n", time, p.ProcessID, p.Wait)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func Test_playAlgorithm(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := algorithms.Lookup("sjf")
+	var w bytes.Buffer
+	if err := playAlgorithm(context.Background(), &w, a, processes, 1e6, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"     0  P1 arrives needing 8\n     0  P1 runs, 8 to go\n     1  P2 arrives needing 4\n     1  P1 is preempted, 7 to go\n",
+		"    26  P3 finishes after waiting 15\n",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// pluginProcess describes one ready process to a plugin.
+type pluginProcess struct {
+	PID       int64 `json:"pid"`
+	Arrival   int64 `json:"arrival"`
+	Burst     int64 `json:"burst"`
+	Remaining int64 `json:"remaining"`
+	Priority  int64 `json:"priority"`
+	Wait      int64 `json:"wait"`
+}
+
+// pluginRequest is the line sent to a plugin at every decision.
+type pluginRequest struct {
+	Time    int64           `json:"time"`
+	Running int64           `json:"running"`
+	Ready   []pluginProcess `json:"ready"`
+}
+
+// pluginResponse is the line a plugin answers each request with.
+type pluginResponse struct {
+	PID int64 `json:"pid"`
+}
+
+// plugin is a running external scheduler. It is sent one JSON pluginRequest per line on stdin and must answer each
+// with one JSON pluginResponse line on stdout naming the PID to run for the next time unit. Its stdin is closed
+// when the simulation ends, and anything it writes to stderr is passed through.
+type plugin struct {
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	enc *json.Encoder
+	dec *json.Decoder
+}
+
+// startPlugin runs command, split on spaces into the executable and its arguments.
+func startPlugin(ctx context.Context, command string) (*plugin, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty plugin command", ErrInvalidArgs)
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: plugin stdin", err)
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: plugin stdout", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%w: starting plugin", err)
+	}
+
+	return &plugin{cmd: cmd, in: in, enc: json.NewEncoder(in), dec: json.NewDecoder(out)}, nil
+}
+
+// choose is the plugin's policy.
+func (p *plugin) choose(d sched.Decision) (int64, error) {
+	req := pluginRequest{Time: d.Time, Running: d.Running, Ready: make([]pluginProcess, len(d.Ready))}
+	for i, r := range d.Ready {
+		req.Ready[i] = pluginProcess{
+			PID:       r.ProcessID,
+			Arrival:   r.ArrivalTime,
+			Burst:     r.Burst,
+			Remaining: r.BurstDuration,
+			Priority:  r.Priority,
+			Wait:      r.Wait,
+		}
+	}
+	if err := p.enc.Encode(req); err != nil {
+		return 0, fmt.Errorf("%w: writing to plugin", err)
+	}
+	var resp pluginResponse
+	if err := p.dec.Decode(&resp); err != nil {
+		return 0, fmt.Errorf("%w: reading plugin response", err)
+	}
+
+	return resp.PID, nil
+}
+
+// close ends the plugin's input and waits for it to exit.
+func (p *plugin) close() error {
+	_ = p.in.Close()
+	if err := p.cmd.Wait(); err != nil {
+		return fmt.Errorf("%w: plugin exit", err)
+	}
+
+	return nil
+}
+
+// pluginAlgorithm is an algorithm that starts command afresh for every run and lets it make every decision through
+// sched.RunPolicy, so the plugin only has to make decisions while the engine keeps the Gantt chart and metrics.
+func pluginAlgorithm(command string) sched.Algorithm {
+	return sched.Algorithm{
+		Scheduler: sched.New("plugin", func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+			p, err := startPlugin(ctx, command)
+			if err != nil {
+				return sched.Result{}, err
+			}
+			result, err := sched.RunPolicy(ctx, processes, p.choose)
+			if closeErr := p.close(); closeErr != nil && err == nil {
+				// the results are complete, so a plugin that exits badly afterwards is only worth a warning
+				sched.LoggerFrom(ctx).Warn("plugin exited badly", "err", closeErr)
+			}
+			return result, err
+		}),
+		Title:       "Plugin " + command,
+		Description: "external scheduler " + command + " choosing the process for every time unit",
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// TestPluginHelper is not a real test: Test_pluginAlgorithm runs the test binary as a plugin that always picks the
+// ready process with the least remaining time.
+func TestPluginHelper(t *testing.T) {
+	if os.Getenv("GO_WANT_PLUGIN_HELPER") != "1" {
+		t.Skip("only runs as a plugin")
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req pluginRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			os.Exit(2)
+		}
+		best := req.Ready[0]
+		for _, p := range req.Ready[1:] {
+			if p.Remaining < best.Remaining {
+				best = p
+			}
+		}
+		fmt.Printf("{\"pid\": %d}\n", best.PID)
+	}
+	os.Exit(0)
+}
+
+func Test_pluginAlgorithm(t *testing.T) {
+	t.Setenv("GO_WANT_PLUGIN_HELPER", "1")
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+	}
+
+	a := pluginAlgorithm(os.Args[0] + " -test.run=^TestPluginHelper$")
+	r := schedule(context.Background(), io.Discard, a, a.Title, processes)
+	want := []sched.TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 6}}
+	if r.Stopped != "" || !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("plugin run stopped %q with Gantt %v, want %v", r.Stopped, r.Gantt, want)
+	}
+
+	missing := pluginAlgorithm("./no-such-plugin")
+	if r := schedule(context.Background(), io.Discard, missing, missing.Title, processes); r.Stopped == "" {
+		t.Error("a plugin that cannot start did not stop the run")
+	}
+}
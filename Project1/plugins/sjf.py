@@ -0,0 +1,18 @@
+#!/usr/bin/env python3
+"""Shortest-remaining-time-first as an external scheduler plugin.
+
+Run it with: go run . -plugin "python3 plugins/sjf.py" -algorithms sjf,plugin example_processes.csv
+
+Every line on stdin is a JSON decision request:
+    {"time": 3, "running": 1, "ready": [{"pid": 1, "arrival": 0, "burst": 5, "remaining": 2, "priority": 2, "wait": 0}, ...]}
+Answer each with one JSON line naming the process to run for the next time unit:
+    {"pid": 1}
+"""
+import json
+import sys
+
+for line in sys.stdin:
+    request = json.loads(line)
+    # ties go to the earliest arrival, which is how the ready list is ordered
+    chosen = min(request["ready"], key=lambda p: p["remaining"])
+    print(json.dumps({"pid": chosen["pid"]}), flush=True)
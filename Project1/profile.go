@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	_ "net/http/pprof" // serves /debug/pprof/ on the default mux for -pprof
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+)
+
+// exitHooks run before fatal exits, so profiles are still written when a run ends early.
+var exitHooks []func()
+
+// startProfiling starts whichever profilers were asked for: a CPU profile written to cpuPath, a heap profile written
+// to memPath when profiling stops, and a net/http/pprof listener on addr. Empty arguments are skipped. The returned
+// function stops profiling and writes the profiles; it is safe to call more than once.
+func startProfiling(cpuPath, memPath, addr string) (func(), error) {
+	var cpuFile *os.File
+	if cpuPath != "" {
+		f, err := os.Create(cpuPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w: creating CPU profile", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("%w: starting CPU profile", err)
+		}
+		cpuFile = f
+	}
+
+	if addr != "" {
+		go func() {
+			logger.Info("serving pprof", "url", "http://"+addr+"/debug/pprof/")
+			if err := http.ListenAndServe(addr, nil); err != nil {
+				logger.Error("pprof listener", "err", err)
+			}
+		}()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if cpuFile != nil {
+				pprof.StopCPUProfile()
+				if err := cpuFile.Close(); err != nil {
+					logger.Warn("closing CPU profile", "err", err)
+				}
+			}
+			if memPath != "" {
+				if err := writeHeapProfile(memPath); err != nil {
+					logger.Warn("writing heap profile", "err", err)
+				}
+			}
+		})
+	}, nil
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating memory profile", err)
+	}
+	defer f.Close()
+	runtime.GC() // report live memory as of the end of the run
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return fmt.Errorf("%w: writing memory profile", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_startProfiling(t *testing.T) {
+	dir := t.TempDir()
+	cpuPath, memPath := filepath.Join(dir, "cpu.out"), filepath.Join(dir, "mem.out")
+	stop, err := startProfiling(cpuPath, memPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes := workload.Generate(rand.New(rand.NewSource(1)), workload.GenerateOptions{Count: 200, MaxBurst: 9, MaxArrival: 50, MaxPriority: 3})
+	scheduleWith("sjf")(context.Background(), io.Discard, "SJF", processes)
+	stop()
+	stop() // stopping twice is harmless
+
+	for _, path := range []string{cpuPath, memPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s is empty", filepath.Base(path))
+		}
+	}
+
+	if _, err := startProfiling(filepath.Join(dir, "missing", "cpu.out"), "", ""); err == nil {
+		t.Error("profiling into a missing directory did not fail")
+	}
+}
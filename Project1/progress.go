@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// A progress bar is shown once a run simulates at least progressMinWork time units or progressMinProcesses
+// processes, below which it would only flicker.
+const (
+	progressMinWork      = 1_000_000
+	progressMinProcesses = 10_000
+	progressInterval     = 200 * time.Millisecond
+	progressWidth        = 30
+)
+
+// progress counts simulated time units across every running scheduler and redraws a bar on w until finished. A nil
+// *progress counts nothing, so the schedulers advance it unconditionally.
+type progress struct {
+	w        io.Writer
+	total    int64
+	done     atomic.Int64
+	start    time.Time
+	stop     chan struct{}
+	finished chan struct{}
+}
+
+// needsProgress reports whether simulating processes with run algorithms is big enough to show progress for.
+func needsProgress(processes []workload.Process, run int) (bool, int64) {
+	var work int64
+	for i := range processes {
+		work += processes[i].BurstDuration
+	}
+	work *= int64(run)
+
+	return work >= progressMinWork || len(processes) >= progressMinProcesses, work
+}
+
+// startProgress draws a bar for total time units of work on w until finish is called.
+func startProgress(w io.Writer, total int64) *progress {
+	p := &progress{w: w, total: total, start: time.Now(), stop: make(chan struct{}), finished: make(chan struct{})}
+	go func() {
+		defer close(p.finished)
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				_, _ = fmt.Fprint(w, "\r\x1b[K")
+				return
+			case now := <-ticker.C:
+				_, _ = fmt.Fprint(w, "\r"+p.render(now))
+			}
+		}
+	}()
+
+	return p
+}
+
+// advance records n more simulated time units.
+func (p *progress) advance(n int64) {
+	if p != nil {
+		p.done.Add(n)
+	}
+}
+
+// finish erases the bar.
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	<-p.finished
+}
+
+// render draws the bar as of now, estimating the time left from the rate so far.
+func (p *progress) render(now time.Time) string {
+	done := p.done.Load()
+	if done > p.total {
+		done = p.total
+	}
+	fraction := 1.0
+	if p.total > 0 {
+		fraction = float64(done) / float64(p.total)
+	}
+	filled := int(fraction * progressWidth)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", progressWidth-filled)
+
+	eta := "ETA --"
+	if elapsed := now.Sub(p.start); done > 0 {
+		left := time.Duration(float64(elapsed) * float64(p.total-done) / float64(done))
+		eta = "ETA " + left.Round(time.Second).String()
+	}
+
+	return fmt.Sprintf("[%s] %3.0f%% %s", bar, fraction*100, eta)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_progress(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &progress{total: 200, start: start}
+	if got, want := p.render(start), "[------------------------------]   0% ETA --"; got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+	p.advance(50)
+	if got, want := p.render(start.Add(10*time.Second)), "[#######-----------------------]  25% ETA 30s"; got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+	p.advance(500) // idle time units are not part of the total, so progress can overshoot
+	if got, want := p.render(start.Add(time.Minute)), "[##############################] 100% ETA 0s"; got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+
+	var none *progress
+	none.advance(1)
+	none.finish()
+
+	var w bytes.Buffer
+	p = startProgress(&w, 10)
+	p.advance(10)
+	time.Sleep(2 * progressInterval)
+	p.finish()
+	if !bytes.Contains(w.Bytes(), []byte("100%")) || !bytes.HasSuffix(w.Bytes(), []byte("\r\x1b[K")) {
+		t.Errorf("progress output %q does not draw the bar and then erase it", w.String())
+	}
+}
+
+func Test_needsProgress(t *testing.T) {
+	t.Parallel()
+	small := []workload.Process{{BurstDuration: 10}, {BurstDuration: 20}}
+	if large, work := needsProgress(small, 4); large || work != 120 {
+		t.Errorf("needsProgress(small) = %v, %d", large, work)
+	}
+	long := []workload.Process{{BurstDuration: progressMinWork / 2}}
+	if large, _ := needsProgress(long, 2); !large {
+		t.Error("a long simulation needs no progress bar")
+	}
+	if large, _ := needsProgress(make([]workload.Process, progressMinProcesses), 1); !large {
+		t.Error("a many-process simulation needs no progress bar")
+	}
+}
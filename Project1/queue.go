@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+func queueCommand(args []string) {
+	fs := flag.NewFlagSet("queue", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "queue [flags] [workload.csv | -example name]")
+	n := fs.Int("n", 2000, "jobs of the generated M/M/1 workload")
+	load := fs.Float64("load", 0.7, "utilization ρ the generated workload's arrivals offer the CPU")
+	service := fs.Float64("service", 5, "mean burst of the generated workload")
+	seed := fs.Int64("seed", 0, "random seed for the generated workload (default: based on the current time)")
+	exampleName := fs.String("example", "", "compare a bundled example workload instead of a generated one ("+
+		exampleNames()+")")
+	selected := fs.String("algorithms", "fcfs,sjf,rr",
+		"comma-separated algorithms to simulate, in order; fcfs, sjf, and rr have models")
+	fs.Int64Var(&options.quantum, "quantum", 1, "round-robin time quantum")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *n < 2 || *load <= 0 || *load >= 1 || *service < 1 || options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -n must be at least 2, -load above 0 and below 1, and -service and "+
+			"-quantum at least 1", ErrInvalidArgs))
+	}
+	run, err := parseAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	options.seed = resolveSeed(*seed)
+	var (
+		processes []workload.Process
+		source    string
+	)
+	if *exampleName != "" || fs.NArg() > 0 {
+		processes = mustLoadWorkloadOrExample(*exampleName, fs.Args())
+		source = workloadName(workloadSource(*exampleName, fs.Args()))
+	} else {
+		options.randomized = true
+		processes = workload.GeneratePoisson(newRand(options.seed, "queue"), *n, *load / *service, *service)
+		source = fmt.Sprintf("M/M/1 workload at load %g with mean burst %g from seed %d", *load, *service,
+			options.seed)
+	}
+
+	reports := runAlgorithms(sched.WithLogger(context.Background(), logger), io.Discard, run, processes)
+	outputQueue(os.Stdout, source, report.NewQueue(processes), run, reports)
+}
+
+// outputQueue writes how closely q fits M/M/1, then the average wait and response of every algorithm run that a
+// queueing model predicts next to what the model predicts.
+func outputQueue(w io.Writer, source string, q report.Queue, run []sched.Algorithm, reports []report.Report) {
+	outputTitle(w, "Queueing theory")
+	_, _ = fmt.Fprintf(w, "%s: %d jobs arriving at rate λ %.4f (interarrival CV %.2f) with mean burst E[S] %.2f "+
+		"(CV %.2f), load ρ %.3f\n", source, q.Jobs, q.ArrivalRate, q.InterarrivalCV, q.MeanService, q.ServiceCV,
+		q.Load())
+	unmodeled := "unstable"
+	if q.ArrivalRate == 0 {
+		unmodeled = "-"
+		_, _ = fmt.Fprintln(w, "The jobs all arrive at once, so there is no arrival rate to model.")
+	} else if math.Abs(q.InterarrivalCV-1) > 0.25 || math.Abs(q.ServiceCV-1) > 0.25 {
+		_, _ = fmt.Fprintln(w, "The workload is far from M/M/1, which needs both CVs near 1; expect the M/M/1 model "+
+			"to miss.")
+	}
+	_, _ = fmt.Fprintln(w)
+
+	table := tablewriter.NewWriter(w)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{"Algorithm", "Model", "Avg wait", "Model wait", "Avg response", "Model response", "Gap"})
+	for i, a := range run {
+		for _, m := range report.QueueModels {
+			if m.Algorithm != a.Name() {
+				continue
+			}
+			simulated := reports[i].Summary.Turnaround
+			row := []string{a.Title, m.Name, fmt.Sprintf("%.2f", reports[i].Summary.Wait), unmodeled,
+				fmt.Sprintf("%.2f", simulated), unmodeled, "-"}
+			if q.Stable() {
+				predicted := q.Response(m)
+				row[3], row[5] = fmt.Sprintf("%.2f", q.Wait(m)), fmt.Sprintf("%.2f", predicted)
+				row[6] = fmt.Sprintf("%+.1f%%", 100*(simulated-predicted)/predicted)
+			}
+			table.Append(row)
+		}
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w, "Response is arrival to completion and wait the part of it not running. Gap is how far "+
+		"the simulated response is from the model's; a finite run, whole time units, and a quantum above 0 all "+
+		"open one.")
+}
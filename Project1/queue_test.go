@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputQueue(t *testing.T) {
+	t.Parallel()
+	// a job of 2 every 5 units, a load of 0.4, which M/M/1 expects to respond in 2/0.6
+	var processes []workload.Process
+	for i := 0; i < 11; i++ {
+		processes = append(processes, workload.Process{ProcessID: int64(i + 1), ArrivalTime: int64(5 * i),
+			BurstDuration: 2})
+	}
+	run, err := parseAlgorithms("fcfs,priority")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports := []report.Report{{Summary: sched.Metrics{Turnaround: 2}}, {Summary: sched.Metrics{Turnaround: 2}}}
+	var w bytes.Buffer
+	outputQueue(&w, "steady", report.NewQueue(processes), run, reports)
+	for _, want := range []string{
+		"steady: 11 jobs arriving at rate λ 0.2000 (interarrival CV 0.00) with mean burst E[S] 2.00 (CV 0.00), " +
+			"load ρ 0.400",
+		"The workload is far from M/M/1",
+		"| First-come, first-serve | M/M/1 |     0.00 |       1.33 |         2.00 |           3.33 | -40.0% |",
+		"| First-come, first-serve | M/G/1 |     0.00 |       0.67 |         2.00 |           2.67 | -25.0% |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputQueue() missing %q:\n%s", want, w.String())
+		}
+	}
+	// priority has no model
+	if strings.Contains(w.String(), "| Priority") {
+		t.Errorf("outputQueue() has a row without a model:\n%s", w.String())
+	}
+
+	w.Reset()
+	together := []workload.Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2}}
+	outputQueue(&w, "at once", report.NewQueue(together), run, reports)
+	if !strings.Contains(w.String(), "no arrival rate to model") || !strings.Contains(w.String(), "|         2.00 | -") {
+		t.Errorf("outputQueue() of jobs arriving at once:\n%s", w.String())
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/template"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+)
+
+// outputReport renders the result of a run, either through the -template file or as the built-in text report, saves
+// any requested SVG charts, and returns the report for callers that need the numbers. If the scheduler stopped
+// early, result holds only the processes that completed and the report says why it stopped. seed is recorded in the
+// report, or 0 for a run that drew no random numbers.
+func outputReport(w io.Writer, title string, result sched.Result, stopped error, seed int64) report.Report {
+	charged := sched.ChargeContextSwitches(sched.ChargeSystemTime(result, options.syscall), options.switchCost)
+	r := report.New(title, charged)
+	if stopped != nil {
+		r.Stopped = stopped.Error()
+	}
+	r.Seed = seed
+	if options.template != nil {
+		if err := options.template.Execute(w, r); err != nil {
+			logger.Warn("rendering template", "algorithm", title, "err", err)
+		}
+	} else {
+		outputText(w, r)
+	}
+
+	if options.svgDir != "" {
+		if err := report.SaveHistogramSVG(options.svgDir, title, r.Histogram); err != nil {
+			logger.Warn("saving histogram", "algorithm", title, "err", err)
+		}
+		if err := report.SaveThroughputSVG(options.svgDir, title, r.Throughput); err != nil {
+			logger.Warn("saving throughput chart", "algorithm", title, "err", err)
+		}
+	}
+
+	return r
+}
+
+// runSeed returns the seed to record in the report of a run of s: -seed if the run drew from it, through the lottery
+// draws or a generated or perturbed workload, and 0 otherwise, so a deterministic report doesn't suggest rerunning
+// with a seed.
+func runSeed(s sched.Scheduler) int64 {
+	if options.randomized || s.Name() == "lottery" {
+		return options.seed
+	}
+
+	return 0
+}
+
+// outputText writes the built-in human-readable report.
+func outputText(w io.Writer, r report.Report) {
+	outputTitle(w, r.Title)
+	if r.Stopped != "" {
+		_, _ = fmt.Fprintf(w, "Stopped early (%s): only the %d processes that finished are shown\n\n",
+			r.Stopped, len(r.Processes))
+	}
+	if r.Seed != 0 {
+		_, _ = fmt.Fprintf(w, "Random seed: %d (rerun with -seed %d to reproduce)\n\n", r.Seed, r.Seed)
+	}
+	report.WriteGantt(w, r.Gantt, options.color)
+	if options.timeline {
+		report.WriteTimeline(w, r)
+	}
+	outputSchedule(w, sortedRows(r.Processes, options.sortBy), r.Summary)
+	outputUtilization(w, r.Busy, r.Idle, r.Overhead)
+	if r.System > 0 {
+		outputCPUTimes(w, r)
+	}
+	_, _ = fmt.Fprintf(w, "Context switches: %d\n\n", r.Switches)
+	outputDeadlines(w, r)
+	if options.sla != nil {
+		outputSLA(w, options.sla.Evaluate(r))
+	}
+	outputFairness(w, r.Processes)
+	report.WriteHistogram(w, r.Histogram)
+	report.WriteThroughputCurve(w, r.Throughput)
+	if options.load {
+		report.WriteLoad(w, report.NewLoad(r.Gantt, r.Processes))
+	}
+}
+
+// templateFuncs are the helpers available to -template files on top of the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"normalizedTurnaround": sched.NormalizedTurnaround,
+	"cpuShare":             report.CPUShare,
+	"percent":              func(f float64) string { return fmt.Sprintf("%.2f%%", f*100) },
+}
+
+// parseReportTemplate loads a -template file.
+func parseReportTemplate(path string) (*template.Template, error) {
+	t, err := template.New(filepath.Base(path)).Funcs(templateFuncs).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing report template", err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_parseReportTemplate(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	tmpl := `{{.Title}}: wait {{printf "%.2f" .Summary.Wait}}, utilization {{percent .Utilization}}
+{{range .Processes}}{{.ProcessID}} {{printf "%.2f" (normalizedTurnaround .)}}
+{{end}}`
+	if err := os.WriteFile(path, []byte(tmpl), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := parseReportTemplate(path)
+	if err != nil {
+		t.Fatalf("parseReportTemplate() error = %v", err)
+	}
+	r := report.New("FCFS", sched.NewResult([]sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 8}}, []workload.Process{
+		{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2},
+		{ProcessID: 2, Burst: 4, Wait: 2, Turnaround: 6, Completion: 8, ArrivalTime: 2},
+	}))
+	var w bytes.Buffer
+	if err := parsed.Execute(&w, r); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := `FCFS: wait 1.00, utilization 75.00%
+1 1.00
+2 1.50
+`
+	if got := w.String(); got != want {
+		t.Errorf("rendered template = %q, want %q", got, want)
+	}
+}
+
+func Test_parseReportTemplateMissing(t *testing.T) {
+	t.Parallel()
+	if _, err := parseReportTemplate(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("parseReportTemplate() error = nil, want an error for a missing file")
+	}
+}
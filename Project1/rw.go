@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/rw"
+	"github.com/olekukonko/tablewriter"
+)
+
+func rwPolicyNames() string {
+	var names []string
+	for _, p := range rw.Policies {
+		names = append(names, p.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parseRWPolicies resolves a comma-separated list of readers–writers policy names, in the order given. An empty list
+// selects every policy.
+func parseRWPolicies(list string) ([]rw.Policy, error) {
+	if list == "" {
+		return rw.Policies, nil
+	}
+	var selected []rw.Policy
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, p := range rw.Policies {
+			if p.Name == strings.TrimSpace(name) {
+				selected, found = append(selected, p), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown readers–writers policy %q (want one of %s)", ErrInvalidArgs, name,
+				rwPolicyNames())
+		}
+	}
+
+	return selected, nil
+}
+
+func rwCommand(args []string) {
+	fs := flag.NewFlagSet("rw", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "rw [flags]")
+	var c rw.Config
+	fs.IntVar(&c.Accesses, "accesses", 60, "accesses in the generated trace")
+	fs.Float64Var(&c.WriteFraction, "writes", 0.2, "fraction of the accesses that write")
+	fs.Int64Var(&c.MeanGap, "gap", 1, "mean time units between one access arriving and the next")
+	read := fs.String("read", "2:6", "from:to range of time units a reader holds the lock")
+	write := fs.String("write", "1:3", "from:to range of time units a writer holds the lock")
+	selected := fs.String("policies", "", "comma-separated policies to run, in order (default all: "+rwPolicyNames()+")")
+	starve := fs.Int64("starve", 20, "count an access as starved when it waits longer than this")
+	detail := fs.Bool("detail", false, "list every access as each policy served it")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for the trace (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if c.Accesses < 1 || c.WriteFraction < 0 || c.WriteFraction > 1 || c.MeanGap < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -accesses must be at least 1, -writes from 0 to 1, and -gap at least 0",
+			ErrInvalidArgs))
+	}
+	var err error
+	if c.ReadMin, c.ReadMax, err = parseRange(*read); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if c.WriteMin, c.WriteMax, err = parseRange(*write); err != nil {
+		fatal(exitInvalid, err)
+	}
+	run, err := parseRWPolicies(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	options.seed = resolveSeed(options.seed)
+	accesses := rw.Generate(newRand(options.seed, "rw"), c)
+
+	results := make([]rw.Result, len(run))
+	for i, p := range run {
+		results[i] = rw.Simulate(accesses, p)
+		outputRW(os.Stdout, results[i], *starve, *detail)
+	}
+	if len(run) > 1 {
+		outputRWSummary(os.Stdout, results, *starve)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "Random seed: %d (rerun with -seed %d to reproduce)\n", options.seed, options.seed)
+}
+
+// outputRW writes the latency of readers and writers under one policy, with how many waited longer than starve, and
+// if detail is set, every access as it was served.
+func outputRW(w io.Writer, r rw.Result, starve int64, detail bool) {
+	outputTitle(w, r.Policy.Title)
+	_, _ = fmt.Fprintf(w, "%s: %s\n", r.Policy.Title, r.Policy.Description)
+	if detail {
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"Access", "Kind", "Arrival", "Start", "Finish", "Latency"})
+		for _, s := range r.Served {
+			table.Append([]string{strconv.Itoa(s.ID), s.Kind.String(), strconv.FormatInt(s.Arrival, 10),
+				strconv.FormatInt(s.Start, 10), strconv.FormatInt(s.Finish, 10), strconv.FormatInt(s.Latency(), 10)})
+		}
+		table.Render()
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Class", "Accesses", "Avg latency", "Longest latency", "Starved"})
+	for _, kind := range []rw.Kind{rw.Read, rw.Write} {
+		c, class := r.Class(kind), "readers"
+		if kind == rw.Write {
+			class = "writers"
+		}
+		table.Append([]string{class, strconv.Itoa(c.Count), fmt.Sprintf("%.2f", c.Average),
+			strconv.FormatInt(c.Longest, 10), strconv.Itoa(r.Starved(kind, starve))})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Starved means waiting longer than %d; the last access finished at %d\n\n", starve,
+		r.Finished())
+}
+
+// outputRWSummary writes the latency of each class under every policy side by side.
+func outputRWSummary(w io.Writer, results []rw.Result, starve int64) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Policy", "Reader avg", "Reader longest", "Writer avg", "Writer longest",
+		"Writers starved", "Finished"})
+	for _, r := range results {
+		reads, writes := r.Class(rw.Read), r.Class(rw.Write)
+		table.Append([]string{r.Policy.Title, fmt.Sprintf("%.2f", reads.Average), strconv.FormatInt(reads.Longest, 10),
+			fmt.Sprintf("%.2f", writes.Average), strconv.FormatInt(writes.Longest, 10),
+			strconv.Itoa(r.Starved(rw.Write, starve)), strconv.FormatInt(r.Finished(), 10)})
+	}
+	table.Render()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/rw"
+)
+
+func Test_parseRWPolicies(t *testing.T) {
+	t.Parallel()
+	got, err := parseRWPolicies("fair, readers")
+	if err != nil || len(got) != 2 || got[0].Name != "fair" || got[1].Name != "readers" {
+		t.Errorf("parseRWPolicies() = %v, %v", got, err)
+	}
+	if all, _ := parseRWPolicies(""); len(all) != len(rw.Policies) {
+		t.Errorf("parseRWPolicies(\"\") = %d policies, want all %d", len(all), len(rw.Policies))
+	}
+	if _, err := parseRWPolicies("random"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseRWPolicies(\"random\") error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_outputRW(t *testing.T) {
+	t.Parallel()
+	accesses := []rw.Access{
+		{ID: 1, Kind: rw.Read, Arrival: 0, Duration: 4},
+		{ID: 2, Kind: rw.Write, Arrival: 1, Duration: 2},
+		{ID: 3, Kind: rw.Read, Arrival: 2, Duration: 2},
+		{ID: 4, Kind: rw.Read, Arrival: 3, Duration: 3},
+	}
+	results := []rw.Result{rw.Simulate(accesses, rw.Policies[0]), rw.Simulate(accesses, rw.Policies[1])}
+	var w bytes.Buffer
+	outputRW(&w, results[0], 4, true)
+	outputRWSummary(&w, results, 4)
+	for _, want := range []string{
+		"|      2 | write |       1 |     6 |      8 |       5 |",
+		"| readers |        3 |        0.00 |               0 |       0 |",
+		"| writers |        1 |        5.00 |               5 |       1 |",
+		"Starved means waiting longer than 4; the last access finished at 8\n",
+		"| Writers-preference |       2.33 |              4 |       3.00 |              3 |               0 |        9 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"GolandProjects/Project1/pkg/report"
+)
+
+// burstTurnarounds gathers the burst and turnaround of every finished process of every report, algorithm by
+// algorithm.
+func burstTurnarounds(reports []report.Report) []report.BurstTurnaround {
+	var points []report.BurstTurnaround
+	for _, r := range reports {
+		points = append(points, r.BurstTurnarounds()...)
+	}
+
+	return points
+}
+
+// saveScatter writes points as CSV to the -scatter file at path.
+func saveScatter(path string, points []report.BurstTurnaround) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating -scatter file", err)
+	}
+	if err := report.WriteCSV(f, points); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing -scatter file", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_saveScatter(t *testing.T) {
+	t.Parallel()
+	r := report.New("FCFS", sched.NewResult([]sched.TimeSlice{{PID: 1, Stop: 2}},
+		[]workload.Process{{ProcessID: 1, Burst: 2, Turnaround: 2, Completion: 2}}))
+	path := filepath.Join(t.TempDir(), "pairs.csv")
+	if err := saveScatter(path, burstTurnarounds([]report.Report{r, r})); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "algorithm,pid,burst,turnaround,normalized_turnaround\nFCFS,1,2,2,1\nFCFS,1,2,2,1\n"
+	if string(b) != want {
+		t.Errorf("saveScatter() wrote %q, want %q", b, want)
+	}
+	if err := saveScatter(filepath.Join(t.TempDir(), "missing", "pairs.csv"), nil); err == nil {
+		t.Error("saveScatter() into a missing directory succeeded")
+	}
+}
@@ -0,0 +1,544 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+//region Policy scripts
+
+// policyVariables are the names a policy script can read for each ready process, besides its own assignments.
+var policyVariables = []string{"pid", "arrival", "burst", "remaining", "priority", "wait", "time", "running"}
+
+// scriptPolicy is a scheduling policy written as a script of assignments, one name = expression per line, with #
+// starting a comment. The language is this package's own, not Python or Starlark: there are no statements besides
+// assignments, no functions besides min, max, and abs, and every value is a float64.
+//
+//	age = time - arrival
+//	key = remaining, -age
+//
+// For every ready process the lines are evaluated in order with policyVariables set (running is 1 for the process
+// that ran the previous time unit and 0 otherwise), and the process with the smallest key runs next. A key may be a
+// comma-separated tuple compared element by element; ties go to the earliest arrival. From loosest to tightest,
+// expressions are "a if cond else b", or, and, not, comparisons (< <= > >= == !=), + and -, * / // and %, and unary
+// minus, over numbers, names, parentheses, and calls to min, max, and abs. True is 1 and false is 0.
+type scriptPolicy struct {
+	Name    string
+	assigns []scriptAssign
+	key     []scriptNode
+}
+
+type scriptAssign struct {
+	Name  string
+	Value scriptNode
+}
+
+// loadPolicyScript parses the policy script at path.
+func loadPolicyScript(path string) (*scriptPolicy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: opening policy script", err)
+	}
+	defer f.Close()
+
+	p, err := parsePolicyScript(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, path)
+	}
+	p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	return p, nil
+}
+
+func parsePolicyScript(r io.Reader) (*scriptPolicy, error) {
+	p := &scriptPolicy{}
+	known := make(map[string]bool)
+	for _, name := range policyVariables {
+		known[name] = true
+	}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, expr, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || !isScriptIdent(name) || scriptKeywords[name] || strings.HasPrefix(expr, "=") {
+			return nil, fmt.Errorf("%w: line %d: want name = expression", ErrInvalidArgs, n)
+		}
+		values, err := parseScriptExprs(expr, known)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d", err, n)
+		}
+		if name == "key" {
+			p.key = values
+			continue
+		}
+		if len(values) != 1 {
+			return nil, fmt.Errorf("%w: line %d: only key may be a tuple", ErrInvalidArgs, n)
+		}
+		p.assigns = append(p.assigns, scriptAssign{Name: name, Value: values[0]})
+		known[name] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading policy script", err)
+	}
+	if p.key == nil {
+		return nil, fmt.Errorf("%w: policy script never sets key", ErrInvalidArgs)
+	}
+
+	return p, nil
+}
+
+// choose runs the ready process with the smallest key.
+func (p *scriptPolicy) choose(d sched.Decision) (int64, error) {
+	var (
+		best    int64
+		bestKey []float64
+	)
+	env := make(map[string]float64, len(policyVariables)+len(p.assigns))
+	for _, r := range d.Ready {
+		env["pid"] = float64(r.ProcessID)
+		env["arrival"] = float64(r.ArrivalTime)
+		env["burst"] = float64(r.Burst)
+		env["remaining"] = float64(r.BurstDuration)
+		env["priority"] = float64(r.Priority)
+		env["wait"] = float64(r.Wait)
+		env["time"] = float64(d.Time)
+		env["running"] = truth(r.ProcessID == d.Running)
+		for _, a := range p.assigns {
+			v, err := a.Value.eval(env)
+			if err != nil {
+				return 0, fmt.Errorf("%w: computing %s for P%d", err, a.Name, r.ProcessID)
+			}
+			env[a.Name] = v
+		}
+		key := make([]float64, len(p.key))
+		for i, k := range p.key {
+			v, err := k.eval(env)
+			if err != nil {
+				return 0, fmt.Errorf("%w: computing key for P%d", err, r.ProcessID)
+			}
+			key[i] = v
+		}
+		if bestKey == nil || keyLess(key, bestKey) {
+			best, bestKey = r.ProcessID, key
+		}
+	}
+
+	return best, nil
+}
+
+// keyLess compares two keys element by element.
+func keyLess(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return false
+}
+
+// scriptAlgorithm is an algorithm whose decisions are made by a policy script.
+func scriptAlgorithm(p *scriptPolicy) sched.Algorithm {
+	return sched.Algorithm{
+		Scheduler: sched.New("policy", func(ctx context.Context, processes []workload.Process) (sched.Result, error) {
+			return sched.RunPolicy(ctx, processes, p.choose)
+		}),
+		Title:       "Policy " + p.Name,
+		Description: "scripted policy " + p.Name + " choosing the process for every time unit",
+	}
+}
+
+//endregion
+
+//region Expressions
+
+// scriptNode is a parsed expression.
+type scriptNode interface {
+	eval(env map[string]float64) (float64, error)
+}
+
+type (
+	scriptNumber float64
+	scriptName   string
+	scriptUnary  struct {
+		Op string
+		X  scriptNode
+	}
+	scriptBinary struct {
+		Op   string
+		L, R scriptNode
+	}
+	scriptCond struct {
+		Then, Cond, Else scriptNode
+	}
+	scriptCall struct {
+		Func string
+		Args []scriptNode
+	}
+)
+
+func (n scriptNumber) eval(map[string]float64) (float64, error) { return float64(n), nil }
+
+func (n scriptName) eval(env map[string]float64) (float64, error) { return env[string(n)], nil }
+
+func (n scriptUnary) eval(env map[string]float64) (float64, error) {
+	x, err := n.X.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	if n.Op == "not" {
+		return truth(x == 0), nil
+	}
+
+	return -x, nil
+}
+
+func (n scriptBinary) eval(env map[string]float64) (float64, error) {
+	l, err := n.L.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	// and and or short-circuit, yielding the deciding operand rather than 0 or 1
+	switch {
+	case n.Op == "and" && l == 0, n.Op == "or" && l != 0:
+		return l, nil
+	}
+	r, err := n.R.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	switch n.Op {
+	case "and", "or":
+		return r, nil
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "<":
+		return truth(l < r), nil
+	case "<=":
+		return truth(l <= r), nil
+	case ">":
+		return truth(l > r), nil
+	case ">=":
+		return truth(l >= r), nil
+	case "==":
+		return truth(l == r), nil
+	case "!=":
+		return truth(l != r), nil
+	}
+	if r == 0 {
+		return 0, fmt.Errorf("%w: division by zero", ErrInvalidArgs)
+	}
+	switch n.Op {
+	case "/":
+		return l / r, nil
+	case "//":
+		return math.Floor(l / r), nil
+	}
+
+	return l - r*math.Floor(l/r), nil // %, with the sign of the divisor, matching //
+}
+
+func (n scriptCond) eval(env map[string]float64) (float64, error) {
+	c, err := n.Cond.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	if c != 0 {
+		return n.Then.eval(env)
+	}
+
+	return n.Else.eval(env)
+}
+
+func (n scriptCall) eval(env map[string]float64) (float64, error) {
+	args := make([]float64, len(n.Args))
+	for i, a := range n.Args {
+		v, err := a.eval(env)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+	if n.Func == "abs" {
+		return math.Abs(args[0]), nil
+	}
+	result := args[0]
+	for _, v := range args[1:] {
+		if (n.Func == "min") == (v < result) {
+			result = v
+		}
+	}
+
+	return result, nil
+}
+
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// scriptParser is a precedence-climbing parser over the tokens of one line.
+type scriptParser struct {
+	tokens []string
+	pos    int
+	known  map[string]bool
+}
+
+// parseScriptExprs parses a comma-separated list of expressions that may only read the known names.
+func parseScriptExprs(s string, known map[string]bool) ([]scriptNode, error) {
+	tokens, err := scriptTokens(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &scriptParser{tokens: tokens, known: known}
+	var nodes []scriptNode
+	for {
+		n, err := p.cond()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidArgs, p.tokens[p.pos])
+	}
+
+	return nodes, nil
+}
+
+func (p *scriptParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *scriptParser) accept(tok string) bool {
+	if p.peek() == tok {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+// cond parses "a if c else b", the loosest-binding expression.
+func (p *scriptParser) cond() (scriptNode, error) {
+	then, err := p.binary(0)
+	if err != nil || !p.accept("if") {
+		return then, err
+	}
+	c, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("else") {
+		return nil, fmt.Errorf("%w: if without else", ErrInvalidArgs)
+	}
+	otherwise, err := p.cond()
+	if err != nil {
+		return nil, err
+	}
+
+	return scriptCond{Then: then, Cond: c, Else: otherwise}, nil
+}
+
+// scriptPrecedence lists the binary operators from loosest to tightest binding.
+var scriptPrecedence = [][]string{
+	{"or"},
+	{"and"},
+	{"<", "<=", ">", ">=", "==", "!="},
+	{"+", "-"},
+	{"*", "/", "//", "%"},
+}
+
+func (p *scriptParser) binary(level int) (scriptNode, error) {
+	if level == len(scriptPrecedence) {
+		return p.unary()
+	}
+	if level == 2 && p.accept("not") {
+		// not binds looser than comparisons but tighter than and
+		x, err := p.binary(level)
+		if err != nil {
+			return nil, err
+		}
+		return scriptUnary{Op: "not", X: x}, nil
+	}
+	l, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		matched := false
+		for _, candidate := range scriptPrecedence[level] {
+			matched = matched || op == candidate
+		}
+		if !matched {
+			return l, nil
+		}
+		p.pos++
+		r, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		l = scriptBinary{Op: op, L: l, R: r}
+	}
+}
+
+func (p *scriptParser) unary() (scriptNode, error) {
+	switch {
+	case p.accept("-"):
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return scriptUnary{Op: "-", X: x}, nil
+	case p.accept("+"):
+		return p.unary()
+	case p.accept("("):
+		x, err := p.cond()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("%w: missing )", ErrInvalidArgs)
+		}
+		return x, nil
+	}
+
+	tok := p.peek()
+	if tok == "" {
+		return nil, fmt.Errorf("%w: expression ends early", ErrInvalidArgs)
+	}
+	p.pos++
+	if v, err := strconv.ParseFloat(tok, 64); err == nil {
+		return scriptNumber(v), nil
+	}
+	if !isScriptIdent(tok) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidArgs, tok)
+	}
+	switch tok {
+	case "True":
+		return scriptNumber(1), nil
+	case "False":
+		return scriptNumber(0), nil
+	case "min", "max", "abs":
+		return p.call(tok)
+	}
+	if !p.known[tok] {
+		return nil, fmt.Errorf("%w: unknown name %q", ErrInvalidArgs, tok)
+	}
+
+	return scriptName(tok), nil
+}
+
+func (p *scriptParser) call(name string) (scriptNode, error) {
+	if !p.accept("(") {
+		return nil, fmt.Errorf("%w: %s must be called", ErrInvalidArgs, name)
+	}
+	var args []scriptNode
+	for !p.accept(")") {
+		if len(args) > 0 && !p.accept(",") {
+			return nil, fmt.Errorf("%w: missing , or ) in %s()", ErrInvalidArgs, name)
+		}
+		a, err := p.cond()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+	}
+	if len(args) == 0 || (name == "abs" && len(args) != 1) {
+		return nil, fmt.Errorf("%w: wrong number of arguments to %s()", ErrInvalidArgs, name)
+	}
+
+	return scriptCall{Func: name, Args: args}, nil
+}
+
+var scriptTwoCharOps = map[string]bool{"<=": true, ">=": true, "==": true, "!=": true, "//": true}
+
+// scriptKeywords cannot be assigned to.
+var scriptKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "if": true, "else": true, "True": true, "False": true,
+	"min": true, "max": true, "abs": true,
+}
+
+// scriptTokens splits an expression into numbers, names, and operators.
+func scriptTokens(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			j := i
+			for j < len(s) && (unicode.IsDigit(rune(s[j])) || s[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case i+1 < len(s) && scriptTwoCharOps[s[i:i+2]]:
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		case strings.ContainsRune("+-*/%()<>,", c):
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q", ErrInvalidArgs, c)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isScriptIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if !(unicode.IsLetter(c) || c == '_' || (i > 0 && unicode.IsDigit(c))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//endregion
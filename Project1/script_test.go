@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_parseScriptExprs(t *testing.T) {
+	t.Parallel()
+	env := map[string]float64{"remaining": 3, "wait": 10, "priority": 2, "running": 1}
+	known := map[string]bool{"remaining": true, "wait": true, "priority": true, "running": true}
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{expr: "1 + 2 * 3", want: 7},
+		{expr: "(1 + 2) * 3", want: 9},
+		{expr: "-remaining + 1", want: -2},
+		{expr: "wait // 4", want: 2},
+		{expr: "wait / 4", want: 2.5},
+		{expr: "-7 % 3", want: 2},
+		{expr: "priority - wait // 4", want: 0},
+		{expr: "remaining < 5 and wait >= 10", want: 1},
+		{expr: "not running or priority == 2", want: 1},
+		{expr: "not remaining > 1", want: 0},
+		{expr: "0 or wait", want: 10},
+		{expr: "100 if running else 0", want: 100},
+		{expr: "1 if remaining > 5 else 2 if wait > 5 else 3", want: 2},
+		{expr: "min(remaining, wait, 7) + max(1, priority) + abs(-1)", want: 6},
+		{expr: "True + False", want: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			nodes, err := parseScriptExprs(tt.expr, known)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := nodes[0].eval(env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(nodes) != 1 || got != tt.want {
+				t.Errorf("%s = %v (%d values), want %v", tt.expr, got, len(nodes), tt.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"1 +", "(1", "burst", "1 if running", "min()", "abs(1, 2)", "a = 1", "1 $ 2", "min"} {
+		if _, err := parseScriptExprs(bad, known); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parsing %q returned %v, want ErrInvalidArgs", bad, err)
+		}
+	}
+	nodes, _ := parseScriptExprs("1 / (remaining - 3)", known)
+	if _, err := nodes[0].eval(env); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("dividing by zero returned %v, want ErrInvalidArgs", err)
+	}
+}
+
+func Test_scriptPolicy(t *testing.T) {
+	t.Parallel()
+	p, err := parsePolicyScript(strings.NewReader(`
+# aging priority
+effective = priority - wait // 4  # one level per 4 units waited
+key = effective, remaining
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready := []workload.Process{
+		{ProcessID: 1, Priority: 1, BurstDuration: 5},
+		{ProcessID: 2, Priority: 3, BurstDuration: 2, Wait: 8},
+		{ProcessID: 3, Priority: 1, BurstDuration: 1, Wait: 1},
+	}
+	tests := []struct {
+		name  string
+		ready []workload.Process
+		want  int64
+	}{
+		{name: "tie broken by remaining", ready: ready[:1:1], want: 1},
+		{name: "aged process wins on remaining", ready: ready, want: 3},
+		{name: "aged process wins", ready: []workload.Process{ready[0], ready[1]}, want: 2},
+	}
+	for _, tt := range tests {
+		got, err := p.choose(sched.Decision{Time: 9, Ready: tt.ready})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: chose P%d, want P%d", tt.name, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "effective = 1\n", "key = x\n", "if = 1\nkey = 1\n", "a = 1, 2\nkey = a\n", "key == 1\n"} {
+		if _, err := parsePolicyScript(strings.NewReader(bad)); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parsing script %q returned %v, want ErrInvalidArgs", bad, err)
+		}
+	}
+}
+
+// Test_bundledPolicies loads every script in policies/, so the examples the README points to keep parsing.
+func Test_bundledPolicies(t *testing.T) {
+	t.Parallel()
+	paths, err := filepath.Glob("policies/*.policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no policies/*.policy scripts found")
+	}
+	for _, path := range paths {
+		if _, err := loadPolicyScript(path); err != nil {
+			t.Errorf("loading %s: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// resolveSeed returns seed, or one drawn from the clock when seed is 0, the flag default.
+func resolveSeed(seed int64) int64 {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return seed
+}
+
+// newRand returns the random source for the randomized component named stream under -seed seed; see sched.NewRand.
+func newRand(seed int64, stream string) *rand.Rand {
+	return sched.NewRand(seed, stream)
+}
+
+// validatePerturb checks a -perturb fraction.
+func validatePerturb(f float64) error {
+	if f < 0 || f >= 1 || math.IsNaN(f) {
+		return fmt.Errorf("%w: -perturb must be at least 0 and less than 1", ErrInvalidArgs)
+	}
+
+	return nil
+}
+
+// perturbBursts returns a copy of processes with each burst scaled by a random factor between 1-fraction and
+// 1+fraction, rounded and kept at least 1, to see how sensitive the schedules are to imprecise burst estimates.
+func perturbBursts(processes []workload.Process, fraction float64, rng *rand.Rand) []workload.Process {
+	perturbed := append([]workload.Process(nil), processes...)
+	if fraction == 0 {
+		return perturbed
+	}
+	for i := range perturbed {
+		factor := 1 + fraction*(2*rng.Float64()-1)
+		perturbed[i].BurstDuration = max(1, int64(math.Round(float64(perturbed[i].BurstDuration)*factor)))
+	}
+
+	return perturbed
+}
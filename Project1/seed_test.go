@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_newRand(t *testing.T) {
+	t.Parallel()
+	draw := func(seed int64, stream string) [3]int64 {
+		rng := newRand(seed, stream)
+		return [3]int64{rng.Int63(), rng.Int63(), rng.Int63()}
+	}
+	if draw(7, "lottery") != draw(7, "lottery") {
+		t.Error("the same seed and stream drew different numbers")
+	}
+	if draw(7, "lottery") == draw(7, "perturb") {
+		t.Error("two streams of one seed drew the same numbers")
+	}
+	if draw(7, "lottery") == draw(8, "lottery") {
+		t.Error("two seeds drew the same numbers")
+	}
+	if draw(7, "")[0] != rand.New(rand.NewSource(7)).Int63() {
+		t.Error("the empty stream does not use the seed as it is")
+	}
+}
+
+func Test_perturbBursts(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, BurstDuration: 10},
+		{ProcessID: 2, BurstDuration: 100},
+		{ProcessID: 3, BurstDuration: 1},
+	}
+	got := perturbBursts(processes, 0.2, newRand(1, "perturb"))
+	if processes[1].BurstDuration != 100 {
+		t.Error("perturbBursts changed its input")
+	}
+	for i, p := range got {
+		lo, hi := float64(processes[i].BurstDuration)*0.8-0.5, float64(processes[i].BurstDuration)*1.2+0.5
+		if b := float64(p.BurstDuration); b < 1 || b < lo || b > hi {
+			t.Errorf("P%d burst %d is outside ±20%% of %d", p.ProcessID, p.BurstDuration, processes[i].BurstDuration)
+		}
+	}
+	again := perturbBursts(processes, 0.2, newRand(1, "perturb"))
+	for i := range got {
+		if got[i] != again[i] {
+			t.Errorf("the same seed perturbed P%d differently", got[i].ProcessID)
+		}
+	}
+
+	if err := validatePerturb(1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("validatePerturb(1) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+// Test_runSeed checks that only the reports of runs that drew random numbers record the seed. It sets the options
+// the algorithms read, so it doesn't run in parallel with the other tests.
+func Test_runSeed(t *testing.T) {
+	saved := options
+	t.Cleanup(func() { options = saved })
+	options.seed = 42
+
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := parseAlgorithms("fcfs,lottery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		randomized bool
+		want       [2]int64
+		printed    int
+	}{
+		{name: "only lottery draws", want: [2]int64{0, 42}, printed: 1},
+		{name: "perturbed workload", randomized: true, want: [2]int64{42, 42}, printed: 2},
+	}
+	for _, tt := range tests {
+		options.randomized = tt.randomized
+		var out strings.Builder
+		reports := runAlgorithms(context.Background(), &out, run, processes)
+		for i, r := range reports {
+			if r.Seed != tt.want[i] {
+				t.Errorf("%s: %s report seed = %d, want %d", tt.name, r.Title, r.Seed, tt.want[i])
+			}
+		}
+		if got := strings.Count(out.String(), "Random seed: 42"); got != tt.printed {
+			t.Errorf("%s: printed the seed %d times, want %d", tt.name, got, tt.printed)
+		}
+	}
+}
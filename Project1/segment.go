@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/segment"
+	"github.com/olekukonko/tablewriter"
+)
+
+func segmentCommand(args []string) {
+	fs := flag.NewFlagSet("segment", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "segment [flags] -addresses \"2:53 3:852w\" (tables.txt | -table \"0 1400 1000 rx\")")
+	inline := fs.String("table", "",
+		"segment tables of semicolon-separated \"NUMBER BASE LIMIT [rwx]\" entries, \"table NAME\" starting each")
+	list := fs.String("addresses", "",
+		"logical addresses SEGMENT:OFFSET separated by spaces or commas, with w after a write and x after a fetch")
+	memory := fs.Int64("memory", 0, "units of physical memory (default: up to the end of the last segment)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if (*inline == "") == (fs.NArg() == 0) {
+		fatal(exitInvalid, fmt.Errorf("%w: give one segment table file or -table", ErrInvalidArgs))
+	}
+	if *memory < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -memory must not be negative", ErrInvalidArgs))
+	}
+
+	var in io.Reader = strings.NewReader(*inline)
+	if *inline == "" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: opening segment tables", err))
+		}
+		defer f.Close()
+		in = f
+	}
+	tables, err := segment.ParseTables(in)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	if len(tables) == 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: no segments", ErrInvalidArgs))
+	}
+	layout, err := segment.LayOut(tables, *memory)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	addrs, err := segment.ParseAddresses(*list)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+
+	outputSegmentTables(os.Stdout, tables, len(tables) > 1 || tables[0].Name != "")
+	if len(addrs) > 0 {
+		outputTranslations(os.Stdout, segment.Translate(tables, addrs))
+	}
+	outputLayout(os.Stdout, layout)
+}
+
+// outputSegmentTables writes every segment of tables with the memory it spans and the accesses it allows. named adds
+// the table each is in.
+func outputSegmentTables(w io.Writer, tables []segment.Table, named bool) {
+	_, _ = fmt.Fprintln(w, "Segment tables")
+	table := tablewriter.NewWriter(w)
+	header := []string{"Segment", "Base", "Limit", "Memory", "Access"}
+	if named {
+		header = append([]string{"Table"}, header...)
+	}
+	table.SetHeader(header)
+	for _, t := range tables {
+		for _, s := range t.Segments {
+			row := []string{strconv.Itoa(s.Number), strconv.FormatInt(s.Base, 10), strconv.FormatInt(s.Limit, 10),
+				fmt.Sprintf("%d-%d", s.Base, s.End()-1), s.Perm.String()}
+			if named {
+				row = append([]string{t.Name}, row...)
+			}
+			table.Append(row)
+		}
+	}
+	table.Render()
+}
+
+// outputTranslations writes the physical address or fault of every logical address, then how many of each fault
+// there were.
+func outputTranslations(w io.Writer, translations []segment.Translation) {
+	_, _ = fmt.Fprintln(w, "Translations")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Logical", "Segment", "Offset", "Access", "Physical", "Fault"})
+	for _, t := range translations {
+		physical := ""
+		if t.Fault == segment.None {
+			physical = strconv.FormatInt(t.Physical, 10)
+		}
+		table.Append([]string{t.Address.String(), strconv.Itoa(t.Address.Segment),
+			strconv.FormatInt(t.Address.Offset, 10), t.Address.Access.String(), physical, t.Fault.String()})
+	}
+	table.Render()
+
+	var faults []string
+	for _, f := range segment.Faults {
+		if n := segment.Count(translations, f); n > 0 {
+			faults = append(faults, fmt.Sprintf("%s: %d", f, n))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%d addresses, %d translated", len(translations), segment.Count(translations, segment.None))
+	if len(faults) > 0 {
+		_, _ = fmt.Fprintf(w, "; %s", strings.Join(faults, ", "))
+	}
+	_, _ = fmt.Fprint(w, "\n\n")
+}
+
+// outputLayout writes the holes the segments leave in physical memory and the external fragmentation they make.
+func outputLayout(w io.Writer, l segment.Layout) {
+	_, _ = fmt.Fprintf(w, "Memory: %d units, %d in segments, %d free in %d holes\n", l.Memory, l.Used, l.Free(),
+		len(l.Holes))
+	if len(l.Holes) == 0 {
+		return
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Hole", "Memory", "Size"})
+	for i, h := range l.Holes {
+		table.Append([]string{strconv.Itoa(i + 1), fmt.Sprintf("%d-%d", h.Base, h.Base+h.Size-1),
+			strconv.FormatInt(h.Size, 10)})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Largest hole: %d units; external fragmentation %.1f%% (free memory outside it)\n",
+		l.Largest(), 100*l.External())
+}
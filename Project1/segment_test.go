@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/segment"
+)
+
+func Test_outputTranslations(t *testing.T) {
+	t.Parallel()
+	tables, err := segment.ParseTables(strings.NewReader("0 1400 1000 rx; 1 6300 400 rw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		addresses string
+		want      []string
+	}{
+		{
+			addresses: "1:53 0:10w 0:1000",
+			want: []string{
+				"| 1:53    |       1 |     53 | r--    |     6353 |                     |",
+				"| 0:10w   |       0 |     10 | -w-    |          | protection fault    |",
+				"3 addresses, 1 translated; offset beyond limit: 1, protection fault: 1",
+			},
+		},
+		{addresses: "0:0x", want: []string{"1 addresses, 1 translated\n"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.addresses, func(t *testing.T) {
+			t.Parallel()
+			addrs, err := segment.ParseAddresses(tt.addresses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var w bytes.Buffer
+			outputTranslations(&w, segment.Translate(tables, addrs))
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("outputTranslations() missing %q:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_outputLayout(t *testing.T) {
+	t.Parallel()
+	tables, _ := segment.ParseTables(strings.NewReader("0 100 100; 1 300 50"))
+	l, err := segment.LayOut(tables, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	outputLayout(&w, l)
+	for _, want := range []string{
+		"Memory: 500 units, 150 in segments, 350 free in 3 holes",
+		"|    2 | 200-299 |  100 |",
+		"Largest hole: 150 units; external fragmentation 57.1%",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputLayout() missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"GolandProjects/Project1/pkg/sched"
+	"github.com/olekukonko/tablewriter"
+)
+
+// selftestCase is a textbook answer: the waiting time of every process, in PID order, when algorithm schedules the
+// bundled example workload.
+type selftestCase struct {
+	Example   string
+	Algorithm string
+	// Quantum is the round-robin quantum the answer assumes.
+	Quantum int64
+	Waits   []int64
+	Source  string
+}
+
+// selftestCases are the worked examples of Silberschatz, Galvin, and Gagne, Operating System Concepts, chapter 5.
+var selftestCases = []selftestCase{
+	{Example: "convoy", Algorithm: "fcfs", Waits: []int64{0, 24, 27}, Source: "FCFS, avg wait 17"},
+	{Example: "convoy", Algorithm: "sjf", Waits: []int64{6, 0, 3}, Source: "FCFS in order P2, P3, P1, avg wait 3"},
+	{Example: "sjf", Algorithm: "sjf", Waits: []int64{3, 16, 9, 0}, Source: "SJF, avg wait 7"},
+	{Example: "srtf", Algorithm: "sjf", Waits: []int64{9, 0, 15, 2}, Source: "preemptive SJF, avg wait 6.5"},
+	{Example: "priority", Algorithm: "priority", Waits: []int64{6, 0, 16, 18, 1}, Source: "priority, avg wait 8.2"},
+	{Example: "convoy", Algorithm: "rr", Quantum: 4, Waits: []int64{6, 4, 7}, Source: "RR q=4, avg wait 5.66"},
+}
+
+// selftestResult is the outcome of one case.
+type selftestResult struct {
+	Case selftestCase
+	Got  []int64
+	Err  error
+}
+
+func (r selftestResult) Passed() bool {
+	return r.Err == nil && reflect.DeepEqual(r.Got, r.Case.Waits)
+}
+
+// runSelftest schedules the example of every case with its algorithm and collects the waiting times.
+func runSelftest(cases []selftestCase) []selftestResult {
+	results := make([]selftestResult, len(cases))
+	for i, c := range cases {
+		results[i].Case = c
+		processes, err := loadExample(c.Example)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		run, err := parseAlgorithms(c.Algorithm)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		var s sched.Scheduler = run[0]
+		if c.Algorithm == "rr" {
+			s = roundRobin(c.Quantum)
+		}
+		r := schedule(context.Background(), io.Discard, s, c.Algorithm, processes)
+		for _, p := range sortedRows(r.Processes, "pid") {
+			results[i].Got = append(results[i].Got, p.Wait)
+		}
+	}
+
+	return results
+}
+
+// selftestCommand checks every algorithm against the textbook answers, exiting with exitFailure if any disagree.
+func selftestCommand(args []string) {
+	fs := flag.NewFlagSet("selftest", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "selftest")
+	_ = fs.Parse(args)
+
+	if !outputSelftest(os.Stdout, runSelftest(selftestCases)) {
+		os.Exit(exitFailure)
+	}
+}
+
+// outputSelftest writes one row per case and reports whether they all passed.
+func outputSelftest(w io.Writer, results []selftestResult) bool {
+	passed := 0
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		result := "PASS"
+		got := fmt.Sprint(r.Got)
+		switch {
+		case r.Err != nil:
+			result, got = "FAIL", r.Err.Error()
+		case !r.Passed():
+			result = "FAIL"
+		default:
+			passed++
+		}
+		rows[i] = []string{r.Case.Example, r.Case.Algorithm, r.Case.Source, fmt.Sprint(r.Case.Waits), got, result}
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Example", "Algorithm", "Textbook", "Expected waits", "Got", "Result"})
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = fmt.Fprintf(w, "%d of %d textbook cases passed\n", passed, len(results))
+
+	return passed == len(results)
+}
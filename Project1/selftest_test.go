@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_runSelftest(t *testing.T) {
+	t.Parallel()
+	for _, r := range runSelftest(selftestCases) {
+		if !r.Passed() {
+			t.Errorf("%s on %s: got waits %v (%v), want %v", r.Case.Algorithm, r.Case.Example, r.Got, r.Err, r.Case.Waits)
+		}
+	}
+
+	results := runSelftest([]selftestCase{
+		{Example: "convoy", Algorithm: "fcfs", Waits: []int64{0, 24, 27}},
+		{Example: "convoy", Algorithm: "fcfs", Waits: []int64{0, 0, 0}},
+		{Example: "lottery", Algorithm: "fcfs"},
+	})
+	var w bytes.Buffer
+	if outputSelftest(&w, results) {
+		t.Error("failing cases reported as passing")
+	}
+	if got := strings.Count(w.String(), "FAIL"); got != 2 || !strings.Contains(w.String(), "1 of 3 textbook cases passed") {
+		t.Errorf("selftest output:\n%s", w.String())
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"GolandProjects/Project1/pkg/api"
+)
+
+// serveCommand serves the REST API of package api, and its gRPC service with -grpc-addr, so web front-ends,
+// autograders, and lab infrastructure can run simulations without starting a process for each.
+func serveCommand(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "serve [flags]")
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	timeout := fs.Duration("timeout", 10*time.Second,
+		"stop each simulation after this long and answer with the processes that finished by then (0 for no limit)")
+	grpcAddr := fs.String("grpc-addr", "", "also serve the gRPC service of simulator.proto on this address (needs -tls-cert)")
+	certFile := fs.String("tls-cert", "", "serve over TLS with this PEM certificate")
+	keyFile := fs.String("tls-key", "", "PEM private key of -tls-cert")
+	fs.String("config", "",
+		"file of default flag values (default scheduler.toml, scheduler.yaml, or scheduler.yml if present)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyDefaults(fs); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if fs.NArg() > 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: serve takes no arguments", ErrInvalidArgs))
+	}
+	if (*certFile == "") != (*keyFile == "") {
+		fatal(exitInvalid, fmt.Errorf("%w: -tls-cert and -tls-key go together", ErrInvalidArgs))
+	}
+	if *grpcAddr != "" && *certFile == "" {
+		fatal(exitInvalid, fmt.Errorf("%w: -grpc-addr needs -tls-cert and -tls-key, as gRPC runs over HTTP/2", ErrInvalidArgs))
+	}
+	if *grpcAddr != "" && *grpcAddr == *addr {
+		fatal(exitInvalid, fmt.Errorf("%w: -grpc-addr must differ from -addr", ErrInvalidArgs))
+	}
+
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	// the first server to fail stops the other
+	ctx, cancel := context.WithCancel(interrupted)
+	defer cancel()
+	servers := map[string]http.Handler{*addr: api.NewHandler(*timeout)}
+	if *grpcAddr != "" {
+		servers[*grpcAddr] = api.NewGRPCHandler(*timeout)
+	}
+	failed := make(chan error, len(servers))
+	for addr, handler := range servers {
+		addr, handler := addr, handler
+		go func() {
+			err := serve(ctx, addr, handler, *certFile, *keyFile)
+			if err != nil {
+				cancel()
+			}
+			failed <- err
+		}()
+	}
+	var errs []error
+	for range servers {
+		errs = append(errs, <-failed)
+	}
+	if err := errors.Join(errs...); err != nil {
+		fatal(exitFailure, err)
+	}
+}
+
+// serve serves handler on addr until ctx ends, then stops accepting connections and waits for the requests in
+// flight to finish. Given a certificate and key, it serves HTTPS, and HTTP/2 along with it.
+func serve(ctx context.Context, addr string, handler http.Handler, certFile, keyFile string) error {
+	server := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	failed := make(chan error, 1)
+	go func() {
+		if certFile != "" {
+			logger.Info("serving", "url", "https://"+addr+"/")
+			failed <- server.ListenAndServeTLS(certFile, keyFile)
+			return
+		}
+		logger.Info("serving", "url", "http://"+addr+"/")
+		failed <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-failed:
+		return fmt.Errorf("%w: serving", err)
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%w: shutting down", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_serve(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		addr    string
+		cert    string
+		wantErr bool
+	}{
+		{name: "stops when the context ends", addr: "127.0.0.1:0"},
+		{name: "bad address", addr: "127.0.0.1:-1", wantErr: true},
+		{name: "missing certificate", addr: "127.0.0.1:0", cert: "testdata/missing.pem", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			if err := serve(ctx, tt.addr, http.NotFoundHandler(), tt.cert, tt.cert); (err != nil) != tt.wantErr {
+				t.Errorf("serve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
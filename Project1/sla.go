@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"github.com/olekukonko/tablewriter"
+)
+
+// latencyMetricNames lists the names of every latency metric for flag help and errors.
+func latencyMetricNames() string {
+	var names []string
+	for _, m := range report.LatencyMetrics {
+		names = append(names, m.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parseSLA parses a -sla list of latency targets on the metric named metric: a bare target applies to every process,
+// and PID=TARGET entries override it for one process, such as "20,3=5". It returns nil for an empty list.
+func parseSLA(list, metric string) (*report.SLA, error) {
+	if list == "" {
+		return nil, nil
+	}
+	sla := &report.SLA{Targets: make(map[int64]int64)}
+	found := false
+	for _, m := range report.LatencyMetrics {
+		if m.Name == metric {
+			sla.Metric, found = m, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: unknown -sla-metric %q (want one of %s)", ErrInvalidArgs, metric,
+			latencyMetricNames())
+	}
+	for _, entry := range strings.Split(list, ",") {
+		pid, target, hasPID := strings.Cut(strings.TrimSpace(entry), "=")
+		if !hasPID {
+			pid, target = "", pid
+		}
+		t, err := strconv.ParseInt(target, 10, 64)
+		if err != nil || t < 1 {
+			return nil, fmt.Errorf("%w: -sla %q: want a target of at least 1", ErrInvalidArgs, entry)
+		}
+		if !hasPID {
+			sla.Target = t
+			continue
+		}
+		id, err := strconv.ParseInt(pid, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: -sla %q: want PID=TARGET", ErrInvalidArgs, entry)
+		}
+		sla.Targets[id] = t
+	}
+
+	return sla, nil
+}
+
+// outputSLA writes the processes of one run that went over their latency target, and how many met theirs.
+func outputSLA(w io.Writer, r report.SLAResult) {
+	metric := strings.ToLower(r.SLA.Metric.Title)
+	var rows [][]string
+	for _, p := range r.Processes {
+		if p.Violation() > 0 {
+			rows = append(rows, []string{strconv.FormatInt(p.PID, 10), strconv.FormatInt(p.Target, 10),
+				strconv.FormatInt(p.Latency, 10), strconv.FormatInt(p.Violation(), 10)})
+		}
+	}
+	if len(rows) > 0 {
+		_, _ = fmt.Fprintf(w, "Latency targets missed (%s)\n", metric)
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"ID", "Target", r.SLA.Metric.Title, "Over by"})
+		table.AppendBulk(rows)
+		table.Render()
+	}
+	_, _ = fmt.Fprintf(w, "Met %d of %d %s targets (%.1f%%); over by %d in total, %d at most\n\n", r.Met,
+		len(r.Processes), metric, 100*r.MetShare(), r.TotalViolation, r.MaxViolation)
+}
+
+// outputSLAs compares how every algorithm met the latency targets of sla.
+func outputSLAs(w io.Writer, sla report.SLA, reports []report.Report) {
+	_, _ = fmt.Fprintf(w, "Latency targets (%s)\n", strings.ToLower(sla.Metric.Title))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Met", "Share met", "Total over", "Max over"})
+	for _, r := range reports {
+		result := sla.Evaluate(r)
+		table.Append([]string{r.Title, fmt.Sprintf("%d of %d", result.Met, len(result.Processes)),
+			fmt.Sprintf("%.1f%%", 100*result.MetShare()), strconv.FormatInt(result.TotalViolation, 10),
+			strconv.FormatInt(result.MaxViolation, 10)})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_parseSLA(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		list, metric string
+		target       int64
+		targets      map[int64]int64
+		wantErr      bool
+	}{
+		{list: "20", metric: "turnaround", target: 20, targets: map[int64]int64{}},
+		{list: "20, 3=5", metric: "wait", target: 20, targets: map[int64]int64{3: 5}},
+		{list: "3=5", metric: "response", targets: map[int64]int64{3: 5}},
+		{list: "0", metric: "turnaround", wantErr: true},
+		{list: "x=5", metric: "turnaround", wantErr: true},
+		{list: "20", metric: "completion", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.list+" "+tt.metric, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSLA(tt.list, tt.metric)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidArgs) {
+					t.Errorf("parseSLA() error = %v, want %v", err, ErrInvalidArgs)
+				}
+				return
+			}
+			if err != nil || got.Metric.Name != tt.metric || got.Target != tt.target ||
+				len(got.Targets) != len(tt.targets) || got.Targets[3] != tt.targets[3] {
+				t.Errorf("parseSLA() = %+v, %v, want %s targets %d and %v", got, err, tt.metric, tt.target,
+					tt.targets)
+			}
+		})
+	}
+	if none, err := parseSLA("", "turnaround"); none != nil || err != nil {
+		t.Errorf("parseSLA(\"\") = %v, %v, want none", none, err)
+	}
+}
+
+func Test_outputSLAs(t *testing.T) {
+	t.Parallel()
+	r := report.New("FCFS", sched.NewResult([]sched.TimeSlice{{PID: 1, Stop: 4}, {PID: 2, Start: 4, Stop: 5}},
+		[]workload.Process{
+			{ProcessID: 1, Burst: 4, Turnaround: 4, Completion: 4},
+			{ProcessID: 2, Burst: 1, Wait: 4, Turnaround: 5, Completion: 5},
+		}))
+	sla := report.SLA{Metric: report.LatencyMetrics[0], Target: 3}
+	var w bytes.Buffer
+	outputSLA(&w, sla.Evaluate(r))
+	outputSLAs(&w, sla, []report.Report{r})
+	for _, want := range []string{
+		"|  2 |      3 |          5 |       2 |",
+		"Met 0 of 2 turnaround targets (0.0%); over by 3 in total, 2 at most",
+		"| FCFS      | 0 of 2 | 0.0%      |          3 |        2 |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputSLAs() missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// resumable maps the algorithms that can be paused and resumed to the policy engine policy each runs as. A new policy
+// is made for every run, so lottery draws start afresh from -seed and round robin's rotation from the first arrival;
+// simulationPolicy resumes the rotation a paused simulation saved instead.
+var resumable = map[string]func() sched.Policy{
+	"fcfs":     func() sched.Policy { return sched.FCFSPolicy },
+	"sjf":      func() sched.Policy { return sched.SRTFPolicy },
+	"priority": func() sched.Policy { return sched.PriorityPolicy },
+	"rr":       func() sched.Policy { return sched.RoundRobinPolicy(func(int64) int64 { return options.quantum }) },
+	"lottery":  func() sched.Policy { return sched.LotteryPolicy(newRand(options.seed, "lottery")) },
+}
+
+// simulationPolicy returns the policy the resumable algorithm named name continues sim under. Round robin keeps its
+// rotation in sim, with -quantum for a simulation that didn't run under it before, so that a run paused partway
+// through a turn resumes with the rest of that turn.
+func simulationPolicy(name string, sim *sched.Simulation) sched.Policy {
+	if name != "rr" {
+		return resumable[name]()
+	}
+	if sim.RoundRobin == nil {
+		sim.RoundRobin = &sched.RoundRobinState{Quantum: options.quantum}
+	}
+
+	return sched.ResumableRoundRobinPolicy(sim.RoundRobin)
+}
+
+func resumableNames() string {
+	names := make([]string, 0, len(resumable))
+	for name := range resumable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
+
+// resumableAlgorithms resolves a comma-separated list of resumable algorithm names, in the order given.
+func resumableAlgorithms(list string) ([]sched.Algorithm, error) {
+	run, err := parseAlgorithms(list)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range run {
+		if _, ok := resumable[a.Name()]; !ok {
+			return nil, fmt.Errorf("%w: %s cannot be paused and resumed (want one of %s)", ErrInvalidArgs, a.Name(),
+				resumableNames())
+		}
+	}
+
+	return run, nil
+}
+
+func snapshotCommand(args []string) {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "snapshot [flags] (workload.csv | -example name) > simulation.json")
+	at := fs.Int64("at", 0, "pause the simulation at this time (default: when interrupted, or at the end)")
+	name := fs.String("algorithm", "fcfs", "algorithm to simulate until the pause ("+resumableNames()+")")
+	exampleName := fs.String("example", "", "simulate a bundled example workload instead of a file ("+exampleNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 1, "round-robin time quantum, saved with the simulation")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for the lottery draws (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *at < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -at must not be negative", ErrInvalidArgs))
+	}
+	if options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs))
+	}
+	if strings.Contains(*name, ",") {
+		fatal(exitInvalid, fmt.Errorf("%w: -algorithm takes one algorithm", ErrInvalidArgs))
+	}
+	if _, err := resumableAlgorithms(*name); err != nil {
+		fatal(exitInvalid, err)
+	}
+	options.seed = resolveSeed(options.seed)
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+
+	// an interrupt pauses the simulation wherever it has got to
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	sim, err := pauseSimulation(sched.WithLogger(interrupted, logger), processes, *name, *at)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	if err := sim.Save(os.Stdout); err != nil {
+		fatal(exitFailure, err)
+	}
+	logger.Info("paused simulation", "time", sim.Time, "finished", len(sim.Done), "of", len(sim.Workload))
+}
+
+// pauseSimulation runs processes under the resumable algorithm named name until time at, or until every process
+// finishes when at is 0, and returns the simulation as it stands. An interrupt through ctx pauses it early rather than
+// failing.
+func pauseSimulation(ctx context.Context, processes []workload.Process, name string, at int64) (*sched.Simulation, error) {
+	until := at
+	if until == 0 {
+		until = -1
+	}
+	sim := sched.NewSimulation(processes)
+	if err := sim.Run(ctx, simulationPolicy(name, sim), until); err != nil && !errors.Is(err, context.Canceled) {
+		return nil, err
+	}
+
+	return sim, nil
+}
+
+func resumeCommand(args []string) {
+	fs := flag.NewFlagSet("resume", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "resume [flags] simulation.json")
+	selected := fs.String("algorithms", "",
+		"comma-separated algorithms to continue the simulation with, each from the same saved state (default all: "+
+			resumableNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 1,
+		"round-robin time quantum, if the simulation did not save one by pausing under rr")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for the lottery draws (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if *selected == "" {
+		*selected = resumableNames()
+	}
+	if options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs))
+	}
+	run, err := resumableAlgorithms(*selected)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	if fs.NArg() != 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: must give a saved simulation to resume", ErrInvalidArgs))
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fatal(exitFailure, fmt.Errorf("%w: opening simulation", err))
+	}
+	sim, err := sched.LoadSimulation(f)
+	_ = f.Close()
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	options.seed = resolveSeed(options.seed)
+
+	ctx := sched.WithLogger(context.Background(), logger)
+	for _, a := range run {
+		r := resumeSimulation(ctx, os.Stdout, a, sim)
+		if r.Stopped != "" {
+			fatal(exitStopped, fmt.Errorf("%s stopped before every process finished: %s", r.Title, r.Stopped))
+		}
+	}
+}
+
+// resumeSimulation continues a copy of sim under the resumable algorithm a to the end and outputs the report of the
+// whole run, titled after the algorithm and the time it resumed from.
+func resumeSimulation(ctx context.Context, w io.Writer, a sched.Algorithm, sim *sched.Simulation) report.Report {
+	resumed := sim.Clone()
+	choose := simulationPolicy(a.Name(), resumed)
+	s := sched.New("resume", func(ctx context.Context, _ []workload.Process) (sched.Result, error) {
+		err := resumed.Run(ctx, choose, -1)
+		return resumed.Result(), err
+	})
+
+	return schedule(ctx, w, s, fmt.Sprintf("%s from time %d", a.Title, sim.Time), resumed.Workload)
+}
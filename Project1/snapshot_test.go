@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+func Test_resumableAlgorithms(t *testing.T) {
+	t.Parallel()
+	run, err := resumableAlgorithms("sjf,rr")
+	if err != nil || len(run) != 2 || run[0].Name() != "sjf" || run[1].Name() != "rr" {
+		t.Errorf("resumableAlgorithms(sjf,rr) = %v, %v", run, err)
+	}
+	if _, err := resumableAlgorithms("mlfq"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("resumableAlgorithms(mlfq) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_resumeSimulation(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sjf, _ := resumableAlgorithms("sjf")
+	sim, err := pauseSimulation(context.Background(), processes, "sjf", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.Time != 3 || sim.Finished() {
+		t.Fatalf("paused at %d with every process finished: %v", sim.Time, sim.Finished())
+	}
+	var saved bytes.Buffer
+	if err := sim.Save(&saved); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := sched.LoadSimulation(&saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// resuming under the same policy finishes the run as if it had never paused
+	r := resumeSimulation(context.Background(), io.Discard, sjf[0], loaded)
+	want, _ := sched.RunPolicy(context.Background(), processes, sched.SRTFPolicy)
+	if r.Title != sjf[0].Title+" from time 3" || !reflect.DeepEqual(r.Gantt, want.Slices) ||
+		!reflect.DeepEqual(r.Processes, want.PerProcess) {
+		t.Errorf("resumed %q: %v, want %v", r.Title, r.Gantt, want.Slices)
+	}
+	// and leaves the saved state alone for the next algorithm
+	if loaded.Time != 3 {
+		t.Errorf("resuming moved the saved simulation to time %d", loaded.Time)
+	}
+}
+
+// Test_resumeSimulation_roundRobin pauses round robin partway through a turn and resumes it. It sets the -quantum the
+// policies read, so it doesn't run in parallel with the other tests.
+func Test_resumeSimulation_roundRobin(t *testing.T) {
+	saved := options
+	t.Cleanup(func() { options = saved })
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr, _ := resumableAlgorithms("rr")
+	options.quantum = 3
+	want, err := sched.RR(context.Background(), processes, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// paused partway through a turn of 3, so the quantum and the turn's time used so far must be saved
+	sim, err := pauseSimulation(context.Background(), processes, "rr", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.RoundRobin == nil || sim.RoundRobin.Quantum != 3 || sim.RoundRobin.Used != 1 {
+		t.Fatalf("paused round robin at %d as %+v, want a quantum of 3 with 1 of the turn used", sim.Time, sim.RoundRobin)
+	}
+	var snapshot bytes.Buffer
+	if err := sim.Save(&snapshot); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := sched.LoadSimulation(&snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a different -quantum on resume doesn't change the saved one
+	options.quantum = 1
+	for i := 0; i < 2; i++ {
+		r := resumeSimulation(context.Background(), io.Discard, rr[0], loaded)
+		if !reflect.DeepEqual(r.Gantt, want.Slices) || !reflect.DeepEqual(r.Processes, want.PerProcess) {
+			t.Errorf("resume %d: %v, want %v", i+1, r.Gantt, want.Slices)
+		}
+	}
+}
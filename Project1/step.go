@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// stepper pauses a simulation after every scheduling decision so it can be followed one time unit at a time. A nil
+// *stepper never pauses, so the schedulers call it unconditionally.
+type stepper struct {
+	in  *bufio.Reader
+	out io.Writer
+	// running is false once the user asked to continue to the end.
+	running bool
+	// exit ends the program when the user quits.
+	exit func(code int)
+}
+
+func newStepper(in io.Reader, out io.Writer) *stepper {
+	return &stepper{in: bufio.NewReader(in), out: out, running: true, exit: os.Exit}
+}
+
+// decide shows the decision to run running at time, with the rest of the ready queue and the Gantt chart so far,
+// then waits for a command: Enter or n for the next decision, c to continue without pausing, q to quit.
+func (s *stepper) decide(title string, time int64, running workload.Process, ready []workload.Process, gantt []sched.TimeSlice) {
+	if s == nil || !s.running {
+		return
+	}
+
+	_, _ = fmt.Fprintf(s.out, "%s, time %d: run P%d (%d left)\n", title, time, running.ProcessID, running.BurstDuration)
+	pids := make([]string, len(ready))
+	for i, p := range ready {
+		pids[i] = fmt.Sprintf("P%d", p.ProcessID)
+	}
+	_, _ = fmt.Fprintf(s.out, "Ready queue: [%s]\n", strings.Join(pids, " "))
+	if len(gantt) > 0 {
+		report.WriteGantt(s.out, gantt, options.color)
+	}
+
+	for {
+		_, _ = fmt.Fprint(s.out, "[Enter/n] next, [c] continue, [q] quit: ")
+		line, err := s.in.ReadString('\n')
+		switch strings.TrimSpace(line) {
+		case "", "n":
+			if err != nil {
+				// input closed: nobody is left to step, so finish the run
+				s.running = false
+			}
+			return
+		case "c":
+			s.running = false
+			return
+		case "q":
+			s.exit(exitOK)
+			return
+		}
+		if err != nil {
+			s.running = false
+			return
+		}
+	}
+}
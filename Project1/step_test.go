@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_stepperDecide(t *testing.T) {
+	t.Parallel()
+	running := workload.Process{ProcessID: 1, BurstDuration: 3}
+	ready := []workload.Process{{ProcessID: 2}, {ProcessID: 3}}
+	gantt := []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}}
+
+	tests := []struct {
+		name    string
+		in      string
+		prompts int
+		exit    bool
+	}{
+		{name: "enter and n step", in: "\nn\n\n", prompts: 3},
+		{name: "continue stops pausing", in: "n\nc\n", prompts: 2},
+		{name: "unknown commands ask again", in: "x\n\n", prompts: 3},
+		{name: "closed input runs to the end", in: "", prompts: 1},
+		{name: "quit exits", in: "q\n", prompts: 1, exit: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			s := newStepper(strings.NewReader(tt.in), &out)
+			exited := false
+			s.exit = func(code int) {
+				exited = code == exitOK
+			}
+			for i := 0; i < 3 && !exited; i++ {
+				s.decide("Test", int64(i), running, ready, gantt)
+			}
+			if got := strings.Count(out.String(), "[q] quit"); got != tt.prompts {
+				t.Errorf("prompted %d times, want %d:\n%s", got, tt.prompts, out.String())
+			}
+			if exited != tt.exit {
+				t.Errorf("exited %v, want %v", exited, tt.exit)
+			}
+			if !strings.Contains(out.String(), "Test, time 0: run P1 (3 left)\nReady queue: [P2 P3]\n") {
+				t.Errorf("missing decision in:\n%s", out.String())
+			}
+		})
+	}
+
+	var s *stepper
+	s.decide("Test", 0, running, ready, gantt) // a nil stepper never pauses
+}
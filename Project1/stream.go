@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// streamSchedule runs s on processes for -stream, where the workload is too large to report in full. The Gantt
+// chart goes to <dir>/<title>.slices as the run goes, and the per-process metrics to <dir>/<title>.csv once it is
+// over; w gets only the run-wide metrics. Any error writing the files is reported as the reason the run stopped.
+func streamSchedule(ctx context.Context, w io.Writer, s sched.Scheduler, title string, processes []workload.Process) report.Report {
+	base := filepath.Join(options.streamDir, report.Slug(title))
+	result, switches, err := streamSlices(ctx, base+".slices", s, title, processes)
+
+	r := report.New(title, result)
+	r.Switches = switches
+	r.Seed = runSeed(s)
+	if err = errors.Join(err, writeProcessColumns(base+".csv", title, r.Processes)); err != nil {
+		r.Stopped = err.Error()
+	}
+	if options.template != nil {
+		if err := options.template.Execute(w, r); err != nil {
+			logger.Warn("rendering template", "algorithm", title, "err", err)
+		}
+		return r
+	}
+	outputStreamed(w, r, base)
+
+	return r
+}
+
+// streamSlices runs s on processes, writing its Gantt chart to the slice file at path, and returns its result
+// without the chart along with the number of context switches in it.
+func streamSlices(ctx context.Context, path string, s sched.Scheduler, title string, processes []workload.Process) (sched.Result, int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return sched.Result{}, 0, fmt.Errorf("%w: creating slice file", err)
+	}
+	sw := sched.NewSliceWriter(f)
+	result, err := s.Schedule(sched.WithSliceSink(schedContext(ctx, title), sw), processes)
+	// schedulers that don't stream, like the policy engine, hand back their whole chart instead
+	for _, slice := range result.Slices {
+		if err == nil {
+			err = sw.WriteSlice(slice)
+		}
+	}
+	result.Slices = nil
+	err = errors.Join(err, sw.Flush(), f.Close())
+
+	return result, sw.ContextSwitches(), err
+}
+
+// writeProcessColumns writes the metrics of the finished processes done of the algorithm titled title to a CSV file
+// at path, through report.ProcessColumns so no row is held as strings.
+func writeProcessColumns(path, title string, done []workload.Process) error {
+	columns := report.NewProcessColumns(len(done))
+	for _, p := range done {
+		columns.Append(p)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating process CSV", err)
+	}
+	if err := columns.WriteCSV(f, title, true); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// outputStreamed writes the run-wide metrics of the streamed report r, and where its chart and per-process metrics
+// went, base being their path without the extension.
+func outputStreamed(w io.Writer, r report.Report, base string) {
+	outputTitle(w, r.Title)
+	if r.Stopped != "" {
+		_, _ = fmt.Fprintf(w, "Stopped early (%s): only the %d processes that finished are included\n\n",
+			r.Stopped, len(r.Processes))
+	}
+	if r.Seed != 0 {
+		_, _ = fmt.Fprintf(w, "Random seed: %d (rerun with -seed %d to reproduce)\n\n", r.Seed, r.Seed)
+	}
+	_, _ = fmt.Fprintf(w, "Gantt chart: %s.slices\nPer-process metrics: %s.csv (%d processes)\n\n",
+		base, base, len(r.Processes))
+	_, _ = fmt.Fprintf(w, "Average wait %.2f, turnaround %.2f, normalized turnaround %.2f; throughput %.2f\n\n",
+		r.Summary.Wait, r.Summary.Turnaround, r.Summary.Normalized, r.Summary.Throughput)
+	outputUtilization(w, r.Busy, r.Idle, r.Overhead)
+	_, _ = fmt.Fprintf(w, "Context switches: %d\n\n", r.Switches)
+}
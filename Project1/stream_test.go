@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+)
+
+func Test_streamSlices(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// lottery runs on the policy engine, which hands its chart back rather than streaming it
+	for _, name := range []string{"fcfs", "sjf", "rr", "lottery"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			a, _ := algorithms.Lookup(name)
+			want, err := a.Schedule(context.Background(), processes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join(t.TempDir(), name+".slices")
+			got, switches, err := streamSlices(context.Background(), path, a, a.Title, processes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Slices != nil || !reflect.DeepEqual(got.PerProcess, want.PerProcess) {
+				t.Errorf("streamSlices() = %+v, want the metrics of %+v without its chart", got, want)
+			}
+			if n := sched.CountContextSwitches(want.Slices); switches != n {
+				t.Errorf("streamSlices() counted %d context switches, want %d", switches, n)
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			var slices []sched.TimeSlice
+			if err := sched.ReadSlices(f, func(s sched.TimeSlice) error {
+				slices = append(slices, s)
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(slices, want.Slices) {
+				t.Errorf("slice file holds %v, want %v", slices, want.Slices)
+			}
+		})
+	}
+}
+
+func Test_outputStreamed(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := sched.FCFS(context.Background(), processes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	base := filepath.Join(dir, "fcfs")
+	r := report.New("FCFS", result)
+	if err := writeProcessColumns(base+".csv", r.Title, r.Processes); err != nil {
+		t.Fatal(err)
+	}
+	csv, err := os.ReadFile(base + ".csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(csv), "\n"); lines != len(processes)+1 {
+		t.Errorf("process CSV has %d lines, want a header and %d rows:\n%s", lines, len(processes), csv)
+	}
+
+	var w bytes.Buffer
+	outputStreamed(&w, r, base)
+	for _, want := range []string{"Gantt chart: " + base + ".slices\n", "(4 processes)", "Context switches: 3\n"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputSummaryLine(t *testing.T) {
+	t.Parallel()
+	reports := []report.Report{
+		report.New("First-come, first-serve", sched.NewResult(nil, []workload.Process{
+			{ProcessID: 1, Burst: 5, Turnaround: 5, Completion: 5},
+			{ProcessID: 2, Burst: 5, Wait: 5, Turnaround: 10, Completion: 10},
+		})),
+	}
+
+	var kv bytes.Buffer
+	if err := outputSummaryLine(&kv, "kv", reports); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(kv.String(), "\n") != 1 {
+		t.Errorf("kv summary is not a single line: %q", kv.String())
+	}
+	for _, want := range []string{"status=ok", "first-come-first-serve.avg_wait=2.50", "first-come-first-serve.utilization=1.0000"} {
+		if !strings.Contains(kv.String(), want) {
+			t.Errorf("kv summary %q missing %q", kv.String(), want)
+		}
+	}
+
+	var js bytes.Buffer
+	if err := outputSummaryLine(&js, "json", reports); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		SchemaVersion int `json:"schema_version"`
+		Status        string
+		Algorithms    []report.AlgorithmRecord
+	}
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatalf("json summary does not decode: %v", err)
+	}
+	if decoded.SchemaVersion != report.SchemaVersion || decoded.Status != "ok" || len(decoded.Algorithms) != 1 ||
+		decoded.Algorithms[0].AvgTurnaround != 7.5 {
+		t.Errorf("json summary = %+v", decoded)
+	}
+
+	// a run cut short by -timeout or an interrupt is flagged
+	reports[0].Stopped = "context deadline exceeded"
+	kv.Reset()
+	if err := outputSummaryLine(&kv, "kv", reports); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(kv.String(), "status=stopped ") {
+		t.Errorf("kv summary of a stopped run = %q", kv.String())
+	}
+
+	// deadline misses are added only for workloads with deadlines
+	if strings.Contains(kv.String(), "deadline_misses") {
+		t.Errorf("kv summary without deadlines = %q", kv.String())
+	}
+	reports[0] = report.New("First-come, first-serve", sched.NewResult(nil, []workload.Process{
+		{ProcessID: 1, Burst: 5, Turnaround: 5, Completion: 5, Deadline: 5},
+		{ProcessID: 2, Burst: 5, Wait: 5, Turnaround: 10, Completion: 10, Deadline: 7},
+	}))
+	kv.Reset()
+	if err := outputSummaryLine(&kv, "kv", reports); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(kv.String(), "first-come-first-serve.deadline_misses=1 first-come-first-serve.max_tardiness=3") {
+		t.Errorf("kv summary with deadlines = %q", kv.String())
+	}
+
+	if err := validateSummaryFormat("xml"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("validateSummaryFormat() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_exitCode(t *testing.T) {
+	t.Parallel()
+	_, unknown := parseAlgorithms("mlfq")
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "ok", want: exitOK},
+		{name: "bad record", err: fmt.Errorf("%w: line 2", workload.ErrBadRecord), want: exitInvalid},
+		{name: "duplicate pid", err: workload.ErrDuplicatePID, want: exitInvalid},
+		{name: "infeasible deadline", err: workload.ErrInfeasibleDeadline, want: exitDeadlineMiss},
+		{name: "unknown algorithm", err: unknown, want: exitInvalid},
+		{name: "bad simulation", err: sched.ErrBadSimulation, want: exitInvalid},
+		{name: "timed out", err: context.DeadlineExceeded, want: exitStopped},
+		{name: "anything else", err: errors.New("disk full"), want: exitFailure},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
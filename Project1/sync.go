@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/synctrace"
+	"github.com/olekukonko/tablewriter"
+)
+
+func syncCommand(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "sync [flags] (trace.txt | -trace \"semaphore S 1; thread T1 P(S) V(S); ...\")")
+	inline := fs.String("trace", "", "semicolon-separated semaphore, mutex, thread, and order entries")
+	schedule := fs.String("schedule", "rr", "how to pick the next thread once any order runs out: rr or random")
+	timeline := fs.Bool("timeline", true, "show every thread's timeline")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for -schedule random (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if (*inline == "") == (fs.NArg() == 0) {
+		fatal(exitInvalid, fmt.Errorf("%w: give one trace file or -trace", ErrInvalidArgs))
+	}
+	if *schedule != "rr" && *schedule != "random" {
+		fatal(exitInvalid, fmt.Errorf("%w: -schedule must be rr or random, got %q", ErrInvalidArgs, *schedule))
+	}
+
+	var in io.Reader = strings.NewReader(*inline)
+	if *inline == "" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: opening trace", err))
+		}
+		defer f.Close()
+		in = f
+	}
+	p, err := synctrace.Parse(in)
+	if err != nil {
+		fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+	}
+	scheduler := synctrace.Scheduler(synctrace.RoundRobin)
+	if *schedule == "random" {
+		options.seed = resolveSeed(options.seed)
+		scheduler = synctrace.Random(newRand(options.seed, "sync"))
+	}
+	r := synctrace.Run(p, scheduler)
+	outputSync(os.Stdout, r, *timeline)
+	if *schedule == "random" {
+		_, _ = fmt.Fprintf(os.Stdout, "Random seed: %d (rerun with -seed %d to reproduce)\n", options.seed,
+			options.seed)
+	}
+}
+
+// outputSync writes every operation of the run in r with what came of it, every thread's timeline if timeline is
+// set, then every violation and whether the threads finished or deadlocked.
+func outputSync(w io.Writer, r synctrace.Result, timeline bool) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Time", "Thread", "Operation", "Outcome"})
+	for _, e := range r.Events {
+		table.Append([]string{strconv.Itoa(e.Time), e.Thread, e.Op.String(), e.Outcome})
+	}
+	table.Render()
+	if timeline {
+		_ = synctrace.WriteTimeline(w, r)
+		_, _ = fmt.Fprintf(w, "%c runs an operation, %c ready, %c blocked, X deadlocked\n", synctrace.MarkRan,
+			synctrace.MarkReady, synctrace.MarkBlocked)
+	}
+
+	if len(r.Violations) == 0 {
+		_, _ = fmt.Fprintln(w, "Violations: none")
+	} else {
+		_, _ = fmt.Fprintf(w, "Violations: %d\n", len(r.Violations))
+		for _, v := range r.Violations {
+			_, _ = fmt.Fprintf(w, "  Time %d: %s %s\n", v.Time, v.Thread, v.Problem)
+		}
+	}
+	if len(r.Deadlock) == 0 {
+		_, _ = fmt.Fprintf(w, "Every thread finished by time %d\n", r.Time)
+		return
+	}
+	waits := make([]string, len(r.Deadlock))
+	for i, d := range r.Deadlock {
+		waits[i] = d.String()
+	}
+	_, _ = fmt.Fprintf(w, "Deadlock at time %d: %s\n", r.Time, strings.Join(waits, "; "))
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/synctrace"
+)
+
+func Test_outputSync(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		trace string
+		want  []string
+	}{
+		{
+			name:  "deadlock",
+			trace: "mutex M; mutex N; thread A lock(M) lock(N); thread B lock(N) lock(M)",
+			want: []string{
+				"|    2 | A      | lock(N)   | blocks, N held by B |",
+				"A #.#-X\n",
+				"Violations: none\n",
+				"Deadlock at time 4: A waits for N held by B; B waits for M held by A\n",
+			},
+		},
+		{
+			name:  "violations",
+			trace: "mutex M; thread A enter(cs) exit(cs); thread B enter(cs) unlock(M) exit(cs)",
+			want: []string{
+				"Violations: 2\n",
+				"  Time 1: B enters cs while A is inside\n",
+				"  Time 3: B unlocks M, which is not locked\n",
+				"Every thread finished by time 5\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := synctrace.Parse(strings.NewReader(tt.trace))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var w bytes.Buffer
+			outputSync(&w, synctrace.Run(p, synctrace.RoundRobin), true)
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
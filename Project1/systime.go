@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"github.com/olekukonko/tablewriter"
+)
+
+// parseSyscallOverhead parses a -syscall list of the percent of their own running time processes spend again in the
+// kernel: a bare percent applies to every process, and PID=PERCENT entries override it for one process, such as
+// "10,3=40". It returns nil for an empty list, charging no kernel time at all.
+func parseSyscallOverhead(list string) (func(pid int64) int64, error) {
+	if list == "" {
+		return nil, nil
+	}
+	var all int64
+	by := make(map[int64]int64)
+	for _, entry := range strings.Split(list, ",") {
+		pid, percent, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			pid, percent = "", pid
+		}
+		p, err := strconv.ParseInt(percent, 10, 64)
+		if err != nil || p < 0 {
+			return nil, fmt.Errorf("%w: -syscall %q: want a percent of at least 0", ErrInvalidArgs, entry)
+		}
+		if !found {
+			all = p
+			continue
+		}
+		id, err := strconv.ParseInt(pid, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: -syscall %q: want PID=PERCENT", ErrInvalidArgs, entry)
+		}
+		by[id] = p
+	}
+
+	return func(pid int64) int64 {
+		if p, ok := by[pid]; ok {
+			return p
+		}
+		return all
+	}, nil
+}
+
+// outputCPUTimes writes the real, user, and system time of every process the way time(1) reports them, and how much
+// of the busy CPU time the kernel took.
+func outputCPUTimes(w io.Writer, r report.Report) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Process", "Real", "User", "Sys", "Sys %"})
+	for _, t := range r.CPUTimes() {
+		table.Append([]string{strconv.FormatInt(t.PID, 10), strconv.FormatInt(t.Real, 10),
+			strconv.FormatInt(t.User, 10), strconv.FormatInt(t.Sys, 10), fmt.Sprintf("%.1f%%", 100*t.SysShare())})
+	}
+	table.Render()
+	var share float64
+	if r.Busy > 0 {
+		share = float64(r.System) / float64(r.Busy)
+	}
+	_, _ = fmt.Fprintf(w, "System time: %d of the %d busy, %.1f%% spent in the kernel\n\n", r.System, r.Busy,
+		100*share)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_parseSyscallOverhead(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		list    string
+		want    map[int64]int64 // percent by PID
+		wantErr bool
+	}{
+		{list: "25", want: map[int64]int64{1: 25, 7: 25}},
+		{list: "10, 3=40", want: map[int64]int64{1: 10, 3: 40}},
+		{list: "2=50", want: map[int64]int64{1: 0, 2: 50}},
+		{list: "-5", wantErr: true},
+		{list: "x=5", wantErr: true},
+		{list: "2=", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.list, func(t *testing.T) {
+			t.Parallel()
+			percent, err := parseSyscallOverhead(tt.list)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidArgs) {
+					t.Errorf("parseSyscallOverhead(%q) error = %v, want %v", tt.list, err, ErrInvalidArgs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSyscallOverhead(%q) error = %v", tt.list, err)
+			}
+			for pid, want := range tt.want {
+				if got := percent(pid); got != want {
+					t.Errorf("parseSyscallOverhead(%q) gives P%d %d%%, want %d%%", tt.list, pid, got, want)
+				}
+			}
+		})
+	}
+	if percent, err := parseSyscallOverhead(""); percent != nil || err != nil {
+		t.Errorf("parseSyscallOverhead(\"\") = %p, %v, want nil", percent, err)
+	}
+}
+
+func Test_outputCPUTimes(t *testing.T) {
+	t.Parallel()
+	gantt := []sched.TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}}
+	done := []workload.Process{
+		{ProcessID: 1, Burst: 4, Turnaround: 4, Completion: 4},
+		{ProcessID: 2, Burst: 2, Wait: 4, Turnaround: 6, Completion: 6},
+	}
+	charged := sched.ChargeSystemTime(sched.NewResult(gantt, done), func(pid int64) int64 { return 50 * pid })
+	var w bytes.Buffer
+	outputCPUTimes(&w, report.New("test", charged))
+	for _, want := range []string{
+		"|       1 |    6 |    4 |   2 | 33.3% |",
+		"|       2 |   10 |    2 |   2 | 50.0% |",
+		"System time: 4 of the 10 busy, 40.0% spent in the kernel\n",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
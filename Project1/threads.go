@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/threading"
+	"github.com/olekukonko/tablewriter"
+)
+
+func threadsCommand(args []string) {
+	fs := flag.NewFlagSet("threads", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "threads [flags] (workload.csv | -example name)")
+	name := fs.String("algorithm", "rr", "algorithm the kernel schedules with ("+resumableNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 2, "round-robin time quantum")
+	exampleName := fs.String("example", "", "simulate a bundled example workload instead of a file ("+exampleNames()+")")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if _, ok := resumable[*name]; !ok {
+		fatal(exitInvalid, fmt.Errorf("%w: threads cannot schedule with %q (want one of %s)", ErrInvalidArgs, *name,
+			resumableNames()))
+	}
+	if options.quantum < 1 {
+		fatal(exitInvalid, fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs))
+	}
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+	a, _ := parseAlgorithms(*name)
+	ctx := sched.WithLogger(context.Background(), logger)
+
+	results := make([]threading.Result, len(threading.Models))
+	for i, m := range threading.Models {
+		policy := whatIfPolicy(*name, func(int64) int64 { return options.quantum })
+		r, err := threading.Run(ctx, processes, m, policy)
+		if err != nil {
+			fatal(exitCode(err), fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+		}
+		results[i] = r
+		outputThreads(os.Stdout, fmt.Sprintf("%s, %s kernel", m.Title, a[0].Title), r)
+	}
+	outputThreadsSummary(os.Stdout, results)
+}
+
+// outputThreads writes the Gantt chart of what the kernel scheduled in r, naming the threads behind its PIDs under
+// one-to-one, and every process with its time blocked and stalled.
+func outputThreads(w io.Writer, title string, r threading.Result) {
+	outputTitle(w, title)
+	_, _ = fmt.Fprintf(w, "%s: %s\n", r.Model.Title, r.Model.Description)
+	report.WriteGantt(w, r.Gantt, options.color)
+	var labels []string
+	for _, e := range r.Entities {
+		if label := e.Label(); label != fmt.Sprintf("P%d", e.PID) {
+			labels = append(labels, fmt.Sprintf("%d=%s", e.PID, label))
+		}
+	}
+	if len(labels) > 0 {
+		_, _ = fmt.Fprintf(w, "Kernel threads: %s\n\n", strings.Join(labels, " "))
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"PID", "Threads", "Burst", "Blocked", "Stalled", "Completion", "Turnaround"})
+	for _, p := range r.Processes {
+		table.Append([]string{strconv.FormatInt(p.ProcessID, 10), strconv.Itoa(p.Threads),
+			strconv.FormatInt(p.BurstDuration, 10), strconv.FormatInt(p.Blocked, 10), strconv.FormatInt(p.Stalled, 10),
+			strconv.FormatInt(p.Completion, 10), strconv.FormatInt(p.Turnaround, 10)})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Stalled is time a process's ready threads sat blocked along with a blocking one; average "+
+		"turnaround %.2f\n\n", r.AverageTurnaround())
+}
+
+// outputThreadsSummary writes the turnaround of every process under each model side by side, with the time it
+// stalled under those that stall.
+func outputThreadsSummary(w io.Writer, results []threading.Result) {
+	header := []string{"PID", "Threads"}
+	for _, r := range results {
+		header = append(header, r.Model.Name+" turnaround")
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append(header, "Stalled"))
+	for i, p := range results[0].Processes {
+		row := []string{strconv.FormatInt(p.ProcessID, 10), strconv.Itoa(p.Threads)}
+		var stalled int64
+		for _, r := range results {
+			row = append(row, strconv.FormatInt(r.Processes[i].Turnaround, 10))
+			stalled += r.Processes[i].Stalled
+		}
+		table.Append(append(row, strconv.FormatInt(stalled, 10)))
+	}
+	table.Render()
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "%s: average turnaround %.2f, %d time units stalled\n", r.Model.Title,
+			r.AverageTurnaround(), r.Stalled())
+	}
+	_, _ = fmt.Fprintln(w)
+}
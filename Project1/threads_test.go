@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/threading"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_outputThreads(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 4, Threads: "1/4/1 2"},
+		{ProcessID: 2, BurstDuration: 3}}
+	results := make([]threading.Result, len(threading.Models))
+	var w bytes.Buffer
+	for i, m := range threading.Models {
+		var err error
+		if results[i], err = threading.Run(context.Background(), processes, m, sched.FCFSPolicy); err != nil {
+			t.Fatal(err)
+		}
+		outputThreads(&w, m.Title, results[i])
+	}
+	outputThreadsSummary(&w, results)
+	for _, want := range []string{
+		"|   1 |       2 |     4 |       4 |       4 |          8 |          8 |",
+		"Kernel threads: 1=P1.T1 2=P1.T2 3=P2.T1\n",
+		"|   1 |       2 |                      8 |                     7 |       4 |",
+		"Many-to-one (user-level): average turnaround 6.00, 4 time units stalled\n",
+		"One-to-one (kernel-level): average turnaround 6.50, 0 time units stalled\n",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+	}
+}
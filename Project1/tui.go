@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+)
+
+// clearScreen moves the cursor home and clears the terminal before each animation frame.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// playback is the state of an animation the user can speed up, slow down, and pause.
+type playback struct {
+	// Speed is how many simulated time units are shown per second.
+	Speed  float64
+	Paused bool
+}
+
+// frameDelay is how long one time unit stays on screen.
+func (p playback) frameDelay() time.Duration {
+	return time.Duration(float64(time.Second) / p.Speed)
+}
+
+// readControls sends every line read from r, trimmed, until r is exhausted.
+func readControls(r io.Reader) <-chan string {
+	controls := make(chan string)
+	go func() {
+		defer close(controls)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			controls <- strings.TrimSpace(scanner.Text())
+		}
+	}()
+
+	return controls
+}
+
+// animate plays each report in turn, redrawing the Gantt chart and ready queue for every time unit. Controls are
+// lines: + and - double and halve the speed, p pauses and resumes, Enter steps one unit while paused, and q stops.
+func animate(w io.Writer, reports []report.Report, controls <-chan string, p playback) {
+	for _, r := range reports {
+		rows := r.Timeline()
+		end := int64(len(rows[0].States))
+		for t := int64(0); t <= end; {
+			renderFrame(w, r, rows, t, p)
+			var timeout <-chan time.Time
+			if !p.Paused {
+				timeout = time.After(p.frameDelay())
+			}
+			select {
+			case <-timeout:
+				t++
+			case cmd, ok := <-controls:
+				if !ok {
+					// no more input, so nobody can unpause
+					controls, p.Paused = nil, false
+					continue
+				}
+				switch cmd {
+				case "+":
+					p.Speed *= 2
+				case "-":
+					p.Speed /= 2
+				case "p":
+					p.Paused = !p.Paused
+				case "q":
+					return
+				case "":
+					if p.Paused {
+						t++
+					}
+				}
+			}
+		}
+	}
+}
+
+// renderFrame draws r as it stood at time t: the Gantt chart up to t and who was running and ready during t.
+func renderFrame(w io.Writer, r report.Report, rows []report.TimelineRow, t int64, p playback) {
+	_, _ = fmt.Fprint(w, clearScreen)
+	outputTitle(w, r.Title)
+	state := fmt.Sprintf("%g time units/s", p.Speed)
+	if p.Paused {
+		state = "paused"
+	}
+	_, _ = fmt.Fprintf(w, "Time %d of %d (%s)\n\n", t, len(rows[0].States), state)
+
+	if gantt := clipGantt(r.Gantt, t); len(gantt) > 0 {
+		report.WriteGantt(w, gantt, options.color)
+	}
+
+	running, ready := "idle", []string{}
+	for _, row := range rows[:len(rows)-1] {
+		if t >= int64(len(row.States)) {
+			break
+		}
+		switch row.States[t] {
+		case report.StateRunning:
+			running = "P" + row.Label
+		case report.StateReady:
+			ready = append(ready, "P"+row.Label)
+		}
+	}
+	if t >= int64(len(rows[0].States)) {
+		running = "done"
+	}
+	_, _ = fmt.Fprintf(w, "Running: %s\nReady queue: [%s]\n\n", running, strings.Join(ready, " "))
+	_, _ = fmt.Fprintln(w, "+/- then Enter: faster/slower, p: pause/resume, Enter: step while paused, q: quit")
+}
+
+// clipGantt returns the part of gantt that happened before time t.
+func clipGantt(gantt []sched.TimeSlice, t int64) []sched.TimeSlice {
+	clipped := make([]sched.TimeSlice, 0, len(gantt))
+	for _, s := range gantt {
+		if s.Start >= t {
+			continue
+		}
+		if s.Stop > t {
+			s.Stop = t
+		}
+		clipped = append(clipped, s)
+	}
+
+	return clipped
+}
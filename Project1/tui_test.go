@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_clipGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []sched.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 6}}
+	tests := []struct {
+		t    int64
+		want []sched.TimeSlice
+	}{
+		{t: 0, want: []sched.TimeSlice{}},
+		{t: 2, want: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 2}}},
+		{t: 4, want: []sched.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 4}}},
+		{t: 9, want: gantt},
+	}
+	for _, tt := range tests {
+		if got := clipGantt(gantt, tt.t); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("clipGantt(%d) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func Test_animate(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+	}
+	reports := []report.Report{
+		scheduleWith("fcfs")(context.Background(), io.Discard, "First", processes),
+		scheduleWith("fcfs")(context.Background(), io.Discard, "Second", processes),
+	}
+
+	var w bytes.Buffer
+	animate(&w, reports, nil, playback{Speed: 1e6})
+	// one frame per time unit 0 through 4 for each report
+	if got := strings.Count(w.String(), clearScreen); got != 10 {
+		t.Errorf("drew %d frames, want 10", got)
+	}
+	if !strings.Contains(w.String(), "Time 1 of 4 (1e+06 time units/s)\n\nGantt schedule") ||
+		!strings.Contains(w.String(), "Running: P1\nReady queue: [P2]") {
+		t.Errorf("frame for time 1 missing from:\n%s", w.String())
+	}
+
+	// paused playback only moves on command, and q stops it
+	controls := make(chan string, 3)
+	controls <- ""
+	controls <- "q"
+	w.Reset()
+	animate(&w, reports, controls, playback{Speed: 1, Paused: true})
+	if got := strings.Count(w.String(), clearScreen); got != 2 {
+		t.Errorf("drew %d frames while paused, want 2", got)
+	}
+}
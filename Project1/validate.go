@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// workloadProblem is one thing wrong with a workload file. Line is 0 for problems with the file as a whole.
+type workloadProblem struct {
+	Line    int
+	Message string
+}
+
+func (p workloadProblem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// validateWorkload checks a workload CSV with workload.Parse and workload.Check, the two halves of the workload.Load
+// every other command reads workloads with, so that a workload it passes is one they accept. It returns how many
+// processes were read and every problem Check finds, or the one that stopped Parse.
+func validateWorkload(r io.Reader) (int, []workloadProblem) {
+	processes, err := workload.Parse(r)
+	if err != nil {
+		return 0, []workloadProblem{{Message: err.Error()}}
+	}
+	var problems []workloadProblem
+	for _, p := range workload.Check(processes) {
+		problems = append(problems, workloadProblem{Line: p.Index + 1, Message: p.Message})
+	}
+
+	return len(processes), problems
+}
+
+// validateCommand checks workload files without scheduling them, exiting with exitInvalid if any has a problem.
+func validateCommand(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "validate workload.csv...")
+	_ = fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		fatal(exitInvalid, fmt.Errorf("%w: must give a scheduling file to validate", ErrInvalidArgs))
+	}
+
+	code := exitOK
+	for _, path := range fs.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			fatal(exitFailure, fmt.Errorf("%w: error opening scheduling file", err))
+		}
+		count, problems := validateWorkload(f)
+		_ = f.Close()
+		outputValidation(os.Stdout, path, count, problems)
+		if len(problems) > 0 {
+			code = exitInvalid
+		}
+	}
+	os.Exit(code)
+}
+
+// outputValidation writes the result of validating the count processes of path.
+func outputValidation(w io.Writer, path string, count int, problems []workloadProblem) {
+	if len(problems) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: OK, %d processes\n", path, count)
+		return
+	}
+	if count == 0 {
+		_, _ = fmt.Fprintf(w, "%s: %d problems\n", path, len(problems))
+	} else {
+		_, _ = fmt.Fprintf(w, "%s: %d problems in %d processes\n", path, len(problems), count)
+	}
+	for _, p := range problems {
+		_, _ = fmt.Fprintf(w, "  %s\n", p)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_validateWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		in    string
+		count int
+		want  []string
+	}{
+		{name: "valid", in: "1,5,0,2\n2,9,1,1\n3,6,2,3\n", count: 3},
+		{name: "priority optional", in: "1,5,0\n2,9,1\n", count: 2},
+		{name: "deadlines", in: "1,5,0,2,5\n2,9,1,1,0\n", count: 2},
+		{name: "threads", in: "1,5,0,2,0,2/3/1 2\n2,9,1,1,0,\n", count: 2},
+		{
+			name: "malformed threads",
+			in:   "1,5,0,2,0,2/3\n2,9,1,1,0,4 4\n",
+			want: []string{`line 1: invalid workload: bad record: thread "2/3" must start and end with a burst`},
+		},
+		{
+			name:  "bad threads and deadlines",
+			in:    "1,5,0,2,4\n2,9,1,1,-3,4 4\n",
+			count: 2,
+			want: []string{
+				"line 1: deadline 4 is before 5, the earliest it can finish",
+				"line 2: threads run 8 in all, not the burst 9",
+				"line 2: deadline -3 must not be negative",
+			},
+		},
+		{
+			name:  "infeasible deadline and duplicate pid",
+			in:    "1,5,0\n2,9,1,1,4\n3,1,2\n4,2,3\n2,6,4\n",
+			count: 5,
+			want: []string{
+				"line 2: deadline 4 is before 10, the earliest it can finish",
+				"line 5: pid 2 is used twice",
+			},
+		},
+		{name: "empty", in: "", want: []string{"no processes"}},
+		{
+			name:  "duplicate pid",
+			in:    "1,5,0\n1,9,1\n",
+			count: 2,
+			want:  []string{"line 2: pid 1 is used twice"},
+		},
+		{
+			name:  "unsorted",
+			in:    "1,5,4\n2,9,1\n3,1,5\n",
+			count: 3,
+			want:  []string{"line 2: arrival 1 is before the previous process's 4"},
+		},
+		{
+			name:  "out of range",
+			in:    "1,0,-1,-2\n7,2,0\n",
+			count: 2,
+			want: []string{
+				"line 1: burst 0 must be at least 1",
+				"line 1: arrival -1 must not be negative",
+				"line 1: priority -2 must not be negative",
+				"line 2: pid 7 must be from 1 to 2",
+			},
+		},
+		{
+			name: "schema",
+			in:   "1,5,0\n2, 5,0,1,0\n3,4\n",
+			want: []string{`invalid workload: bad record: line 2: burst " 5" is not an integer`},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			count, problems := validateWorkload(strings.NewReader(tt.in))
+			var got []string
+			for _, p := range problems {
+				got = append(got, p.String())
+			}
+			if count != tt.count || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateWorkload() = %d, %q, want %d, %q", count, got, tt.count, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/paging"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// vmConfig is how the vm command pages the processes it schedules: the frames they share, how they are allocated
+// among them, or with PFF the shares to start from, which then follow each process's fault rate, how many recently
+// used pages a fault brings back with it, and how long a page fault blocks the process that took it.
+type vmConfig struct {
+	Frames     int
+	Allocation paging.FrameAllocation
+	PFF        *paging.PFF
+	Prepage    int
+	FaultTime  int64
+}
+
+// String describes c for report titles.
+func (c vmConfig) String() string {
+	replacement := "global LRU"
+	switch {
+	case c.PFF != nil:
+		replacement = fmt.Sprintf("local LRU under PFF %g%%-%g%% per %d references", 100*c.PFF.Lower,
+			100*c.PFF.Upper, c.PFF.Window)
+	case c.Allocation.Local():
+		replacement = "local LRU in " + c.Allocation.Name + " shares"
+	}
+	if c.Prepage > 0 {
+		replacement += fmt.Sprintf(", prepaging %d", c.Prepage)
+	}
+
+	return fmt.Sprintf("%d frames, %s, faults serviced in %d", c.Frames, replacement, c.FaultTime)
+}
+
+// vmReferences draws a reference string for every process of processes, one reference per time unit of its burst,
+// uniformly from pages pages of its own.
+func vmReferences(rng *rand.Rand, processes []workload.Process, pages int64) map[int64][]paging.Reference {
+	refs := make(map[int64][]paging.Reference, len(processes))
+	for _, p := range processes {
+		refs[p.ProcessID] = paging.Generate(rng, int(p.BurstDuration), pages, 0)
+	}
+
+	return refs
+}
+
+// runVM runs processes under policy with every time unit of a burst making the next reference of the process's
+// reference string, and a reference to a page not in a frame blocking the process until its fault is serviced. It
+// returns the report of the run and the pager, with the faults of each process and how its frames changed. A
+// process's size, for proportional allocation, is the distinct pages of its reference string. PFF with global
+// allocation starts from equal shares.
+func runVM(ctx context.Context, processes []workload.Process, policy sched.Policy,
+	refs map[int64][]paging.Reference, c vmConfig) (report.Report, *paging.Pager, error) {
+	sizes := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		distinct := make(map[int64]bool)
+		for _, ref := range refs[p.ProcessID] {
+			distinct[ref.Page] = true
+		}
+		sizes[p.ProcessID] = int64(len(distinct))
+	}
+	if c.PFF != nil && !c.Allocation.Local() {
+		c.Allocation = paging.FrameAllocations[1] // equal
+	}
+	pager, err := paging.NewPager(c.Frames, c.Allocation, sizes)
+	if err != nil {
+		return report.Report{}, nil, fmt.Errorf("%w: %w", ErrInvalidArgs, err)
+	}
+	pager.PFF, pager.Prepage = c.PFF, c.Prepage
+	faulted := make(map[int64]int64) // the reference each process last faulted on, by its index
+	block := func(time int64, p workload.Process) int64 {
+		i := p.Burst - p.BurstDuration
+		access := pager.Access
+		if at, ok := faulted[p.ProcessID]; ok && at == i && c.FaultTime > 0 {
+			access = pager.Retry
+		}
+		if access(p.ProcessID, refs[p.ProcessID][i], time, time+c.FaultTime) {
+			faulted[p.ProcessID] = i
+			return c.FaultTime
+		}
+		return 0
+	}
+	sim := sched.NewSimulation(processes)
+	if err := sim.RunBlocking(ctx, policy, block, -1); err != nil {
+		return report.Report{}, nil, err
+	}
+
+	return report.New(c.String(), sim.Result()), pager, nil
+}
+
+func vmCommand(args []string) {
+	fs := flag.NewFlagSet("vm", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "vm [flags] (workload.csv | -example name)")
+	name := fs.String("algorithm", "rr", "algorithm to schedule with ("+resumableNames()+")")
+	fs.Int64Var(&options.quantum, "quantum", 2, "round-robin time quantum")
+	var c vmConfig
+	fs.IntVar(&c.Frames, "frames", 12, "page frames the processes share")
+	allocation := fs.String("allocation", "global", "comma-separated frame allocations to compare, in order ("+
+		frameAllocationNames()+")")
+	fs.Int64Var(&c.FaultTime, "fault-time", 10, "time units a page fault blocks the process that took it")
+	fs.IntVar(&c.Prepage, "prepage", 0,
+		"recently used pages of the faulting process a fault brings back along with the one it faulted on")
+	pff := fs.String("pff", "", "lower:upper percent of faults outside which page-fault frequency control takes a "+
+		"frame from a process or gives it one, replacing locally (default: fixed allocation)")
+	pffWindow := fs.Int("pff-window", 8, "references of a process between reviews of its frames under -pff")
+	pages := fs.Int64("pages", 4, "distinct pages each process references, its working set")
+	sweep := fs.String("sweep", "", "run with every number of frames in this from:to range instead, to find thrashing")
+	exampleName := fs.String("example", "", "simulate a bundled example workload instead of a file ("+exampleNames()+")")
+	fs.Int64Var(&options.seed, "seed", 0, "random seed for the reference strings (default: based on the current time)")
+	applyLog := addLogFlags(fs)
+	_ = fs.Parse(args)
+	if err := applyLog(); err != nil {
+		fatal(exitInvalid, err)
+	}
+	if _, ok := resumable[*name]; !ok {
+		fatal(exitInvalid, fmt.Errorf("%w: vm cannot schedule with %q (want one of %s)", ErrInvalidArgs, *name,
+			resumableNames()))
+	}
+	if options.quantum < 1 || *pages < 1 || c.FaultTime < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -quantum and -pages must be at least 1, -fault-time at least 0",
+			ErrInvalidArgs))
+	}
+	if c.Prepage < 0 {
+		fatal(exitInvalid, fmt.Errorf("%w: -prepage must not be negative", ErrInvalidArgs))
+	}
+	if *pff != "" {
+		lower, upper, err := parseRange(*pff)
+		if err != nil {
+			fatal(exitInvalid, err)
+		}
+		c.PFF = &paging.PFF{Window: *pffWindow, Lower: float64(lower) / 100, Upper: float64(upper) / 100}
+		if err := c.PFF.Validate(); err != nil {
+			fatal(exitInvalid, fmt.Errorf("%w: %w", ErrInvalidArgs, err))
+		}
+	}
+	allocations, err := parseFrameAllocations(*allocation)
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	options.seed = resolveSeed(options.seed)
+	processes := mustLoadWorkloadOrExample(*exampleName, fs.Args())
+	refs := vmReferences(newRand(options.seed, "vm"), processes, *pages)
+	policy := func() sched.Policy {
+		return whatIfPolicy(*name, func(int64) int64 { return options.quantum })
+	}
+	a, _ := parseAlgorithms(*name)
+	ctx := sched.WithLogger(context.Background(), logger)
+
+	// the same run with every page resident is what the faults cost against
+	resident, err := sched.RunPolicy(ctx, processes, policy())
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	baseline := report.New("Every page resident", resident)
+
+	if *sweep != "" {
+		from, to, err := parseRange(*sweep)
+		if err != nil {
+			fatal(exitInvalid, err)
+		}
+		if from < int64(len(processes)) {
+			fatal(exitInvalid, fmt.Errorf("%w: -sweep must start at %d frames or more, one per process",
+				ErrInvalidArgs, len(processes)))
+		}
+		for _, c.Allocation = range allocations {
+			var reports []report.Report
+			var faults []int
+			for frames := from; frames <= to; frames++ {
+				c.Frames = int(frames)
+				r, pager, err := runVM(ctx, processes, policy(), refs, c)
+				if err != nil {
+					fatal(exitCode(err), err)
+				}
+				reports, faults = append(reports, r), append(faults, sumFaults(pager.Faults))
+			}
+			outputTitle(os.Stdout, fmt.Sprintf("%s: %d pages per process, %s allocation", a[0].Title, *pages,
+				c.Allocation.Name))
+			outputVMSweep(os.Stdout, from, reports, faults, baseline)
+		}
+		return
+	}
+
+	var reports []report.Report
+	var pagers []*paging.Pager
+	for _, c.Allocation = range allocations {
+		r, pager, err := runVM(ctx, processes, policy(), refs, c)
+		if err != nil {
+			fatal(exitCode(err), err)
+		}
+		r.Seed = options.seed
+		outputVM(os.Stdout, fmt.Sprintf("%s: %s", a[0].Title, c), r, pager, baseline)
+		reports, pagers = append(reports, r), append(pagers, pager)
+	}
+	if len(allocations) > 1 {
+		outputTitle(os.Stdout, "Frame allocations")
+		outputVMAllocations(os.Stdout, allocations, reports, pagers)
+	}
+}
+
+// frameAllocationNames lists the names of every frame allocation for flag help and errors.
+func frameAllocationNames() string {
+	var names []string
+	for _, a := range paging.FrameAllocations {
+		names = append(names, a.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// parseFrameAllocations resolves a comma-separated list of frame allocation names, in the order given.
+func parseFrameAllocations(list string) ([]paging.FrameAllocation, error) {
+	var selected []paging.FrameAllocation
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, a := range paging.FrameAllocations {
+			if a.Name == strings.TrimSpace(name) {
+				selected, found = append(selected, a), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown frame allocation %q (want one of %s)", ErrInvalidArgs, name,
+				frameAllocationNames())
+		}
+	}
+
+	return selected, nil
+}
+
+// sumFaults returns the faults of every process together.
+func sumFaults(faults map[int64]int) int {
+	total := 0
+	for _, n := range faults {
+		total += n
+	}
+
+	return total
+}
+
+// outputVM writes the Gantt chart of a run with paging, each process's faults, its fault rate, and the time they kept
+// it blocked, and its turnaround against the run with every page resident. Under local replacement, each process also
+// shows the frames it may hold, or under PFF, its frames over time, and with prepaging, the pages prepaged for it and
+// how many it used.
+func outputVM(w io.Writer, title string, r report.Report, pager *paging.Pager, baseline report.Report) {
+	outputTitle(w, title)
+	if r.Seed != 0 {
+		_, _ = fmt.Fprintf(w, "Random seed: %d (rerun with -seed %d to reproduce)\n\n", r.Seed, r.Seed)
+	}
+	report.WriteGantt(w, r.Gantt, options.color)
+
+	resident := make(map[int64]workload.Process, len(baseline.Processes))
+	for _, p := range baseline.Processes {
+		resident[p.ProcessID] = p
+	}
+	header := []string{"PID", "Burst", "Faults", "Fault rate", "Blocked", "Wait", "Turnaround", "Resident turnaround"}
+	prepaging, pff := sumFaults(pager.Prepaged) > 0, pager.PFF != nil
+	shares := !pff && len(pager.Allocations) > 0
+	if shares {
+		header = append(header, "Frames")
+	}
+	if prepaging {
+		header = append(header, "Prepaged", "Used")
+	}
+	if pff {
+		header = append(header, "Frames over time")
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	for _, p := range r.Processes {
+		pid := p.ProcessID
+		rate := float64(pager.Faults[pid]) / float64(max(pager.References[pid], 1))
+		row := []string{strconv.FormatInt(pid, 10), strconv.FormatInt(p.Burst, 10), strconv.Itoa(pager.Faults[pid]),
+			fmt.Sprintf("%.1f%%", 100*rate), strconv.FormatInt(p.Turnaround-p.Wait-p.Burst, 10),
+			strconv.FormatInt(p.Wait, 10), strconv.FormatInt(p.Turnaround, 10),
+			strconv.FormatInt(resident[pid].Turnaround, 10)}
+		if shares {
+			row = append(row, strconv.Itoa(pager.Allocations[pid][0].Frames))
+		}
+		if prepaging {
+			row = append(row, strconv.Itoa(pager.Prepaged[pid]), strconv.Itoa(pager.PrepageHits[pid]))
+		}
+		if pff {
+			row = append(row, formatAllocations(pager.Allocations[pid]))
+		}
+		table.Append(row)
+	}
+	table.Render()
+	if pff {
+		_, _ = fmt.Fprintln(w, "Frames over time lists the frames a process may hold as FRAMES@TIME, from the start")
+	}
+	outputUtilization(w, r.Busy, r.Idle, r.Overhead)
+	refs := sumFaults(pager.References)
+	_, _ = fmt.Fprintf(w, "Page faults: %d in %d references (%.1f%%), stretching the average turnaround %.2fx, from "+
+		"%.2f with every page resident to %.2f\n", sumFaults(pager.Faults), refs,
+		100*float64(sumFaults(pager.Faults))/float64(max(refs, 1)),
+		r.Summary.Turnaround/max(baseline.Summary.Turnaround, 1), baseline.Summary.Turnaround, r.Summary.Turnaround)
+	if prepaging {
+		prepaged, used := sumFaults(pager.Prepaged), sumFaults(pager.PrepageHits)
+		_, _ = fmt.Fprintf(w, "Prepaging: %d pages brought back ahead of use, %d of them used before eviction "+
+			"(%.1f%%)\n", prepaged, used, 100*float64(used)/float64(prepaged))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// formatAllocations lists the frames a process could hold over time, such as "3@0 4@12 3@30".
+func formatAllocations(allocations []paging.Allocation) string {
+	parts := make([]string, len(allocations))
+	for i, a := range allocations {
+		parts[i] = fmt.Sprintf("%d@%d", a.Frames, a.Time)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// outputVMSweep writes the faults, CPU utilization, and average turnaround with every number of frames from from on,
+// with a bar chart of the utilization, where thrashing shows as the frames below which it collapses.
+func outputVMSweep(w io.Writer, from int64, reports []report.Report, faults []int, baseline report.Report) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Frames", "Faults", "CPU utilization", "Avg turnaround", ""})
+	table.SetAutoFormatHeaders(false)
+	for i, r := range reports {
+		bar := strings.Repeat("#", int(r.Utilization()*pagingBarWidth+0.5))
+		table.Append([]string{strconv.FormatInt(from+int64(i), 10), strconv.Itoa(faults[i]),
+			fmt.Sprintf("%.2f%%", 100*r.Utilization()), fmt.Sprintf("%.2f", r.Summary.Turnaround), bar})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "With every page resident: CPU utilization %.2f%%, average turnaround %.2f\n",
+		100*baseline.Utilization(), baseline.Summary.Turnaround)
+	if onset := thrashingOnset(from, reports); onset >= from {
+		_, _ = fmt.Fprintf(w, "Thrashing at %d frames and fewer: CPU utilization under half of the best in the "+
+			"range\n\n", onset)
+	} else {
+		_, _ = fmt.Fprintf(w, "No thrashing from %d frames on: CPU utilization stays over half of the best in the "+
+			"range\n\n", from)
+	}
+}
+
+// thrashingOnset returns the most frames, of those from from on that reports were run with, with which the CPU
+// utilization falls under half of the best of them, or from-1 if it never does. The best, rather than that with every
+// page resident, leaves out the faults no number of frames avoids, those of each page's first reference.
+func thrashingOnset(from int64, reports []report.Report) int64 {
+	var best float64
+	for _, r := range reports {
+		best = max(best, r.Utilization())
+	}
+	onset := from - 1
+	for i, r := range reports {
+		if r.Utilization() < best/2 {
+			onset = from + int64(i)
+		}
+	}
+
+	return onset
+}
+
+// outputVMAllocations compares the runs of the same processes under each frame allocation: the faults of them all,
+// the process faulting the most often, and what the faults left of the CPU utilization and average turnaround.
+func outputVMAllocations(w io.Writer, allocations []paging.FrameAllocation, reports []report.Report,
+	pagers []*paging.Pager) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Allocation", "Faults", "Fault rate", "Worst process", "CPU utilization",
+		"Avg turnaround"})
+	table.SetAutoFormatHeaders(false)
+	for i, a := range allocations {
+		pager, faults, refs := pagers[i], sumFaults(pagers[i].Faults), sumFaults(pagers[i].References)
+		var (
+			worst int64
+			rate  = -1.0
+		)
+		for _, p := range reports[i].Processes {
+			if r := float64(pager.Faults[p.ProcessID]) / float64(max(pager.References[p.ProcessID], 1)); r > rate {
+				worst, rate = p.ProcessID, r
+			}
+		}
+		table.Append([]string{a.Title, strconv.Itoa(faults),
+			fmt.Sprintf("%.1f%%", 100*float64(faults)/float64(max(refs, 1))),
+			fmt.Sprintf("P%d at %.1f%%", worst, 100*rate), fmt.Sprintf("%.2f%%", 100*reports[i].Utilization()),
+			fmt.Sprintf("%.2f", reports[i].Summary.Turnaround)})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
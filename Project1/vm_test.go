@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/paging"
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_runVM(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2, ArrivalTime: 1}}
+	refs := map[int64][]paging.Reference{
+		1: {{Page: 0}, {Page: 1}, {Page: 0}},
+		2: {{Page: 0}, {Page: 0}},
+	}
+	tests := []struct {
+		name    string
+		config  vmConfig
+		gantt   []sched.TimeSlice
+		faults  map[int64]int
+		wantErr bool
+	}{
+		{
+			name:   "free faults",
+			config: vmConfig{Frames: 3},
+			gantt:  []sched.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}},
+			faults: map[int64]int{1: 2, 2: 1},
+		},
+		{
+			// P1 faults at 0 and 2, and P2 at 1 while P1 is blocked
+			name:   "faults block",
+			config: vmConfig{Frames: 3, FaultTime: 2},
+			gantt: []sched.TimeSlice{{PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 5},
+				{PID: 1, Start: 5, Stop: 7}},
+			faults: map[int64]int{1: 2, 2: 1},
+		},
+		{
+			// a frame each, so P1 faults on every reference
+			name:   "equal shares",
+			config: vmConfig{Frames: 2, Allocation: paging.FrameAllocations[1]},
+			gantt:  []sched.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}},
+			faults: map[int64]int{1: 3, 2: 1},
+		},
+		{name: "too few frames", config: vmConfig{Frames: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, pager, err := runVM(context.Background(), processes, sched.FCFSPolicy, refs, tt.config)
+			if tt.wantErr {
+				if !errors.Is(err, paging.ErrTooFewFrames) {
+					t.Errorf("runVM() error = %v, want %v", err, paging.ErrTooFewFrames)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r.Gantt, tt.gantt) || !reflect.DeepEqual(pager.Faults, tt.faults) {
+				t.Errorf("runVM() = %v with faults %v, want %v with %v", r.Gantt, pager.Faults, tt.gantt, tt.faults)
+			}
+		})
+	}
+}
+
+func Test_outputVM(t *testing.T) {
+	t.Parallel()
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 2}}
+	refs := map[int64][]paging.Reference{1: {{Page: 0}, {Page: 1}}}
+	r, pager, err := runVM(context.Background(), processes, sched.FCFSPolicy, refs, vmConfig{Frames: 1, FaultTime: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline, _ := sched.RunPolicy(context.Background(), processes, sched.FCFSPolicy)
+	var w bytes.Buffer
+	outputVM(&w, "FCFS", r, pager, report.New("Every page resident", baseline))
+	for _, want := range []string{
+		"|   1 |     2 |      2 | 100.0%     |       6 |    0 |          8 |                   2 |",
+		"CPU utilization: 25.00%",
+		"Page faults: 2 in 2 references (100.0%), stretching the average turnaround 4.00x, from 2.00 with every " +
+			"page resident to 8.00",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputVM() missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_parseFrameAllocations(t *testing.T) {
+	t.Parallel()
+	got, err := parseFrameAllocations("proportional, global")
+	if err != nil || len(got) != 2 || got[0].Name != "proportional" || got[1].Name != "global" {
+		t.Errorf("parseFrameAllocations() = %+v, %v", got, err)
+	}
+	if _, err := parseFrameAllocations("priority"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseFrameAllocations(\"priority\") error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_thrashingOnset(t *testing.T) {
+	t.Parallel()
+	run := func(busy, idle int64) report.Report { return report.Report{Busy: busy, Idle: idle} }
+	tests := []struct {
+		name    string
+		reports []report.Report
+		want    int64
+	}{
+		// utilization 20%, 20%, 40%, and 50%: the first two are under half of the best
+		{name: "collapse", reports: []report.Report{run(1, 4), run(1, 4), run(2, 3), run(1, 1)}, want: 6},
+		{name: "none", reports: []report.Report{run(2, 3), run(1, 1)}, want: 4},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := thrashingOnset(5, tt.reports); got != tt.want {
+				t.Errorf("thrashingOnset() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputVMAllocations(t *testing.T) {
+	t.Parallel()
+	// P2 arrives once P1 is done, so globally P1 has both frames to itself
+	processes := []workload.Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 1, ArrivalTime: 20}}
+	refs := map[int64][]paging.Reference{1: {{Page: 0}, {Page: 1}, {Page: 0}}, 2: {{Page: 0}}}
+	var (
+		reports []report.Report
+		pagers  []*paging.Pager
+	)
+	for _, a := range paging.FrameAllocations[:2] {
+		r, pager, err := runVM(context.Background(), processes, sched.FCFSPolicy, refs,
+			vmConfig{Frames: 2, Allocation: a, FaultTime: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		reports, pagers = append(reports, r), append(pagers, pager)
+	}
+	var w bytes.Buffer
+	outputVMAllocations(&w, paging.FrameAllocations[:2], reports, pagers)
+	for _, want := range []string{
+		"| Global       |      3 | 75.0%      | P2 at 100.0%  |",
+		"| Local, equal |      4 | 100.0%     | P1 at 100.0%  |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputVMAllocations() missing %q:\n%s", want, w.String())
+		}
+	}
+}
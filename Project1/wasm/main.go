@@ -0,0 +1,34 @@
+//go:build js && wasm
+
+// Command wasm builds the simulator for the browser, so a course webpage can run it client-side with no backend:
+//
+//	GOOS=js GOARCH=wasm go build -o wasm/scheduler.wasm ./wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" wasm/
+//
+// Once loaded, it defines a global simulate(workloadJSON, optionsJSON) function. workloadJSON is a JSON array of
+// processes and optionsJSON the rest of a request, both in the documents of package api; it returns the JSON
+// response as a string, with any error in its error field. index.html is an example page. Before Go 1.24,
+// wasm_exec.js is in misc/wasm instead of lib/wasm.
+package main
+
+import (
+	"context"
+	"syscall/js"
+
+	"GolandProjects/Project1/pkg/api"
+)
+
+func main() {
+	js.Global().Set("simulate", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		var workload, options string
+		if len(args) > 0 {
+			workload = args[0].String()
+		}
+		if len(args) > 1 && args[1].Type() == js.TypeString {
+			options = args[1].String()
+		}
+		return string(api.SimulateJSON(context.Background(), []byte(workload), []byte(options)))
+	}))
+	// keep the functions defined for as long as the page lives
+	select {}
+}
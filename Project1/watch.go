@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+// watchInterval is how often -watch checks the workload file for changes.
+const watchInterval = 500 * time.Millisecond
+
+// watchWorkload calls simulate with the workload at path, and again whenever the file's size or modification time
+// changes, until stop is closed (a nil stop watches forever). A workload that cannot be read or parsed is reported to
+// w and skipped, so a half-saved edit does not end the session.
+func watchWorkload(w io.Writer, path string, interval time.Duration, stop <-chan struct{}, simulate func([]workload.Process)) {
+	_, _ = fmt.Fprintf(w, "Watching %s for changes (interrupt to stop)\n", path)
+	var (
+		last    os.FileInfo
+		missing bool
+	)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			if !missing {
+				_, _ = fmt.Fprintf(w, "%v: waiting for %s to appear\n", err, path)
+				last, missing = nil, true
+			}
+		case last == nil || info.Size() != last.Size() || !info.ModTime().Equal(last.ModTime()):
+			if last != nil {
+				_, _ = fmt.Fprintf(w, "%s changed at %s, re-running\n", path, info.ModTime().Format(time.Kitchen))
+			}
+			last, missing = info, false
+			if processes, err := loadWorkloadFile(path); err != nil {
+				_, _ = fmt.Fprintln(w, err)
+			} else {
+				simulate(processes)
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadWorkloadFile opens and parses the workload at path.
+func loadWorkloadFile(path string) ([]workload.Process, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error opening scheduling file", err)
+	}
+	defer f.Close()
+
+	return workload.Load(f)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"GolandProjects/Project1/pkg/workload"
+)
+
+func Test_watchWorkload(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "workload.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu   sync.Mutex
+		runs [][]workload.Process
+	)
+	ran := make(chan struct{}, 10)
+	stop := make(chan struct{})
+	finished := make(chan struct{})
+	var log strings.Builder
+	go func() {
+		defer close(finished)
+		watchWorkload(&log, path, time.Millisecond, stop, func(processes []workload.Process) {
+			mu.Lock()
+			runs = append(runs, processes)
+			mu.Unlock()
+			ran <- struct{}{}
+		})
+	}()
+
+	<-ran
+	// a malformed edit is reported and skipped, and the next good one runs again
+	if err := os.WriteFile(path, []byte("1,5\n2,3,1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("1,5,0,2\n2,3,1,1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("changed workload was not re-run")
+	}
+	close(stop)
+	<-finished
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(runs) != 2 || len(runs[0]) != 1 || len(runs[1]) != 2 {
+		t.Errorf("ran workloads %v, want the original and then the two-process edit", runs)
+	}
+	if !strings.Contains(log.String(), "want 3 to 6 fields") {
+		t.Errorf("malformed edit was not reported:\n%s", log.String())
+	}
+}
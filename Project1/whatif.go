@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"GolandProjects/Project1/pkg/report"
+	"GolandProjects/Project1/pkg/sched"
+	"GolandProjects/Project1/pkg/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+// processChange sets one number of the process PID to Value.
+type processChange struct {
+	PID   int64
+	Value int64
+}
+
+// whatIf is a change to a run from time At on: new bursts or priorities for some processes, or a new round-robin
+// quantum.
+type whatIf struct {
+	At         int64
+	Bursts     []processChange
+	Priorities []processChange
+	// Quantum is the quantum from At on, or 0 to keep it.
+	Quantum int64
+}
+
+// String describes w as the question it asks, as in "P4's burst were 3 from time 20".
+func (w whatIf) String() string {
+	var parts []string
+	for _, c := range w.Bursts {
+		parts = append(parts, fmt.Sprintf("P%d's burst were %d", c.PID, c.Value))
+	}
+	for _, c := range w.Priorities {
+		parts = append(parts, fmt.Sprintf("P%d's priority were %d", c.PID, c.Value))
+	}
+	if w.Quantum > 0 {
+		parts = append(parts, fmt.Sprintf("the quantum were %d", w.Quantum))
+	}
+
+	return fmt.Sprintf("%s from time %d", strings.Join(parts, " and "), w.At)
+}
+
+// parseProcessChanges parses a comma-separated list of PID=value pairs given to the flag named name, with or without
+// a P before each PID.
+func parseProcessChanges(name, list string) ([]processChange, error) {
+	if list == "" {
+		return nil, nil
+	}
+	var changes []processChange
+	for _, pair := range strings.Split(list, ",") {
+		pid, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: -%s wants PID=value pairs, not %q", ErrInvalidArgs, name, pair)
+		}
+		var c processChange
+		var err error
+		if c.PID, err = strconv.ParseInt(strings.TrimPrefix(pid, "P"), 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: -%s: %q is not a PID", ErrInvalidArgs, name, pid)
+		}
+		if c.Value, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: -%s: %q is not a number", ErrInvalidArgs, name, value)
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// whatIfPolicy returns a new policy engine policy for the algorithm named name: a resumable one, or round-robin with
+// quantum asked for at every decision.
+func whatIfPolicy(name string, quantum func(time int64) int64) sched.Policy {
+	if name == "rr" {
+		return sched.RoundRobinPolicy(quantum)
+	}
+
+	return resumable[name]()
+}
+
+// whatIfRun is what the whatif command was asked: the change to make, the algorithm to make it under with its
+// quantum and seed, and the workload, a bundled example or the file arguments.
+type whatIfRun struct {
+	Change    whatIf
+	Algorithm string
+	Quantum   int64
+	Seed      int64
+	Example   string
+	Files     []string
+	// ApplyLog applies the -log flags.
+	ApplyLog func() error
+}
+
+// parseWhatIf parses and checks the whatif command's flags.
+func parseWhatIf(args []string) (whatIfRun, error) {
+	fs := flag.NewFlagSet("whatif", flag.ExitOnError)
+	fs.Usage = commandUsage(fs, "whatif [flags] (workload.csv | -example name)")
+	var r whatIfRun
+	fs.Int64Var(&r.Change.At, "at", 0, "time the change takes effect")
+	fs.StringVar(&r.Algorithm, "algorithm", "fcfs", "algorithm to simulate ("+resumableNames()+")")
+	bursts := fs.String("burst", "", "comma-separated PID=burst pairs giving processes a new whole burst")
+	priorities := fs.String("priority", "", "comma-separated PID=priority pairs giving processes a new priority")
+	fs.Int64Var(&r.Quantum, "quantum", 1, "round-robin time quantum before the change")
+	fs.Int64Var(&r.Change.Quantum, "new-quantum", 0, "round-robin time quantum from -at on (default: unchanged)")
+	fs.StringVar(&r.Example, "example", "", "simulate a bundled example workload instead of a file ("+exampleNames()+")")
+	fs.Int64Var(&r.Seed, "seed", 0, "random seed for the lottery draws (default: based on the current time)")
+	r.ApplyLog = addLogFlags(fs)
+	_ = fs.Parse(args)
+	r.Files = fs.Args()
+
+	if r.Change.At < 0 {
+		return r, fmt.Errorf("%w: -at must not be negative", ErrInvalidArgs)
+	}
+	if _, ok := resumable[r.Algorithm]; !ok {
+		return r, fmt.Errorf("%w: whatif cannot simulate %q (want one of %s)", ErrInvalidArgs, r.Algorithm,
+			resumableNames())
+	}
+	if r.Quantum < 1 || r.Change.Quantum < 0 {
+		return r, fmt.Errorf("%w: quanta must be at least 1", ErrInvalidArgs)
+	}
+	if r.Change.Quantum > 0 && r.Algorithm != "rr" {
+		return r, fmt.Errorf("%w: -new-quantum only applies to rr", ErrInvalidArgs)
+	}
+	var err error
+	if r.Change.Bursts, err = parseProcessChanges("burst", *bursts); err != nil {
+		return r, err
+	}
+	if r.Change.Priorities, err = parseProcessChanges("priority", *priorities); err != nil {
+		return r, err
+	}
+	if len(r.Change.Bursts) == 0 && len(r.Change.Priorities) == 0 && r.Change.Quantum == 0 {
+		return r, fmt.Errorf("%w: must give a change with -burst, -priority, or -new-quantum", ErrInvalidArgs)
+	}
+
+	return r, nil
+}
+
+func whatIfCommand(args []string) {
+	r, err := parseWhatIf(args)
+	if err == nil {
+		err = r.ApplyLog()
+	}
+	if err != nil {
+		fatal(exitInvalid, err)
+	}
+	options.quantum, options.seed = r.Quantum, resolveSeed(r.Seed)
+	processes := mustLoadWorkloadOrExample(r.Example, r.Files)
+
+	ctx := sched.WithLogger(context.Background(), logger)
+	original, changed, err := runWhatIf(ctx, processes, r.Algorithm, options.quantum, r.Change)
+	if err != nil {
+		fatal(exitCode(err), err)
+	}
+	a, _ := parseAlgorithms(r.Algorithm)
+	outputWhatIf(os.Stdout, fmt.Sprintf("%s: what if %s", a[0].Title, r.Change), original, changed)
+}
+
+// runWhatIf runs processes under the algorithm named name to the end, and again with change made partway, pausing
+// the second run at change.At to make it as snapshot does. quantum is round-robin's quantum before the change.
+func runWhatIf(ctx context.Context, processes []workload.Process, name string, quantum int64, change whatIf) (report.Report, report.Report, error) {
+	original, err := sched.RunPolicy(ctx, processes, whatIfPolicy(name, func(int64) int64 { return quantum }))
+	if err != nil {
+		return report.Report{}, report.Report{}, err
+	}
+
+	// the same policy carries on after the pause, so round-robin keeps its queue
+	choose := whatIfPolicy(name, func(time int64) int64 {
+		if change.Quantum > 0 && time >= change.At {
+			return change.Quantum
+		}
+		return quantum
+	})
+	sim := sched.NewSimulation(processes)
+	if err := sim.Run(ctx, choose, change.At); err != nil {
+		return report.Report{}, report.Report{}, err
+	}
+	for _, c := range change.Bursts {
+		if err := sim.SetBurst(c.PID, c.Value); err != nil {
+			return report.Report{}, report.Report{}, err
+		}
+	}
+	for _, c := range change.Priorities {
+		if err := sim.SetPriority(c.PID, c.Value); err != nil {
+			return report.Report{}, report.Report{}, err
+		}
+	}
+	if err := sim.Run(ctx, choose, -1); err != nil {
+		return report.Report{}, report.Report{}, err
+	}
+
+	return report.New("Original", original), report.New("What-if", sim.Result()), nil
+}
+
+// outputWhatIf writes the metric deltas of changed against original, the processes whose wait or completion moved,
+// and where their Gantt charts part.
+func outputWhatIf(w io.Writer, title string, original, changed report.Report) {
+	_, _ = fmt.Fprintln(w, title)
+	rows, _ := metricDeltas(original, changed)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Metric", "Original", "What-if", "Delta"})
+	table.AppendBulk(rows)
+	table.Render()
+
+	completed := make(map[int64]workload.Process, len(changed.Processes))
+	for _, p := range changed.Processes {
+		completed[p.ProcessID] = p
+	}
+	rows = nil
+	for _, p := range original.Processes {
+		q := completed[p.ProcessID]
+		if p.Wait == q.Wait && p.Completion == q.Completion {
+			continue
+		}
+		rows = append(rows, []string{fmt.Sprintf("P%d", p.ProcessID), strconv.FormatInt(p.Wait, 10),
+			strconv.FormatInt(q.Wait, 10), fmt.Sprintf("%+d", q.Wait-p.Wait), strconv.FormatInt(p.Completion, 10),
+			strconv.FormatInt(q.Completion, 10)})
+	}
+	if len(rows) == 0 {
+		_, _ = fmt.Fprint(w, "No process waits or completes any differently\n\n")
+	} else {
+		table = tablewriter.NewWriter(w)
+		table.SetHeader([]string{"PID", "Wait", "What-if wait", "Delta", "Completion", "What-if completion"})
+		table.AppendBulk(rows)
+		table.Render()
+	}
+
+	diffs := ganttDivergences(original.Gantt, changed.Gantt)
+	if len(diffs) == 0 {
+		_, _ = fmt.Fprint(w, "Gantt charts match\n\n")
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Gantt charts part at slice %d: %s vs %s\n\n", diffs[0].Index, formatSlice(diffs[0].A),
+		formatSlice(diffs[0].B))
+}
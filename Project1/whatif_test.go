@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GolandProjects/Project1/pkg/sched"
+)
+
+func Test_parseProcessChanges(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		list    string
+		want    []processChange
+		wantErr bool
+	}{
+		{list: "", want: nil},
+		{list: "4=3", want: []processChange{{PID: 4, Value: 3}}},
+		{list: "P4=3, 2=1", want: []processChange{{PID: 4, Value: 3}, {PID: 2, Value: 1}}},
+		{list: "4", wantErr: true},
+		{list: "x=3", wantErr: true},
+		{list: "4=y", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.list, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseProcessChanges("burst", tt.list)
+			if tt.wantErr != errors.Is(err, ErrInvalidArgs) || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseProcessChanges(%q) = %v, %v", tt.list, got, err)
+			}
+		})
+	}
+}
+
+func Test_parseWhatIf(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    string
+		want    whatIf
+		wantErr error
+	}{
+		{name: "new quantum with rr", args: "-algorithm rr -quantum 2 -new-quantum 4 -at 5 workload.csv",
+			want: whatIf{At: 5, Quantum: 4}},
+		{name: "bursts and priorities", args: "-at 3 -burst P1=2,2=4 -priority 3=1 workload.csv",
+			want: whatIf{At: 3, Bursts: []processChange{{1, 2}, {2, 4}}, Priorities: []processChange{{3, 1}}}},
+		{name: "new quantum with fcfs", args: "-algorithm fcfs -new-quantum 4 workload.csv", wantErr: ErrInvalidArgs},
+		{name: "no change", args: "-algorithm rr -at 5 workload.csv", wantErr: ErrInvalidArgs},
+		{name: "negative time", args: "-at -1 -burst 1=2 workload.csv", wantErr: ErrInvalidArgs},
+		{name: "unknown algorithm", args: "-algorithm mlfq -burst 1=2 workload.csv", wantErr: ErrInvalidArgs},
+		{name: "bad quantum", args: "-algorithm rr -quantum 0 -new-quantum 2 workload.csv", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := parseWhatIf(strings.Fields(tt.args))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseWhatIf(%s) error = %v, want %v", tt.args, err, tt.wantErr)
+			}
+			if err == nil && (!reflect.DeepEqual(r.Change, tt.want) || !reflect.DeepEqual(r.Files, []string{"workload.csv"})) {
+				t.Errorf("parseWhatIf(%s) = %+v, %q, want %+v", tt.args, r.Change, r.Files, tt.want)
+			}
+		})
+	}
+}
+
+func Test_whatIf_String(t *testing.T) {
+	t.Parallel()
+	w := whatIf{At: 20, Bursts: []processChange{{PID: 4, Value: 3}}, Quantum: 4}
+	if got, want := w.String(), "P4's burst were 3 and the quantum were 4 from time 20"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func Test_runWhatIf(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		alg      string
+		change   whatIf
+		wantWait float64
+		wantErr  error
+	}{
+		// P1 has run 1 unit by time 2, so it finishes next
+		{name: "shorter burst", alg: "sjf", change: whatIf{At: 2, Bursts: []processChange{{PID: 1, Value: 2}}},
+			wantWait: 3.5},
+		// P2 has run since 2, so a quantum of 4 from 3 lets it finish its burst of 4
+		{name: "longer quantum", alg: "rr", change: whatIf{At: 3, Quantum: 4}, wantWait: 12.25},
+		{name: "finished", alg: "fcfs", change: whatIf{At: 20, Bursts: []processChange{{PID: 1, Value: 2}}},
+			wantErr: sched.ErrUnchangeable},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			original, changed, err := runWhatIf(context.Background(), processes, tt.alg, 2, tt.change)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("runWhatIf() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed.Summary.Wait != tt.wantWait {
+				t.Errorf("what-if avg wait = %.2f, want %.2f", changed.Summary.Wait, tt.wantWait)
+			}
+
+			// a change to nothing reruns the original exactly
+			_, same, err := runWhatIf(context.Background(), processes, tt.alg, 2, whatIf{At: tt.change.At})
+			if err != nil || !reflect.DeepEqual(same.Gantt, original.Gantt) {
+				t.Errorf("unchanged rerun = %v, %v, want %v", same.Gantt, err, original.Gantt)
+			}
+		})
+	}
+}
+
+func Test_outputWhatIf(t *testing.T) {
+	t.Parallel()
+	processes, err := loadExample("srtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	original, changed, err := runWhatIf(context.Background(), processes, "sjf", 1,
+		whatIf{At: 2, Bursts: []processChange{{PID: 1, Value: 2}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	outputWhatIf(&w, "SJF: what if", original, changed)
+	for _, want := range []string{
+		"| avg wait             |     6.50 |    3.50 |   -3.00 |",
+		"| utilization          |   1.0000 |  1.0000 | =       |",
+		"| P1  |    9 |            1 |    -8 |         17 |                  3 |",
+		"Gantt charts part at slice 1: P2 1-5 vs P2 1-2",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputWhatIf() missing %q:\n%s", want, w.String())
+		}
+	}
+	w.Reset()
+	outputWhatIf(&w, "SJF: what if", original, original)
+	if !strings.Contains(w.String(), "No process waits or completes any differently") {
+		t.Errorf("outputWhatIf() of the same run:\n%s", w.String())
+	}
+}
+
+// Test_whatIfCommand runs the whatif command in a child test process, since it exits on bad flags, with the arguments
+// in WHATIF_ARGS.
+func Test_whatIfCommand(t *testing.T) {
+	if args, ok := os.LookupEnv("WHATIF_ARGS"); ok {
+		whatIfCommand(strings.Fields(args))
+		return
+	}
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     string
+		wantCode int
+		want     string
+	}{
+		{name: "new quantum", args: "-algorithm rr -quantum 2 -new-quantum 4 -at 5 -example rr-quantum",
+			want: "what if the quantum were 4 from time 5"},
+		{name: "new quantum without rr", args: "-algorithm fcfs -new-quantum 4 -at 5 -example rr-quantum",
+			wantCode: exitInvalid, want: "-new-quantum only applies to rr"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := exec.Command(os.Args[0], "-test.run=^Test_whatIfCommand$")
+			cmd.Env = append(os.Environ(), "WHATIF_ARGS="+tt.args)
+			out, err := cmd.CombinedOutput()
+			code := 0
+			var exit *exec.ExitError
+			if errors.As(err, &exit) {
+				code = exit.ExitCode()
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if code != tt.wantCode || !strings.Contains(string(out), tt.want) {
+				t.Errorf("whatif %s exited %d, want %d with %q:\n%s", tt.args, code, tt.wantCode, tt.want, out)
+			}
+		})
+	}
+}
@@ -1,8 +1,7 @@
 module GolandProjects
 
-go 1.20
+go 1.21
 
-require (
-	github.com/mattn/go-runewidth v0.0.9 // indirect
-	github.com/olekukonko/tablewriter v0.0.5 // indirect
-)
+require github.com/olekukonko/tablewriter v0.0.5
+
+require github.com/mattn/go-runewidth v0.0.9 // indirect